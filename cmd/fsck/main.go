@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api"
+)
+
+func main() {
+	api.Fsck()
+}
@@ -0,0 +1,5 @@
+ALTER TABLE `entries`
+DROP COLUMN `encoding`;
+
+ALTER TABLE `volumes`
+DROP COLUMN `compression`;
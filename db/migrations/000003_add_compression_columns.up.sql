@@ -0,0 +1,5 @@
+ALTER TABLE `volumes`
+ADD COLUMN `compression` VARCHAR(32) NOT NULL DEFAULT "" COMMENT "圧縮方式" AFTER `is_public`;
+
+ALTER TABLE `entries`
+ADD COLUMN `encoding` VARCHAR(32) NOT NULL DEFAULT "" COMMENT "エンコーディング" AFTER `type`;
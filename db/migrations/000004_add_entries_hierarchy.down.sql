@@ -0,0 +1,24 @@
+ALTER TABLE `entries`
+ADD COLUMN `key` VARCHAR(512) NOT NULL DEFAULT "" COMMENT "キー" AFTER `volume_id`;
+
+UPDATE `entries` AS `e`
+INNER JOIN (
+  WITH RECURSIVE `paths` (`id`, `key`) AS (
+    SELECT `id`, CAST(`name` AS CHAR(512)) FROM `entries` WHERE `parent_id` IS NULL
+    UNION ALL
+    SELECT `c`.`id`, CONCAT(`p`.`key`, '/', `c`.`name`) FROM `paths` AS `p` INNER JOIN `entries` AS `c` ON `c`.`parent_id` = `p`.`id`
+  )
+  SELECT `id`, `key` FROM `paths`
+) AS `p` ON `p`.`id` = `e`.`id`
+SET `e`.`key` = `p`.`key`;
+
+ALTER TABLE `entries`
+DROP FOREIGN KEY `fk_entries_parent_id`;
+
+ALTER TABLE `entries`
+DROP INDEX `uq_entries_volume_id_and_parent_id_and_name`,
+DROP INDEX `idx_entries_parent_id_and_name`,
+DROP COLUMN `parent_id`,
+DROP COLUMN `name`,
+ALTER COLUMN `key` DROP DEFAULT,
+ADD UNIQUE `uq_entries_volume_id_and_key` (`volume_id`, `key`);
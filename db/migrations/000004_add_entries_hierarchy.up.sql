@@ -0,0 +1,19 @@
+ALTER TABLE `entries`
+ADD COLUMN `parent_id` CHAR(36) NULL COMMENT "親エントリーID" AFTER `volume_id`,
+ADD COLUMN `name` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "名前" AFTER `parent_id`;
+
+UPDATE `entries`
+SET `name` = SUBSTRING_INDEX(`key`, '/', -1);
+
+UPDATE `entries` AS `e`
+INNER JOIN `entries` AS `p` ON `p`.`volume_id` = `e`.`volume_id` AND `p`.`key` = SUBSTRING(`e`.`key`, 1, LENGTH(`e`.`key`) - LENGTH(`e`.`name`) - 1)
+SET `e`.`parent_id` = `p`.`id`
+WHERE `e`.`key` LIKE '%/%';
+
+ALTER TABLE `entries`
+DROP INDEX `uq_entries_volume_id_and_key`,
+DROP COLUMN `key`,
+ALTER COLUMN `name` DROP DEFAULT,
+ADD UNIQUE `uq_entries_volume_id_and_parent_id_and_name` (`volume_id`, (COALESCE(`parent_id`, '')), `name`),
+ADD INDEX `idx_entries_parent_id_and_name` (`parent_id`, `name`),
+ADD CONSTRAINT `fk_entries_parent_id` FOREIGN KEY (`parent_id`) REFERENCES `entries` (`id`);
@@ -0,0 +1,4 @@
+ALTER TABLE `jobs`
+DROP INDEX `idx_jobs_status_and_run_at`;
+
+DROP TABLE IF EXISTS `jobs`;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS `jobs` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `account_id` CHAR(36) NOT NULL COMMENT "アカウントID",
+  `type` VARCHAR(255) NOT NULL COMMENT "種別",
+  `status` VARCHAR(255) NOT NULL COMMENT "状態",
+  `volume_name` VARCHAR(255) NOT NULL COMMENT "ボリューム名",
+  `key` VARCHAR(512) NOT NULL COMMENT "キー",
+  `new_key` VARCHAR(512) NOT NULL COMMENT "変更後のキー",
+  `result_key` VARCHAR(512) NOT NULL COMMENT "結果のキー",
+  `error` TEXT NOT NULL COMMENT "エラー",
+  `total_entries` BIGINT UNSIGNED NOT NULL COMMENT "総エントリー数",
+  `processed_entries` BIGINT UNSIGNED NOT NULL COMMENT "処理済みエントリー数",
+  `total_bytes` BIGINT UNSIGNED NOT NULL COMMENT "総バイト数",
+  `processed_bytes` BIGINT UNSIGNED NOT NULL COMMENT "処理済みバイト数",
+  `attempts` INT UNSIGNED NOT NULL COMMENT "試行回数",
+  `run_at` DATETIME (6) NOT NULL COMMENT "実行予定日時",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  `updated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT "更新日時",
+  PRIMARY KEY (`id`),
+  INDEX `idx_jobs_status_and_run_at` (`status`, `run_at`)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE `jobs`
+ADD COLUMN `new_volume_name` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "変更後のボリューム名" AFTER `key`;
@@ -0,0 +1,2 @@
+ALTER TABLE `jobs`
+ADD COLUMN `conflict` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "競合方針" AFTER `new_key`;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `webhooks` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `account_id` CHAR(36) NOT NULL COMMENT "アカウントID",
+  `volume_id` CHAR(36) NOT NULL COMMENT "ボリュームID",
+  `url` VARCHAR(2048) NOT NULL COMMENT "送信先URL",
+  `secret` CHAR(64) NOT NULL COMMENT "署名の鍵",
+  `events` VARCHAR(1024) NOT NULL COMMENT "イベント種別",
+  `prefix` VARCHAR(512) NOT NULL COMMENT "キーの前方一致",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  `updated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT "更新日時",
+  PRIMARY KEY (`id`),
+  CONSTRAINT `fk_webhooks_volume_id` FOREIGN KEY (`volume_id`) REFERENCES `volumes` (`id`) ON DELETE CASCADE
+);
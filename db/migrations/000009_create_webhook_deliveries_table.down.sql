@@ -0,0 +1,5 @@
+ALTER TABLE `webhook_deliveries`
+DROP INDEX `idx_webhook_deliveries_status_and_run_at`,
+DROP INDEX `idx_webhook_deliveries_webhook_id_and_created_at`;
+
+DROP TABLE IF EXISTS `webhook_deliveries`;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `webhook_id` CHAR(36) NOT NULL COMMENT "WebhookID",
+  `event_id` CHAR(36) NOT NULL COMMENT "イベントID",
+  `event_type` VARCHAR(255) NOT NULL COMMENT "イベント種別",
+  `url` VARCHAR(2048) NOT NULL COMMENT "送信先URL",
+  `payload` TEXT NOT NULL COMMENT "ペイロード",
+  `signature` VARCHAR(255) NOT NULL COMMENT "署名",
+  `status` VARCHAR(255) NOT NULL COMMENT "状態",
+  `response_status` INT UNSIGNED NOT NULL COMMENT "応答ステータス",
+  `error` TEXT NOT NULL COMMENT "エラー",
+  `attempts` INT UNSIGNED NOT NULL COMMENT "試行回数",
+  `run_at` DATETIME (6) NOT NULL COMMENT "送信予定日時",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  `updated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT "更新日時",
+  PRIMARY KEY (`id`),
+  INDEX `idx_webhook_deliveries_status_and_run_at` (`status`, `run_at`),
+  INDEX `idx_webhook_deliveries_webhook_id_and_created_at` (`webhook_id`, `created_at`)
+);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS `change_sequences` (
+  `volume_id` CHAR(36) NOT NULL COMMENT "ボリュームID",
+  `sequence` BIGINT UNSIGNED NOT NULL COMMENT "最新の連番",
+  PRIMARY KEY (`volume_id`),
+  CONSTRAINT `fk_change_sequences_volume_id` FOREIGN KEY (`volume_id`) REFERENCES `volumes` (`id`) ON DELETE CASCADE
+);
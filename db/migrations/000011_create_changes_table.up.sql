@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `changes` (
+  `volume_id` CHAR(36) NOT NULL COMMENT "ボリュームID",
+  `sequence` BIGINT UNSIGNED NOT NULL COMMENT "連番",
+  `event_id` CHAR(36) NOT NULL COMMENT "イベントID",
+  `account_id` CHAR(36) NOT NULL COMMENT "アカウントID",
+  `type` VARCHAR(255) NOT NULL COMMENT "イベント種別",
+  `key` VARCHAR(512) NOT NULL COMMENT "キー",
+  `new_key` VARCHAR(512) NOT NULL COMMENT "変更後のキー",
+  `size` BIGINT UNSIGNED NOT NULL COMMENT "サイズ",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  PRIMARY KEY (`volume_id`, `sequence`),
+  CONSTRAINT `fk_changes_volume_id` FOREIGN KEY (`volume_id`) REFERENCES `volumes` (`id`) ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE `audit_logs`
+DROP INDEX `idx_audit_logs_owner_id_and_id`,
+DROP INDEX `idx_audit_logs_owner_id_and_created_at`;
+
+DROP TABLE IF EXISTS `audit_logs`;
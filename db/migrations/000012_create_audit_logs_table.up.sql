@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `audit_logs` (
+  `id` BIGINT UNSIGNED NOT NULL AUTO_INCREMENT COMMENT "ID",
+  `request_id` VARCHAR(255) NOT NULL COMMENT "リクエストID",
+  `owner_id` CHAR(36) NULL COMMENT "所有者のアカウントID",
+  `actor_id` CHAR(36) NULL COMMENT "操作者のアカウントID",
+  `credential_type` VARCHAR(32) NOT NULL COMMENT "認証情報の種別",
+  `client_ip` VARCHAR(45) NOT NULL COMMENT "クライアントのIPアドレス",
+  `user_agent` VARCHAR(512) NOT NULL COMMENT "ユーザーエージェント",
+  `operation` VARCHAR(255) NOT NULL COMMENT "操作",
+  `volume_name` VARCHAR(255) NOT NULL COMMENT "ボリューム名",
+  `key` VARCHAR(1024) NOT NULL COMMENT "キー",
+  `new_volume_name` VARCHAR(255) NOT NULL COMMENT "変更後のボリューム名",
+  `new_key` VARCHAR(1024) NOT NULL COMMENT "変更後のキー",
+  `status` INT UNSIGNED NOT NULL COMMENT "応答ステータス",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  PRIMARY KEY (`id`),
+  INDEX `idx_audit_logs_owner_id_and_id` (`owner_id`, `id`),
+  INDEX `idx_audit_logs_owner_id_and_created_at` (`owner_id`, `created_at`)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS `image_presets` (
+  `id` CHAR(36) NOT NULL COMMENT "ID",
+  `account_id` CHAR(36) NOT NULL COMMENT "アカウントID",
+  `volume_id` CHAR(36) NOT NULL COMMENT "ボリュームID",
+  `name` VARCHAR(64) NOT NULL COMMENT "プリセット名",
+  `width` INT UNSIGNED NOT NULL COMMENT "幅",
+  `height` INT UNSIGNED NOT NULL COMMENT "高さ",
+  `fit` VARCHAR(16) NOT NULL COMMENT "収め方",
+  `crop_x` INT UNSIGNED NOT NULL COMMENT "切り抜きのX座標",
+  `crop_y` INT UNSIGNED NOT NULL COMMENT "切り抜きのY座標",
+  `crop_width` INT UNSIGNED NOT NULL COMMENT "切り抜きの幅",
+  `crop_height` INT UNSIGNED NOT NULL COMMENT "切り抜きの高さ",
+  `quality` TINYINT UNSIGNED NOT NULL COMMENT "品質",
+  `format` VARCHAR(16) NOT NULL COMMENT "出力形式",
+  `created_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT "作成日時",
+  `updated_at` DATETIME (6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT "更新日時",
+  PRIMARY KEY (`id`),
+  UNIQUE (`volume_id`, `name`),
+  CONSTRAINT `fk_image_presets_volume_id` FOREIGN KEY (`volume_id`) REFERENCES `volumes` (`id`) ON DELETE CASCADE
+);
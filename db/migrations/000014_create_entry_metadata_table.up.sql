@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS `entry_metadata` (
+  `entry_id` CHAR(36) NOT NULL COMMENT "エントリーID",
+  `width` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT "幅",
+  `height` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT "高さ",
+  `taken_at` DATETIME NULL COMMENT "撮影日時",
+  `camera_make` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "カメラのメーカー",
+  `camera_model` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "カメラの機種",
+  `latitude` DOUBLE NULL COMMENT "緯度",
+  `longitude` DOUBLE NULL COMMENT "経度",
+  `page_count` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT "ページ数",
+  `duration` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT "再生時間(ミリ秒)",
+  `title` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "タイトル",
+  `artist` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "アーティスト",
+  `album` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "アルバム",
+  PRIMARY KEY (`entry_id`),
+  INDEX `idx_entry_metadata_taken_at` (`taken_at`),
+  CONSTRAINT `fk_entry_metadata_entry_id` FOREIGN KEY (`entry_id`) REFERENCES `entries` (`id`) ON DELETE CASCADE
+);
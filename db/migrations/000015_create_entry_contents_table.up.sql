@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS `entry_contents` (
+  `entry_id` CHAR(36) NOT NULL COMMENT "エントリーID",
+  `content` MEDIUMTEXT NOT NULL COMMENT "本文",
+  PRIMARY KEY (`entry_id`),
+  FULLTEXT INDEX `idx_entry_contents_content` (`content`) WITH PARSER ngram,
+  CONSTRAINT `fk_entry_contents_entry_id` FOREIGN KEY (`entry_id`) REFERENCES `entries` (`id`) ON DELETE CASCADE
+);
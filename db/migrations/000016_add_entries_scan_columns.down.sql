@@ -0,0 +1,4 @@
+ALTER TABLE `entries`
+DROP COLUMN `scanned_at`,
+DROP COLUMN `scan_signature`,
+DROP COLUMN `scan_status`;
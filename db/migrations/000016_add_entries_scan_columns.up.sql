@@ -0,0 +1,4 @@
+ALTER TABLE `entries`
+ADD COLUMN `scan_status` VARCHAR(16) NOT NULL DEFAULT "" COMMENT "スキャン結果" AFTER `encoding`,
+ADD COLUMN `scan_signature` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "検出名" AFTER `scan_status`,
+ADD COLUMN `scanned_at` DATETIME (6) NULL COMMENT "スキャン日時" AFTER `scan_signature`;
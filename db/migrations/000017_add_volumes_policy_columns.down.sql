@@ -0,0 +1,8 @@
+ALTER TABLE `volumes`
+DROP COLUMN `max_entries_per_folder`,
+DROP COLUMN `max_key_depth`,
+DROP COLUMN `denied_extensions`,
+DROP COLUMN `allowed_extensions`,
+DROP COLUMN `denied_types`,
+DROP COLUMN `allowed_types`,
+DROP COLUMN `max_file_size`;
@@ -0,0 +1,8 @@
+ALTER TABLE `volumes`
+ADD COLUMN `max_file_size` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT "ファイルサイズの上限" AFTER `compression`,
+ADD COLUMN `allowed_types` VARCHAR(1024) NOT NULL DEFAULT "" COMMENT "許可する種別" AFTER `max_file_size`,
+ADD COLUMN `denied_types` VARCHAR(1024) NOT NULL DEFAULT "" COMMENT "拒否する種別" AFTER `allowed_types`,
+ADD COLUMN `allowed_extensions` VARCHAR(1024) NOT NULL DEFAULT "" COMMENT "許可する拡張子" AFTER `denied_types`,
+ADD COLUMN `denied_extensions` VARCHAR(1024) NOT NULL DEFAULT "" COMMENT "拒否する拡張子" AFTER `allowed_extensions`,
+ADD COLUMN `max_key_depth` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT "キーの階層の上限" AFTER `denied_extensions`,
+ADD COLUMN `max_entries_per_folder` INT UNSIGNED NOT NULL DEFAULT 0 COMMENT "フォルダ直下のエントリー数の上限" AFTER `max_key_depth`;
@@ -0,0 +1,3 @@
+ALTER TABLE `volumes`
+DROP COLUMN `drop_prefix`,
+DROP COLUMN `is_droppable`;
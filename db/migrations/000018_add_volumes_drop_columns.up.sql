@@ -0,0 +1,3 @@
+ALTER TABLE `volumes`
+ADD COLUMN `is_droppable` TINYINT(1) NOT NULL DEFAULT 0 COMMENT "匿名の投稿の許可フラグ" AFTER `max_entries_per_folder`,
+ADD COLUMN `drop_prefix` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "匿名の投稿先の接頭辞" AFTER `is_droppable`;
@@ -0,0 +1,5 @@
+ALTER TABLE `volumes`
+MODIFY COLUMN `name` VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT "ボリューム名";
+
+ALTER TABLE `entries`
+MODIFY COLUMN `name` VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT "名前";
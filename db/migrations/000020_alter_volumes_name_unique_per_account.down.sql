@@ -0,0 +1,3 @@
+ALTER TABLE `volumes`
+DROP INDEX `uq_volumes_account_id_and_name`,
+ADD UNIQUE `uq_volumes_name` (`name`);
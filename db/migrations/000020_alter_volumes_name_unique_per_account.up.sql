@@ -0,0 +1,3 @@
+ALTER TABLE `volumes`
+DROP INDEX `uq_volumes_name`,
+ADD UNIQUE `uq_volumes_account_id_and_name` (`account_id`, `name`);
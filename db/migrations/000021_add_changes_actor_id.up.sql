@@ -0,0 +1,2 @@
+ALTER TABLE `changes`
+ADD COLUMN `actor_id` CHAR(36) NULL COMMENT "操作者のアカウントID" AFTER `account_id`;
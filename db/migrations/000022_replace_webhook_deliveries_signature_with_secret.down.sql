@@ -0,0 +1,5 @@
+ALTER TABLE `webhook_deliveries`
+ADD COLUMN `signature` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "署名" AFTER `payload`;
+
+ALTER TABLE `webhook_deliveries`
+DROP COLUMN `secret`;
@@ -0,0 +1,13 @@
+ALTER TABLE `webhook_deliveries`
+ADD COLUMN `secret` CHAR(64) NOT NULL DEFAULT "" COMMENT "署名の鍵" AFTER `payload`;
+
+UPDATE `webhook_deliveries` AS `d`
+INNER JOIN `webhooks` AS `w` ON `d`.`webhook_id` = `w`.`id`
+SET `d`.`secret` = `w`.`secret`;
+
+UPDATE `webhook_deliveries`
+SET `status` = "failed", `error` = "webhook secret is not found"
+WHERE `secret` = "" AND `status` IN ("pending", "running");
+
+ALTER TABLE `webhook_deliveries`
+DROP COLUMN `signature`;
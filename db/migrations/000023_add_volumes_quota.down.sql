@@ -0,0 +1,3 @@
+ALTER TABLE `volumes`
+DROP COLUMN `max_volume_entries`,
+DROP COLUMN `max_volume_size`;
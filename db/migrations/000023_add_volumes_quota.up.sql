@@ -0,0 +1,3 @@
+ALTER TABLE `volumes`
+ADD COLUMN `max_volume_size` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT "ボリュームの容量の上限" AFTER `max_entries_per_folder`,
+ADD COLUMN `max_volume_entries` BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT "ボリュームのエントリー数の上限" AFTER `max_volume_size`;
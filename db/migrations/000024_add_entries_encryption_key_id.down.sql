@@ -0,0 +1,2 @@
+ALTER TABLE `entries`
+DROP COLUMN `encryption_key_id`;
@@ -0,0 +1,2 @@
+ALTER TABLE `entries`
+ADD COLUMN `encryption_key_id` VARCHAR(255) NOT NULL DEFAULT "" COMMENT "マスターキーID" AFTER `encoding`;
@@ -0,0 +1,2 @@
+ALTER TABLE `audit_logs`
+ADD COLUMN `principal` VARCHAR(16) NOT NULL DEFAULT "" COMMENT "認証情報の識別子" AFTER `credential_type`;
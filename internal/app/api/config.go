@@ -1,17 +1,77 @@
 package api
 
-import "os"
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+)
+
+var (
+	ErrInvalidEncryptionKeys    = errors.New("invalid FILE_SYSTEM_ENCRYPTION_KEYS")
+	ErrInvalidMalwareScanner    = errors.New("invalid MALWARE_SCANNER_ADDRESS")
+	ErrInvalidMalwareScanAction = errors.New("invalid MALWARE_SCAN_ACTION")
+	ErrInvalidDropRateLimit     = errors.New("invalid DROP_RATE_LIMIT")
+	ErrInvalidDropRateWindow    = errors.New("invalid DROP_RATE_WINDOW")
+	ErrInvalidTrustedProxies    = errors.New("invalid TRUSTED_PROXIES")
+	ErrInvalidAuditLogAdminIDs  = errors.New("invalid AUDIT_LOG_ADMIN_IDS")
+)
+
+const (
+	defaultDropRateLimit  = 10
+	defaultDropRateWindow = time.Minute
+)
 
 type serverConfig struct {
 	database   databaseConfig
 	fileSystem fileSystemConfig
+	scanner    scannerConfig
+	drop       dropConfig
+	proxy      proxyConfig
+	auditLog   auditLogConfig
 }
 
-func loadServerConfig() *serverConfig {
+func loadServerConfig() (*serverConfig, error) {
+	fileSystem, err := loadFileSystemConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scanner, err := loadScannerConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	drop, err := loadDropConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := loadProxyConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog, err := loadAuditLogConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &serverConfig{
 		database:   *loadDatabaseConfig(),
-		fileSystem: *loadFileSystemConfig(),
-	}
+		fileSystem: *fileSystem,
+		scanner:    *scanner,
+		drop:       *drop,
+		proxy:      *proxy,
+		auditLog:   *auditLog,
+	}, nil
 }
 
 type databaseConfig struct {
@@ -33,11 +93,159 @@ func loadDatabaseConfig() *databaseConfig {
 }
 
 type fileSystemConfig struct {
-	BasePath string
+	BasePath        string
+	EncryptionKeyID string
+	EncryptionKeys  map[string][]byte
 }
 
-func loadFileSystemConfig() *fileSystemConfig {
+func loadFileSystemConfig() (*fileSystemConfig, error) {
+	keyID := os.Getenv("FILE_SYSTEM_ENCRYPTION_KEY_ID")
+	keys, err := parseEncryptionKeys(os.Getenv("FILE_SYSTEM_ENCRYPTION_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := keys[keyID]; keyID != "" && !ok {
+		return nil, ErrInvalidEncryptionKeys
+	}
+
 	return &fileSystemConfig{
-		BasePath: os.Getenv("FILE_SYSTEM_BASE_PATH"),
+		BasePath:        os.Getenv("FILE_SYSTEM_BASE_PATH"),
+		EncryptionKeyID: keyID,
+		EncryptionKeys:  keys,
+	}, nil
+}
+
+// NOTE: "ID:Base64エンコードした32byteの鍵"をカンマ区切りで受け取る.
+func parseEncryptionKeys(value string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	if value == "" {
+		return keys, nil
 	}
+
+	for pair := range strings.SplitSeq(value, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || id == "" || 255 < len(id) {
+			return nil, ErrInvalidEncryptionKeys
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, ErrInvalidEncryptionKeys
+		}
+		keys[id] = key
+	}
+
+	return keys, nil
+}
+
+type scannerConfig struct {
+	Network string
+	Address string
+	Action  string
+}
+
+// NOTE: "tcp://host:port"または"unix:///path"でclamdを指定し, "fake://"はEICARテストファイルのみを検出する.
+func loadScannerConfig() (*scannerConfig, error) {
+	action := os.Getenv("MALWARE_SCAN_ACTION")
+	switch action {
+	case "":
+		action = usecase.ScanActionReject
+	case usecase.ScanActionReject, usecase.ScanActionQuarantine:
+	default:
+		return nil, ErrInvalidMalwareScanAction
+	}
+
+	address := os.Getenv("MALWARE_SCANNER_ADDRESS")
+	if address == "" {
+		return &scannerConfig{Action: action}, nil
+	}
+
+	network, address, ok := strings.Cut(address, "://")
+	if !ok || (network != "tcp" && network != "unix" && network != "fake") {
+		return nil, ErrInvalidMalwareScanner
+	}
+
+	return &scannerConfig{
+		Network: network,
+		Address: address,
+		Action:  action,
+	}, nil
+}
+
+type dropConfig struct {
+	RateLimit  uint64
+	RateWindow time.Duration
+}
+
+// NOTE: 匿名の投稿の上限をIPアドレス毎に"DROP_RATE_WINDOW"あたりの回数で指定する.
+func loadDropConfig() (*dropConfig, error) {
+	config := &dropConfig{
+		RateLimit:  defaultDropRateLimit,
+		RateWindow: defaultDropRateWindow,
+	}
+
+	if value := os.Getenv("DROP_RATE_LIMIT"); value != "" {
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 {
+			return nil, ErrInvalidDropRateLimit
+		}
+		config.RateLimit = limit
+	}
+
+	if value := os.Getenv("DROP_RATE_WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			return nil, ErrInvalidDropRateWindow
+		}
+		config.RateWindow = window
+	}
+
+	return config, nil
+}
+
+type proxyConfig struct {
+	TrustedProxies []string
+}
+
+// NOTE: 転送元のIPアドレスを信頼するプロキシのIPアドレス又はCIDRをカンマ区切りで受け取り, 未指定の場合は接続元のIPアドレスを利用する.
+func loadProxyConfig() (*proxyConfig, error) {
+	config := &proxyConfig{}
+
+	value := os.Getenv("TRUSTED_PROXIES")
+	if value == "" {
+		return config, nil
+	}
+
+	for v := range strings.SplitSeq(value, ",") {
+		proxy := strings.TrimSpace(v)
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, ErrInvalidTrustedProxies
+		}
+		config.TrustedProxies = append(config.TrustedProxies, proxy)
+	}
+
+	return config, nil
+}
+
+type auditLogConfig struct {
+	AdminIDs []uuid.UUID
+}
+
+// NOTE: 全ての監査ログを参照できる管理者のアカウントIDをカンマ区切りで受け取る.
+func loadAuditLogConfig() (*auditLogConfig, error) {
+	config := &auditLogConfig{}
+
+	value := os.Getenv("AUDIT_LOG_ADMIN_IDS")
+	if value == "" {
+		return config, nil
+	}
+
+	for v := range strings.SplitSeq(value, ",") {
+		id, err := uuid.Parse(strings.TrimSpace(v))
+		if err != nil || id == uuid.Nil {
+			return nil, ErrInvalidAuditLogAdminIDs
+		}
+		config.AdminIDs = append(config.AdminIDs, id)
+	}
+
+	return config, nil
 }
@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CredentialTypeSession   = "session"
+	CredentialTypeAccessKey = "access_key"
+	CredentialTypeAnonymous = "anonymous"
+	CredentialTypeUnknown   = "unknown"
+)
+
+const (
+	maxAuditLogRequestIDLength = 255
+	maxAuditLogUserAgentLength = 512
+	maxAuditLogNameLength      = 255
+	maxAuditLogKeyLength       = 1024
+)
+
+// NOTE: 認証情報を復元できないよう, ハッシュ値の先頭のみを識別子とする.
+const auditLogPrincipalLength = 8
+
+// NOTE: 追記のみを行う記録であり, IDは登録順に採番する.
+type AuditLog struct {
+	ID             uint64
+	RequestID      string
+	OwnerID        uuid.UUID
+	ActorID        uuid.UUID
+	CredentialType string
+	Principal      string
+	ClientIP       string
+	UserAgent      string
+	Operation      string
+	VolumeName     string
+	Key            string
+	NewVolumeName  string
+	NewKey         string
+	Status         uint64
+	CreatedAt      time.Time
+}
+
+// NOTE: 不正なリクエストも記録するため, 上限を超える文字列は切り詰める.
+func NewAuditLog(
+	requestID string,
+	ownerID, actorID uuid.UUID,
+	credentialType, principal, clientIP, userAgent, operation, volumeName, key, newVolumeName, newKey string,
+	status uint64,
+) *AuditLog {
+	return &AuditLog{
+		RequestID:      truncate(requestID, maxAuditLogRequestIDLength),
+		OwnerID:        ownerID,
+		ActorID:        actorID,
+		CredentialType: credentialType,
+		Principal:      principal,
+		ClientIP:       clientIP,
+		UserAgent:      truncate(userAgent, maxAuditLogUserAgentLength),
+		Operation:      operation,
+		VolumeName:     truncate(volumeName, maxAuditLogNameLength),
+		Key:            truncate(key, maxAuditLogKeyLength),
+		NewVolumeName:  truncate(newVolumeName, maxAuditLogNameLength),
+		NewKey:         truncate(newKey, maxAuditLogKeyLength),
+		Status:         status,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func RestoreAuditLog(
+	id uint64,
+	requestID string,
+	ownerID, actorID uuid.UUID,
+	credentialType, principal, clientIP, userAgent, operation, volumeName, key, newVolumeName, newKey string,
+	status uint64,
+	createdAt time.Time,
+) *AuditLog {
+	return &AuditLog{
+		ID:             id,
+		RequestID:      requestID,
+		OwnerID:        ownerID,
+		ActorID:        actorID,
+		CredentialType: credentialType,
+		Principal:      principal,
+		ClientIP:       clientIP,
+		UserAgent:      userAgent,
+		Operation:      operation,
+		VolumeName:     volumeName,
+		Key:            key,
+		NewVolumeName:  newVolumeName,
+		NewKey:         newKey,
+		Status:         status,
+		CreatedAt:      createdAt,
+	}
+}
+
+// NOTE: 認証情報そのものは記録せず, Authorization ヘッダーのスキームから種別のみを判定する.
+func ResolveCredentialType(credential string) string {
+	if credential == "" {
+		return CredentialTypeAnonymous
+	}
+
+	scheme, _, _ := strings.Cut(credential, " ")
+	switch strings.TrimSuffix(scheme, ":") {
+	case "Session":
+		return CredentialTypeSession
+	case "AccessKey":
+		return CredentialTypeAccessKey
+	default:
+		return CredentialTypeUnknown
+	}
+}
+
+// NOTE: 認可に失敗した場合もアカウントを特定せずに同じ認証情報による試行を突き合わせられるようにする.
+func ResolvePrincipal(credential string) string {
+	if credential == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:auditLogPrincipalLength])
+}
+
+func truncate(s string, length int) string {
+	if utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	return string([]rune(s)[:length])
+}
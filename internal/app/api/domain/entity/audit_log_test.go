@@ -0,0 +1,139 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	ownerID := uuid.New()
+	actorID := uuid.New()
+
+	tests := []struct {
+		name           string
+		inputUserAgent string
+		inputKey       string
+		expectResult   *entity.AuditLog
+	}{
+		{
+			name:           "successfully initialized",
+			inputUserAgent: "agent",
+			inputKey:       "key",
+			expectResult: &entity.AuditLog{
+				RequestID:      "request",
+				OwnerID:        ownerID,
+				ActorID:        actorID,
+				CredentialType: entity.CredentialTypeSession,
+				Principal:      "principal",
+				ClientIP:       "127.0.0.1",
+				UserAgent:      "agent",
+				Operation:      "entry.update",
+				VolumeName:     "volume",
+				Key:            "key",
+				NewVolumeName:  "other",
+				NewKey:         "new",
+				Status:         200,
+			},
+		},
+		{
+			name:           "long values are truncated",
+			inputUserAgent: strings.Repeat("あ", 513),
+			inputKey:       strings.Repeat("a", 1025),
+			expectResult: &entity.AuditLog{
+				RequestID:      "request",
+				OwnerID:        ownerID,
+				ActorID:        actorID,
+				CredentialType: entity.CredentialTypeSession,
+				Principal:      "principal",
+				ClientIP:       "127.0.0.1",
+				UserAgent:      strings.Repeat("あ", 512),
+				Operation:      "entry.update",
+				VolumeName:     "volume",
+				Key:            strings.Repeat("a", 1024),
+				NewVolumeName:  "other",
+				NewKey:         "new",
+				Status:         200,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := entity.NewAuditLog("request", ownerID, actorID, entity.CredentialTypeSession, "principal", "127.0.0.1", tt.inputUserAgent, "entry.update", "volume", tt.inputKey, "other", "new", 200)
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(entity.AuditLog{}, "CreatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestResolveCredentialType(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputCredential string
+		expectResult    string
+	}{
+		{
+			name:            "session",
+			inputCredential: "Session 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS",
+			expectResult:    entity.CredentialTypeSession,
+		},
+		{
+			name:            "access key",
+			inputCredential: "AccessKey 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS",
+			expectResult:    entity.CredentialTypeAccessKey,
+		},
+		{
+			name:            "anonymous",
+			inputCredential: "",
+			expectResult:    entity.CredentialTypeAnonymous,
+		},
+		{
+			name:            "unknown",
+			inputCredential: "Bearer 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS",
+			expectResult:    entity.CredentialTypeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := entity.ResolveCredentialType(tt.inputCredential); result != tt.expectResult {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestResolvePrincipal(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputCredential string
+		expectResult    string
+	}{
+		{
+			name:            "session",
+			inputCredential: "Session 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS",
+			expectResult:    "0e045a2938edf961",
+		},
+		{
+			name:            "anonymous",
+			inputCredential: "",
+			expectResult:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := entity.ResolvePrincipal(tt.inputCredential); result != tt.expectResult {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectResult, result)
+			}
+		})
+	}
+}
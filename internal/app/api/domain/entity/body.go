@@ -0,0 +1,24 @@
+package entity
+
+type Body struct {
+	Path       string
+	Size       uint64
+	IsFolder   bool
+	Attributes BodyAttributes
+}
+
+// NOTE: ボディの保存形式を表し, BodyRepositoryのデコレータが保存時に設定して取得時に参照する.
+type BodyAttributes struct {
+	EncryptionKeyID string
+	Encoding        string
+	// NOTE: 取得時のみ指定し, Encodingと一致する場合は展開せずに返却させる.
+	ContentEncoding string
+}
+
+func RestoreBody(path string, size uint64, isFolder bool) *Body {
+	return &Body{
+		Path:     path,
+		Size:     size,
+		IsFolder: isFolder,
+	}
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NOTE: ボリューム毎の変更履歴であり, キーは対象のボリューム内のキーとする.
+type Change struct {
+	VolumeID  uuid.UUID
+	Sequence  uint64
+	EventID   uuid.UUID
+	AccountID uuid.UUID
+	ActorID   uuid.UUID
+	Type      string
+	Key       string
+	NewKey    string
+	Size      uint64
+	CreatedAt time.Time
+}
+
+// NOTE: 別のボリュームへの移動は移動元の削除と移動先の作成, 複製は複製先の作成, 匿名の投稿は作成として記録する.
+func NewChanges(event *Event) []*Change {
+	if event == nil || !event.IsEntryEvent() {
+		return nil
+	}
+
+	if event.Type == EventTypeEntryDropped {
+		return []*Change{newChange(event, event.VolumeID, EventTypeEntryCreated, event.Key, "")}
+	}
+	if event.NewVolumeID == uuid.Nil || event.NewVolumeID == event.VolumeID {
+		return []*Change{newChange(event, event.VolumeID, event.Type, event.Key, event.NewKey)}
+	}
+
+	created := newChange(event, event.NewVolumeID, EventTypeEntryCreated, event.NewKey, "")
+	if event.Type == EventTypeEntryCopied {
+		return []*Change{created}
+	}
+	return []*Change{newChange(event, event.VolumeID, EventTypeEntryDeleted, event.Key, ""), created}
+}
+
+func RestoreChange(volumeID uuid.UUID, sequence uint64, eventID, accountID, actorID uuid.UUID, changeType, key, newKey string, size uint64, createdAt time.Time) *Change {
+	return &Change{
+		VolumeID:  volumeID,
+		Sequence:  sequence,
+		EventID:   eventID,
+		AccountID: accountID,
+		ActorID:   actorID,
+		Type:      changeType,
+		Key:       key,
+		NewKey:    newKey,
+		Size:      size,
+		CreatedAt: createdAt,
+	}
+}
+
+// NOTE: 移動, 複製の場合は変更前と変更後のいずれかのキーが一致する場合を対象とする.
+func (c *Change) Matches(prefix string) bool {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+	return hasKeyPrefix(c.Key, prefix) || (c.NewKey != "" && hasKeyPrefix(c.NewKey, prefix))
+}
+
+func newChange(event *Event, volumeID uuid.UUID, changeType, key, newKey string) *Change {
+	return &Change{
+		VolumeID:  volumeID,
+		EventID:   event.ID,
+		AccountID: event.AccountID,
+		ActorID:   event.ActorID,
+		Type:      changeType,
+		Key:       key,
+		NewKey:    newKey,
+		Size:      event.Size,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func hasKeyPrefix(key, prefix string) bool {
+	return key == prefix || strings.HasPrefix(key, prefix+"/")
+}
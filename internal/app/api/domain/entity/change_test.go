@@ -0,0 +1,114 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewChanges(t *testing.T) {
+	volume := &entity.Volume{ID: uuid.New(), AccountID: uuid.New(), Name: "name"}
+	other := &entity.Volume{ID: uuid.New(), AccountID: volume.AccountID, Name: "other"}
+
+	tests := []struct {
+		name             string
+		inputType        string
+		inputVolume      *entity.Volume
+		inputDestination *entity.Volume
+		expectResult     []*entity.Change
+	}{
+		{
+			name:         "created",
+			inputType:    entity.EventTypeEntryCreated,
+			inputVolume:  volume,
+			expectResult: []*entity.Change{{VolumeID: volume.ID, AccountID: volume.AccountID, ActorID: volume.AccountID, Type: entity.EventTypeEntryCreated, Key: "key", Size: 10}},
+		},
+		{
+			name:         "dropped",
+			inputType:    entity.EventTypeEntryDropped,
+			inputVolume:  volume,
+			expectResult: []*entity.Change{{VolumeID: volume.ID, AccountID: volume.AccountID, ActorID: volume.AccountID, Type: entity.EventTypeEntryCreated, Key: "key", Size: 10}},
+		},
+		{
+			name:             "renamed in same volume",
+			inputType:        entity.EventTypeEntryRenamed,
+			inputVolume:      volume,
+			inputDestination: volume,
+			expectResult:     []*entity.Change{{VolumeID: volume.ID, AccountID: volume.AccountID, ActorID: volume.AccountID, Type: entity.EventTypeEntryRenamed, Key: "key", NewKey: "new_key", Size: 10}},
+		},
+		{
+			name:             "renamed to other volume",
+			inputType:        entity.EventTypeEntryRenamed,
+			inputVolume:      volume,
+			inputDestination: other,
+			expectResult: []*entity.Change{
+				{VolumeID: volume.ID, AccountID: volume.AccountID, ActorID: volume.AccountID, Type: entity.EventTypeEntryDeleted, Key: "key", Size: 10},
+				{VolumeID: other.ID, AccountID: volume.AccountID, ActorID: volume.AccountID, Type: entity.EventTypeEntryCreated, Key: "new_key", Size: 10},
+			},
+		},
+		{
+			name:             "copied to other volume",
+			inputType:        entity.EventTypeEntryCopied,
+			inputVolume:      volume,
+			inputDestination: other,
+			expectResult:     []*entity.Change{{VolumeID: other.ID, AccountID: volume.AccountID, ActorID: volume.AccountID, Type: entity.EventTypeEntryCreated, Key: "new_key", Size: 10}},
+		},
+		{
+			name:         "volume event",
+			inputType:    entity.EventTypeVolumeUpdated,
+			inputVolume:  volume,
+			expectResult: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := entity.NewEvent(tt.inputType, tt.inputVolume, "key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			event.SetSize(10)
+			event.ActorID = volume.AccountID
+			if tt.inputDestination != nil {
+				if err := event.SetDestination(tt.inputDestination, "new_key"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(entity.Change{}, "EventID", "CreatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, entity.NewChanges(event), opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestChange_Matches(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputKey      string
+		inputNewKey   string
+		inputPrefix   string
+		expectMatches bool
+	}{
+		{name: "no prefix", inputKey: "key", inputPrefix: "", expectMatches: true},
+		{name: "same key as prefix", inputKey: "dir", inputPrefix: "dir", expectMatches: true},
+		{name: "key under prefix", inputKey: "dir/key", inputPrefix: "/dir/", expectMatches: true},
+		{name: "key with same beginning", inputKey: "directory", inputPrefix: "dir", expectMatches: false},
+		{name: "new key under prefix", inputKey: "key", inputNewKey: "dir/key", inputPrefix: "dir", expectMatches: true},
+		{name: "not matched", inputKey: "key", inputNewKey: "new_key", inputPrefix: "dir", expectMatches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := &entity.Change{Key: tt.inputKey, NewKey: tt.inputNewKey}
+			if matches := change.Matches(tt.inputPrefix); matches != tt.expectMatches {
+				t.Errorf("\nexpect: %t\ngot: %t", tt.expectMatches, matches)
+			}
+		})
+	}
+}
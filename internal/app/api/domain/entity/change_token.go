@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrInvalidChangeToken = status.Error(code.BadRequest, "invalid change token")
+
+// NOTE: ボリュームを再作成すると連番が初めからになるため, トークンにボリュームIDを含めて検証する.
+type ChangeToken struct {
+	VolumeID uuid.UUID
+	Sequence uint64
+}
+
+func NewChangeToken(volumeID uuid.UUID, sequence uint64) *ChangeToken {
+	return &ChangeToken{
+		VolumeID: volumeID,
+		Sequence: sequence,
+	}
+}
+
+func ParseChangeToken(token string) (*ChangeToken, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidChangeToken
+	}
+
+	volumeID, sequence, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, ErrInvalidChangeToken
+	}
+
+	id, err := uuid.Parse(volumeID)
+	if err != nil {
+		return nil, ErrInvalidChangeToken
+	}
+	seq, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidChangeToken
+	}
+
+	return NewChangeToken(id, seq), nil
+}
+
+func (t *ChangeToken) Verify(volumeID uuid.UUID) error {
+	if t.VolumeID != volumeID {
+		return ErrInvalidChangeToken
+	}
+	return nil
+}
+
+func (t *ChangeToken) Advance(changes []*Change) {
+	if 0 < len(changes) {
+		t.Sequence = changes[len(changes)-1].Sequence
+	}
+}
+
+func (t *ChangeToken) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.VolumeID.String() + ":" + strconv.FormatUint(t.Sequence, 10)))
+}
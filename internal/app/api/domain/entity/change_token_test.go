@@ -0,0 +1,123 @@
+package entity_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestParseChangeToken(t *testing.T) {
+	volumeID := uuid.New()
+
+	tests := []struct {
+		name         string
+		inputToken   string
+		expectResult *entity.ChangeToken
+		expectError  error
+	}{
+		{
+			name:         "successfully parsed",
+			inputToken:   entity.NewChangeToken(volumeID, 42).String(),
+			expectResult: &entity.ChangeToken{VolumeID: volumeID, Sequence: 42},
+			expectError:  nil,
+		},
+		{
+			name:         "invalid encoding",
+			inputToken:   "!",
+			expectResult: nil,
+			expectError:  entity.ErrInvalidChangeToken,
+		},
+		{
+			name:         "missing separator",
+			inputToken:   base64.RawURLEncoding.EncodeToString([]byte(volumeID.String())),
+			expectResult: nil,
+			expectError:  entity.ErrInvalidChangeToken,
+		},
+		{
+			name:         "invalid volume id",
+			inputToken:   base64.RawURLEncoding.EncodeToString([]byte("volume:42")),
+			expectResult: nil,
+			expectError:  entity.ErrInvalidChangeToken,
+		},
+		{
+			name:         "invalid sequence",
+			inputToken:   base64.RawURLEncoding.EncodeToString([]byte(volumeID.String() + ":-1")),
+			expectResult: nil,
+			expectError:  entity.ErrInvalidChangeToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := entity.ParseChangeToken(tt.inputToken)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestChangeToken_Verify(t *testing.T) {
+	volumeID := uuid.New()
+
+	tests := []struct {
+		name          string
+		inputVolumeID uuid.UUID
+		expectError   error
+	}{
+		{
+			name:          "same volume",
+			inputVolumeID: volumeID,
+			expectError:   nil,
+		},
+		{
+			name:          "other volume",
+			inputVolumeID: uuid.New(),
+			expectError:   entity.ErrInvalidChangeToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.NewChangeToken(volumeID, 1)
+			if err := token.Verify(tt.inputVolumeID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestChangeToken_Advance(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputChanges   []*entity.Change
+		expectSequence uint64
+	}{
+		{
+			name:           "advanced",
+			inputChanges:   []*entity.Change{{Sequence: 2}, {Sequence: 3}},
+			expectSequence: 3,
+		},
+		{
+			name:           "no changes",
+			inputChanges:   []*entity.Change{},
+			expectSequence: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := entity.NewChangeToken(uuid.New(), 1)
+			token.Advance(tt.inputChanges)
+			if token.Sequence != tt.expectSequence {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectSequence, token.Sequence)
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package entity
 
 import (
-	"regexp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
@@ -11,23 +15,39 @@ import (
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
 )
 
+const entryETagSize = 16
+
+const (
+	EntryScanStatusClean    = "clean"
+	EntryScanStatusInfected = "infected"
+
+	maxEntryScanSignatureLength = 255
+)
+
 var (
 	ErrRequiredEntryAccountID = status.Error(code.Internal, "account id for entry is required")
 	ErrRequiredEntryVolumeID  = status.Error(code.Internal, "volume id for entry is required")
 	ErrShortEntryKey          = status.Error(code.UnprocessableContent, "entry key is too short")
 	ErrLongEntryKey           = status.Error(code.UnprocessableContent, "entry key is too long")
 	ErrInvalidEntryKey        = status.Error(code.UnprocessableContent, "entry key contains invalid characters")
+	ErrEntryQuarantined       = status.Error(code.MalwareDetected, "entry is quarantined")
 )
 
 type Entry struct {
-	ID        uuid.UUID
-	AccountID uuid.UUID
-	VolumeID  uuid.UUID
-	Key       string
-	Size      uint64
-	Type      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	VolumeID        uuid.UUID
+	ParentID        uuid.UUID
+	Key             string
+	Size            uint64
+	Type            string
+	Encoding        string
+	EncryptionKeyID string
+	ScanStatus      string
+	ScanSignature   string
+	ScannedAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 func NewEntry(accountID, volumeID uuid.UUID, key string, size uint64, entryType string) (*Entry, error) {
@@ -56,51 +76,133 @@ func NewEntry(accountID, volumeID uuid.UUID, key string, size uint64, entryType
 	return &entry, nil
 }
 
-func RestoreEntry(id, accountID, volumeID uuid.UUID, key string, size uint64, entryType string, createdAt, updatedAt time.Time) *Entry {
+func RestoreEntry(id, accountID, volumeID, parentID uuid.UUID, key string, size uint64, entryType, encoding, encryptionKeyID, scanStatus, scanSignature string, scannedAt *time.Time, createdAt, updatedAt time.Time) *Entry {
 	return &Entry{
-		ID:        id,
-		AccountID: accountID,
-		VolumeID:  volumeID,
-		Key:       key,
-		Size:      size,
-		Type:      entryType,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:              id,
+		AccountID:       accountID,
+		VolumeID:        volumeID,
+		ParentID:        parentID,
+		Key:             key,
+		Size:            size,
+		Type:            entryType,
+		Encoding:        encoding,
+		EncryptionKeyID: encryptionKeyID,
+		ScanStatus:      scanStatus,
+		ScanSignature:   scanSignature,
+		ScannedAt:       scannedAt,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
 	}
 }
 
+// NOTE: キー全体はカラムに合わせて文字数で, 各階層の名前はファイル名としてバイト数で制限する.
 func (e *Entry) SetKey(key string) error {
-	key = strings.Trim(key, "/")
+	key = normalizeName(strings.Trim(key, "/"))
 	if len(key) < 1 {
 		return ErrShortEntryKey
 	}
-	if 512 < len(key) {
+	if 512 < utf8.RuneCountInString(key) {
 		return ErrLongEntryKey
 	}
 
 	for k := range strings.SplitSeq(key, "/") {
-		if len(k) < 1 || 255 < len(k) {
+		if !isValidName(k) {
 			return ErrInvalidEntryKey
 		}
 	}
 
-	matched, err := regexp.MatchString(`^[A-Za-z0-9!@#$%^&()_\-+=\[\]{};',./~ ]*$`, key)
-	if err != nil {
+	e.Key = key
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+func (e *Entry) SetVolumeID(volumeID uuid.UUID) error {
+	if err := e.setVolumeID(volumeID); err != nil {
 		return err
 	}
-	if !matched {
-		return ErrInvalidEntryKey
-	}
-
-	e.Key = key
 	e.UpdatedAt = time.Now()
 	return nil
 }
 
+// NOTE: ルートのエントリーはuuid.Nilを親とする.
+func (e *Entry) SetParentID(parentID uuid.UUID) {
+	e.ParentID = parentID
+	e.UpdatedAt = time.Now()
+}
+
+func (e *Entry) SetSize(size uint64) {
+	e.Size = size
+	e.UpdatedAt = time.Now()
+}
+
+func (e *Entry) SetType(entryType string) {
+	e.Type = entryType
+	e.UpdatedAt = time.Now()
+}
+
+func (e *Entry) SetEncoding(encoding string) {
+	e.Encoding = encoding
+	e.UpdatedAt = time.Now()
+}
+
+// NOTE: ボディは変わらないため更新日時は更新しない.
+func (e *Entry) SetBodyAttributes(attributes *BodyAttributes) {
+	e.EncryptionKeyID = attributes.EncryptionKeyID
+	e.Encoding = attributes.Encoding
+}
+
+func (e *Entry) BodyAttributes() *BodyAttributes {
+	return &BodyAttributes{EncryptionKeyID: e.EncryptionKeyID, Encoding: e.Encoding}
+}
+
+// NOTE: 検出名が空の場合は感染していないとみなす. ボディは変わらないため更新日時は更新しない.
+func (e *Entry) SetScanResult(signature string) {
+	e.ScanStatus = EntryScanStatusClean
+	if signature != "" {
+		e.ScanStatus = EntryScanStatusInfected
+	}
+	if maxEntryScanSignatureLength < len(signature) {
+		signature = signature[:maxEntryScanSignatureLength]
+	}
+	e.ScanSignature = signature
+
+	now := time.Now()
+	e.ScannedAt = &now
+}
+
+func (e *Entry) Name() string {
+	return path.Base(e.Key)
+}
+
+// NOTE: 内容の更新で必ず更新日時が変わるため, IDと更新日時, サイズから生成する.
+func (e *Entry) ETag() string {
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s:%d:%d", e.ID, e.UpdatedAt.UnixNano(), e.Size))
+	return hex.EncodeToString(sum[:entryETagSize])
+}
+
+func (e *Entry) IsQuarantined() bool {
+	return e.ScanStatus == EntryScanStatusInfected
+}
+
 func (e *Entry) IsFolder() bool {
 	return e.Type == "folder"
 }
 
+func (e *Entry) IsCompressible() bool {
+	mediaType, _, _ := strings.Cut(e.Type, ";")
+	mediaType = strings.TrimSpace(mediaType)
+
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/javascript", "application/x-ndjson", "application/wasm":
+		return true
+	default:
+		return false
+	}
+}
+
 func (e *Entry) generateID() error {
 	id, err := uuid.NewRandom()
 	if err != nil {
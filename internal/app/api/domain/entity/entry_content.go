@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	maxEntryContentLength      = 1 << 20
+	maxEntryContentQueryLength = 255
+)
+
+// NOTE: 全文検索の演算子として解釈される記号は検索語から除く.
+const entryContentQueryOperators = `+-<>()~*"@`
+
+var (
+	ErrRequiredEntryContentEntryID = status.Error(code.Internal, "entry id for entry content is required")
+	ErrLongEntryContentQuery       = status.Error(code.BadRequest, "search query is too long")
+	ErrInvalidEntryContentQuery    = status.Error(code.BadRequest, "search query has no terms")
+)
+
+type EntryContent struct {
+	EntryID uuid.UUID
+	Content string
+}
+
+type EntryContentMatch struct {
+	EntryID uuid.UUID
+	Snippet string
+}
+
+func NewEntryContent(entryID uuid.UUID, content string) (*EntryContent, error) {
+	entryContent := EntryContent{
+		Content: truncateEntryContent(content),
+	}
+
+	if err := entryContent.setEntryID(entryID); err != nil {
+		return nil, err
+	}
+
+	return &entryContent, nil
+}
+
+func RestoreEntryContentMatch(entryID uuid.UUID, snippet string) *EntryContentMatch {
+	return &EntryContentMatch{
+		EntryID: entryID,
+		Snippet: snippet,
+	}
+}
+
+// NOTE: 空白で区切られた検索語を全て含むエントリーを検索する.
+func ParseEntryContentQuery(query string) ([]string, error) {
+	if maxEntryContentQueryLength < utf8.RuneCountInString(query) {
+		return nil, ErrLongEntryContentQuery
+	}
+
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		term = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(entryContentQueryOperators, r) {
+				return -1
+			}
+			return r
+		}, term)
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil, ErrInvalidEntryContentQuery
+	}
+	return terms, nil
+}
+
+func (c *EntryContent) setEntryID(entryID uuid.UUID) error {
+	if entryID == uuid.Nil {
+		return ErrRequiredEntryContentEntryID
+	}
+	c.EntryID = entryID
+	return nil
+}
+
+// NOTE: 本文はバイト数で制限し, 文字の途中で切り詰めない.
+func truncateEntryContent(content string) string {
+	if len(content) <= maxEntryContentLength {
+		return content
+	}
+	content = content[:maxEntryContentLength]
+	for !utf8.ValidString(content) {
+		content = content[:len(content)-1]
+	}
+	return content
+}
@@ -0,0 +1,86 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewEntryContent(t *testing.T) {
+	entryID := uuid.New()
+
+	tests := []struct {
+		name         string
+		inputEntryID uuid.UUID
+		inputContent string
+		expectResult *entity.EntryContent
+		expectError  error
+	}{
+		{
+			name:         "successfully initialized",
+			inputEntryID: entryID,
+			inputContent: "content",
+			expectResult: &entity.EntryContent{EntryID: entryID, Content: "content"},
+			expectError:  nil,
+		},
+		{
+			name:         "entry id is nil",
+			inputEntryID: uuid.Nil,
+			inputContent: "content",
+			expectResult: nil,
+			expectError:  entity.ErrRequiredEntryContentEntryID,
+		},
+		{
+			name:         "long content",
+			inputEntryID: entryID,
+			inputContent: "a" + strings.Repeat("あ", 1<<19),
+			expectResult: &entity.EntryContent{EntryID: entryID, Content: "a" + strings.Repeat("あ", (1<<20-1)/3)},
+			expectError:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := entity.NewEntryContent(tt.inputEntryID, tt.inputContent)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseEntryContentQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputQuery   string
+		expectResult []string
+		expectError  error
+	}{
+		{name: "successfully parsed", inputQuery: " holos  ストレージ ", expectResult: []string{"holos", "ストレージ"}, expectError: nil},
+		{name: "operators", inputQuery: `+"holos" -storage*`, expectResult: []string{"holos", "storage"}, expectError: nil},
+		{name: "only operators", inputQuery: `+ - "`, expectResult: nil, expectError: entity.ErrInvalidEntryContentQuery},
+		{name: "long query", inputQuery: strings.Repeat("あ", 256), expectResult: nil, expectError: entity.ErrLongEntryContentQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := entity.ParseEntryContentQuery(tt.inputQuery)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const maxEntryMetadataTextLength = 255
+
+var ErrRequiredEntryMetadataEntryID = status.Error(code.Internal, "entry id for entry metadata is required")
+
+type EntryMetadata struct {
+	EntryID     uuid.UUID
+	Width       uint64
+	Height      uint64
+	TakenAt     *time.Time
+	CameraMake  string
+	CameraModel string
+	Latitude    *float64
+	Longitude   *float64
+	PageCount   uint64
+	Duration    uint64
+	Title       string
+	Artist      string
+	Album       string
+}
+
+func NewEntryMetadata(entryID uuid.UUID, width, height uint64, takenAt *time.Time, cameraMake, cameraModel string, latitude, longitude *float64, pageCount, duration uint64, title, artist, album string) (*EntryMetadata, error) {
+	metadata := EntryMetadata{
+		Width:       width,
+		Height:      height,
+		TakenAt:     takenAt,
+		CameraMake:  truncateEntryMetadataText(cameraMake),
+		CameraModel: truncateEntryMetadataText(cameraModel),
+		PageCount:   pageCount,
+		Duration:    duration,
+		Title:       truncateEntryMetadataText(title),
+		Artist:      truncateEntryMetadataText(artist),
+		Album:       truncateEntryMetadataText(album),
+	}
+
+	if err := metadata.setEntryID(entryID); err != nil {
+		return nil, err
+	}
+	metadata.setLocation(latitude, longitude)
+
+	return &metadata, nil
+}
+
+func RestoreEntryMetadata(entryID uuid.UUID, width, height uint64, takenAt *time.Time, cameraMake, cameraModel string, latitude, longitude *float64, pageCount, duration uint64, title, artist, album string) *EntryMetadata {
+	return &EntryMetadata{
+		EntryID:     entryID,
+		Width:       width,
+		Height:      height,
+		TakenAt:     takenAt,
+		CameraMake:  cameraMake,
+		CameraModel: cameraModel,
+		Latitude:    latitude,
+		Longitude:   longitude,
+		PageCount:   pageCount,
+		Duration:    duration,
+		Title:       title,
+		Artist:      artist,
+		Album:       album,
+	}
+}
+
+func (m *EntryMetadata) setEntryID(entryID uuid.UUID) error {
+	if entryID == uuid.Nil {
+		return ErrRequiredEntryMetadataEntryID
+	}
+	m.EntryID = entryID
+	return nil
+}
+
+// NOTE: 緯度と経度のどちらかが欠けている, または範囲外の場合は位置情報を持たないものとする.
+func (m *EntryMetadata) setLocation(latitude, longitude *float64) {
+	if latitude == nil || longitude == nil {
+		return
+	}
+	if *latitude < -90 || 90 < *latitude || *longitude < -180 || 180 < *longitude {
+		return
+	}
+	m.Latitude = latitude
+	m.Longitude = longitude
+}
+
+func truncateEntryMetadataText(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxEntryMetadataTextLength {
+		return text
+	}
+	return string(runes[:maxEntryMetadataTextLength])
+}
@@ -0,0 +1,98 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func TestNewEntryMetadata(t *testing.T) {
+	entryID := uuid.New()
+
+	tests := []struct {
+		name           string
+		inputEntryID   uuid.UUID
+		inputLatitude  *float64
+		inputLongitude *float64
+		inputTitle     string
+		expectResult   *entity.EntryMetadata
+		expectError    error
+	}{
+		{
+			name:           "successfully initialized",
+			inputEntryID:   entryID,
+			inputLatitude:  pointer(35.5),
+			inputLongitude: pointer(-139.75),
+			inputTitle:     "title",
+			expectResult:   &entity.EntryMetadata{EntryID: entryID, Width: 40, Height: 20, Latitude: pointer(35.5), Longitude: pointer(-139.75), Title: "title"},
+			expectError:    nil,
+		},
+		{
+			name:           "entry id is nil",
+			inputEntryID:   uuid.Nil,
+			inputLatitude:  nil,
+			inputLongitude: nil,
+			inputTitle:     "",
+			expectResult:   nil,
+			expectError:    entity.ErrRequiredEntryMetadataEntryID,
+		},
+		{
+			name:           "latitude only",
+			inputEntryID:   entryID,
+			inputLatitude:  pointer(35.5),
+			inputLongitude: nil,
+			inputTitle:     "",
+			expectResult:   &entity.EntryMetadata{EntryID: entryID, Width: 40, Height: 20},
+			expectError:    nil,
+		},
+		{
+			name:           "latitude out of range",
+			inputEntryID:   entryID,
+			inputLatitude:  pointer(90.5),
+			inputLongitude: pointer(139.75),
+			inputTitle:     "",
+			expectResult:   &entity.EntryMetadata{EntryID: entryID, Width: 40, Height: 20},
+			expectError:    nil,
+		},
+		{
+			name:           "longitude out of range",
+			inputEntryID:   entryID,
+			inputLatitude:  pointer(35.5),
+			inputLongitude: pointer(-180.5),
+			inputTitle:     "",
+			expectResult:   &entity.EntryMetadata{EntryID: entryID, Width: 40, Height: 20},
+			expectError:    nil,
+		},
+		{
+			name:           "long title",
+			inputEntryID:   entryID,
+			inputLatitude:  nil,
+			inputLongitude: nil,
+			inputTitle:     strings.Repeat("あ", 256),
+			expectResult:   &entity.EntryMetadata{EntryID: entryID, Width: 40, Height: 20, Title: strings.Repeat("あ", 255)},
+			expectError:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := entity.NewEntryMetadata(tt.inputEntryID, 40, 20, nil, "", "", tt.inputLatitude, tt.inputLongitude, 0, 0, tt.inputTitle, "", "")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -99,13 +99,23 @@ func TestEntry_SetKey(t *testing.T) {
 		{name: "include greater than sign", inputKey: "entry>key", expectError: entity.ErrInvalidEntryKey},
 		{name: "include less than sign", inputKey: "entry<key", expectError: entity.ErrInvalidEntryKey},
 		{name: "include vertical bar", inputKey: "entry|key", expectError: entity.ErrInvalidEntryKey},
-		{name: "full width", inputKey: "エントリーキー", expectError: entity.ErrInvalidEntryKey},
+		{name: "full width", inputKey: "エントリーキー", expectError: nil},
+		{name: "emoji", inputKey: "📁/👨‍👩‍👧.txt", expectError: nil},
+		{name: "include control character", inputKey: "entry\x00key", expectError: entity.ErrInvalidEntryKey},
+		{name: "include bidi control character", inputKey: "entry\u202ekey", expectError: entity.ErrInvalidEntryKey},
+		{name: "invalid utf-8", inputKey: "entry\xffkey", expectError: entity.ErrInvalidEntryKey},
+		{name: "current directory", inputKey: "entry/./key", expectError: entity.ErrInvalidEntryKey},
+		{name: "parent directory", inputKey: "entry/../key", expectError: entity.ErrInvalidEntryKey},
 		{name: "0 characters", inputKey: strings.Repeat("a", 0), expectError: entity.ErrShortEntryKey},
 		{name: "1 characters", inputKey: strings.Repeat("a", 1), expectError: nil},
 		{name: "512 characters", inputKey: strings.Repeat("a/", 255) + "aa", expectError: nil},
 		{name: "513 characters", inputKey: strings.Repeat("a", 513), expectError: entity.ErrLongEntryKey},
 		{name: "255 characters per element", inputKey: strings.Repeat("a", 255), expectError: nil},
-		{name: "255 characters per element", inputKey: strings.Repeat("a", 256), expectError: entity.ErrInvalidEntryKey},
+		{name: "256 characters per element", inputKey: strings.Repeat("a", 256), expectError: entity.ErrInvalidEntryKey},
+		{name: "512 multibyte characters", inputKey: strings.Repeat("あ/", 255) + "ああ", expectError: nil},
+		{name: "513 multibyte characters", inputKey: strings.Repeat("あ/", 256) + "あ", expectError: entity.ErrLongEntryKey},
+		{name: "255 bytes per element", inputKey: strings.Repeat("あ", 85), expectError: nil},
+		{name: "258 bytes per element", inputKey: strings.Repeat("あ", 86), expectError: entity.ErrInvalidEntryKey},
 		{name: "consecutive slashes", inputKey: "entry//key", expectError: entity.ErrInvalidEntryKey},
 	}
 	for _, tt := range tests {
@@ -116,3 +126,137 @@ func TestEntry_SetKey(t *testing.T) {
 		})
 	}
 }
+
+func TestEntry_SetKey_Normalization(t *testing.T) {
+	entry := &entity.Entry{}
+
+	// NOTE: "ガ"を結合文字で表した"カ"と濁点の組み合わせ.
+	if err := entry.SetKey("/フォルダ/\u30ab\u3099.txt/"); err != nil {
+		t.Error(err)
+	}
+
+	if expect := "フォルダ/\u30ac.txt"; entry.Key != expect {
+		t.Errorf("\nexpect: %v\ngot: %v", expect, entry.Key)
+	}
+}
+
+func TestEntry_SetVolumeID(t *testing.T) {
+	entry := &entity.Entry{}
+
+	tests := []struct {
+		name          string
+		inputVolumeID uuid.UUID
+		expectError   error
+	}{
+		{name: "valid", inputVolumeID: uuid.New(), expectError: nil},
+		{name: "nil", inputVolumeID: uuid.Nil, expectError: entity.ErrRequiredEntryVolumeID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entry.SetVolumeID(tt.inputVolumeID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestEntry_IsCompressible(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputType    string
+		expectResult bool
+	}{
+		{name: "text", inputType: "text/plain; charset=utf-8", expectResult: true},
+		{name: "json", inputType: "application/json", expectResult: true},
+		{name: "structured syntax suffix", inputType: "image/svg+xml", expectResult: true},
+		{name: "image", inputType: "image/jpeg", expectResult: false},
+		{name: "archive", inputType: "application/zip", expectResult: false},
+		{name: "folder", inputType: "folder", expectResult: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &entity.Entry{Type: tt.inputType}
+			if result := entry.IsCompressible(); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestEntry_SetScanResult(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputSignature  string
+		expectStatus    string
+		expectSignature string
+	}{
+		{name: "clean", inputSignature: "", expectStatus: entity.EntryScanStatusClean, expectSignature: ""},
+		{name: "infected", inputSignature: "Eicar-Signature", expectStatus: entity.EntryScanStatusInfected, expectSignature: "Eicar-Signature"},
+		{name: "long signature", inputSignature: strings.Repeat("a", 256), expectStatus: entity.EntryScanStatusInfected, expectSignature: strings.Repeat("a", 255)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedAt := time.Now().Add(-time.Hour)
+			entry := &entity.Entry{UpdatedAt: updatedAt}
+			entry.SetScanResult(tt.inputSignature)
+
+			if entry.ScanStatus != tt.expectStatus {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectStatus, entry.ScanStatus)
+			}
+			if entry.ScanSignature != tt.expectSignature {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectSignature, entry.ScanSignature)
+			}
+			if entry.ScannedAt == nil {
+				t.Error("scanned_at is not set")
+			}
+			if !entry.UpdatedAt.Equal(updatedAt) {
+				t.Error("expect updated_at not to be changed")
+			}
+			if result := entry.IsQuarantined(); result != (tt.expectStatus == entity.EntryScanStatusInfected) {
+				t.Errorf("\nexpect: %v\ngot: %v", !result, result)
+			}
+		})
+	}
+}
+
+func TestEntry_Name(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputKey     string
+		expectResult string
+	}{
+		{name: "root", inputKey: "sample.txt", expectResult: "sample.txt"},
+		{name: "nested", inputKey: "key/sample.txt", expectResult: "sample.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &entity.Entry{Key: tt.inputKey}
+			if result := entry.Name(); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestEntry_ETag(t *testing.T) {
+	now := time.Now()
+	entry := &entity.Entry{ID: uuid.New(), Size: 4, UpdatedAt: now}
+
+	tests := []struct {
+		name        string
+		inputEntry  *entity.Entry
+		expectEqual bool
+	}{
+		{name: "same", inputEntry: &entity.Entry{ID: entry.ID, Size: 4, UpdatedAt: now}, expectEqual: true},
+		{name: "updated", inputEntry: &entity.Entry{ID: entry.ID, Size: 4, UpdatedAt: now.Add(time.Nanosecond)}, expectEqual: false},
+		{name: "resized", inputEntry: &entity.Entry{ID: entry.ID, Size: 5, UpdatedAt: now}, expectEqual: false},
+		{name: "other entry", inputEntry: &entity.Entry{ID: uuid.New(), Size: 4, UpdatedAt: now}, expectEqual: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.inputEntry.ETag() == entry.ETag(); result != tt.expectEqual {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectEqual, result)
+			}
+		})
+	}
+}
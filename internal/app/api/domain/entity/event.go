@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	EventTypeEntryCreated  = "entry.created"
+	EventTypeEntryUpdated  = "entry.updated"
+	EventTypeEntryRenamed  = "entry.renamed"
+	EventTypeEntryDeleted  = "entry.deleted"
+	EventTypeEntryCopied   = "entry.copied"
+	EventTypeEntryDropped  = "entry.dropped"
+	EventTypeVolumeUpdated = "volume.updated"
+	EventTypeVolumeDeleted = "volume.deleted"
+)
+
+var eventTypes = []string{EventTypeEntryCreated, EventTypeEntryUpdated, EventTypeEntryRenamed, EventTypeEntryDeleted, EventTypeEntryCopied, EventTypeEntryDropped, EventTypeVolumeUpdated, EventTypeVolumeDeleted}
+
+var (
+	ErrRequiredEventVolume = status.Error(code.Internal, "volume for event is required")
+	ErrInvalidEventType    = status.Error(code.UnprocessableContent, "event type is not supported")
+)
+
+type Event struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	ActorID       uuid.UUID
+	Type          string
+	VolumeID      uuid.UUID
+	VolumeName    string
+	Key           string
+	NewVolumeID   uuid.UUID
+	NewVolumeName string
+	NewKey        string
+	Size          uint64
+	CreatedAt     time.Time
+}
+
+func NewEvent(eventType string, volume *Volume, key string) (*Event, error) {
+	if volume == nil {
+		return nil, ErrRequiredEventVolume
+	}
+
+	event := Event{
+		AccountID:  volume.AccountID,
+		VolumeID:   volume.ID,
+		VolumeName: volume.Name,
+		Key:        key,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := event.generateID(); err != nil {
+		return nil, err
+	}
+	if err := event.setType(eventType); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// NOTE: 移動, 複製及びボリューム名の変更の場合は変更後のボリュームとキーを保持する.
+func (e *Event) SetDestination(volume *Volume, key string) error {
+	if volume == nil {
+		return ErrRequiredEventVolume
+	}
+	e.NewVolumeID = volume.ID
+	e.NewVolumeName = volume.Name
+	e.NewKey = key
+	return nil
+}
+
+func (e *Event) SetSize(size uint64) {
+	e.Size = size
+}
+
+func (e *Event) IsEntryEvent() bool {
+	return strings.HasPrefix(e.Type, "entry.")
+}
+
+// NOTE: 別のボリュームへ移動, 複製した場合は双方のボリュームを対象とする.
+func (e *Event) VolumeIDs() []uuid.UUID {
+	if e.NewVolumeID == uuid.Nil || e.NewVolumeID == e.VolumeID {
+		return []uuid.UUID{e.VolumeID}
+	}
+	return []uuid.UUID{e.VolumeID, e.NewVolumeID}
+}
+
+func (e *Event) generateID() error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	e.ID = id
+	return nil
+}
+
+func (e *Event) setType(eventType string) error {
+	if !slices.Contains(eventTypes, eventType) {
+		return ErrInvalidEventType
+	}
+	e.Type = eventType
+	return nil
+}
@@ -0,0 +1,78 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewEvent(t *testing.T) {
+	volume := &entity.Volume{ID: uuid.New(), AccountID: uuid.New(), Name: "name"}
+
+	tests := []struct {
+		name        string
+		inputType   string
+		inputVolume *entity.Volume
+		expectError error
+	}{
+		{name: "successfully initialized", inputType: entity.EventTypeEntryCreated, inputVolume: volume, expectError: nil},
+		{name: "volume is nil", inputType: entity.EventTypeEntryCreated, inputVolume: nil, expectError: entity.ErrRequiredEventVolume},
+		{name: "invalid type", inputType: "entry.read", inputVolume: volume, expectError: entity.ErrInvalidEventType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := entity.NewEvent(tt.inputType, tt.inputVolume, "key")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if event == nil {
+					t.Fatal("event is nil")
+				}
+				if event.ID == uuid.Nil {
+					t.Error("id is not set")
+				}
+				if event.VolumeName != volume.Name {
+					t.Errorf("\nexpect: %s\ngot: %s", volume.Name, event.VolumeName)
+				}
+			}
+		})
+	}
+}
+
+func TestEvent_VolumeIDs(t *testing.T) {
+	volume := &entity.Volume{ID: uuid.New(), AccountID: uuid.New(), Name: "name"}
+	other := &entity.Volume{ID: uuid.New(), AccountID: volume.AccountID, Name: "other"}
+
+	tests := []struct {
+		name             string
+		inputDestination *entity.Volume
+		expectVolumeIDs  []uuid.UUID
+	}{
+		{name: "no destination", inputDestination: nil, expectVolumeIDs: []uuid.UUID{volume.ID}},
+		{name: "same volume", inputDestination: volume, expectVolumeIDs: []uuid.UUID{volume.ID}},
+		{name: "other volume", inputDestination: other, expectVolumeIDs: []uuid.UUID{volume.ID, other.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := entity.NewEvent(entity.EventTypeEntryCopied, volume, "key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.inputDestination != nil {
+				if err := event.SetDestination(tt.inputDestination, "key"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if diff := cmp.Diff(tt.expectVolumeIDs, event.VolumeIDs()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var (
+	ErrRequiredImagePresetAccountID      = status.Error(code.Internal, "account id for image preset is required")
+	ErrRequiredImagePresetVolumeID       = status.Error(code.Internal, "volume id for image preset is required")
+	ErrRequiredImagePresetTransformation = status.Error(code.Internal, "transformation for image preset is required")
+	ErrShortImagePresetName              = status.Error(code.UnprocessableContent, "image preset name is too short")
+	ErrLongImagePresetName               = status.Error(code.UnprocessableContent, "image preset name is too long")
+	ErrInvalidImagePresetName            = status.Error(code.UnprocessableContent, "image preset name contains invalid characters")
+)
+
+var imagePresetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_\-]*$`)
+
+type ImagePreset struct {
+	ID             uuid.UUID
+	AccountID      uuid.UUID
+	VolumeID       uuid.UUID
+	Name           string
+	Transformation *ImageTransformation
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewImagePreset(accountID, volumeID uuid.UUID, name string, transformation *ImageTransformation) (*ImagePreset, error) {
+	var preset ImagePreset
+
+	if err := preset.generateID(); err != nil {
+		return nil, err
+	}
+	if err := preset.setAccountID(accountID); err != nil {
+		return nil, err
+	}
+	if err := preset.setVolumeID(volumeID); err != nil {
+		return nil, err
+	}
+	if err := preset.setName(name); err != nil {
+		return nil, err
+	}
+	if err := preset.setTransformation(transformation); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	preset.CreatedAt = now
+	preset.UpdatedAt = now
+
+	return &preset, nil
+}
+
+func RestoreImagePreset(id, accountID, volumeID uuid.UUID, name string, transformation *ImageTransformation, createdAt, updatedAt time.Time) *ImagePreset {
+	return &ImagePreset{
+		ID:             id,
+		AccountID:      accountID,
+		VolumeID:       volumeID,
+		Name:           name,
+		Transformation: transformation,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+func (p *ImagePreset) generateID() error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	p.ID = id
+	return nil
+}
+
+func (p *ImagePreset) setAccountID(accountID uuid.UUID) error {
+	if accountID == uuid.Nil {
+		return ErrRequiredImagePresetAccountID
+	}
+	p.AccountID = accountID
+	return nil
+}
+
+func (p *ImagePreset) setVolumeID(volumeID uuid.UUID) error {
+	if volumeID == uuid.Nil {
+		return ErrRequiredImagePresetVolumeID
+	}
+	p.VolumeID = volumeID
+	return nil
+}
+
+func (p *ImagePreset) setName(name string) error {
+	if len(name) < 1 {
+		return ErrShortImagePresetName
+	}
+	if 64 < len(name) {
+		return ErrLongImagePresetName
+	}
+	if !imagePresetNamePattern.MatchString(name) {
+		return ErrInvalidImagePresetName
+	}
+	p.Name = name
+	return nil
+}
+
+func (p *ImagePreset) setTransformation(transformation *ImageTransformation) error {
+	if transformation == nil {
+		return ErrRequiredImagePresetTransformation
+	}
+	p.Transformation = transformation
+	return nil
+}
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewImagePreset(t *testing.T) {
+	transformation := &entity.ImageTransformation{Width: 100, Fit: entity.ImageFitContain, Quality: 85}
+
+	tests := []struct {
+		name                string
+		inputAccountID      uuid.UUID
+		inputVolumeID       uuid.UUID
+		inputName           string
+		inputTransformation *entity.ImageTransformation
+		expectError         error
+	}{
+		{name: "successfully initialized", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputName: "small_thumb-1", inputTransformation: transformation, expectError: nil},
+		{name: "account id is nil", inputAccountID: uuid.Nil, inputVolumeID: uuid.New(), inputName: "small", inputTransformation: transformation, expectError: entity.ErrRequiredImagePresetAccountID},
+		{name: "volume id is nil", inputAccountID: uuid.New(), inputVolumeID: uuid.Nil, inputName: "small", inputTransformation: transformation, expectError: entity.ErrRequiredImagePresetVolumeID},
+		{name: "empty name", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputName: "", inputTransformation: transformation, expectError: entity.ErrShortImagePresetName},
+		{name: "64 characters name", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputName: strings.Repeat("a", 64), inputTransformation: transformation, expectError: nil},
+		{name: "65 characters name", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputName: strings.Repeat("a", 65), inputTransformation: transformation, expectError: entity.ErrLongImagePresetName},
+		{name: "invalid name", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputName: "small thumb", inputTransformation: transformation, expectError: entity.ErrInvalidImagePresetName},
+		{name: "transformation is nil", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputName: "small", inputTransformation: nil, expectError: entity.ErrRequiredImagePresetTransformation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preset, err := entity.NewImagePreset(tt.inputAccountID, tt.inputVolumeID, tt.inputName, tt.inputTransformation)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if preset == nil {
+					t.Fatal("image preset is nil")
+				}
+				if preset.ID == uuid.Nil {
+					t.Error("id is not set")
+				}
+				if preset.CreatedAt.IsZero() || !preset.CreatedAt.Equal(preset.UpdatedAt) {
+					t.Error("created_at and updated_at are not set")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	maxImageDimension          = 2048
+	maxImageCropCoordinate     = 1 << 20
+	defaultImageQuality        = 85
+	ImageFitContain            = "contain"
+	ImageFitCover              = "cover"
+	ImageFitFill               = "fill"
+	ImageFormatJPEG            = "jpeg"
+	ImageFormatPNG             = "png"
+	imageTransformationKeySize = 16
+)
+
+var (
+	ErrLargeImageSize      = status.Error(code.UnprocessableContent, "image size is too large")
+	ErrRequiredImageSize   = status.Error(code.UnprocessableContent, "image width and height are required for the fit")
+	ErrInvalidImageFit     = status.Error(code.UnprocessableContent, "image fit is not supported")
+	ErrInvalidImageCrop    = status.Error(code.UnprocessableContent, "image crop is invalid")
+	ErrInvalidImageQuality = status.Error(code.UnprocessableContent, "image quality must be between 1 and 100")
+	ErrInvalidImageFormat  = status.Error(code.UnprocessableContent, "image format is not supported")
+)
+
+var imageFits = []string{ImageFitContain, ImageFitCover, ImageFitFill}
+
+// NOTE: 幅, 高さの0は未指定, 切り抜きの幅と高さの0は切り抜かないことを表す.
+type ImageTransformation struct {
+	Width      uint64
+	Height     uint64
+	Fit        string
+	CropX      uint64
+	CropY      uint64
+	CropWidth  uint64
+	CropHeight uint64
+	Quality    uint64
+	Format     string
+}
+
+// NOTE: プリセットとの比較のため, 省略された値は既定値で補完する.
+func NewImageTransformation(width, height uint64, fit string, cropX, cropY, cropWidth, cropHeight, quality uint64, format string) (*ImageTransformation, error) {
+	var transformation ImageTransformation
+
+	if err := transformation.setSize(width, height, fit); err != nil {
+		return nil, err
+	}
+	if err := transformation.setCrop(cropX, cropY, cropWidth, cropHeight); err != nil {
+		return nil, err
+	}
+	if err := transformation.setQuality(quality); err != nil {
+		return nil, err
+	}
+	if err := transformation.setFormat(format); err != nil {
+		return nil, err
+	}
+
+	return &transformation, nil
+}
+
+func RestoreImageTransformation(width, height uint64, fit string, cropX, cropY, cropWidth, cropHeight, quality uint64, format string) *ImageTransformation {
+	return &ImageTransformation{
+		Width:      width,
+		Height:     height,
+		Fit:        fit,
+		CropX:      cropX,
+		CropY:      cropY,
+		CropWidth:  cropWidth,
+		CropHeight: cropHeight,
+		Quality:    quality,
+		Format:     format,
+	}
+}
+
+func (t *ImageTransformation) String() string {
+	return fmt.Sprintf("w=%d,h=%d,fit=%s,crop=%d:%d:%d:%d,q=%d,format=%s", t.Width, t.Height, t.Fit, t.CropX, t.CropY, t.CropWidth, t.CropHeight, t.Quality, t.Format)
+}
+
+func (t *ImageTransformation) Equal(transformation *ImageTransformation) bool {
+	if t == nil || transformation == nil {
+		return t == transformation
+	}
+	return t.String() == transformation.String()
+}
+
+// NOTE: 派生コンテンツの名前に利用するため, ファイル名に利用できる短い値とする.
+func (t *ImageTransformation) Key() string {
+	sum := sha256.Sum256([]byte(t.String()))
+	return hex.EncodeToString(sum[:imageTransformationKeySize])
+}
+
+func (t *ImageTransformation) HasCrop() bool {
+	return t.CropWidth != 0
+}
+
+func (t *ImageTransformation) setSize(width, height uint64, fit string) error {
+	if maxImageDimension < width || maxImageDimension < height {
+		return ErrLargeImageSize
+	}
+	if fit == "" {
+		fit = ImageFitContain
+	}
+	if !slices.Contains(imageFits, fit) {
+		return ErrInvalidImageFit
+	}
+	if fit != ImageFitContain && (width == 0 || height == 0) {
+		return ErrRequiredImageSize
+	}
+	t.Width = width
+	t.Height = height
+	t.Fit = fit
+	return nil
+}
+
+func (t *ImageTransformation) setCrop(x, y, width, height uint64) error {
+	if (x != 0 || y != 0 || width != 0 || height != 0) && (width == 0 || height == 0) {
+		return ErrInvalidImageCrop
+	}
+	if maxImageCropCoordinate < max(x, y, width, height) || maxImageCropCoordinate < x+width || maxImageCropCoordinate < y+height {
+		return ErrInvalidImageCrop
+	}
+	t.CropX = x
+	t.CropY = y
+	t.CropWidth = width
+	t.CropHeight = height
+	return nil
+}
+
+func (t *ImageTransformation) setQuality(quality uint64) error {
+	if quality == 0 {
+		quality = defaultImageQuality
+	}
+	if 100 < quality {
+		return ErrInvalidImageQuality
+	}
+	t.Quality = quality
+	return nil
+}
+
+// NOTE: 形式を省略した場合は元の形式に応じて決定する.
+func (t *ImageTransformation) setFormat(format string) error {
+	if format != "" && format != ImageFormatJPEG && format != ImageFormatPNG {
+		return ErrInvalidImageFormat
+	}
+	t.Format = format
+	return nil
+}
@@ -0,0 +1,73 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewImageTransformation(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputWidth   uint64
+		inputHeight  uint64
+		inputFit     string
+		inputCrop    [4]uint64
+		inputQuality uint64
+		inputFormat  string
+		expectResult *entity.ImageTransformation
+		expectError  error
+	}{
+		{name: "default values", expectResult: &entity.ImageTransformation{Fit: entity.ImageFitContain, Quality: 85}, expectError: nil},
+		{name: "all values", inputWidth: 100, inputHeight: 200, inputFit: entity.ImageFitCover, inputCrop: [4]uint64{1, 2, 3, 4}, inputQuality: 60, inputFormat: entity.ImageFormatPNG, expectResult: &entity.ImageTransformation{Width: 100, Height: 200, Fit: entity.ImageFitCover, CropX: 1, CropY: 2, CropWidth: 3, CropHeight: 4, Quality: 60, Format: entity.ImageFormatPNG}, expectError: nil},
+		{name: "2048 width", inputWidth: 2048, expectResult: &entity.ImageTransformation{Width: 2048, Fit: entity.ImageFitContain, Quality: 85}, expectError: nil},
+		{name: "2049 width", inputWidth: 2049, expectError: entity.ErrLargeImageSize},
+		{name: "2049 height", inputHeight: 2049, expectError: entity.ErrLargeImageSize},
+		{name: "invalid fit", inputFit: "stretch", expectError: entity.ErrInvalidImageFit},
+		{name: "fill without height", inputWidth: 100, inputFit: entity.ImageFitFill, expectError: entity.ErrRequiredImageSize},
+		{name: "crop without size", inputCrop: [4]uint64{1, 2, 0, 0}, expectError: entity.ErrInvalidImageCrop},
+		{name: "too large crop", inputCrop: [4]uint64{1 << 20, 0, 1, 1}, expectError: entity.ErrInvalidImageCrop},
+		{name: "101 quality", inputQuality: 101, expectError: entity.ErrInvalidImageQuality},
+		{name: "invalid format", inputFormat: "webp", expectError: entity.ErrInvalidImageFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := entity.NewImageTransformation(tt.inputWidth, tt.inputHeight, tt.inputFit, tt.inputCrop[0], tt.inputCrop[1], tt.inputCrop[2], tt.inputCrop[3], tt.inputQuality, tt.inputFormat)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestImageTransformation_Equal(t *testing.T) {
+	transformation := &entity.ImageTransformation{Width: 100, Fit: entity.ImageFitContain, Quality: 85}
+
+	tests := []struct {
+		name                string
+		inputTransformation *entity.ImageTransformation
+		expectResult        bool
+	}{
+		{name: "same", inputTransformation: &entity.ImageTransformation{Width: 100, Fit: entity.ImageFitContain, Quality: 85}, expectResult: true},
+		{name: "different width", inputTransformation: &entity.ImageTransformation{Width: 200, Fit: entity.ImageFitContain, Quality: 85}, expectResult: false},
+		{name: "different format", inputTransformation: &entity.ImageTransformation{Width: 100, Fit: entity.ImageFitContain, Quality: 85, Format: entity.ImageFormatPNG}, expectResult: false},
+		{name: "nil", inputTransformation: nil, expectResult: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := transformation.Equal(tt.inputTransformation); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+			if tt.expectResult && transformation.Key() != tt.inputTransformation.Key() {
+				t.Error("keys are not equal")
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	JobTypeCopy   = "copy"
+	JobTypeUpdate = "update"
+	JobTypeDelete = "delete"
+	JobTypeScan   = "scan"
+
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+const (
+	MaxJobAttempts   = 5
+	jobRetryInterval = 10 * time.Second
+)
+
+var (
+	ErrRequiredJobAccountID = status.Error(code.Internal, "account id for job is required")
+	ErrInvalidJobType       = status.Error(code.UnprocessableContent, "job type is not supported")
+	ErrJobAlreadyFinished   = status.Error(code.Conflict, "job already finished")
+)
+
+type Job struct {
+	ID               uuid.UUID
+	AccountID        uuid.UUID
+	Type             string
+	Status           string
+	VolumeName       string
+	Key              string
+	NewVolumeName    string
+	NewKey           string
+	Conflict         string
+	ResultKey        string
+	Error            string
+	TotalEntries     uint64
+	ProcessedEntries uint64
+	TotalBytes       uint64
+	ProcessedBytes   uint64
+	Attempts         uint64
+	RunAt            time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func NewJob(accountID uuid.UUID, jobType, volumeName, key, newVolumeName, newKey, conflict string) (*Job, error) {
+	job := Job{
+		Status:        JobStatusPending,
+		VolumeName:    volumeName,
+		Key:           key,
+		NewVolumeName: newVolumeName,
+		NewKey:        newKey,
+		Conflict:      conflict,
+	}
+
+	if err := job.generateID(); err != nil {
+		return nil, err
+	}
+	if err := job.setAccountID(accountID); err != nil {
+		return nil, err
+	}
+	if err := job.setType(jobType); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job.RunAt = now
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	return &job, nil
+}
+
+func RestoreJob(
+	id, accountID uuid.UUID,
+	jobType, jobStatus, volumeName, key, newVolumeName, newKey, conflict, resultKey, jobError string,
+	totalEntries, processedEntries, totalBytes, processedBytes, attempts uint64,
+	runAt, createdAt, updatedAt time.Time,
+) *Job {
+	return &Job{
+		ID:               id,
+		AccountID:        accountID,
+		Type:             jobType,
+		Status:           jobStatus,
+		VolumeName:       volumeName,
+		Key:              key,
+		NewVolumeName:    newVolumeName,
+		NewKey:           newKey,
+		Conflict:         conflict,
+		ResultKey:        resultKey,
+		Error:            jobError,
+		TotalEntries:     totalEntries,
+		ProcessedEntries: processedEntries,
+		TotalBytes:       totalBytes,
+		ProcessedBytes:   processedBytes,
+		Attempts:         attempts,
+		RunAt:            runAt,
+		CreatedAt:        createdAt,
+		UpdatedAt:        updatedAt,
+	}
+}
+
+func (j *Job) Start() {
+	j.Status = JobStatusRunning
+	j.Attempts++
+	j.Error = ""
+	j.ProcessedEntries = 0
+	j.ProcessedBytes = 0
+	j.UpdatedAt = time.Now()
+}
+
+func (j *Job) Succeed(resultKey string) {
+	j.Status = JobStatusSucceeded
+	j.ResultKey = resultKey
+	j.ProcessedEntries = j.TotalEntries
+	j.ProcessedBytes = j.TotalBytes
+	j.UpdatedAt = time.Now()
+}
+
+// NOTE: 再試行可能な場合は試行回数に応じて間隔を空けて再実行する.
+func (j *Job) Fail(err error, retryable bool) {
+	now := time.Now()
+	j.Error = err.Error()
+	j.UpdatedAt = now
+
+	if retryable && j.Attempts < MaxJobAttempts {
+		j.Status = JobStatusPending
+		j.RunAt = now.Add(jobRetryInterval << (j.Attempts - 1))
+		return
+	}
+	j.Status = JobStatusFailed
+}
+
+func (j *Job) Cancel() error {
+	if j.IsFinished() {
+		return ErrJobAlreadyFinished
+	}
+	j.Status = JobStatusCancelled
+	j.UpdatedAt = time.Now()
+	return nil
+}
+
+func (j *Job) IsFinished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed || j.Status == JobStatusCancelled
+}
+
+func (j *Job) generateID() error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	j.ID = id
+	return nil
+}
+
+func (j *Job) setAccountID(accountID uuid.UUID) error {
+	if accountID == uuid.Nil {
+		return ErrRequiredJobAccountID
+	}
+	j.AccountID = accountID
+	return nil
+}
+
+func (j *Job) setType(jobType string) error {
+	switch jobType {
+	case JobTypeCopy, JobTypeUpdate, JobTypeDelete, JobTypeScan:
+		j.Type = jobType
+		return nil
+	default:
+		return ErrInvalidJobType
+	}
+}
@@ -0,0 +1,104 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputAccountID uuid.UUID
+		inputType      string
+		expectError    error
+	}{
+		{name: "successfully initialized", inputAccountID: uuid.New(), inputType: entity.JobTypeCopy, expectError: nil},
+		{name: "account id is nil", inputAccountID: uuid.Nil, inputType: entity.JobTypeCopy, expectError: entity.ErrRequiredJobAccountID},
+		{name: "scan type", inputAccountID: uuid.New(), inputType: entity.JobTypeScan, expectError: nil},
+		{name: "invalid type", inputAccountID: uuid.New(), inputType: "archive", expectError: entity.ErrInvalidJobType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := entity.NewJob(tt.inputAccountID, tt.inputType, "volume", "key", "", "", "")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if job == nil {
+					t.Fatal("job is nil")
+				}
+				if job.ID == uuid.Nil {
+					t.Error("id is not set")
+				}
+				if job.Status != entity.JobStatusPending {
+					t.Errorf("\nexpect: %s\ngot: %s", entity.JobStatusPending, job.Status)
+				}
+				if job.RunAt.IsZero() {
+					t.Error("run_at is not set")
+				}
+			}
+		})
+	}
+}
+
+func TestJob_Fail(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputAttempts  uint64
+		inputRetryable bool
+		expectStatus   string
+		expectDelay    time.Duration
+	}{
+		{name: "retry", inputAttempts: 1, inputRetryable: true, expectStatus: entity.JobStatusPending, expectDelay: 10 * time.Second},
+		{name: "backoff", inputAttempts: 3, inputRetryable: true, expectStatus: entity.JobStatusPending, expectDelay: 40 * time.Second},
+		{name: "attempts exceeded", inputAttempts: entity.MaxJobAttempts, inputRetryable: true, expectStatus: entity.JobStatusFailed, expectDelay: 0},
+		{name: "not retryable", inputAttempts: 1, inputRetryable: false, expectStatus: entity.JobStatusFailed, expectDelay: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &entity.Job{Status: entity.JobStatusRunning, Attempts: tt.inputAttempts}
+
+			job.Fail(errors.New("test"), tt.inputRetryable)
+
+			if job.Status != tt.expectStatus {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectStatus, job.Status)
+			}
+			if job.Error != "test" {
+				t.Errorf("\nexpect: test\ngot: %s", job.Error)
+			}
+			if tt.expectDelay != 0 {
+				if delay := job.RunAt.Sub(job.UpdatedAt); delay != tt.expectDelay {
+					t.Errorf("\nexpect: %v\ngot: %v", tt.expectDelay, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestJob_Cancel(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputStatus string
+		expectError error
+	}{
+		{name: "pending", inputStatus: entity.JobStatusPending, expectError: nil},
+		{name: "running", inputStatus: entity.JobStatusRunning, expectError: nil},
+		{name: "succeeded", inputStatus: entity.JobStatusSucceeded, expectError: entity.ErrJobAlreadyFinished},
+		{name: "failed", inputStatus: entity.JobStatusFailed, expectError: entity.ErrJobAlreadyFinished},
+		{name: "cancelled", inputStatus: entity.JobStatusCancelled, expectError: entity.ErrJobAlreadyFinished},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &entity.Job{Status: tt.inputStatus}
+			if err := job.Cancel(); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NOTE: ボディの保存先のファイル名となるため, ファイルシステムのNAME_MAXに合わせてバイト数で制限する.
+const maxNameBytes = 255
+
+// NOTE: 主要なファイルシステムで利用できない文字を許可しない.
+const reservedNameCharacters = `\:*?"<>|`
+
+// NOTE: 見た目が同じ名前を同一に扱うため, NFCに正規化する.
+func normalizeName(name string) string {
+	return norm.NFC.String(name)
+}
+
+func isValidName(name string) bool {
+	if name == "" || name == "." || name == ".." {
+		return false
+	}
+	if maxNameBytes < len(name) || !utf8.ValidString(name) {
+		return false
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r) || strings.ContainsRune(reservedNameCharacters, r) {
+			return false
+		}
+	}
+	return true
+}
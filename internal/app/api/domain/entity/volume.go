@@ -1,7 +1,7 @@
 package entity
 
 import (
-	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,22 +11,27 @@ import (
 )
 
 var (
-	ErrRequiredVolumeAccountID = status.Error(code.Internal, "account id for volume is required")
-	ErrShortVolumeName         = status.Error(code.UnprocessableContent, "volume name is too short")
-	ErrLongVolumeName          = status.Error(code.UnprocessableContent, "volume name is too long")
-	ErrInvalidVolumeName       = status.Error(code.UnprocessableContent, "volume name contains invalid characters")
+	ErrRequiredVolumeAccountID  = status.Error(code.Internal, "account id for volume is required")
+	ErrRequiredVolumePolicy     = status.Error(code.Internal, "policy for volume is required")
+	ErrShortVolumeName          = status.Error(code.UnprocessableContent, "volume name is too short")
+	ErrLongVolumeName           = status.Error(code.UnprocessableContent, "volume name is too long")
+	ErrInvalidVolumeName        = status.Error(code.UnprocessableContent, "volume name contains invalid characters")
+	ErrInvalidVolumeCompression = status.Error(code.UnprocessableContent, "volume compression is not supported")
 )
 
 type Volume struct {
-	ID        uuid.UUID
-	AccountID uuid.UUID
-	Name      string
-	IsPublic  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Name        string
+	IsPublic    bool
+	Compression string
+	Policy      *VolumePolicy
+	Drop        *VolumeDrop
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
-func NewVolume(accountID uuid.UUID, name string, isPublic bool) (*Volume, error) {
+func NewVolume(accountID uuid.UUID, name string, isPublic bool, compression string, policy *VolumePolicy, drop *VolumeDrop) (*Volume, error) {
 	var volume Volume
 
 	if err := volume.generateID(); err != nil {
@@ -39,6 +44,13 @@ func NewVolume(accountID uuid.UUID, name string, isPublic bool) (*Volume, error)
 		return nil, err
 	}
 	volume.SetIsPublic(isPublic)
+	if err := volume.SetCompression(compression); err != nil {
+		return nil, err
+	}
+	if err := volume.SetPolicy(policy); err != nil {
+		return nil, err
+	}
+	volume.SetDrop(drop)
 
 	now := time.Now()
 	volume.CreatedAt = now
@@ -47,28 +59,29 @@ func NewVolume(accountID uuid.UUID, name string, isPublic bool) (*Volume, error)
 	return &volume, nil
 }
 
-func RestoreVolume(id, accountID uuid.UUID, name string, isPublic bool, createdAt, updatedAt time.Time) *Volume {
+func RestoreVolume(id, accountID uuid.UUID, name string, isPublic bool, compression string, policy *VolumePolicy, drop *VolumeDrop, createdAt, updatedAt time.Time) *Volume {
 	return &Volume{
-		ID:        id,
-		AccountID: accountID,
-		Name:      name,
-		IsPublic:  isPublic,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:          id,
+		AccountID:   accountID,
+		Name:        name,
+		IsPublic:    isPublic,
+		Compression: compression,
+		Policy:      policy,
+		Drop:        drop,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
 	}
 }
 
+// NOTE: ボディの保存先のディレクトリ名となるため, バイト数で制限する.
 func (v *Volume) SetName(name string) error {
+	name = normalizeName(name)
 	if len(name) < 1 {
 		return ErrShortVolumeName
-	} else if 255 < len(name) {
+	} else if maxNameBytes < len(name) {
 		return ErrLongVolumeName
 	}
-	matched, err := regexp.MatchString(`^[A-Za-z0-9!@#$%^&()_\-+=\[\]{};',.~ ]*$`, name)
-	if err != nil {
-		return err
-	}
-	if !matched {
+	if strings.Contains(name, "/") || !isValidName(name) {
 		return ErrInvalidVolumeName
 	}
 	v.Name = name
@@ -81,6 +94,92 @@ func (v *Volume) SetIsPublic(isPublic bool) {
 	v.UpdatedAt = time.Now()
 }
 
+func (v *Volume) SetCompression(compression string) error {
+	if compression != "" && compression != "gzip" && compression != "zstd" {
+		return ErrInvalidVolumeCompression
+	}
+	v.Compression = compression
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+func (v *Volume) SetPolicy(policy *VolumePolicy) error {
+	if policy == nil {
+		return ErrRequiredVolumePolicy
+	}
+	v.Policy = policy
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+// NOTE: nilの場合は匿名の投稿を無効とする.
+func (v *Volume) SetDrop(drop *VolumeDrop) {
+	v.Drop = drop
+	v.UpdatedAt = time.Now()
+}
+
+func (v *Volume) IsDroppable() bool {
+	return v.Drop != nil
+}
+
+func (v *Volume) ValidateDrop(entry *Entry) error {
+	if v.Drop == nil {
+		return ErrEntryNotDroppable
+	}
+	return v.Drop.Validate(entry)
+}
+
+// NOTE: フォルダはサイズと種別を検証しない.
+func (v *Volume) ValidateEntry(entry *Entry) error {
+	if v.Policy == nil {
+		return nil
+	}
+	if err := v.Policy.ValidateDepth(entry.Key); err != nil {
+		return err
+	}
+	if entry.IsFolder() {
+		return nil
+	}
+	if err := v.Policy.ValidateSize(entry.Size); err != nil {
+		return err
+	}
+	return v.Policy.ValidateType(entry.Key, entry.Type)
+}
+
+func (v *Volume) ValidateEntrySize(size uint64) error {
+	if v.Policy == nil {
+		return nil
+	}
+	return v.Policy.ValidateSize(size)
+}
+
+func (v *Volume) ValidateEntryCount(count uint64) error {
+	if v.Policy == nil {
+		return nil
+	}
+	return v.Policy.ValidateCount(count)
+}
+
+func (v *Volume) HasEntryCountLimit() bool {
+	return v.Policy != nil && v.Policy.MaxEntriesPerFolder != 0
+}
+
+func (v *Volume) ValidateUsage(usage *VolumeUsage, size, count uint64) error {
+	if v.Policy == nil {
+		return nil
+	}
+	return v.Policy.ValidateUsage(usage, size, count)
+}
+
+func (v *Volume) HasUsageLimit() bool {
+	return v.Policy != nil && (v.Policy.MaxVolumeSize != 0 || v.Policy.MaxVolumeEntries != 0)
+}
+
+// NOTE: ボリューム名はアカウント毎に一意のため, ボディはアカウントIDを含むパスに保存する.
+func (v *Volume) Path() string {
+	return v.AccountID.String() + "/" + v.Name
+}
+
 func (v *Volume) generateID() error {
 	id, err := uuid.NewRandom()
 	if err != nil {
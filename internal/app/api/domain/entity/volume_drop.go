@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const maxVolumeDropPrefixLength = 255
+
+var (
+	ErrInvalidVolumeDropPrefix = status.Error(code.UnprocessableContent, "drop prefix is invalid")
+	ErrEntryNotDroppable       = status.Error(code.Forbidden, "entry cannot be dropped")
+)
+
+// NOTE: 接頭辞が空の場合はボリューム全体を投稿先とする.
+type VolumeDrop struct {
+	Prefix string
+}
+
+func NewVolumeDrop(prefix string) (*VolumeDrop, error) {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return &VolumeDrop{Prefix: prefix}, nil
+	}
+
+	var entry Entry
+	if err := entry.SetKey(prefix); err != nil {
+		return nil, ErrInvalidVolumeDropPrefix
+	}
+	if maxVolumeDropPrefixLength < utf8.RuneCountInString(entry.Key) {
+		return nil, ErrInvalidVolumeDropPrefix
+	}
+	return &VolumeDrop{Prefix: entry.Key}, nil
+}
+
+func RestoreVolumeDrop(prefix string) *VolumeDrop {
+	return &VolumeDrop{Prefix: prefix}
+}
+
+// NOTE: 投稿先のフォルダ自体やフォルダの作成は許可しない.
+func (d *VolumeDrop) Validate(entry *Entry) error {
+	if entry.IsFolder() {
+		return ErrEntryNotDroppable
+	}
+	if d.Prefix != "" && !strings.HasPrefix(entry.Key, d.Prefix+"/") {
+		return ErrEntryNotDroppable
+	}
+	return nil
+}
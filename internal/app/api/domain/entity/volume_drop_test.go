@@ -0,0 +1,62 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewVolumeDrop(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPrefix string
+		expectDrop  *entity.VolumeDrop
+		expectError error
+	}{
+		{name: "whole volume", inputPrefix: "", expectDrop: &entity.VolumeDrop{Prefix: ""}, expectError: nil},
+		{name: "folder", inputPrefix: "/inbox/uploads/", expectDrop: &entity.VolumeDrop{Prefix: "inbox/uploads"}, expectError: nil},
+		{name: "normalized", inputPrefix: "\u30ab\u3099", expectDrop: &entity.VolumeDrop{Prefix: "\u30ac"}, expectError: nil},
+		{name: "invalid characters", inputPrefix: "inbox?", expectDrop: nil, expectError: entity.ErrInvalidVolumeDropPrefix},
+		{name: "empty segment", inputPrefix: "inbox//uploads", expectDrop: nil, expectError: entity.ErrInvalidVolumeDropPrefix},
+		{name: "too long", inputPrefix: strings.Repeat("a/", 127) + "aa", expectDrop: nil, expectError: entity.ErrInvalidVolumeDropPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drop, err := entity.NewVolumeDrop(tt.inputPrefix)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectDrop, drop); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestVolumeDrop_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputDrop   *entity.VolumeDrop
+		inputEntry  *entity.Entry
+		expectError error
+	}{
+		{name: "file in prefix", inputDrop: &entity.VolumeDrop{Prefix: "inbox"}, inputEntry: &entity.Entry{Key: "inbox/sample.txt", Type: "text/plain"}, expectError: nil},
+		{name: "file in nested folder", inputDrop: &entity.VolumeDrop{Prefix: "inbox"}, inputEntry: &entity.Entry{Key: "inbox/folder/sample.txt", Type: "text/plain"}, expectError: nil},
+		{name: "file in whole volume", inputDrop: &entity.VolumeDrop{Prefix: ""}, inputEntry: &entity.Entry{Key: "sample.txt", Type: "text/plain"}, expectError: nil},
+		{name: "file outside prefix", inputDrop: &entity.VolumeDrop{Prefix: "inbox"}, inputEntry: &entity.Entry{Key: "inboxes/sample.txt", Type: "text/plain"}, expectError: entity.ErrEntryNotDroppable},
+		{name: "prefix itself", inputDrop: &entity.VolumeDrop{Prefix: "inbox"}, inputEntry: &entity.Entry{Key: "inbox", Type: "text/plain"}, expectError: entity.ErrEntryNotDroppable},
+		{name: "folder", inputDrop: &entity.VolumeDrop{Prefix: "inbox"}, inputEntry: &entity.Entry{Key: "inbox/folder", Type: "folder"}, expectError: entity.ErrEntryNotDroppable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputDrop.Validate(tt.inputEntry); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
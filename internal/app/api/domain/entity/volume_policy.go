@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"mime"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const maxVolumePolicyListLength = 1024
+
+var (
+	ErrInvalidVolumePolicyType      = status.Error(code.UnprocessableContent, "volume policy type is invalid")
+	ErrInvalidVolumePolicyExtension = status.Error(code.UnprocessableContent, "volume policy extension is invalid")
+	ErrLongVolumePolicyList         = status.Error(code.UnprocessableContent, "volume policy list is too long")
+	ErrEntryTooLarge                = status.Error(code.ContentTooLarge, "entry size exceeds the volume limit")
+	ErrEntryTypeNotAllowed          = status.Error(code.UnsupportedMediaType, "entry type is not allowed in the volume")
+	ErrEntryTooDeep                 = status.Error(code.UnprocessableContent, "entry key exceeds the volume depth limit")
+	ErrTooManyEntries               = status.Error(code.UnprocessableContent, "folder exceeds the volume entry limit")
+	ErrVolumeCapacityExceeded       = status.Error(code.ContentTooLarge, "volume capacity is exceeded")
+	ErrVolumeEntryCountExceeded     = status.Error(code.UnprocessableContent, "volume entry count is exceeded")
+)
+
+var (
+	volumePolicyTypePattern      = regexp.MustCompile(`^[a-z0-9!#$&^_.+\-]+/([a-z0-9!#$&^_.+\-]+|\*)$`)
+	volumePolicyExtensionPattern = regexp.MustCompile(`^\.?[a-z0-9_+\-]+$`)
+)
+
+// NOTE: 0及び空のリストは制限しないことを表す.
+type VolumePolicy struct {
+	MaxFileSize         uint64
+	AllowedTypes        []string
+	DeniedTypes         []string
+	AllowedExtensions   []string
+	DeniedExtensions    []string
+	MaxKeyDepth         uint64
+	MaxEntriesPerFolder uint64
+	MaxVolumeSize       uint64
+	MaxVolumeEntries    uint64
+}
+
+func NewVolumePolicy(maxFileSize uint64, allowedTypes, deniedTypes, allowedExtensions, deniedExtensions []string, maxKeyDepth, maxEntriesPerFolder, maxVolumeSize, maxVolumeEntries uint64) (*VolumePolicy, error) {
+	policy := VolumePolicy{
+		MaxFileSize:         maxFileSize,
+		MaxKeyDepth:         maxKeyDepth,
+		MaxEntriesPerFolder: maxEntriesPerFolder,
+		MaxVolumeSize:       maxVolumeSize,
+		MaxVolumeEntries:    maxVolumeEntries,
+	}
+
+	var err error
+	if policy.AllowedTypes, err = normalizeVolumePolicyList(allowedTypes, volumePolicyTypePattern, ErrInvalidVolumePolicyType); err != nil {
+		return nil, err
+	}
+	if policy.DeniedTypes, err = normalizeVolumePolicyList(deniedTypes, volumePolicyTypePattern, ErrInvalidVolumePolicyType); err != nil {
+		return nil, err
+	}
+	if policy.AllowedExtensions, err = normalizeVolumePolicyList(allowedExtensions, volumePolicyExtensionPattern, ErrInvalidVolumePolicyExtension); err != nil {
+		return nil, err
+	}
+	if policy.DeniedExtensions, err = normalizeVolumePolicyList(deniedExtensions, volumePolicyExtensionPattern, ErrInvalidVolumePolicyExtension); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func RestoreVolumePolicy(maxFileSize uint64, allowedTypes, deniedTypes, allowedExtensions, deniedExtensions []string, maxKeyDepth, maxEntriesPerFolder, maxVolumeSize, maxVolumeEntries uint64) *VolumePolicy {
+	return &VolumePolicy{
+		MaxFileSize:         maxFileSize,
+		AllowedTypes:        allowedTypes,
+		DeniedTypes:         deniedTypes,
+		AllowedExtensions:   allowedExtensions,
+		DeniedExtensions:    deniedExtensions,
+		MaxKeyDepth:         maxKeyDepth,
+		MaxEntriesPerFolder: maxEntriesPerFolder,
+		MaxVolumeSize:       maxVolumeSize,
+		MaxVolumeEntries:    maxVolumeEntries,
+	}
+}
+
+func (p *VolumePolicy) ValidateSize(size uint64) error {
+	if p.MaxFileSize != 0 && p.MaxFileSize < size {
+		return ErrEntryTooLarge
+	}
+	return nil
+}
+
+// NOTE: 拒否リストを優先し, 許可リストが空でない場合は一致するもののみ許可する.
+func (p *VolumePolicy) ValidateType(key, entryType string) error {
+	mediaType, _, err := mime.ParseMediaType(entryType)
+	if err != nil {
+		mediaType = strings.ToLower(entryType)
+	}
+	if matchVolumePolicyType(p.DeniedTypes, mediaType) || (len(p.AllowedTypes) != 0 && !matchVolumePolicyType(p.AllowedTypes, mediaType)) {
+		return ErrEntryTypeNotAllowed
+	}
+
+	extension := strings.ToLower(strings.TrimPrefix(path.Ext(key), "."))
+	if slices.Contains(p.DeniedExtensions, extension) || (len(p.AllowedExtensions) != 0 && !slices.Contains(p.AllowedExtensions, extension)) {
+		return ErrEntryTypeNotAllowed
+	}
+	return nil
+}
+
+func (p *VolumePolicy) ValidateDepth(key string) error {
+	if p.MaxKeyDepth != 0 && p.MaxKeyDepth < uint64(strings.Count(key, "/")+1) {
+		return ErrEntryTooDeep
+	}
+	return nil
+}
+
+// NOTE: countは追加前のフォルダ直下のエントリー数を表す.
+func (p *VolumePolicy) ValidateCount(count uint64) error {
+	if p.MaxEntriesPerFolder != 0 && p.MaxEntriesPerFolder <= count {
+		return ErrTooManyEntries
+	}
+	return nil
+}
+
+// NOTE: usageは追加前のボリュームの使用量, size及びcountは追加するエントリーの合計を表す.
+func (p *VolumePolicy) ValidateUsage(usage *VolumeUsage, size, count uint64) error {
+	if p.MaxVolumeSize != 0 && p.MaxVolumeSize < usage.Size+size {
+		return ErrVolumeCapacityExceeded
+	}
+	if p.MaxVolumeEntries != 0 && p.MaxVolumeEntries < usage.EntryCount+count {
+		return ErrVolumeEntryCountExceeded
+	}
+	return nil
+}
+
+func normalizeVolumePolicyList(values []string, pattern *regexp.Regexp, invalidErr error) ([]string, error) {
+	var normalized []string
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !pattern.MatchString(value) {
+			return nil, invalidErr
+		}
+		normalized = append(normalized, strings.TrimPrefix(value, "."))
+	}
+	if maxVolumePolicyListLength < len(strings.Join(normalized, ",")) {
+		return nil, ErrLongVolumePolicyList
+	}
+	return normalized, nil
+}
+
+// NOTE: "image/*"のようにサブタイプを省略した場合は同じトップレベルのタイプに一致する.
+func matchVolumePolicyType(types []string, mediaType string) bool {
+	return slices.ContainsFunc(types, func(t string) bool {
+		if prefix, ok := strings.CutSuffix(t, "*"); ok {
+			return strings.HasPrefix(mediaType, prefix)
+		}
+		return t == mediaType
+	})
+}
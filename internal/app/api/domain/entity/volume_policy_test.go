@@ -0,0 +1,154 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewVolumePolicy(t *testing.T) {
+	tests := []struct {
+		name                   string
+		inputAllowedTypes      []string
+		inputDeniedTypes       []string
+		inputAllowedExtensions []string
+		inputDeniedExtensions  []string
+		expectResult           *entity.VolumePolicy
+		expectError            error
+	}{
+		{name: "no limits", expectResult: &entity.VolumePolicy{}, expectError: nil},
+		{name: "normalized values", inputAllowedTypes: []string{" Image/* ", "text/plain"}, inputDeniedTypes: []string{"image/svg+xml"}, inputAllowedExtensions: []string{".PNG", "txt"}, inputDeniedExtensions: []string{"exe"}, expectResult: &entity.VolumePolicy{AllowedTypes: []string{"image/*", "text/plain"}, DeniedTypes: []string{"image/svg+xml"}, AllowedExtensions: []string{"png", "txt"}, DeniedExtensions: []string{"exe"}}, expectError: nil},
+		{name: "type without subtype", inputAllowedTypes: []string{"image"}, expectError: entity.ErrInvalidVolumePolicyType},
+		{name: "type with comma", inputDeniedTypes: []string{"image/png,text/plain"}, expectError: entity.ErrInvalidVolumePolicyType},
+		{name: "extension with separator", inputAllowedExtensions: []string{"tar.gz"}, expectError: entity.ErrInvalidVolumePolicyExtension},
+		{name: "empty extension", inputDeniedExtensions: []string{"."}, expectError: entity.ErrInvalidVolumePolicyExtension},
+		{name: "too long list", inputDeniedExtensions: []string{strings.Repeat("a", 512), strings.Repeat("b", 512)}, expectError: entity.ErrLongVolumePolicyList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := entity.NewVolumePolicy(0, tt.inputAllowedTypes, tt.inputDeniedTypes, tt.inputAllowedExtensions, tt.inputDeniedExtensions, 0, 0, 0, 0)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(result, tt.expectResult); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestVolumePolicy_ValidateSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPolicy *entity.VolumePolicy
+		inputSize   uint64
+		expectError error
+	}{
+		{name: "no limit", inputPolicy: &entity.VolumePolicy{}, inputSize: 1 << 40, expectError: nil},
+		{name: "equal to limit", inputPolicy: &entity.VolumePolicy{MaxFileSize: 4}, inputSize: 4, expectError: nil},
+		{name: "exceeds limit", inputPolicy: &entity.VolumePolicy{MaxFileSize: 4}, inputSize: 5, expectError: entity.ErrEntryTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputPolicy.ValidateSize(tt.inputSize); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolumePolicy_ValidateType(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPolicy *entity.VolumePolicy
+		inputKey    string
+		inputType   string
+		expectError error
+	}{
+		{name: "no limit", inputPolicy: &entity.VolumePolicy{}, inputKey: "sample.exe", inputType: "application/octet-stream", expectError: nil},
+		{name: "allowed type", inputPolicy: &entity.VolumePolicy{AllowedTypes: []string{"text/plain"}}, inputKey: "sample.txt", inputType: "text/plain; charset=utf-8", expectError: nil},
+		{name: "allowed wildcard type", inputPolicy: &entity.VolumePolicy{AllowedTypes: []string{"image/*"}}, inputKey: "sample.png", inputType: "image/png", expectError: nil},
+		{name: "not allowed type", inputPolicy: &entity.VolumePolicy{AllowedTypes: []string{"image/*"}}, inputKey: "sample.txt", inputType: "text/plain; charset=utf-8", expectError: entity.ErrEntryTypeNotAllowed},
+		{name: "denied type", inputPolicy: &entity.VolumePolicy{AllowedTypes: []string{"image/*"}, DeniedTypes: []string{"image/svg+xml"}}, inputKey: "sample.svg", inputType: "image/svg+xml", expectError: entity.ErrEntryTypeNotAllowed},
+		{name: "allowed extension", inputPolicy: &entity.VolumePolicy{AllowedExtensions: []string{"png"}}, inputKey: "key/sample.PNG", inputType: "image/png", expectError: nil},
+		{name: "not allowed extension", inputPolicy: &entity.VolumePolicy{AllowedExtensions: []string{"png"}}, inputKey: "key/sample", inputType: "image/png", expectError: entity.ErrEntryTypeNotAllowed},
+		{name: "denied extension", inputPolicy: &entity.VolumePolicy{DeniedExtensions: []string{"exe"}}, inputKey: "key/sample.exe", inputType: "application/octet-stream", expectError: entity.ErrEntryTypeNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputPolicy.ValidateType(tt.inputKey, tt.inputType); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolumePolicy_ValidateDepth(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPolicy *entity.VolumePolicy
+		inputKey    string
+		expectError error
+	}{
+		{name: "no limit", inputPolicy: &entity.VolumePolicy{}, inputKey: "a/b/c/d", expectError: nil},
+		{name: "equal to limit", inputPolicy: &entity.VolumePolicy{MaxKeyDepth: 2}, inputKey: "a/b", expectError: nil},
+		{name: "exceeds limit", inputPolicy: &entity.VolumePolicy{MaxKeyDepth: 2}, inputKey: "a/b/c", expectError: entity.ErrEntryTooDeep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputPolicy.ValidateDepth(tt.inputKey); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolumePolicy_ValidateCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPolicy *entity.VolumePolicy
+		inputCount  uint64
+		expectError error
+	}{
+		{name: "no limit", inputPolicy: &entity.VolumePolicy{}, inputCount: 1 << 20, expectError: nil},
+		{name: "below limit", inputPolicy: &entity.VolumePolicy{MaxEntriesPerFolder: 2}, inputCount: 1, expectError: nil},
+		{name: "reaches limit", inputPolicy: &entity.VolumePolicy{MaxEntriesPerFolder: 2}, inputCount: 2, expectError: entity.ErrTooManyEntries},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputPolicy.ValidateCount(tt.inputCount); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolumePolicy_ValidateUsage(t *testing.T) {
+	usage := &entity.VolumeUsage{Size: 8, EntryCount: 2}
+
+	tests := []struct {
+		name        string
+		inputPolicy *entity.VolumePolicy
+		inputSize   uint64
+		inputCount  uint64
+		expectError error
+	}{
+		{name: "no limit", inputPolicy: &entity.VolumePolicy{}, inputSize: 1 << 40, inputCount: 1 << 20, expectError: nil},
+		{name: "equal to size limit", inputPolicy: &entity.VolumePolicy{MaxVolumeSize: 12}, inputSize: 4, inputCount: 1, expectError: nil},
+		{name: "exceeds size limit", inputPolicy: &entity.VolumePolicy{MaxVolumeSize: 12}, inputSize: 5, inputCount: 1, expectError: entity.ErrVolumeCapacityExceeded},
+		{name: "equal to entry limit", inputPolicy: &entity.VolumePolicy{MaxVolumeEntries: 4}, inputSize: 0, inputCount: 2, expectError: nil},
+		{name: "exceeds entry limit", inputPolicy: &entity.VolumePolicy{MaxVolumeEntries: 4}, inputSize: 0, inputCount: 3, expectError: entity.ErrVolumeEntryCountExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputPolicy.ValidateUsage(usage, tt.inputSize, tt.inputCount); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
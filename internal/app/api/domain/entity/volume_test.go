@@ -34,19 +34,25 @@ func assertVolume(t *testing.T, v *entity.Volume) {
 
 func TestNewVolume(t *testing.T) {
 	tests := []struct {
-		name           string
-		inputAccountID uuid.UUID
-		inputName      string
-		inputIsPublic  bool
-		expectError    error
+		name             string
+		inputAccountID   uuid.UUID
+		inputName        string
+		inputIsPublic    bool
+		inputCompression string
+		inputPolicy      *entity.VolumePolicy
+		inputDrop        *entity.VolumeDrop
+		expectError      error
 	}{
-		{name: "successfully initialized", inputAccountID: uuid.New(), inputName: "name", inputIsPublic: false, expectError: nil},
-		{name: "account id is nil", inputAccountID: uuid.Nil, inputName: "name", inputIsPublic: false, expectError: entity.ErrRequiredVolumeAccountID},
-		{name: "invalid name", inputAccountID: uuid.New(), inputName: "", inputIsPublic: false, expectError: entity.ErrShortVolumeName},
+		{name: "successfully initialized", inputAccountID: uuid.New(), inputName: "name", inputIsPublic: false, inputCompression: "", inputPolicy: &entity.VolumePolicy{}, expectError: nil},
+		{name: "account id is nil", inputAccountID: uuid.Nil, inputName: "name", inputIsPublic: false, inputCompression: "", inputPolicy: &entity.VolumePolicy{}, expectError: entity.ErrRequiredVolumeAccountID},
+		{name: "invalid name", inputAccountID: uuid.New(), inputName: "", inputIsPublic: false, inputCompression: "", inputPolicy: &entity.VolumePolicy{}, expectError: entity.ErrShortVolumeName},
+		{name: "invalid compression", inputAccountID: uuid.New(), inputName: "name", inputIsPublic: false, inputCompression: "br", inputPolicy: &entity.VolumePolicy{}, expectError: entity.ErrInvalidVolumeCompression},
+		{name: "droppable volume", inputAccountID: uuid.New(), inputName: "name", inputIsPublic: false, inputCompression: "", inputPolicy: &entity.VolumePolicy{}, inputDrop: &entity.VolumeDrop{Prefix: "inbox"}, expectError: nil},
+		{name: "policy is nil", inputAccountID: uuid.New(), inputName: "name", inputIsPublic: false, inputCompression: "", inputPolicy: nil, expectError: entity.ErrRequiredVolumePolicy},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			volume, err := entity.NewVolume(tt.inputAccountID, tt.inputName, tt.inputIsPublic)
+			volume, err := entity.NewVolume(tt.inputAccountID, tt.inputName, tt.inputIsPublic, tt.inputCompression, tt.inputPolicy, tt.inputDrop)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -89,11 +95,18 @@ func TestVolume_SetName(t *testing.T) {
 		{name: "include greater than sign", inputName: "volume>name", expectError: entity.ErrInvalidVolumeName},
 		{name: "include less than sign", inputName: "volume<name", expectError: entity.ErrInvalidVolumeName},
 		{name: "include vertical bar", inputName: "volume|name", expectError: entity.ErrInvalidVolumeName},
-		{name: "full width", inputName: "ボリューム名", expectError: entity.ErrInvalidVolumeName},
+		{name: "full width", inputName: "ボリューム名", expectError: nil},
+		{name: "include control character", inputName: "volume\tname", expectError: entity.ErrInvalidVolumeName},
+		{name: "include bidi control character", inputName: "volume\u202ename", expectError: entity.ErrInvalidVolumeName},
+		{name: "invalid utf-8", inputName: "volume\xffname", expectError: entity.ErrInvalidVolumeName},
+		{name: "current directory", inputName: ".", expectError: entity.ErrInvalidVolumeName},
+		{name: "parent directory", inputName: "..", expectError: entity.ErrInvalidVolumeName},
 		{name: "0 characters", inputName: strings.Repeat("a", 0), expectError: entity.ErrShortVolumeName},
 		{name: "1 characters", inputName: strings.Repeat("a", 1), expectError: nil},
 		{name: "255 characters", inputName: strings.Repeat("a", 255), expectError: nil},
 		{name: "256 characters", inputName: strings.Repeat("a", 256), expectError: entity.ErrLongVolumeName},
+		{name: "255 bytes", inputName: strings.Repeat("あ", 85), expectError: nil},
+		{name: "258 bytes", inputName: strings.Repeat("あ", 86), expectError: entity.ErrLongVolumeName},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -103,3 +116,136 @@ func TestVolume_SetName(t *testing.T) {
 		})
 	}
 }
+
+func TestVolume_SetName_Normalization(t *testing.T) {
+	volume := &entity.Volume{}
+
+	if err := volume.SetName("\u30ab\u3099"); err != nil {
+		t.Error(err)
+	}
+
+	if expect := "\u30ac"; volume.Name != expect {
+		t.Errorf("\nexpect: %v\ngot: %v", expect, volume.Name)
+	}
+}
+
+func TestVolume_Path(t *testing.T) {
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: uuid.MustParse("0b6a3a7e-6a3f-4b8e-9c8e-2f7d0a1f4c55"),
+		Name:      "name",
+	}
+
+	if expect := "0b6a3a7e-6a3f-4b8e-9c8e-2f7d0a1f4c55/name"; volume.Path() != expect {
+		t.Errorf("\nexpect: %v\ngot: %v", expect, volume.Path())
+	}
+}
+
+func TestVolume_SetCompression(t *testing.T) {
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		inputCompression string
+		expectError      error
+	}{
+		{name: "no compression", inputCompression: "", expectError: nil},
+		{name: "gzip", inputCompression: "gzip", expectError: nil},
+		{name: "zstd", inputCompression: "zstd", expectError: nil},
+		{name: "unsupported compression", inputCompression: "br", expectError: entity.ErrInvalidVolumeCompression},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := volume.SetCompression(tt.inputCompression); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolume_ValidateEntry(t *testing.T) {
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		Name:      "name",
+		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{MaxFileSize: 4, AllowedTypes: []string{"text/*"}, MaxKeyDepth: 2},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		inputVolume *entity.Volume
+		inputEntry  *entity.Entry
+		expectError error
+	}{
+		{name: "valid file", inputVolume: volume, inputEntry: &entity.Entry{Key: "key/sample.txt", Size: 4, Type: "text/plain; charset=utf-8"}, expectError: nil},
+		{name: "valid folder", inputVolume: volume, inputEntry: &entity.Entry{Key: "key/folder", Type: "folder"}, expectError: nil},
+		{name: "too deep folder", inputVolume: volume, inputEntry: &entity.Entry{Key: "key/folder/folder", Type: "folder"}, expectError: entity.ErrEntryTooDeep},
+		{name: "too large file", inputVolume: volume, inputEntry: &entity.Entry{Key: "key/sample.txt", Size: 5, Type: "text/plain; charset=utf-8"}, expectError: entity.ErrEntryTooLarge},
+		{name: "not allowed type", inputVolume: volume, inputEntry: &entity.Entry{Key: "key/sample.png", Size: 4, Type: "image/png"}, expectError: entity.ErrEntryTypeNotAllowed},
+		{name: "policy is not set", inputVolume: &entity.Volume{}, inputEntry: &entity.Entry{Key: "key/sample.png", Size: 5, Type: "image/png"}, expectError: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.inputVolume.ValidateEntry(tt.inputEntry); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolume_ValidateEntryCount(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputVolume    *entity.Volume
+		inputCount     uint64
+		expectHasLimit bool
+		expectError    error
+	}{
+		{name: "under limit", inputVolume: &entity.Volume{Policy: &entity.VolumePolicy{MaxEntriesPerFolder: 2}}, inputCount: 1, expectHasLimit: true, expectError: nil},
+		{name: "reached limit", inputVolume: &entity.Volume{Policy: &entity.VolumePolicy{MaxEntriesPerFolder: 2}}, inputCount: 2, expectHasLimit: true, expectError: entity.ErrTooManyEntries},
+		{name: "unlimited", inputVolume: &entity.Volume{Policy: &entity.VolumePolicy{}}, inputCount: 2, expectHasLimit: false, expectError: nil},
+		{name: "policy is not set", inputVolume: &entity.Volume{}, inputCount: 2, expectHasLimit: false, expectError: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hasLimit := tt.inputVolume.HasEntryCountLimit(); hasLimit != tt.expectHasLimit {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectHasLimit, hasLimit)
+			}
+			if err := tt.inputVolume.ValidateEntryCount(tt.inputCount); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVolume_ValidateDrop(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputVolume *entity.Volume
+		inputEntry  *entity.Entry
+		expectError error
+	}{
+		{name: "droppable", inputVolume: &entity.Volume{Drop: &entity.VolumeDrop{Prefix: "inbox"}}, inputEntry: &entity.Entry{Key: "inbox/sample.txt", Type: "text/plain"}, expectError: nil},
+		{name: "not droppable", inputVolume: &entity.Volume{}, inputEntry: &entity.Entry{Key: "inbox/sample.txt", Type: "text/plain"}, expectError: entity.ErrEntryNotDroppable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if isDroppable := tt.inputVolume.IsDroppable(); isDroppable != (tt.expectError == nil) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError == nil, isDroppable)
+			}
+			if err := tt.inputVolume.ValidateDrop(tt.inputEntry); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package entity
+
+// NOTE: ボリューム内の全てのエントリーのサイズの合計と件数を表す.
+type VolumeUsage struct {
+	Size       uint64
+	EntryCount uint64
+}
+
+func RestoreVolumeUsage(size, entryCount uint64) *VolumeUsage {
+	return &VolumeUsage{
+		Size:       size,
+		EntryCount: entryCount,
+	}
+}
@@ -0,0 +1,181 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/network"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const webhookSecretSize = 32
+
+var (
+	ErrRequiredWebhookAccountID = status.Error(code.Internal, "account id for webhook is required")
+	ErrRequiredWebhookVolumeID  = status.Error(code.Internal, "volume id for webhook is required")
+	ErrInvalidWebhookURL        = status.Error(code.UnprocessableContent, "webhook url must be an absolute http or https url")
+	ErrLongWebhookURL           = status.Error(code.UnprocessableContent, "webhook url is too long")
+	ErrPrivateWebhookURL        = status.Error(code.UnprocessableContent, "webhook url must not point to a private network")
+	ErrInvalidWebhookEvent      = status.Error(code.UnprocessableContent, "webhook event is not supported")
+	ErrLongWebhookPrefix        = status.Error(code.UnprocessableContent, "webhook prefix is too long")
+)
+
+type Webhook struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	VolumeID  uuid.UUID
+	URL       string
+	Secret    string
+	Events    []string
+	Prefix    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewWebhook(accountID, volumeID uuid.UUID, webhookURL string, events []string, prefix string) (*Webhook, error) {
+	var webhook Webhook
+
+	if err := webhook.generateID(); err != nil {
+		return nil, err
+	}
+	if err := webhook.generateSecret(); err != nil {
+		return nil, err
+	}
+	if err := webhook.setAccountID(accountID); err != nil {
+		return nil, err
+	}
+	if err := webhook.setVolumeID(volumeID); err != nil {
+		return nil, err
+	}
+	if err := webhook.setURL(webhookURL); err != nil {
+		return nil, err
+	}
+	if err := webhook.setEvents(events); err != nil {
+		return nil, err
+	}
+	if err := webhook.setPrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	return &webhook, nil
+}
+
+func RestoreWebhook(id, accountID, volumeID uuid.UUID, webhookURL, secret string, events []string, prefix string, createdAt, updatedAt time.Time) *Webhook {
+	return &Webhook{
+		ID:        id,
+		AccountID: accountID,
+		VolumeID:  volumeID,
+		URL:       webhookURL,
+		Secret:    secret,
+		Events:    events,
+		Prefix:    prefix,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// NOTE: 種別を指定していない場合は全ての種別を, 前方一致のキーはエントリーのイベントのみを対象とする.
+func (w *Webhook) Matches(event *Event) bool {
+	if event == nil {
+		return false
+	}
+	if len(w.Events) != 0 && !slices.Contains(w.Events, event.Type) {
+		return false
+	}
+	if w.Prefix == "" || !event.IsEntryEvent() {
+		return true
+	}
+	return hasKeyPrefix(event.Key, w.Prefix) || (event.NewKey != "" && hasKeyPrefix(event.NewKey, w.Prefix))
+}
+
+func (w *Webhook) generateID() error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	w.ID = id
+	return nil
+}
+
+func (w *Webhook) generateSecret() error {
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	w.Secret = hex.EncodeToString(secret)
+	return nil
+}
+
+func (w *Webhook) setAccountID(accountID uuid.UUID) error {
+	if accountID == uuid.Nil {
+		return ErrRequiredWebhookAccountID
+	}
+	w.AccountID = accountID
+	return nil
+}
+
+func (w *Webhook) setVolumeID(volumeID uuid.UUID) error {
+	if volumeID == uuid.Nil {
+		return ErrRequiredWebhookVolumeID
+	}
+	w.VolumeID = volumeID
+	return nil
+}
+
+func (w *Webhook) setURL(webhookURL string) error {
+	if 2048 < len(webhookURL) {
+		return ErrLongWebhookURL
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if !isPublicHost(u.Hostname()) {
+		return ErrPrivateWebhookURL
+	}
+	w.URL = webhookURL
+	return nil
+}
+
+func (w *Webhook) setEvents(events []string) error {
+	for _, event := range events {
+		if !slices.Contains(eventTypes, event) {
+			return ErrInvalidWebhookEvent
+		}
+	}
+	w.Events = events
+	return nil
+}
+
+func (w *Webhook) setPrefix(prefix string) error {
+	prefix = strings.Trim(prefix, "/")
+	if 512 < len(prefix) {
+		return ErrLongWebhookPrefix
+	}
+	w.Prefix = prefix
+	return nil
+}
+
+// NOTE: 名前解決の結果は送信時に検証するため, ここではIPアドレスとlocalhostのみを判定する.
+func isPublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return network.IsPublicAddr(addr)
+	}
+	return true
+}
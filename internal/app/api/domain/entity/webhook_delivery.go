@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusRunning   = "running"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+const (
+	MaxWebhookDeliveryAttempts   = 8
+	webhookDeliveryRetryInterval = 10 * time.Second
+)
+
+var (
+	ErrRequiredWebhook      = status.Error(code.Internal, "webhook is required")
+	ErrRequiredWebhookEvent = status.Error(code.Internal, "event for webhook is required")
+)
+
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	URL            string
+	Payload        []byte
+	Secret         string
+	Status         string
+	ResponseStatus uint64
+	Error          string
+	Attempts       uint64
+	RunAt          time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NOTE: 送信までに Webhook が削除されても送信できるように送信先と署名の鍵を保持する.
+func NewWebhookDelivery(webhook *Webhook, event *Event, payload []byte) (*WebhookDelivery, error) {
+	if webhook == nil {
+		return nil, ErrRequiredWebhook
+	}
+	if event == nil {
+		return nil, ErrRequiredWebhookEvent
+	}
+
+	delivery := WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		URL:       webhook.URL,
+		Payload:   payload,
+		Secret:    webhook.Secret,
+		Status:    WebhookDeliveryStatusPending,
+	}
+
+	if err := delivery.generateID(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	delivery.RunAt = now
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+
+	return &delivery, nil
+}
+
+func RestoreWebhookDelivery(
+	id, webhookID, eventID uuid.UUID,
+	eventType, deliveryURL string,
+	payload []byte,
+	secret, deliveryStatus string,
+	responseStatus uint64,
+	deliveryError string,
+	attempts uint64,
+	runAt, createdAt, updatedAt time.Time,
+) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             id,
+		WebhookID:      webhookID,
+		EventID:        eventID,
+		EventType:      eventType,
+		URL:            deliveryURL,
+		Payload:        payload,
+		Secret:         secret,
+		Status:         deliveryStatus,
+		ResponseStatus: responseStatus,
+		Error:          deliveryError,
+		Attempts:       attempts,
+		RunAt:          runAt,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+}
+
+// NOTE: 受信側で改ざんと再送の攻撃を検知できるよう, 送信日時とペイロードを連結した HMAC-SHA256 を付与する.
+func (d *WebhookDelivery) Sign(timestamp time.Time) string {
+	mac := hmac.New(sha256.New, []byte(d.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10) + "."))
+	mac.Write(d.Payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *WebhookDelivery) Start() {
+	d.Status = WebhookDeliveryStatusRunning
+	d.Attempts++
+	d.UpdatedAt = time.Now()
+}
+
+func (d *WebhookDelivery) Succeed(responseStatus uint64) {
+	d.Status = WebhookDeliveryStatusSucceeded
+	d.ResponseStatus = responseStatus
+	d.Error = ""
+	d.UpdatedAt = time.Now()
+}
+
+// NOTE: 試行回数の上限までは試行回数に応じて間隔を空けて再送する.
+func (d *WebhookDelivery) Fail(responseStatus uint64, err error) {
+	now := time.Now()
+	d.ResponseStatus = responseStatus
+	d.Error = err.Error()
+	d.UpdatedAt = now
+
+	if d.Attempts < MaxWebhookDeliveryAttempts {
+		d.Status = WebhookDeliveryStatusPending
+		d.RunAt = now.Add(webhookDeliveryRetryInterval << (d.Attempts - 1))
+		return
+	}
+	d.Status = WebhookDeliveryStatusFailed
+}
+
+func (d *WebhookDelivery) generateID() error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	d.ID = id
+	return nil
+}
@@ -0,0 +1,105 @@
+package entity_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewWebhookDelivery(t *testing.T) {
+	webhook := &entity.Webhook{ID: uuid.New(), URL: "https://example.com", Secret: "secret"}
+	event := &entity.Event{ID: uuid.New(), Type: entity.EventTypeEntryCreated}
+	payload := []byte("{}")
+
+	tests := []struct {
+		name         string
+		inputWebhook *entity.Webhook
+		inputEvent   *entity.Event
+		expectError  error
+	}{
+		{name: "successfully initialized", inputWebhook: webhook, inputEvent: event, expectError: nil},
+		{name: "webhook is nil", inputWebhook: nil, inputEvent: event, expectError: entity.ErrRequiredWebhook},
+		{name: "event is nil", inputWebhook: webhook, inputEvent: nil, expectError: entity.ErrRequiredWebhookEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery, err := entity.NewWebhookDelivery(tt.inputWebhook, tt.inputEvent, payload)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if delivery == nil {
+					t.Fatal("delivery is nil")
+				}
+				if delivery.Status != entity.WebhookDeliveryStatusPending {
+					t.Errorf("\nexpect: %s\ngot: %s", entity.WebhookDeliveryStatusPending, delivery.Status)
+				}
+				if delivery.URL != webhook.URL {
+					t.Errorf("\nexpect: %s\ngot: %s", webhook.URL, delivery.URL)
+				}
+				if delivery.Secret != webhook.Secret {
+					t.Errorf("\nexpect: %s\ngot: %s", webhook.Secret, delivery.Secret)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Fail(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputAttempts uint64
+		expectStatus  string
+		expectDelay   time.Duration
+	}{
+		{name: "retry", inputAttempts: 1, expectStatus: entity.WebhookDeliveryStatusPending, expectDelay: 10 * time.Second},
+		{name: "backoff", inputAttempts: 4, expectStatus: entity.WebhookDeliveryStatusPending, expectDelay: 80 * time.Second},
+		{name: "attempts exceeded", inputAttempts: entity.MaxWebhookDeliveryAttempts, expectStatus: entity.WebhookDeliveryStatusFailed, expectDelay: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := &entity.WebhookDelivery{Status: entity.WebhookDeliveryStatusRunning, Attempts: tt.inputAttempts}
+
+			delivery.Fail(500, errors.New("test"))
+
+			if delivery.Status != tt.expectStatus {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectStatus, delivery.Status)
+			}
+			if delivery.ResponseStatus != 500 {
+				t.Errorf("\nexpect: 500\ngot: %d", delivery.ResponseStatus)
+			}
+			if delivery.Error != "test" {
+				t.Errorf("\nexpect: test\ngot: %s", delivery.Error)
+			}
+			if tt.expectDelay != 0 {
+				if delay := delivery.RunAt.Sub(delivery.UpdatedAt); delay != tt.expectDelay {
+					t.Errorf("\nexpect: %v\ngot: %v", tt.expectDelay, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Sign(t *testing.T) {
+	delivery := &entity.WebhookDelivery{Secret: "secret", Payload: []byte(`{"type":"entry.created"}`)}
+	timestamp := time.Unix(1700000000, 0)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`1700000000.{"type":"entry.created"}`))
+	expect := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if signature := delivery.Sign(timestamp); signature != expect {
+		t.Errorf("\nexpect: %s\ngot: %s", expect, signature)
+	}
+	if signature := delivery.Sign(timestamp.Add(time.Second)); signature == expect {
+		t.Error("signature does not depend on timestamp")
+	}
+}
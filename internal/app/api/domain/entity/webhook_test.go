@@ -0,0 +1,108 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+func TestNewWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		inputAccountID uuid.UUID
+		inputVolumeID  uuid.UUID
+		inputURL       string
+		inputEvents    []string
+		inputPrefix    string
+		expectPrefix   string
+		expectError    error
+	}{
+		{name: "successfully initialized", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://example.com/hook", inputEvents: []string{entity.EventTypeEntryCreated}, inputPrefix: "/dir/", expectPrefix: "dir", expectError: nil},
+		{name: "http", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "http://example.com", inputEvents: nil, inputPrefix: "", expectPrefix: "", expectError: nil},
+		{name: "account id is nil", inputAccountID: uuid.Nil, inputVolumeID: uuid.New(), inputURL: "https://example.com", inputEvents: nil, inputPrefix: "", expectError: entity.ErrRequiredWebhookAccountID},
+		{name: "volume id is nil", inputAccountID: uuid.New(), inputVolumeID: uuid.Nil, inputURL: "https://example.com", inputEvents: nil, inputPrefix: "", expectError: entity.ErrRequiredWebhookVolumeID},
+		{name: "empty url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "", inputEvents: nil, inputPrefix: "", expectError: entity.ErrInvalidWebhookURL},
+		{name: "invalid scheme", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "ftp://example.com", inputEvents: nil, inputPrefix: "", expectError: entity.ErrInvalidWebhookURL},
+		{name: "relative url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "/hook", inputEvents: nil, inputPrefix: "", expectError: entity.ErrInvalidWebhookURL},
+		{name: "2048 characters url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://example.com/" + strings.Repeat("a", 2028), inputEvents: nil, inputPrefix: "", expectError: nil},
+		{name: "2049 characters url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://example.com/" + strings.Repeat("a", 2029), inputEvents: nil, inputPrefix: "", expectError: entity.ErrLongWebhookURL},
+		{name: "loopback url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "http://127.0.0.1:8000/hook", inputEvents: nil, inputPrefix: "", expectError: entity.ErrPrivateWebhookURL},
+		{name: "localhost url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "http://localhost/hook", inputEvents: nil, inputPrefix: "", expectError: entity.ErrPrivateWebhookURL},
+		{name: "private network url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "http://10.0.0.1/hook", inputEvents: nil, inputPrefix: "", expectError: entity.ErrPrivateWebhookURL},
+		{name: "metadata url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "http://169.254.169.254/latest/meta-data/", inputEvents: nil, inputPrefix: "", expectError: entity.ErrPrivateWebhookURL},
+		{name: "ipv6 loopback url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "http://[::1]/hook", inputEvents: nil, inputPrefix: "", expectError: entity.ErrPrivateWebhookURL},
+		{name: "public ip url", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://93.184.215.14/hook", inputEvents: nil, inputPrefix: "", expectPrefix: "", expectError: nil},
+		{name: "invalid event", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://example.com", inputEvents: []string{"entry.read"}, inputPrefix: "", expectError: entity.ErrInvalidWebhookEvent},
+		{name: "512 characters prefix", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://example.com", inputEvents: nil, inputPrefix: strings.Repeat("a", 512), expectPrefix: strings.Repeat("a", 512), expectError: nil},
+		{name: "513 characters prefix", inputAccountID: uuid.New(), inputVolumeID: uuid.New(), inputURL: "https://example.com", inputEvents: nil, inputPrefix: strings.Repeat("a", 513), expectError: entity.ErrLongWebhookPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook, err := entity.NewWebhook(tt.inputAccountID, tt.inputVolumeID, tt.inputURL, tt.inputEvents, tt.inputPrefix)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectError == nil {
+				if webhook == nil {
+					t.Fatal("webhook is nil")
+				}
+				if webhook.ID == uuid.Nil {
+					t.Error("id is not set")
+				}
+				if len(webhook.Secret) != 64 {
+					t.Errorf("\nexpect: 64\ngot: %d", len(webhook.Secret))
+				}
+				if webhook.Prefix != tt.expectPrefix {
+					t.Errorf("\nexpect: %s\ngot: %s", tt.expectPrefix, webhook.Prefix)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhook_Matches(t *testing.T) {
+	volume := &entity.Volume{ID: uuid.New(), AccountID: uuid.New(), Name: "name"}
+
+	tests := []struct {
+		name          string
+		inputEvents   []string
+		inputPrefix   string
+		inputType     string
+		inputKey      string
+		inputNewKey   string
+		expectMatches bool
+	}{
+		{name: "all events", inputEvents: nil, inputPrefix: "", inputType: entity.EventTypeEntryCreated, inputKey: "key", expectMatches: true},
+		{name: "subscribed event", inputEvents: []string{entity.EventTypeEntryCreated}, inputPrefix: "", inputType: entity.EventTypeEntryCreated, inputKey: "key", expectMatches: true},
+		{name: "unsubscribed event", inputEvents: []string{entity.EventTypeEntryCreated}, inputPrefix: "", inputType: entity.EventTypeEntryDeleted, inputKey: "key", expectMatches: false},
+		{name: "same key as prefix", inputEvents: nil, inputPrefix: "dir", inputType: entity.EventTypeEntryCreated, inputKey: "dir", expectMatches: true},
+		{name: "key under prefix", inputEvents: nil, inputPrefix: "dir", inputType: entity.EventTypeEntryCreated, inputKey: "dir/key", expectMatches: true},
+		{name: "key with same beginning", inputEvents: nil, inputPrefix: "dir", inputType: entity.EventTypeEntryCreated, inputKey: "directory", expectMatches: false},
+		{name: "new key under prefix", inputEvents: nil, inputPrefix: "dir", inputType: entity.EventTypeEntryRenamed, inputKey: "key", inputNewKey: "dir/key", expectMatches: true},
+		{name: "volume event with prefix", inputEvents: nil, inputPrefix: "dir", inputType: entity.EventTypeVolumeUpdated, inputKey: "", expectMatches: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &entity.Webhook{Events: tt.inputEvents, Prefix: tt.inputPrefix}
+
+			event, err := entity.NewEvent(tt.inputType, volume, tt.inputKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.inputNewKey != "" {
+				if err := event.SetDestination(volume, tt.inputNewKey); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if matches := webhook.Matches(event); matches != tt.expectMatches {
+				t.Errorf("\nexpect: %t\ngot: %t", tt.expectMatches, matches)
+			}
+		})
+	}
+}
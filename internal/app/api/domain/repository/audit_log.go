@@ -0,0 +1,28 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+// NOTE: 値が設定されていない条件は絞り込みに利用しない.
+type AuditLogCondition struct {
+	ActorID    *uuid.UUID
+	VolumeName string
+	Operation  string
+	ClientIP   string
+	Principal  string
+	Since      *time.Time
+	Until      *time.Time
+}
+
+type AuditLogRepository interface {
+	Create(context.Context, *entity.AuditLog) error
+	FindByOwnerID(context.Context, uuid.UUID, *AuditLogCondition, uint64, uint64) ([]*entity.AuditLog, error)
+	FindAll(context.Context, *AuditLogCondition, uint64, uint64) ([]*entity.AuditLog, error)
+}
@@ -1,12 +1,20 @@
 //go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
 package repository
 
-import "io"
+import (
+	"context"
+	"io"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
 
 type BodyRepository interface {
-	Create(string, io.Reader) error
-	Update(string, string) error
-	Delete(string) error
-	Copy(string, string) error
-	FindOneByPath(string) (io.ReadCloser, error)
+	Create(context.Context, string, io.Reader, *entity.BodyAttributes) error
+	Update(context.Context, string, string) error
+	Delete(context.Context, string) error
+	Copy(context.Context, string, string) error
+	FindOneByPath(context.Context, string, *entity.BodyAttributes) (io.ReadCloser, error)
+	FindByPath(context.Context, string) ([]*entity.Body, error)
+	CreateDerived(context.Context, string, string, io.Reader) error
+	FindOneDerived(context.Context, string, string) (io.ReadCloser, error)
 }
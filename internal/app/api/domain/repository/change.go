@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+type ChangeRepository interface {
+	Create(context.Context, *entity.Change) error
+	FindLatestSequenceByVolumeID(context.Context, uuid.UUID) (uint64, error)
+	FindByVolumeIDAndSequenceGreaterThan(context.Context, uuid.UUID, uint64, uint64) ([]*entity.Change, error)
+}
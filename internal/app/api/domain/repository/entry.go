@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,11 +14,23 @@ import (
 
 var ErrEntryNotFound = status.Error(code.NotFound, "entry not found")
 
+// NOTE: 値が設定されていない条件は絞り込みに利用せず, 条件を設定した場合はメタデータを持たないエントリーを除外する.
+type EntryCondition struct {
+	TakenFrom *time.Time
+	TakenTo   *time.Time
+	Camera    string
+}
+
 type EntryRepository interface {
 	Create(context.Context, *entity.Entry) error
 	Update(context.Context, *entity.Entry) error
 	Delete(context.Context, *entity.Entry) error
+	DeleteByPrefix(context.Context, string, uuid.UUID) error
+	CopyByPrefix(context.Context, string, uuid.UUID, string, uuid.UUID) error
+	MoveByPrefix(context.Context, string, uuid.UUID, uuid.UUID) error
 	FindOneByKeyAndVolumeID(context.Context, string, uuid.UUID) (*entity.Entry, error)
 	FindOneByKeyAndVolumeIDAndAccountID(context.Context, string, uuid.UUID, uuid.UUID) (*entity.Entry, error)
-	FindByVolumeIDAndAccountID(context.Context, uuid.UUID, uuid.UUID, *string, *uint64) ([]*entity.Entry, error)
+	FindByVolumeIDAndAccountID(context.Context, uuid.UUID, uuid.UUID, *string, *uint64, *EntryCondition) ([]*entity.Entry, error)
+	CountByParentIDAndVolumeID(context.Context, uuid.UUID, uuid.UUID) (uint64, error)
+	SumByVolumeID(context.Context, uuid.UUID) (*entity.VolumeUsage, error)
 }
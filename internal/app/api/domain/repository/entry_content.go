@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+type EntryContentRepository interface {
+	Create(context.Context, *entity.EntryContent) error
+	Copy(context.Context, uuid.UUID, uuid.UUID) error
+	Search(context.Context, uuid.UUID, uuid.UUID, []string, uint64) ([]*entity.EntryContentMatch, error)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+type EntryMetadataRepository interface {
+	Create(context.Context, *entity.EntryMetadata) error
+	Copy(context.Context, uuid.UUID, uuid.UUID) error
+	FindByEntryIDs(context.Context, []uuid.UUID) ([]*entity.EntryMetadata, error)
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrImagePresetNotFound = status.Error(code.NotFound, "image preset not found")
+
+type ImagePresetRepository interface {
+	Create(context.Context, *entity.ImagePreset) error
+	Delete(context.Context, *entity.ImagePreset) error
+	FindOneByIDAndVolumeIDAndAccountID(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*entity.ImagePreset, error)
+	FindOneByNameAndVolumeID(context.Context, string, uuid.UUID) (*entity.ImagePreset, error)
+	FindByVolumeID(context.Context, uuid.UUID) ([]*entity.ImagePreset, error)
+}
@@ -0,0 +1,25 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrJobNotFound = status.Error(code.NotFound, "job not found")
+
+type JobRepository interface {
+	Create(context.Context, *entity.Job) error
+	Update(context.Context, *entity.Job) error
+	AddProgress(context.Context, uuid.UUID, uint64, uint64) error
+	SetTotal(context.Context, uuid.UUID, uint64, uint64) error
+	FindOneByID(context.Context, uuid.UUID) (*entity.Job, error)
+	FindOneByIDAndAccountID(context.Context, uuid.UUID, uuid.UUID) (*entity.Job, error)
+	FindOneRunnable(context.Context, time.Time) (*entity.Job, error)
+}
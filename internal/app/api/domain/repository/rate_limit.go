@@ -0,0 +1,11 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+)
+
+// NOTE: 上限に達していない場合は回数を加算してtrueを返却する.
+type RateLimitRepository interface {
+	Allow(context.Context, string) (bool, error)
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrScanFailed = status.Error(code.Internal, "malware scan failed")
+
+// NOTE: 検出した場合は検出名を返却し, 検出しなかった場合は空文字を返却する.
+type ScannerRepository interface {
+	Scan(context.Context, io.Reader) (string, error)
+}
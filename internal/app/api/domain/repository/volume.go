@@ -17,8 +17,9 @@ type VolumeRepository interface {
 	Create(context.Context, *entity.Volume) error
 	Update(context.Context, *entity.Volume) error
 	Delete(context.Context, *entity.Volume) error
-	FindOneByName(context.Context, string) (*entity.Volume, error)
 	FindOneByNameAndAccountID(context.Context, string, uuid.UUID) (*entity.Volume, error)
 	FindOneByIDAndAccountID(context.Context, uuid.UUID, uuid.UUID) (*entity.Volume, error)
 	FindByAccountID(context.Context, uuid.UUID) ([]*entity.Volume, error)
+	FindByName(context.Context, string) ([]*entity.Volume, error)
+	FindAll(context.Context) ([]*entity.Volume, error)
 }
@@ -0,0 +1,21 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrWebhookNotFound = status.Error(code.NotFound, "webhook not found")
+
+type WebhookRepository interface {
+	Create(context.Context, *entity.Webhook) error
+	Delete(context.Context, *entity.Webhook) error
+	FindOneByIDAndVolumeIDAndAccountID(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*entity.Webhook, error)
+	FindByVolumeID(context.Context, uuid.UUID) ([]*entity.Webhook, error)
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrWebhookDeliveryNotFound = status.Error(code.NotFound, "webhook delivery not found")
+
+type WebhookDeliveryRepository interface {
+	Create(context.Context, *entity.WebhookDelivery) error
+	Update(context.Context, *entity.WebhookDelivery) error
+	FindOneRunnable(context.Context, time.Time) (*entity.WebhookDelivery, error)
+	FindByWebhookID(context.Context, uuid.UUID, uint64) ([]*entity.WebhookDelivery, error)
+}
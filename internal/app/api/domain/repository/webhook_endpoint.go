@@ -0,0 +1,23 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var (
+	ErrWebhookRejected       = status.Error(code.Internal, "webhook endpoint rejected the delivery")
+	ErrWebhookUnresolvable   = status.Error(code.UnprocessableContent, "webhook url host could not be resolved")
+	ErrWebhookPrivateAddress = status.Error(code.UnprocessableContent, "webhook url must not point to a private network")
+)
+
+// NOTE: 送信先の応答ステータスを返却し, 2xx 以外の場合は ErrWebhookRejected を返す.
+// NOTE: Verify は送信先のホストを名前解決し, 内部のアドレスを含む場合は ErrWebhookPrivateAddress を返す.
+type WebhookEndpointRepository interface {
+	Verify(context.Context, string) error
+	Send(context.Context, *entity.WebhookDelivery) (uint64, error)
+}
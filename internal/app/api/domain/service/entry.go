@@ -4,27 +4,50 @@ package service
 import (
 	"context"
 	"errors"
+	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
 )
 
+const (
+	ConflictPolicyFail      = "fail"
+	ConflictPolicyRename    = "rename"
+	ConflictPolicyOverwrite = "overwrite"
+	ConflictPolicyMerge     = "merge"
+	ConflictPolicySkip      = "skip"
+
+	EntryResultCreated     = "created"
+	EntryResultRenamed     = "renamed"
+	EntryResultOverwritten = "overwritten"
+	EntryResultMerged      = "merged"
+	EntryResultSkipped     = "skipped"
+)
+
+var conflictPolicies = []string{ConflictPolicyFail, ConflictPolicyRename, ConflictPolicyOverwrite, ConflictPolicyMerge, ConflictPolicySkip}
+
 var (
-	ErrRequiredEntry      = status.Error(code.Internal, "entry is required")
-	ErrEntryAlreadyExists = status.Error(code.Conflict, "entry key already used")
+	ErrRequiredEntry          = status.Error(code.Internal, "entry is required")
+	ErrEntryAlreadyExists     = status.Error(code.Conflict, "entry key already used")
+	ErrEntryCircularReference = status.Error(code.UnprocessableContent, "entry cannot be moved into itself")
+	ErrInvalidConflictPolicy  = status.Error(code.UnprocessableContent, "conflict policy is not supported")
 )
 
 type EntryService interface {
 	Exists(context.Context, *entity.Entry) error
-	CreateAncestors(context.Context, *entity.Entry) error
-	UpdateDescendants(context.Context, *entity.Entry, string) error
+	CreateAncestors(context.Context, *entity.Entry, *entity.Volume) error
 	DeleteDescendants(context.Context, *entity.Entry) error
-	Copy(context.Context, *entity.Entry) (*entity.Entry, error)
-	CopyDescendants(context.Context, *entity.Entry, string) error
+	Copy(context.Context, *entity.Entry, uuid.UUID, string) (*entity.Entry, error)
+	Resolve(context.Context, *entity.Entry, string, uuid.UUID, string) (*entity.Entry, string, error)
+	CopyDescendants(context.Context, *entity.Entry, string, uuid.UUID) error
+	MoveDescendants(context.Context, *entity.Entry, string, uuid.UUID) error
 }
 
 type entryService struct {
@@ -52,134 +75,217 @@ func (s *entryService) Exists(ctx context.Context, entry *entity.Entry) error {
 	return ErrEntryAlreadyExists
 }
 
-func (s *entryService) CreateAncestors(ctx context.Context, entry *entity.Entry) error {
+// NOTE: volume を指定した場合は作成する祖先にボリュームの制限を適用する.
+func (s *entryService) CreateAncestors(ctx context.Context, entry *entity.Entry, volume *entity.Volume) error {
 	if entry == nil {
 		return ErrRequiredEntry
 	}
 
+	parentID := uuid.Nil
 	for _, dir := range s.extractDirs(entry.Key) {
-		ent, err := entity.NewEntry(entry.AccountID, entry.VolumeID, dir, 0, "folder")
+		ancestor, err := s.entryRepo.FindOneByKeyAndVolumeID(ctx, dir, entry.VolumeID)
 		if err != nil {
-			return err
-		}
-		if err := s.Exists(ctx, ent); err != nil {
-			if errors.Is(err, ErrEntryAlreadyExists) {
-				continue
-			} else {
+			if !errors.Is(err, repository.ErrEntryNotFound) {
+				return err
+			}
+			ancestor, err = entity.NewEntry(entry.AccountID, entry.VolumeID, dir, 0, "folder")
+			if err != nil {
+				return err
+			}
+			ancestor.SetParentID(parentID)
+			if err := s.validateAncestor(ctx, ancestor, volume); err != nil {
+				return err
+			}
+			if err := s.entryRepo.Create(ctx, ancestor); err != nil {
 				return err
 			}
 		}
-		if err := s.entryRepo.Create(ctx, ent); err != nil {
-			return err
+		if ancestor.ID == entry.ID {
+			return ErrEntryCircularReference
 		}
+		parentID = ancestor.ID
 	}
 
+	entry.SetParentID(parentID)
 	return nil
 }
 
-func (s *entryService) UpdateDescendants(ctx context.Context, entry *entity.Entry, src string) error {
+func (s *entryService) DeleteDescendants(ctx context.Context, entry *entity.Entry) error {
 	if entry == nil {
 		return ErrRequiredEntry
 	}
 
 	if entry.IsFolder() {
-		descendants, err := s.entryRepo.FindByVolumeIDAndAccountID(ctx, entry.VolumeID, entry.AccountID, &src, nil)
-		if err != nil {
-			return err
-		}
+		return s.entryRepo.DeleteByPrefix(ctx, entry.Key, entry.VolumeID)
+	}
 
-		for _, descendant := range descendants {
-			key := strings.Replace(descendant.Key, src, entry.Key, 1)
-			if err := descendant.SetKey(key); err != nil {
-				return err
-			}
-			if err := s.entryRepo.Update(ctx, descendant); err != nil {
-				return err
-			}
+	return nil
+}
+
+func (s *entryService) Copy(_ context.Context, entry *entity.Entry, volumeID uuid.UUID, key string) (*entity.Entry, error) {
+	if entry == nil {
+		return nil, ErrRequiredEntry
+	}
+
+	copied, err := entity.NewEntry(entry.AccountID, volumeID, key, entry.Size, entry.Type)
+	if err != nil {
+		return nil, err
+	}
+	copied.SetBodyAttributes(entry.BodyAttributes())
+
+	return copied, nil
+}
+
+// NOTE: 移動先または複製先で競合するエントリーと競合方針に従った処理結果を返す.
+func (s *entryService) Resolve(ctx context.Context, entry *entity.Entry, src string, srcVolumeID uuid.UUID, policy string) (*entity.Entry, string, error) {
+	if entry == nil {
+		return nil, "", ErrRequiredEntry
+	}
+	if !slices.Contains(conflictPolicies, policy) {
+		return nil, "", ErrInvalidConflictPolicy
+	}
+
+	conflict, err := s.entryRepo.FindOneByKeyAndVolumeID(ctx, entry.Key, entry.VolumeID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrEntryNotFound) {
+			return nil, "", err
 		}
+		conflict = nil
 	}
 
-	return nil
+	result := s.resolveConflict(entry, conflict, policy)
+	if err := s.applyResult(ctx, entry, conflict, result, src, srcVolumeID); err != nil {
+		return nil, "", err
+	}
+
+	if result == EntryResultCreated || result == EntryResultRenamed {
+		return nil, result, nil
+	}
+	return conflict, result, nil
 }
 
-func (s *entryService) DeleteDescendants(ctx context.Context, entry *entity.Entry) error {
+func (s *entryService) CopyDescendants(ctx context.Context, entry *entity.Entry, src string, srcVolumeID uuid.UUID) error {
 	if entry == nil {
 		return ErrRequiredEntry
 	}
 
 	if entry.IsFolder() {
-		descendants, err := s.entryRepo.FindByVolumeIDAndAccountID(ctx, entry.VolumeID, entry.AccountID, &entry.Key, nil)
-		if err != nil {
-			return err
-		}
-
-		for _, descendant := range descendants {
-			if err := s.entryRepo.Delete(ctx, descendant); err != nil {
-				return err
-			}
-		}
+		return s.entryRepo.CopyByPrefix(ctx, src, srcVolumeID, entry.Key, entry.VolumeID)
 	}
 
 	return nil
 }
 
-func (s *entryService) Copy(ctx context.Context, entry *entity.Entry) (*entity.Entry, error) {
+// NOTE: 子孫は親への参照を保持するため, ボリュームを跨ぐ場合のみ更新する.
+func (s *entryService) MoveDescendants(ctx context.Context, entry *entity.Entry, src string, srcVolumeID uuid.UUID) error {
 	if entry == nil {
-		return nil, ErrRequiredEntry
+		return ErrRequiredEntry
 	}
 
-	name := filepath.Base(entry.Key)
-	ext := filepath.Ext(name)
-	base := strings.TrimSuffix(name, ext)
-	key := strings.Replace(entry.Key, name, base+" copy"+ext, 1)
+	if entry.IsFolder() && entry.VolumeID != srcVolumeID {
+		return s.entryRepo.MoveByPrefix(ctx, src, srcVolumeID, entry.VolumeID)
+	}
 
-	copied, err := entity.NewEntry(entry.AccountID, entry.VolumeID, key, entry.Size, entry.Type)
-	if err != nil {
-		return nil, err
+	return nil
+}
+
+func (s *entryService) resolveConflict(entry, conflict *entity.Entry, policy string) string {
+	if conflict == nil {
+		return EntryResultCreated
 	}
 
-	if err := s.Exists(ctx, copied); err != nil {
-		if errors.Is(err, ErrEntryAlreadyExists) {
-			copied, err = s.Copy(ctx, copied)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			return nil, err
+	switch policy {
+	case ConflictPolicyRename:
+		return EntryResultRenamed
+	case ConflictPolicyOverwrite:
+		return EntryResultOverwritten
+	case ConflictPolicyMerge, ConflictPolicySkip:
+		if entry.IsFolder() && conflict.IsFolder() {
+			return EntryResultMerged
 		}
+		if policy == ConflictPolicyMerge {
+			return EntryResultOverwritten
+		}
+		return EntryResultSkipped
+	default:
+		return ""
 	}
-
-	return copied, nil
 }
 
-func (s *entryService) CopyDescendants(ctx context.Context, entry *entity.Entry, src string) error {
-	if entry == nil {
-		return ErrRequiredEntry
+func (s *entryService) applyResult(ctx context.Context, entry, conflict *entity.Entry, result, src string, srcVolumeID uuid.UUID) error {
+	switch result {
+	case EntryResultCreated:
+		return s.validateDestination(entry, src, srcVolumeID)
+	case EntryResultRenamed:
+		if err := s.rename(ctx, entry); err != nil {
+			return err
+		}
+		return s.validateDestination(entry, src, srcVolumeID)
+	case EntryResultOverwritten, EntryResultMerged:
+		return s.validateConflict(conflict, src, srcVolumeID)
+	case EntryResultSkipped:
+		return nil
+	default:
+		return ErrEntryAlreadyExists
 	}
+}
 
-	if entry.IsFolder() {
-		descendants, err := s.entryRepo.FindByVolumeIDAndAccountID(ctx, entry.VolumeID, entry.AccountID, &src, nil)
-		if err != nil {
+// NOTE: 競合しなくなるまでキーに" copy"を付与する.
+func (s *entryService) rename(ctx context.Context, entry *entity.Entry) error {
+	for {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if err := entry.SetKey(strings.TrimSuffix(entry.Key, name) + strings.TrimSuffix(name, ext) + " copy" + ext); err != nil {
 			return err
 		}
 
-		for _, descendant := range descendants {
-			key := strings.Replace(descendant.Key, src, entry.Key, 1)
-			copied, err := entity.NewEntry(descendant.AccountID, descendant.VolumeID, key, descendant.Size, descendant.Type)
-			if err != nil {
-				return err
-			}
-			if err := s.entryRepo.Create(ctx, copied); err != nil {
-				return err
-			}
+		if err := s.Exists(ctx, entry); err == nil || !errors.Is(err, ErrEntryAlreadyExists) {
+			return err
 		}
 	}
+}
 
+func (s *entryService) validateDestination(entry *entity.Entry, src string, srcVolumeID uuid.UUID) error {
+	if entry.VolumeID == srcVolumeID && s.isDescendant(entry.Key, src) {
+		return ErrEntryCircularReference
+	}
 	return nil
 }
 
+// NOTE: 自身や上位, 下位のエントリーを上書きすると移動元または複製元が失われる.
+func (s *entryService) validateConflict(conflict *entity.Entry, src string, srcVolumeID uuid.UUID) error {
+	if conflict.VolumeID != srcVolumeID {
+		return nil
+	}
+	if conflict.Key == src || s.isDescendant(conflict.Key, src) || s.isDescendant(src, conflict.Key) {
+		return ErrEntryCircularReference
+	}
+	return nil
+}
+
+func (s *entryService) isDescendant(key, ancestor string) bool {
+	return strings.HasPrefix(key, ancestor+"/")
+}
+
+func (s *entryService) validateAncestor(ctx context.Context, ancestor *entity.Entry, volume *entity.Volume) error {
+	if volume == nil {
+		return nil
+	}
+	if err := volume.ValidateEntry(ancestor); err != nil {
+		return err
+	}
+	if !volume.HasEntryCountLimit() {
+		return nil
+	}
+	count, err := s.entryRepo.CountByParentIDAndVolumeID(ctx, ancestor.ParentID, volume.ID)
+	if err != nil {
+		return err
+	}
+	return volume.ValidateEntryCount(count)
+}
+
 func (s *entryService) extractDirs(key string) []string {
-	dirKey := filepath.Dir(key)
+	dirKey := path.Dir(key)
 	if dirKey == "." {
 		return nil
 	}
@@ -188,8 +294,9 @@ func (s *entryService) extractDirs(key string) []string {
 	var current string
 
 	for i, part := range strings.Split(dirKey, "/") {
-		current += part + "/"
+		current += part
 		dirs[i] = current
+		current += "/"
 	}
 
 	return dirs
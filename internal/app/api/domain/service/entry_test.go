@@ -120,9 +120,13 @@ func TestEntry_CreateAncestors(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
+	limitedVolume := &entity.Volume{ID: volumeID, AccountID: accountID, Name: "name", Policy: &entity.VolumePolicy{MaxEntriesPerFolder: 1}}
+	shallowVolume := &entity.Volume{ID: volumeID, AccountID: accountID, Name: "name", Policy: &entity.VolumePolicy{MaxKeyDepth: 1}}
+
 	tests := []struct {
 		name             string
 		inputEntry       *entity.Entry
+		inputVolume      *entity.Volume
 		expectError      error
 		setMockEntryRepo func(*mockRepository.MockEntryRepository)
 	}{
@@ -143,6 +147,70 @@ func TestEntry_CreateAncestors(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:        "ancestor within entry count limit",
+			inputEntry:  entry,
+			inputVolume: limitedVolume,
+			expectError: nil,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					CountByParentIDAndVolumeID(gomock.Any(), uuid.Nil, volumeID).
+					Return(uint64(0), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "ancestor exceeds entry count limit",
+			inputEntry:  entry,
+			inputVolume: limitedVolume,
+			expectError: entity.ErrTooManyEntries,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					CountByParentIDAndVolumeID(gomock.Any(), uuid.Nil, volumeID).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "ancestor exceeds depth limit",
+			inputEntry:  &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volumeID, Key: "key/sub/sample.txt", Type: "text/plain"},
+			inputVolume: shallowVolume,
+			expectError: entity.ErrEntryTooDeep,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+			},
+		},
 		{
 			name:             "entry is nil",
 			inputEntry:       nil,
@@ -161,6 +229,18 @@ func TestEntry_CreateAncestors(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:        "move into itself",
+			inputEntry:  &entity.Entry{ID: ancestorEntry.ID, AccountID: accountID, VolumeID: volumeID, Key: "key/sub", Type: "folder"},
+			expectError: service.ErrEntryCircularReference,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(ancestorEntry, nil).
+					Times(1)
+			},
+		},
 		{
 			name:        "find entry error",
 			inputEntry:  entry,
@@ -202,14 +282,18 @@ func TestEntry_CreateAncestors(t *testing.T) {
 			tt.setMockEntryRepo(entryRepo)
 
 			serv := service.NewEntryService(entryRepo)
-			if err := serv.CreateAncestors(ctx, tt.inputEntry); !errors.Is(err, tt.expectError) {
+			if err := serv.CreateAncestors(ctx, tt.inputEntry, tt.inputVolume); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
+
+			if tt.expectError == nil && tt.inputEntry.ParentID == uuid.Nil {
+				t.Error("parent_id is not set")
+			}
 		})
 	}
 }
 
-func TestEntry_UpdateDescendants(t *testing.T) {
+func TestEntry_DeleteDescendants(t *testing.T) {
 	accountID := uuid.New()
 	volumeID := uuid.New()
 	fileEntry := &entity.Entry{
@@ -226,51 +310,33 @@ func TestEntry_UpdateDescendants(t *testing.T) {
 		ID:        uuid.New(),
 		AccountID: accountID,
 		VolumeID:  volumeID,
-		Key:       "update",
+		Key:       "key",
 		Size:      0,
 		Type:      "folder",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	descendantEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volumeID,
-		Key:       "key/sample.txt",
-		Size:      4,
-		Type:      "text/plain; charset=utf-8",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
 
 	tests := []struct {
 		name             string
 		inputEntry       *entity.Entry
-		inputSrc         string
 		expectError      error
 		setMockEntryRepo func(*mockRepository.MockEntryRepository)
 	}{
 		{
-			name:             "update file entry",
+			name:             "delete file entry",
 			inputEntry:       fileEntry,
-			inputSrc:         "key",
 			expectError:      nil,
 			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
 		},
 		{
-			name:        "update folder entry",
+			name:        "delete folder entry",
 			inputEntry:  folderEntry,
-			inputSrc:    "key",
 			expectError: nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{descendantEntry}, nil).
-					Times(1)
-				entryRepo.
-					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
+					DeleteByPrefix(gomock.Any(), "key", volumeID).
 					Return(nil).
 					Times(1)
 			},
@@ -278,37 +344,17 @@ func TestEntry_UpdateDescendants(t *testing.T) {
 		{
 			name:             "entry is nil",
 			inputEntry:       nil,
-			inputSrc:         "update",
 			expectError:      service.ErrRequiredEntry,
 			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
 		},
 		{
-			name:        "find entry error",
-			inputEntry:  folderEntry,
-			inputSrc:    "key",
-			expectError: sql.ErrConnDone,
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
-					Times(1)
-			},
-		},
-		{
-			name:        "update entry error",
+			name:        "delete entry error",
 			inputEntry:  folderEntry,
-			inputSrc:    "key",
 			expectError: sql.ErrConnDone,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{descendantEntry}, nil).
-					Times(1)
-				entryRepo.
-					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
+					DeleteByPrefix(gomock.Any(), "key", volumeID).
 					Return(sql.ErrConnDone).
 					Times(1)
 			},
@@ -325,16 +371,17 @@ func TestEntry_UpdateDescendants(t *testing.T) {
 			tt.setMockEntryRepo(entryRepo)
 
 			serv := service.NewEntryService(entryRepo)
-			if err := serv.UpdateDescendants(ctx, tt.inputEntry, tt.inputSrc); !errors.Is(err, tt.expectError) {
+			if err := serv.DeleteDescendants(ctx, tt.inputEntry); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 		})
 	}
 }
 
-func TestEntry_DeleteDescendants(t *testing.T) {
+func TestEntry_Copy(t *testing.T) {
 	accountID := uuid.New()
 	volumeID := uuid.New()
+	otherVolumeID := uuid.New()
 	fileEntry := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: accountID,
@@ -342,234 +389,390 @@ func TestEntry_DeleteDescendants(t *testing.T) {
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
+		Encoding:  "gzip",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	folderEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volumeID,
-		Key:       "key",
-		Size:      0,
-		Type:      "folder",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+
+	tests := []struct {
+		name          string
+		inputEntry    *entity.Entry
+		inputVolumeID uuid.UUID
+		inputKey      string
+		expectResult  *entity.Entry
+		expectError   error
+	}{
+		{
+			name:          "copy entry",
+			inputEntry:    fileEntry,
+			inputVolumeID: volumeID,
+			inputKey:      "new_key/sample.txt",
+			expectResult:  &entity.Entry{AccountID: accountID, VolumeID: volumeID, Key: "new_key/sample.txt", Size: fileEntry.Size, Type: fileEntry.Type, Encoding: fileEntry.Encoding},
+			expectError:   nil,
+		},
+		{
+			name:          "copy to other volume",
+			inputEntry:    fileEntry,
+			inputVolumeID: otherVolumeID,
+			inputKey:      "key/sample.txt",
+			expectResult:  &entity.Entry{AccountID: accountID, VolumeID: otherVolumeID, Key: "key/sample.txt", Size: fileEntry.Size, Type: fileEntry.Type, Encoding: fileEntry.Encoding},
+			expectError:   nil,
+		},
+		{
+			name:          "invalid key",
+			inputEntry:    fileEntry,
+			inputVolumeID: volumeID,
+			inputKey:      "",
+			expectResult:  nil,
+			expectError:   entity.ErrShortEntryKey,
+		},
+		{
+			name:         "entry is nil",
+			inputEntry:   nil,
+			expectResult: nil,
+			expectError:  service.ErrRequiredEntry,
+		},
 	}
-	descendantEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volumeID,
-		Key:       "key/sample.txt",
-		Size:      4,
-		Type:      "text/plain; charset=utf-8",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+
+			serv := service.NewEntryService(entryRepo)
+
+			result, err := serv.Copy(ctx, tt.inputEntry, tt.inputVolumeID, tt.inputKey)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(entity.Entry{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
 	}
+}
+
+func TestEntry_Resolve(t *testing.T) {
+	accountID := uuid.New()
+	volumeID := uuid.New()
+	otherVolumeID := uuid.New()
+	newEntry := func(volumeID uuid.UUID, key, entryType string) *entity.Entry {
+		return &entity.Entry{
+			ID:        uuid.New(),
+			AccountID: accountID,
+			VolumeID:  volumeID,
+			Key:       key,
+			Type:      entryType,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+	}
+	conflictFile := newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8")
+	conflictFolder := newEntry(volumeID, "dst", "folder")
 
 	tests := []struct {
 		name             string
 		inputEntry       *entity.Entry
+		inputSrc         string
+		inputSrcVolumeID uuid.UUID
+		inputPolicy      string
+		expectConflict   *entity.Entry
+		expectResult     string
+		expectKey        string
 		expectError      error
 		setMockEntryRepo func(*mockRepository.MockEntryRepository)
 	}{
 		{
-			name:             "delete file entry",
-			inputEntry:       fileEntry,
+			name:             "no conflict",
+			inputEntry:       newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8"),
+			inputSrc:         "src/sample.txt",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyFail,
+			expectConflict:   nil,
+			expectResult:     service.EntryResultCreated,
+			expectKey:        "dst/sample.txt",
 			expectError:      nil,
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample.txt", volumeID).
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+			},
 		},
 		{
-			name:        "delete folder entry",
-			inputEntry:  folderEntry,
-			expectError: nil,
+			name:             "fail",
+			inputEntry:       newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8"),
+			inputSrc:         "src/sample.txt",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyFail,
+			expectConflict:   nil,
+			expectResult:     "",
+			expectKey:        "dst/sample.txt",
+			expectError:      service.ErrEntryAlreadyExists,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample.txt", volumeID).
+					Return(conflictFile, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "rename",
+			inputEntry:       newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8"),
+			inputSrc:         "src/sample.txt",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyRename,
+			expectConflict:   nil,
+			expectResult:     service.EntryResultRenamed,
+			expectKey:        "dst/sample copy copy.txt",
+			expectError:      nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{descendantEntry}, nil).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample.txt", volumeID).
+					Return(conflictFile, nil).
 					Times(1)
 				entryRepo.
 					EXPECT().
-					Delete(gomock.Any(), gomock.Any()).
-					Return(nil).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample copy.txt", volumeID).
+					Return(conflictFile, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample copy copy.txt", volumeID).
+					Return(nil, repository.ErrEntryNotFound).
 					Times(1)
 			},
 		},
 		{
-			name:             "entry is nil",
-			inputEntry:       nil,
-			expectError:      service.ErrRequiredEntry,
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			name:             "rename same key",
+			inputEntry:       newEntry(volumeID, "key/key", "folder"),
+			inputSrc:         "key/key",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyRename,
+			expectConflict:   nil,
+			expectResult:     service.EntryResultRenamed,
+			expectKey:        "key/key copy",
+			expectError:      nil,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "key/key", volumeID).
+					Return(newEntry(volumeID, "key/key", "folder"), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "key/key copy", volumeID).
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+			},
 		},
 		{
-			name:        "find entry error",
-			inputEntry:  folderEntry,
-			expectError: sql.ErrConnDone,
+			name:             "overwrite",
+			inputEntry:       newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8"),
+			inputSrc:         "src/sample.txt",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyOverwrite,
+			expectConflict:   conflictFile,
+			expectResult:     service.EntryResultOverwritten,
+			expectKey:        "dst/sample.txt",
+			expectError:      nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample.txt", volumeID).
+					Return(conflictFile, nil).
 					Times(1)
 			},
 		},
 		{
-			name:        "delete entry error",
-			inputEntry:  folderEntry,
-			expectError: sql.ErrConnDone,
+			name:             "merge folders",
+			inputEntry:       newEntry(volumeID, "dst", "folder"),
+			inputSrc:         "src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyMerge,
+			expectConflict:   conflictFolder,
+			expectResult:     service.EntryResultMerged,
+			expectKey:        "dst",
+			expectError:      nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{descendantEntry}, nil).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst", volumeID).
+					Return(conflictFolder, nil).
 					Times(1)
+			},
+		},
+		{
+			name:             "merge files",
+			inputEntry:       newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8"),
+			inputSrc:         "src/sample.txt",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyMerge,
+			expectConflict:   conflictFile,
+			expectResult:     service.EntryResultOverwritten,
+			expectKey:        "dst/sample.txt",
+			expectError:      nil,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					Delete(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample.txt", volumeID).
+					Return(conflictFile, nil).
 					Times(1)
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			ctx := t.Context()
-
-			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
-			tt.setMockEntryRepo(entryRepo)
-
-			serv := service.NewEntryService(entryRepo)
-			if err := serv.DeleteDescendants(ctx, tt.inputEntry); !errors.Is(err, tt.expectError) {
-				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
-			}
-		})
-	}
-}
-
-func TestEntry_Copy(t *testing.T) {
-	accountID := uuid.New()
-	volumeID := uuid.New()
-	fileEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volumeID,
-		Key:       "key/sample.txt",
-		Size:      4,
-		Type:      "text/plain; charset=utf-8",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	folderEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volumeID,
-		Key:       "key",
-		Size:      0,
-		Type:      "folder",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	copiedFileEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: fileEntry.AccountID,
-		VolumeID:  fileEntry.VolumeID,
-		Key:       "key/sample copy.txt",
-		Size:      fileEntry.Size,
-		Type:      fileEntry.Type,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	copiedFolderEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: folderEntry.AccountID,
-		VolumeID:  folderEntry.VolumeID,
-		Key:       "key copy",
-		Size:      folderEntry.Size,
-		Type:      folderEntry.Type,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	copiedFileEntryTwice := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: fileEntry.AccountID,
-		VolumeID:  fileEntry.VolumeID,
-		Key:       "key/sample copy copy.txt",
-		Size:      fileEntry.Size,
-		Type:      fileEntry.Type,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	tests := []struct {
-		name             string
-		inputEntry       *entity.Entry
-		expectResult     *entity.Entry
-		expectError      error
-		setMockEntryRepo func(*mockRepository.MockEntryRepository)
-	}{
 		{
-			name:         "copy file entry",
-			inputEntry:   fileEntry,
-			expectResult: copiedFileEntry,
-			expectError:  nil,
+			name:             "skip folders",
+			inputEntry:       newEntry(volumeID, "dst", "folder"),
+			inputSrc:         "src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicySkip,
+			expectConflict:   conflictFolder,
+			expectResult:     service.EntryResultMerged,
+			expectKey:        "dst",
+			expectError:      nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst", volumeID).
+					Return(conflictFolder, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "skip files",
+			inputEntry:       newEntry(volumeID, "dst/sample.txt", "text/plain; charset=utf-8"),
+			inputSrc:         "src/sample.txt",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicySkip,
+			expectConflict:   conflictFile,
+			expectResult:     service.EntryResultSkipped,
+			expectKey:        "dst/sample.txt",
+			expectError:      nil,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst/sample.txt", volumeID).
+					Return(conflictFile, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "into itself",
+			inputEntry:       newEntry(volumeID, "src/dst", "folder"),
+			inputSrc:         "src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyFail,
+			expectConflict:   nil,
+			expectResult:     "",
+			expectKey:        "src/dst",
+			expectError:      service.ErrEntryCircularReference,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeID(gomock.Any(), "src/dst", volumeID).
 					Return(nil, repository.ErrEntryNotFound).
 					Times(1)
 			},
 		},
 		{
-			name:         "copy folder entry",
-			inputEntry:   folderEntry,
-			expectResult: copiedFolderEntry,
-			expectError:  nil,
+			name:             "into itself in other volume",
+			inputEntry:       newEntry(otherVolumeID, "src/dst", "folder"),
+			inputSrc:         "src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyFail,
+			expectConflict:   nil,
+			expectResult:     service.EntryResultCreated,
+			expectKey:        "src/dst",
+			expectError:      nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					FindOneByKeyAndVolumeID(gomock.Any(), "src/dst", otherVolumeID).
 					Return(nil, repository.ErrEntryNotFound).
 					Times(1)
 			},
 		},
 		{
-			name:         "duplicate key",
-			inputEntry:   fileEntry,
-			expectResult: copiedFileEntryTwice,
-			expectError:  nil,
+			name:             "overwrite ancestor",
+			inputEntry:       newEntry(volumeID, "dst", "folder"),
+			inputSrc:         "dst/src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyOverwrite,
+			expectConflict:   nil,
+			expectResult:     "",
+			expectKey:        "dst",
+			expectError:      service.ErrEntryCircularReference,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(copiedFileEntry, nil).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst", volumeID).
+					Return(conflictFolder, nil).
 					Times(1)
+			},
+		},
+		{
+			name:             "merge into itself",
+			inputEntry:       newEntry(volumeID, "dst", "folder"),
+			inputSrc:         "dst",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyMerge,
+			expectConflict:   nil,
+			expectResult:     "",
+			expectKey:        "dst",
+			expectError:      service.ErrEntryCircularReference,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, repository.ErrEntryNotFound).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst", volumeID).
+					Return(conflictFolder, nil).
 					Times(1)
 			},
 		},
+		{
+			name:             "invalid policy",
+			inputEntry:       newEntry(volumeID, "dst", "folder"),
+			inputSrc:         "src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      "replace",
+			expectConflict:   nil,
+			expectResult:     "",
+			expectKey:        "dst",
+			expectError:      service.ErrInvalidConflictPolicy,
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+		},
 		{
 			name:             "entry is nil",
 			inputEntry:       nil,
-			expectResult:     nil,
+			inputPolicy:      service.ConflictPolicyFail,
+			expectConflict:   nil,
+			expectResult:     "",
 			expectError:      service.ErrRequiredEntry,
 			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
 		},
 		{
-			name:         "find entry error",
-			inputEntry:   fileEntry,
-			expectResult: nil,
-			expectError:  sql.ErrConnDone,
+			name:             "find entry error",
+			inputEntry:       newEntry(volumeID, "dst", "folder"),
+			inputSrc:         "src",
+			inputSrcVolumeID: volumeID,
+			inputPolicy:      service.ConflictPolicyFail,
+			expectConflict:   nil,
+			expectResult:     "",
+			expectKey:        "dst",
+			expectError:      sql.ErrConnDone,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					FindOneByKeyAndVolumeID(gomock.Any(), "dst", volumeID).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -587,44 +790,37 @@ func TestEntry_Copy(t *testing.T) {
 
 			serv := service.NewEntryService(entryRepo)
 
-			result, err := serv.Copy(ctx, tt.inputEntry)
+			conflict, result, err := serv.Resolve(ctx, tt.inputEntry, tt.inputSrc, tt.inputSrcVolumeID, tt.inputPolicy)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
-
-			opts := cmp.Options{
-				cmpopts.IgnoreFields(entity.Entry{}, "ID", "CreatedAt", "UpdatedAt"),
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectResult, result)
 			}
-			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+			if tt.inputEntry != nil && tt.inputEntry.Key != tt.expectKey {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectKey, tt.inputEntry.Key)
+			}
+
+			if diff := cmp.Diff(tt.expectConflict, conflict); diff != "" {
 				t.Error(diff)
 			}
 		})
 	}
 }
 
-func CopyDescendants(t *testing.T) {
+func TestEntry_CopyDescendants(t *testing.T) {
 	accountID := uuid.New()
 	volumeID := uuid.New()
-	fileEntry := &entity.Entry{
+	copiedFileEntry := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		VolumeID:  volumeID,
-		Key:       "key/sample.txt",
+		Key:       "key/sample copy.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	copiedFileEntry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: fileEntry.AccountID,
-		VolumeID:  fileEntry.VolumeID,
-		Key:       "key/sample copy.txt",
-		Size:      fileEntry.Size,
-		Type:      fileEntry.Type,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
 	copiedFolderEntry := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: accountID,
@@ -658,12 +854,7 @@ func CopyDescendants(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{fileEntry}, nil).
-					Times(1)
-				entryRepo.
-					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					CopyByPrefix(gomock.Any(), "key", volumeID, "key copy", volumeID).
 					Return(nil).
 					Times(1)
 			},
@@ -676,32 +867,112 @@ func CopyDescendants(t *testing.T) {
 			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
 		},
 		{
-			name:        "find entry error",
+			name:        "copy entry error",
 			inputEntry:  copiedFolderEntry,
 			inputSrc:    "key",
 			expectError: sql.ErrConnDone,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					CopyByPrefix(gomock.Any(), "key", volumeID, "key copy", volumeID).
+					Return(sql.ErrConnDone).
 					Times(1)
 			},
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			serv := service.NewEntryService(entryRepo)
+			if err := serv.CopyDescendants(ctx, tt.inputEntry, tt.inputSrc, volumeID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestEntry_MoveDescendants(t *testing.T) {
+	accountID := uuid.New()
+	volumeID := uuid.New()
+	newVolumeID := uuid.New()
+	folderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  newVolumeID,
+		Key:       "new_key",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	fileEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  newVolumeID,
+		Key:       "new_key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		inputEntry       *entity.Entry
+		inputVolumeID    uuid.UUID
+		expectError      error
+		setMockEntryRepo func(*mockRepository.MockEntryRepository)
+	}{
 		{
-			name:        "create entry error",
-			inputEntry:  copiedFolderEntry,
-			inputSrc:    "key",
-			expectError: sql.ErrConnDone,
+			name:          "move folder entry to other volume",
+			inputEntry:    folderEntry,
+			inputVolumeID: volumeID,
+			expectError:   nil,
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{fileEntry}, nil).
+					MoveByPrefix(gomock.Any(), "key", volumeID, newVolumeID).
+					Return(nil).
 					Times(1)
+			},
+		},
+		{
+			name:             "move folder entry in same volume",
+			inputEntry:       folderEntry,
+			inputVolumeID:    newVolumeID,
+			expectError:      nil,
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+		},
+		{
+			name:             "move file entry",
+			inputEntry:       fileEntry,
+			inputVolumeID:    volumeID,
+			expectError:      nil,
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+		},
+		{
+			name:             "entry is nil",
+			inputEntry:       nil,
+			inputVolumeID:    volumeID,
+			expectError:      service.ErrRequiredEntry,
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+		},
+		{
+			name:          "move entry error",
+			inputEntry:    folderEntry,
+			inputVolumeID: volumeID,
+			expectError:   sql.ErrConnDone,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					MoveByPrefix(gomock.Any(), "key", volumeID, newVolumeID).
 					Return(sql.ErrConnDone).
 					Times(1)
 			},
@@ -718,7 +989,7 @@ func CopyDescendants(t *testing.T) {
 			tt.setMockEntryRepo(entryRepo)
 
 			serv := service.NewEntryService(entryRepo)
-			if err := serv.CopyDescendants(ctx, tt.inputEntry, tt.inputSrc); !errors.Is(err, tt.expectError) {
+			if err := serv.MoveDescendants(ctx, tt.inputEntry, "key", tt.inputVolumeID); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 		})
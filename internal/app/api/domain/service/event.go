@@ -0,0 +1,133 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../../test/mock/domain/$GOPACKAGE/$GOFILE
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/actor"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredEvent = status.Error(code.Internal, "event is required")
+
+type eventPayload struct {
+	ID            uuid.UUID  `json:"id"`
+	Type          string     `json:"type"`
+	ActorID       *uuid.UUID `json:"actor_id,omitempty"`
+	VolumeName    string     `json:"volume_name"`
+	Key           string     `json:"key,omitempty"`
+	NewVolumeName string     `json:"new_volume_name,omitempty"`
+	NewKey        string     `json:"new_key,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type EventService interface {
+	Publish(context.Context, *entity.Event) error
+}
+
+type eventService struct {
+	changeRepo          repository.ChangeRepository
+	webhookRepo         repository.WebhookRepository
+	webhookDeliveryRepo repository.WebhookDeliveryRepository
+}
+
+func NewEventService(changeRepo repository.ChangeRepository, webhookRepo repository.WebhookRepository, webhookDeliveryRepo repository.WebhookDeliveryRepository) EventService {
+	return &eventService{
+		changeRepo:          changeRepo,
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+	}
+}
+
+// NOTE: 呼び出し元と同じトランザクションで変更履歴と送信待ちの配信を作成し, 送信はワーカーが行う.
+func (s *eventService) Publish(ctx context.Context, event *entity.Event) error {
+	if event == nil {
+		return ErrRequiredEvent
+	}
+	// NOTE: 匿名の操作は操作者を設定しない.
+	event.ActorID = actor.ID(ctx)
+
+	if err := s.record(ctx, event); err != nil {
+		return err
+	}
+
+	webhooks, err := s.findWebhooks(ctx, event)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(&eventPayload{
+		ID:            event.ID,
+		Type:          event.Type,
+		ActorID:       actorIDOrNil(event.ActorID),
+		VolumeName:    event.VolumeName,
+		Key:           event.Key,
+		NewVolumeName: event.NewVolumeName,
+		NewKey:        event.NewKey,
+		CreatedAt:     event.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		delivery, err := entity.NewWebhookDelivery(webhook, event, payload)
+		if err != nil {
+			return err
+		}
+		if err := s.webhookDeliveryRepo.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NOTE: ボリューム間の移動で採番の行ロックがデッドロックしないよう, ボリュームIDの順に記録する.
+func (s *eventService) record(ctx context.Context, event *entity.Event) error {
+	changes := entity.NewChanges(event)
+	slices.SortFunc(changes, func(a, b *entity.Change) int {
+		return bytes.Compare(a.VolumeID[:], b.VolumeID[:])
+	})
+
+	for _, change := range changes {
+		if err := s.changeRepo.Create(ctx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *eventService) findWebhooks(ctx context.Context, event *entity.Event) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	for _, volumeID := range event.VolumeIDs() {
+		candidates, err := s.webhookRepo.FindByVolumeID(ctx, volumeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, webhook := range candidates {
+			if webhook.Matches(event) {
+				webhooks = append(webhooks, webhook)
+			}
+		}
+	}
+	return webhooks, nil
+}
+
+func actorIDOrNil(actorID uuid.UUID) *uuid.UUID {
+	if actorID == uuid.Nil {
+		return nil
+	}
+	return &actorID
+}
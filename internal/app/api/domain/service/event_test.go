@@ -0,0 +1,322 @@
+package service_test
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/service"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/actor"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+)
+
+func TestEvent_Publish(t *testing.T) {
+	volume := &entity.Volume{ID: uuid.New(), AccountID: uuid.New(), Name: "name"}
+	other := &entity.Volume{ID: uuid.New(), AccountID: volume.AccountID, Name: "other"}
+
+	event, err := entity.NewEvent(entity.EventTypeEntryCreated, volume, "dir/key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copiedEvent, err := entity.NewEvent(entity.EventTypeEntryCopied, volume, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := copiedEvent.SetDestination(other, "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	movedEvent, err := entity.NewEvent(entity.EventTypeEntryRenamed, volume, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := movedEvent.SetDestination(other, "key"); err != nil {
+		t.Fatal(err)
+	}
+	first, second := volume, other
+	if bytes.Compare(first.ID[:], second.ID[:]) > 0 {
+		first, second = second, first
+	}
+
+	matchedWebhook := &entity.Webhook{ID: uuid.New(), VolumeID: volume.ID, URL: "https://example.com", Secret: "secret", Prefix: "dir"}
+	unmatchedWebhook := &entity.Webhook{ID: uuid.New(), VolumeID: volume.ID, URL: "https://example.com", Secret: "secret", Events: []string{entity.EventTypeEntryDeleted}}
+	otherWebhook := &entity.Webhook{ID: uuid.New(), VolumeID: other.ID, URL: "https://example.com", Secret: "secret"}
+
+	tests := []struct {
+		name                       string
+		inputEvent                 *entity.Event
+		expectError                error
+		setMockChangeRepo          func(*mockRepository.MockChangeRepository)
+		setMockWebhookRepo         func(*mockRepository.MockWebhookRepository)
+		setMockWebhookDeliveryRepo func(*mockRepository.MockWebhookDeliveryRepository)
+	}{
+		{
+			name:        "matched webhook",
+			inputEvent:  event,
+			expectError: nil,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), volume.ID).
+					Return([]*entity.Webhook{matchedWebhook, unmatchedWebhook}, nil).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Cond(func(delivery *entity.WebhookDelivery) bool {
+						return delivery.WebhookID == matchedWebhook.ID && delivery.EventID == event.ID
+					})).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "other volume",
+			inputEvent:  copiedEvent,
+			expectError: nil,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), volume.ID).
+					Return([]*entity.Webhook{}, nil).
+					Times(1)
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), other.ID).
+					Return([]*entity.Webhook{otherWebhook}, nil).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "no webhooks",
+			inputEvent:  event,
+			expectError: nil,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), gomock.Any()).
+					Return([]*entity.Webhook{}, nil).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(*mockRepository.MockWebhookDeliveryRepository) {},
+		},
+		{
+			name:        "moved to other volume",
+			inputEvent:  movedEvent,
+			expectError: nil,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				gomock.InOrder(
+					changeRepo.
+						EXPECT().
+						Create(gomock.Any(), gomock.Cond(func(change *entity.Change) bool {
+							return change.VolumeID == first.ID
+						})).
+						Return(nil).
+						Times(1),
+					changeRepo.
+						EXPECT().
+						Create(gomock.Any(), gomock.Cond(func(change *entity.Change) bool {
+							return change.VolumeID == second.ID
+						})).
+						Return(nil).
+						Times(1),
+				)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), gomock.Any()).
+					Return([]*entity.Webhook{}, nil).
+					Times(2)
+			},
+			setMockWebhookDeliveryRepo: func(*mockRepository.MockWebhookDeliveryRepository) {},
+		},
+		{
+			name:                       "event is nil",
+			inputEvent:                 nil,
+			expectError:                service.ErrRequiredEvent,
+			setMockChangeRepo:          func(*mockRepository.MockChangeRepository) {},
+			setMockWebhookRepo:         func(*mockRepository.MockWebhookRepository) {},
+			setMockWebhookDeliveryRepo: func(*mockRepository.MockWebhookDeliveryRepository) {},
+		},
+		{
+			name:        "record error",
+			inputEvent:  event,
+			expectError: sql.ErrConnDone,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockWebhookRepo:         func(*mockRepository.MockWebhookRepository) {},
+			setMockWebhookDeliveryRepo: func(*mockRepository.MockWebhookDeliveryRepository) {},
+		},
+		{
+			name:        "find error",
+			inputEvent:  event,
+			expectError: sql.ErrConnDone,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(*mockRepository.MockWebhookDeliveryRepository) {},
+		},
+		{
+			name:        "create error",
+			inputEvent:  event,
+			expectError: sql.ErrConnDone,
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), gomock.Any()).
+					Return([]*entity.Webhook{matchedWebhook}, nil).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			changeRepo := mockRepository.NewMockChangeRepository(ctrl)
+			tt.setMockChangeRepo(changeRepo)
+
+			webhookRepo := mockRepository.NewMockWebhookRepository(ctrl)
+			tt.setMockWebhookRepo(webhookRepo)
+
+			webhookDeliveryRepo := mockRepository.NewMockWebhookDeliveryRepository(ctrl)
+			tt.setMockWebhookDeliveryRepo(webhookDeliveryRepo)
+
+			serv := service.NewEventService(changeRepo, webhookRepo, webhookDeliveryRepo)
+			if err := serv.Publish(ctx, tt.inputEvent); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestEvent_Publish_Actor(t *testing.T) {
+	volume := &entity.Volume{ID: uuid.New(), AccountID: uuid.New(), Name: "name"}
+	webhook := &entity.Webhook{ID: uuid.New(), VolumeID: volume.ID, URL: "https://example.com", Secret: "secret"}
+	actorID := uuid.New()
+
+	tests := []struct {
+		name          string
+		withActor     bool
+		expectActorID uuid.UUID
+		expectPayload bool
+	}{
+		{name: "with actor", withActor: true, expectActorID: actorID, expectPayload: true},
+		{name: "anonymous", withActor: false, expectActorID: uuid.Nil, expectPayload: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+			if tt.withActor {
+				ctx = actor.WithID(ctx, actorID)
+			}
+
+			event, err := entity.NewEvent(entity.EventTypeEntryCreated, volume, "key")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			changeRepo := mockRepository.NewMockChangeRepository(ctrl)
+			changeRepo.
+				EXPECT().
+				Create(gomock.Any(), gomock.Cond(func(change *entity.Change) bool {
+					return change.ActorID == tt.expectActorID
+				})).
+				Return(nil).
+				Times(1)
+
+			webhookRepo := mockRepository.NewMockWebhookRepository(ctrl)
+			webhookRepo.
+				EXPECT().
+				FindByVolumeID(gomock.Any(), volume.ID).
+				Return([]*entity.Webhook{webhook}, nil).
+				Times(1)
+
+			webhookDeliveryRepo := mockRepository.NewMockWebhookDeliveryRepository(ctrl)
+			webhookDeliveryRepo.
+				EXPECT().
+				Create(gomock.Any(), gomock.Cond(func(delivery *entity.WebhookDelivery) bool {
+					return bytes.Contains(delivery.Payload, []byte(`"actor_id":"`+actorID.String()+`"`)) == tt.expectPayload
+				})).
+				Return(nil).
+				Times(1)
+
+			serv := service.NewEventService(changeRepo, webhookRepo, webhookDeliveryRepo)
+			if err := serv.Publish(ctx, event); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ func (s *volumeService) Exists(ctx context.Context, volume *entity.Volume) error
 	if volume == nil {
 		return ErrRequiredVolume
 	}
-	_, err := s.volumeRepo.FindOneByName(ctx, volume.Name)
+	_, err := s.volumeRepo.FindOneByNameAndAccountID(ctx, volume.Name, volume.AccountID)
 	if err != nil {
 		if errors.Is(err, repository.ErrVolumeNotFound) {
 			return nil
@@ -53,7 +53,7 @@ func (s *volumeService) CanDelete(ctx context.Context, volume *entity.Volume) er
 		return ErrRequiredVolume
 	}
 
-	entries, err := s.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, volume.AccountID, nil, nil)
+	entries, err := s.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, volume.AccountID, nil, nil, nil)
 	if err != nil {
 		return err
 	}
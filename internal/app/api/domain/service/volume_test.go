@@ -38,7 +38,7 @@ func TestVolume_Exists(t *testing.T) {
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, repository.ErrVolumeNotFound).
 					Times(1)
 			},
@@ -50,7 +50,7 @@ func TestVolume_Exists(t *testing.T) {
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(volume, nil).
 					Times(1)
 			},
@@ -68,7 +68,7 @@ func TestVolume_Exists(t *testing.T) {
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -125,7 +125,7 @@ func TestVolume_CanDelete(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, nil).
 					Times(1)
 			},
@@ -137,7 +137,7 @@ func TestVolume_CanDelete(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return([]*entity.Entry{entry}, nil).
 					Times(1)
 			},
@@ -155,7 +155,7 @@ func TestVolume_CanDelete(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/service"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func Fsck() {
+	repair := flag.Bool("repair", false, "repair detected discrepancies")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-repair] [volume ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	conf, err := loadServerConfig()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	db, err := NewDatabase(&conf.database)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	fs := afero.NewOsFs()
+	if err := migrateVolumeLayout(db, fs, conf.fileSystem.BasePath); err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	transactionObj := file.NewTransactionObject(transaction.NewDBTransactionObject(db))
+	volumeRepo := database.NewVolumeRepository(db)
+	entryRepo := database.NewEntryRepository(db)
+	bodyRepo := newBodyRepository(fs, &conf.fileSystem)
+	entryServ := service.NewEntryService(entryRepo)
+	fsckUC := usecase.NewFsckUsecase(transactionObj, volumeRepo, entryRepo, bodyRepo, entryServ)
+
+	reports, err := fsckUC.CheckAll(context.Background(), flag.Args(), *repair)
+	if closeErr := db.Close(); closeErr != nil {
+		log.Println(closeErr.Error())
+	}
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	unresolved, err := printFsckReports(reports)
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	// NOTE: 未解決の不整合が残っている場合は終了コードで通知する.
+	if unresolved {
+		os.Exit(1)
+	}
+}
+
+func printFsckReports(reports []*dto.FsckReportDTO) (bool, error) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tVOLUME\tCATEGORY\tKEY\tEXPECTED\tACTUAL\tREPAIRED")
+	unresolved := false
+	for _, report := range reports {
+		for _, issue := range report.Issues {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%t\n", report.AccountID, report.VolumeName, issue.Category, issue.Key, issue.Expected, issue.Actual, issue.Repaired)
+			if !issue.Repaired {
+				unresolved = true
+			}
+		}
+	}
+	return unresolved, w.Flush()
+}
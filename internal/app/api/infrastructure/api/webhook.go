@@ -0,0 +1,106 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/network"
+)
+
+type webhookEndpointRepository struct {
+	client *http.Client
+}
+
+// NOTE: 名前解決の結果を差し替えて内部のアドレスに接続させる攻撃を防ぐため, 接続直前のアドレスを検証する.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: verifyDialAddress,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
+
+func NewWebhookEndpointRepository(client *http.Client) repository.WebhookEndpointRepository {
+	return &webhookEndpointRepository{
+		client: client,
+	}
+}
+
+func (r *webhookEndpointRepository) Verify(ctx context.Context, webhookURL string) error {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return entity.ErrInvalidWebhookURL
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return repository.ErrWebhookUnresolvable
+	}
+	for _, addr := range addrs {
+		if !network.IsPublicAddr(addr) {
+			return repository.ErrWebhookPrivateAddress
+		}
+	}
+	return nil
+}
+
+func (r *webhookEndpointRepository) Send(ctx context.Context, delivery *entity.WebhookDelivery) (statusCode uint64, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Holos-Event", delivery.EventType)
+	req.Header.Set("X-Holos-Delivery", delivery.ID.String())
+	req.Header.Set("X-Holos-Timestamp", strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set("X-Holos-Signature", delivery.Sign(timestamp))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// NOTE: errに直接詰めると関数内のエラーがnilで上書きされるためエラー発生時のみ上書きする.
+		if e := resp.Body.Close(); e != nil {
+			err = e
+		}
+	}()
+
+	// NOTE: 接続を再利用できるように応答のボディを読み捨てる.
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return uint64(resp.StatusCode), err
+	}
+
+	if resp.StatusCode < http.StatusOK || http.StatusMultipleChoices <= resp.StatusCode {
+		return uint64(resp.StatusCode), repository.ErrWebhookRejected
+	}
+	return uint64(resp.StatusCode), nil
+}
+
+func verifyDialAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !network.IsPublicAddr(addrPort.Addr()) {
+		return repository.ErrWebhookPrivateAddress
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package api_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/api"
+)
+
+func TestWebhookEndpoint_Verify(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputURL    string
+		expectError error
+	}{
+		{
+			name:        "public address",
+			inputURL:    "https://93.184.215.14/hook",
+			expectError: nil,
+		},
+		{
+			name:        "loopback address",
+			inputURL:    "http://127.0.0.1/hook",
+			expectError: repository.ErrWebhookPrivateAddress,
+		},
+		{
+			name:        "metadata address",
+			inputURL:    "http://169.254.169.254/latest/meta-data",
+			expectError: repository.ErrWebhookPrivateAddress,
+		},
+		{
+			name:        "localhost",
+			inputURL:    "http://localhost/hook",
+			expectError: repository.ErrWebhookPrivateAddress,
+		},
+		{
+			name:        "unresolvable host",
+			inputURL:    "http://holos.invalid/hook",
+			expectError: repository.ErrWebhookUnresolvable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := api.NewWebhookEndpointRepository(http.DefaultClient)
+			if err := repo.Verify(t.Context(), tt.inputURL); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestWebhookEndpoint_Send(t *testing.T) {
+	delivery := &entity.WebhookDelivery{
+		ID:        uuid.New(),
+		EventType: entity.EventTypeEntryCreated,
+		Payload:   []byte(`{"type":"entry.created"}`),
+		Secret:    "secret",
+	}
+
+	tests := []struct {
+		name            string
+		expectResult    uint64
+		expectError     error
+		mockHandlerFunc http.HandlerFunc
+	}{
+		{
+			name:         "successfully sent",
+			expectResult: http.StatusNoContent,
+			expectError:  nil,
+			mockHandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+				timestamp, err := strconv.ParseInt(r.Header.Get("X-Holos-Timestamp"), 10, 64)
+				if err != nil {
+					t.Error(err)
+				}
+				if string(body) != string(delivery.Payload) || r.Header.Get("X-Holos-Signature") != delivery.Sign(time.Unix(timestamp, 0)) || r.Header.Get("X-Holos-Event") != delivery.EventType || r.Header.Get("X-Holos-Delivery") != delivery.ID.String() {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			},
+		},
+		{
+			name:         "rejected",
+			expectResult: http.StatusInternalServerError,
+			expectError:  repository.ErrWebhookRejected,
+			mockHandlerFunc: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte("internal server error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.mockHandlerFunc)
+			defer srv.Close()
+
+			delivery.URL = srv.URL
+
+			repo := api.NewWebhookEndpointRepository(srv.Client())
+			result, err := repo.Send(t.Context(), delivery)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestWebhookEndpoint_Send_PrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	delivery := &entity.WebhookDelivery{
+		ID:        uuid.New(),
+		URL:       srv.URL,
+		EventType: entity.EventTypeEntryCreated,
+		Payload:   []byte(`{"type":"entry.created"}`),
+		Secret:    "secret",
+	}
+
+	repo := api.NewWebhookEndpointRepository(api.NewWebhookClient(time.Second))
+	if _, err := repo.Send(t.Context(), delivery); !errors.Is(err, repository.ErrWebhookPrivateAddress) {
+		t.Errorf("\nexpect: %v\ngot: %v", repository.ErrWebhookPrivateAddress, err)
+	}
+}
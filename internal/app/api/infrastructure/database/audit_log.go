@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredAuditLog = status.Error(code.Internal, "audit log is required")
+
+type auditLogRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditLogRepository(db *sqlx.DB) repository.AuditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, auditLog *entity.AuditLog) error {
+	if auditLog == nil {
+		return ErrRequiredAuditLog
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToAuditLogModel(auditLog)
+	result, err := driver.NamedExecContext(ctx, "INSERT INTO audit_logs (request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at) VALUES (:request_id, :owner_id, :actor_id, :credential_type, :principal, :client_ip, :user_agent, :operation, :volume_name, :key, :new_volume_name, :new_key, :status, :created_at);", model)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	auditLog.ID = uint64(id)
+	return nil
+}
+
+func (r *auditLogRepository) FindByOwnerID(ctx context.Context, ownerID uuid.UUID, condition *repository.AuditLogCondition, cursor, limit uint64) ([]*entity.AuditLog, error) {
+	return r.find(ctx, []string{"owner_id = ?"}, []any{ownerID}, condition, cursor, limit)
+}
+
+// NOTE: 所有者を特定できない認可の失敗も含めて返却する.
+func (r *auditLogRepository) FindAll(ctx context.Context, condition *repository.AuditLogCondition, cursor, limit uint64) ([]*entity.AuditLog, error) {
+	return r.find(ctx, nil, nil, condition, cursor, limit)
+}
+
+// NOTE: 新しい順に返却し, カーソルを指定した場合はカーソルより前に登録された記録を返却する.
+func (r *auditLogRepository) find(ctx context.Context, clauses []string, args []any, condition *repository.AuditLogCondition, cursor, limit uint64) (auditLogs []*entity.AuditLog, err error) {
+	where, args := buildAuditLogCondition(clauses, args, condition, cursor)
+	args = append(args, limit)
+
+	driver := transaction.GetDriver(ctx, r.db)
+	rows, err := driver.QueryxContext(ctx, "SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs"+where+" ORDER BY id DESC LIMIT ?;", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.AuditLogModel
+	for rows.Next() {
+		var model model.AuditLogModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return transformer.ToAuditLogEntities(models), nil
+}
+
+func buildAuditLogCondition(clauses []string, args []any, condition *repository.AuditLogCondition, cursor uint64) (string, []any) {
+	if cursor != 0 {
+		clauses = append(clauses, "id < ?")
+		args = append(args, cursor)
+	}
+	if condition != nil {
+		clauses, args = appendAuditLogCondition(clauses, args, condition)
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+func appendAuditLogCondition(clauses []string, args []any, condition *repository.AuditLogCondition) ([]string, []any) {
+	if condition.ActorID != nil {
+		clauses = append(clauses, "actor_id = ?")
+		args = append(args, *condition.ActorID)
+	}
+	for _, filter := range []struct{ column, value string }{
+		{"volume_name", condition.VolumeName},
+		{"operation", condition.Operation},
+		{"client_ip", condition.ClientIP},
+		{"principal", condition.Principal},
+	} {
+		if filter.value != "" {
+			clauses = append(clauses, filter.column+" = ?")
+			args = append(args, filter.value)
+		}
+	}
+	if condition.Since != nil {
+		clauses = append(clauses, "created_at >= ?")
+		args = append(args, *condition.Since)
+	}
+	if condition.Until != nil {
+		clauses = append(clauses, "created_at < ?")
+		args = append(args, *condition.Until)
+	}
+	return clauses, args
+}
@@ -0,0 +1,269 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var auditLogColumns = []string{"id", "request_id", "owner_id", "actor_id", "credential_type", "principal", "client_ip", "user_agent", "operation", "volume_name", "key", "new_volume_name", "new_key", "status", "created_at"}
+
+func newAuditLog() *entity.AuditLog {
+	return &entity.AuditLog{
+		ID:             1,
+		RequestID:      "request",
+		OwnerID:        uuid.New(),
+		ActorID:        uuid.New(),
+		CredentialType: entity.CredentialTypeSession,
+		Principal:      "0123456789abcdef",
+		ClientIP:       "127.0.0.1",
+		UserAgent:      "agent",
+		Operation:      "entry.update",
+		VolumeName:     "volume",
+		Key:            "key",
+		NewVolumeName:  "other",
+		NewKey:         "new",
+		Status:         200,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func TestAuditLog_Create(t *testing.T) {
+	auditLog := newAuditLog()
+
+	tests := []struct {
+		name          string
+		inputAuditLog *entity.AuditLog
+		expectID      uint64
+		expectError   error
+		setMockDB     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "successfully inserted",
+			inputAuditLog: &entity.AuditLog{RequestID: auditLog.RequestID, OwnerID: auditLog.OwnerID, ActorID: auditLog.ActorID, CredentialType: auditLog.CredentialType, Principal: auditLog.Principal, ClientIP: auditLog.ClientIP, UserAgent: auditLog.UserAgent, Operation: auditLog.Operation, VolumeName: auditLog.VolumeName, Key: auditLog.Key, NewVolumeName: auditLog.NewVolumeName, NewKey: auditLog.NewKey, Status: auditLog.Status, CreatedAt: auditLog.CreatedAt},
+			expectID:      5,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_logs (request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(auditLog.RequestID, auditLog.OwnerID, auditLog.ActorID, auditLog.CredentialType, auditLog.Principal, auditLog.ClientIP, auditLog.UserAgent, auditLog.Operation, auditLog.VolumeName, auditLog.Key, auditLog.NewVolumeName, auditLog.NewKey, auditLog.Status, auditLog.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(5, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "anonymous",
+			inputAuditLog: &entity.AuditLog{RequestID: auditLog.RequestID, CredentialType: entity.CredentialTypeAnonymous, Operation: auditLog.Operation, Status: 401, CreatedAt: auditLog.CreatedAt},
+			expectID:      6,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_logs (request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(auditLog.RequestID, nil, nil, entity.CredentialTypeAnonymous, "", "", "", auditLog.Operation, "", "", "", "", uint64(401), auditLog.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(6, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "audit log is nil",
+			inputAuditLog: nil,
+			expectError:   database.ErrRequiredAuditLog,
+			setMockDB:     func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:          "insert error",
+			inputAuditLog: &entity.AuditLog{RequestID: auditLog.RequestID, OwnerID: auditLog.OwnerID, ActorID: auditLog.ActorID, CredentialType: auditLog.CredentialType, Principal: auditLog.Principal, ClientIP: auditLog.ClientIP, UserAgent: auditLog.UserAgent, Operation: auditLog.Operation, VolumeName: auditLog.VolumeName, Key: auditLog.Key, NewVolumeName: auditLog.NewVolumeName, NewKey: auditLog.NewKey, Status: auditLog.Status, CreatedAt: auditLog.CreatedAt},
+			expectID:      0,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_logs (request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(auditLog.RequestID, auditLog.OwnerID, auditLog.ActorID, auditLog.CredentialType, auditLog.Principal, auditLog.ClientIP, auditLog.UserAgent, auditLog.Operation, auditLog.VolumeName, auditLog.Key, auditLog.NewVolumeName, auditLog.NewKey, auditLog.Status, auditLog.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewAuditLogRepository(db)
+			if err := repo.Create(t.Context(), tt.inputAuditLog); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.inputAuditLog != nil && tt.inputAuditLog.ID != tt.expectID {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectID, tt.inputAuditLog.ID)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestAuditLog_FindByOwnerID(t *testing.T) {
+	auditLog := newAuditLog()
+	since := time.Now().Add(-time.Hour)
+	until := time.Now()
+
+	tests := []struct {
+		name           string
+		inputCondition *repository.AuditLogCondition
+		inputCursor    uint64
+		expectResult   []*entity.AuditLog
+		expectError    error
+		setMockDB      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:           "successfully found",
+			inputCondition: nil,
+			inputCursor:    0,
+			expectResult:   []*entity.AuditLog{auditLog},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs WHERE owner_id = ? ORDER BY id DESC LIMIT ?;")).
+					WithArgs(auditLog.OwnerID, uint64(100)).
+					WillReturnRows(sqlmock.NewRows(auditLogColumns).AddRow(auditLog.ID, auditLog.RequestID, auditLog.OwnerID, auditLog.ActorID, auditLog.CredentialType, auditLog.Principal, auditLog.ClientIP, auditLog.UserAgent, auditLog.Operation, auditLog.VolumeName, auditLog.Key, auditLog.NewVolumeName, auditLog.NewKey, auditLog.Status, auditLog.CreatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "with condition and cursor",
+			inputCondition: &repository.AuditLogCondition{ActorID: &auditLog.ActorID, VolumeName: "volume", Operation: "entry.update", Since: &since, Until: &until},
+			inputCursor:    10,
+			expectResult:   []*entity.AuditLog{auditLog},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs WHERE owner_id = ? AND id < ? AND actor_id = ? AND volume_name = ? AND operation = ? AND created_at >= ? AND created_at < ? ORDER BY id DESC LIMIT ?;")).
+					WithArgs(auditLog.OwnerID, uint64(10), auditLog.ActorID, "volume", "entry.update", since, until, uint64(100)).
+					WillReturnRows(sqlmock.NewRows(auditLogColumns).AddRow(auditLog.ID, auditLog.RequestID, auditLog.OwnerID, auditLog.ActorID, auditLog.CredentialType, auditLog.Principal, auditLog.ClientIP, auditLog.UserAgent, auditLog.Operation, auditLog.VolumeName, auditLog.Key, auditLog.NewVolumeName, auditLog.NewKey, auditLog.Status, auditLog.CreatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "find error",
+			inputCondition: nil,
+			inputCursor:    0,
+			expectResult:   nil,
+			expectError:    sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs WHERE owner_id = ? ORDER BY id DESC LIMIT ?;")).
+					WithArgs(auditLog.OwnerID, uint64(100)).
+					WillReturnRows(sqlmock.NewRows(auditLogColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewAuditLogRepository(db)
+			result, err := repo.FindByOwnerID(t.Context(), auditLog.OwnerID, tt.inputCondition, tt.inputCursor, 100)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestAuditLog_FindAll(t *testing.T) {
+	auditLog := newAuditLog()
+	auditLog.OwnerID = uuid.Nil
+
+	tests := []struct {
+		name           string
+		inputCondition *repository.AuditLogCondition
+		inputCursor    uint64
+		expectResult   []*entity.AuditLog
+		expectError    error
+		setMockDB      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:           "successfully found",
+			inputCondition: nil,
+			inputCursor:    0,
+			expectResult:   []*entity.AuditLog{auditLog},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs ORDER BY id DESC LIMIT ?;")).
+					WithArgs(uint64(100)).
+					WillReturnRows(sqlmock.NewRows(auditLogColumns).AddRow(auditLog.ID, auditLog.RequestID, nil, auditLog.ActorID, auditLog.CredentialType, auditLog.Principal, auditLog.ClientIP, auditLog.UserAgent, auditLog.Operation, auditLog.VolumeName, auditLog.Key, auditLog.NewVolumeName, auditLog.NewKey, auditLog.Status, auditLog.CreatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "with condition and cursor",
+			inputCondition: &repository.AuditLogCondition{ClientIP: "127.0.0.1", Principal: "0123456789abcdef"},
+			inputCursor:    10,
+			expectResult:   []*entity.AuditLog{auditLog},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs WHERE id < ? AND client_ip = ? AND principal = ? ORDER BY id DESC LIMIT ?;")).
+					WithArgs(uint64(10), "127.0.0.1", "0123456789abcdef", uint64(100)).
+					WillReturnRows(sqlmock.NewRows(auditLogColumns).AddRow(auditLog.ID, auditLog.RequestID, nil, auditLog.ActorID, auditLog.CredentialType, auditLog.Principal, auditLog.ClientIP, auditLog.UserAgent, auditLog.Operation, auditLog.VolumeName, auditLog.Key, auditLog.NewVolumeName, auditLog.NewKey, auditLog.Status, auditLog.CreatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "find error",
+			inputCondition: nil,
+			inputCursor:    0,
+			expectResult:   nil,
+			expectError:    sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, request_id, owner_id, actor_id, credential_type, principal, client_ip, user_agent, operation, volume_name, `key`, new_volume_name, new_key, status, created_at FROM audit_logs ORDER BY id DESC LIMIT ?;")).
+					WithArgs(uint64(100)).
+					WillReturnRows(sqlmock.NewRows(auditLogColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewAuditLogRepository(db)
+			result, err := repo.FindAll(t.Context(), tt.inputCondition, tt.inputCursor, 100)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredChange = status.Error(code.Internal, "change is required")
+
+type changeRepository struct {
+	db *sqlx.DB
+}
+
+func NewChangeRepository(db *sqlx.DB) repository.ChangeRepository {
+	return &changeRepository{
+		db: db,
+	}
+}
+
+// NOTE: 採番した行をコミットまでロックし, 同じボリュームの変更履歴を採番順にコミットする.
+func (r *changeRepository) Create(ctx context.Context, change *entity.Change) error {
+	if change == nil {
+		return ErrRequiredChange
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	if _, err := driver.ExecContext(ctx, "INSERT INTO change_sequences (volume_id, sequence) VALUES (?, 1) ON DUPLICATE KEY UPDATE sequence = sequence + 1;", change.VolumeID); err != nil {
+		return err
+	}
+	if err := driver.QueryRowxContext(ctx, "SELECT sequence FROM change_sequences WHERE volume_id = ? LIMIT 1;", change.VolumeID).Scan(&change.Sequence); err != nil {
+		return err
+	}
+
+	model := transformer.ToChangeModel(change)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO changes (volume_id, sequence, event_id, account_id, actor_id, type, `key`, new_key, size, created_at) VALUES (:volume_id, :sequence, :event_id, :account_id, :actor_id, :type, :key, :new_key, :size, :created_at);", model)
+	return err
+}
+
+func (r *changeRepository) FindLatestSequenceByVolumeID(ctx context.Context, volumeID uuid.UUID) (uint64, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var sequence uint64
+	if err := driver.QueryRowxContext(ctx, "SELECT sequence FROM change_sequences WHERE volume_id = ? LIMIT 1;", volumeID).Scan(&sequence); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return sequence, nil
+}
+
+func (r *changeRepository) FindByVolumeIDAndSequenceGreaterThan(ctx context.Context, volumeID uuid.UUID, sequence, limit uint64) (changes []*entity.Change, err error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	rows, err := driver.QueryxContext(ctx, "SELECT volume_id, sequence, event_id, account_id, actor_id, type, `key`, new_key, size, created_at FROM changes WHERE volume_id = ? AND sequence > ? ORDER BY sequence LIMIT ?;", volumeID, sequence, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.ChangeModel
+	for rows.Next() {
+		var model model.ChangeModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return transformer.ToChangeEntities(models), nil
+}
@@ -0,0 +1,228 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var changeColumns = []string{"volume_id", "sequence", "event_id", "account_id", "actor_id", "type", "key", "new_key", "size", "created_at"}
+
+func newChange() *entity.Change {
+	return &entity.Change{
+		VolumeID:  uuid.New(),
+		Sequence:  1,
+		EventID:   uuid.New(),
+		AccountID: uuid.New(),
+		ActorID:   uuid.New(),
+		Type:      entity.EventTypeEntryCreated,
+		Key:       "key",
+		Size:      10,
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestChange_Create(t *testing.T) {
+	change := newChange()
+
+	tests := []struct {
+		name           string
+		inputChange    *entity.Change
+		expectSequence uint64
+		expectError    error
+		setMockDB      func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:           "successfully inserted",
+			inputChange:    &entity.Change{VolumeID: change.VolumeID, EventID: change.EventID, AccountID: change.AccountID, ActorID: change.ActorID, Type: change.Type, Key: change.Key, Size: change.Size, CreatedAt: change.CreatedAt},
+			expectSequence: 3,
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO change_sequences (volume_id, sequence) VALUES (?, 1) ON DUPLICATE KEY UPDATE sequence = sequence + 1;")).
+					WithArgs(change.VolumeID).
+					WillReturnResult(sqlmock.NewResult(1, 2)).
+					WillReturnError(nil)
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT sequence FROM change_sequences WHERE volume_id = ? LIMIT 1;")).
+					WithArgs(change.VolumeID).
+					WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(3)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO changes (volume_id, sequence, event_id, account_id, actor_id, type, `key`, new_key, size, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(change.VolumeID, uint64(3), change.EventID, change.AccountID, uuid.NullUUID{UUID: change.ActorID, Valid: true}, change.Type, change.Key, change.NewKey, change.Size, change.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "change is nil",
+			inputChange: nil,
+			expectError: database.ErrRequiredChange,
+			setMockDB:   func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:           "sequence error",
+			inputChange:    &entity.Change{VolumeID: change.VolumeID},
+			expectSequence: 0,
+			expectError:    sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO change_sequences (volume_id, sequence) VALUES (?, 1) ON DUPLICATE KEY UPDATE sequence = sequence + 1;")).
+					WithArgs(change.VolumeID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewChangeRepository(db)
+			if err := repo.Create(t.Context(), tt.inputChange); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.inputChange != nil && tt.inputChange.Sequence != tt.expectSequence {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectSequence, tt.inputChange.Sequence)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestChange_FindLatestSequenceByVolumeID(t *testing.T) {
+	volumeID := uuid.New()
+
+	tests := []struct {
+		name         string
+		expectResult uint64
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: 5,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT sequence FROM change_sequences WHERE volume_id = ? LIMIT 1;")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(5)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "no changes",
+			expectResult: 0,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT sequence FROM change_sequences WHERE volume_id = ? LIMIT 1;")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows([]string{"sequence"})).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: 0,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT sequence FROM change_sequences WHERE volume_id = ? LIMIT 1;")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows([]string{"sequence"})).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewChangeRepository(db)
+			result, err := repo.FindLatestSequenceByVolumeID(t.Context(), volumeID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectResult, result)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestChange_FindByVolumeIDAndSequenceGreaterThan(t *testing.T) {
+	change := newChange()
+
+	tests := []struct {
+		name         string
+		expectResult []*entity.Change
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: []*entity.Change{change},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT volume_id, sequence, event_id, account_id, actor_id, type, `key`, new_key, size, created_at FROM changes WHERE volume_id = ? AND sequence > ? ORDER BY sequence LIMIT ?;")).
+					WithArgs(change.VolumeID, uint64(0), uint64(100)).
+					WillReturnRows(sqlmock.NewRows(changeColumns).AddRow(change.VolumeID, change.Sequence, change.EventID, change.AccountID, change.ActorID, change.Type, change.Key, change.NewKey, change.Size, change.CreatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT volume_id, sequence, event_id, account_id, actor_id, type, `key`, new_key, size, created_at FROM changes WHERE volume_id = ? AND sequence > ? ORDER BY sequence LIMIT ?;")).
+					WithArgs(change.VolumeID, uint64(0), uint64(100)).
+					WillReturnRows(sqlmock.NewRows(changeColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewChangeRepository(db)
+			result, err := repo.FindByVolumeIDAndSequenceGreaterThan(t.Context(), change.VolumeID, 0, 100)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
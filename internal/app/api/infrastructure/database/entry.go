@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -13,12 +15,25 @@ import (
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/progress"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
 )
 
 var ErrRequiredEntry = status.Error(code.Internal, "entry is required")
 
+// NOTE: 1文で更新する行数を制限しロックの範囲を抑える.
+const entryBatchSize = 1000
+
+// NOTE: キーは保持しないため, 前方一致の代わりに親エントリーを辿って子孫を特定する.
+const entryDescendantsQuery = "WITH RECURSIVE paths (id, depth) AS (SELECT id, 1 FROM entries WHERE parent_id = ? UNION ALL SELECT e.id, p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id) "
+
+// NOTE: LIKEの既定のエスケープ文字で特殊文字をエスケープする.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// NOTE: キーは保持せず親エントリーを辿って導出する.
+const entryColumns = "e.id, e.account_id, e.volume_id, e.parent_id, e.name, p.`key`, e.size, e.type, e.encoding, e.encryption_key_id, e.scan_status, e.scan_signature, e.scanned_at, e.created_at, e.updated_at"
+
 type entryRepository struct {
 	db *sqlx.DB
 }
@@ -36,7 +51,7 @@ func (r *entryRepository) Create(ctx context.Context, entry *entity.Entry) error
 
 	driver := transaction.GetDriver(ctx, r.db)
 	model := transformer.ToEntryModel(entry)
-	_, err := driver.NamedExecContext(ctx, "INSERT INTO entries (id, account_id, volume_id, `key`, size, type, created_at, updated_at) VALUES (:id, :account_id, :volume_id, :key, :size, :type, :created_at, :updated_at);", model)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO entries (id, account_id, volume_id, parent_id, name, size, type, encoding, encryption_key_id, scan_status, scan_signature, scanned_at, created_at, updated_at) VALUES (:id, :account_id, :volume_id, :parent_id, :name, :size, :type, :encoding, :encryption_key_id, :scan_status, :scan_signature, :scanned_at, :created_at, :updated_at);", model)
 	return err
 }
 
@@ -47,7 +62,7 @@ func (r *entryRepository) Update(ctx context.Context, entry *entity.Entry) error
 
 	driver := transaction.GetDriver(ctx, r.db)
 	model := transformer.ToEntryModel(entry)
-	_, err := driver.NamedExecContext(ctx, "UPDATE entries SET account_id = :account_id, volume_id = :volume_id, `key` = :key, size = :size, type = :type, updated_at = :updated_at WHERE id = :id LIMIT 1;", model)
+	_, err := driver.NamedExecContext(ctx, "UPDATE entries SET account_id = :account_id, volume_id = :volume_id, parent_id = :parent_id, name = :name, size = :size, type = :type, encoding = :encoding, encryption_key_id = :encryption_key_id, scan_status = :scan_status, scan_signature = :scan_signature, scanned_at = :scanned_at, updated_at = :updated_at WHERE id = :id LIMIT 1;", model)
 	return err
 }
 
@@ -62,50 +77,110 @@ func (r *entryRepository) Delete(ctx context.Context, entry *entity.Entry) error
 	return err
 }
 
-func (r *entryRepository) FindOneByKeyAndVolumeID(ctx context.Context, key string, volumeID uuid.UUID) (*entity.Entry, error) {
+func (r *entryRepository) DeleteByPrefix(ctx context.Context, prefix string, volumeID uuid.UUID) error {
+	parent, err := r.FindOneByKeyAndVolumeID(ctx, prefix, volumeID)
+	if err != nil {
+		if errors.Is(err, repository.ErrEntryNotFound) {
+			return nil
+		}
+		return err
+	}
+
 	driver := transaction.GetDriver(ctx, r.db)
-	var model model.EntryModel
-	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? LIMIT 1;", key, volumeID).StructScan(&model); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, repository.ErrEntryNotFound
+	var depth uint64
+	if err := driver.QueryRowxContext(ctx, entryDescendantsQuery+"SELECT COALESCE(MAX(depth), 0) FROM paths;", parent.ID).Scan(&depth); err != nil {
+		return err
+	}
+
+	// NOTE: 親エントリーへの参照が残らないよう深い階層から削除する.
+	for ; depth > 0; depth-- {
+		if err := r.execBatches(ctx, "DELETE FROM entries WHERE id IN (SELECT id FROM ("+entryDescendantsQuery+"SELECT id FROM paths WHERE depth = ?) AS d) LIMIT ?;", parent.ID, depth); err != nil {
+			return err
 		}
-		return nil, err
 	}
-	return transformer.ToEntryEntity(&model), nil
+
+	return nil
 }
 
-func (r *entryRepository) FindOneByKeyAndVolumeIDAndAccountID(ctx context.Context, key string, volumeID, accountID uuid.UUID) (*entity.Entry, error) {
-	driver := transaction.GetDriver(ctx, r.db)
-	var model model.EntryModel
-	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? AND account_id = ? LIMIT 1;", key, volumeID, accountID).StructScan(&model); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, repository.ErrEntryNotFound
+func (r *entryRepository) CopyByPrefix(ctx context.Context, src string, srcVolumeID uuid.UUID, dst string, dstVolumeID uuid.UUID) error {
+	srcEntry, err := r.FindOneByKeyAndVolumeID(ctx, src, srcVolumeID)
+	if err != nil {
+		return err
+	}
+	dstEntry, err := r.FindOneByKeyAndVolumeID(ctx, dst, dstVolumeID)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: 複製先のIDは複製元のIDから導出し, 対応表を保持せずに親子関係を引き継ぐ.
+	salt, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for offset := 0; ; offset += entryBatchSize {
+		n, err := r.copyBatch(ctx, srcEntry.ID, dstEntry.ID, dstVolumeID, salt, offset, now)
+		if err != nil {
+			return err
+		}
+		if n < entryBatchSize {
+			return nil
 		}
-		return nil, err
 	}
-	return transformer.ToEntryEntity(&model), nil
 }
 
-func (r *entryRepository) FindByVolumeIDAndAccountID(ctx context.Context, volumeID, accountID uuid.UUID, prefix *string, depth *uint64) (entries []*entity.Entry, err error) {
+// NOTE: 親子関係は変わらないため子孫のボリュームIDのみ更新する.
+func (r *entryRepository) MoveByPrefix(ctx context.Context, prefix string, volumeID, newVolumeID uuid.UUID) error {
+	parent, err := r.FindOneByKeyAndVolumeID(ctx, prefix, volumeID)
+	if err != nil {
+		return err
+	}
+
+	return r.execBatches(ctx, "UPDATE entries SET volume_id = ? WHERE volume_id <> ? AND id IN (SELECT id FROM ("+entryDescendantsQuery+"SELECT id FROM paths) AS d) LIMIT ?;", newVolumeID, newVolumeID, parent.ID)
+}
+
+func (r *entryRepository) FindOneByKeyAndVolumeID(ctx context.Context, key string, volumeID uuid.UUID) (*entity.Entry, error) {
+	return r.findOneByKey(ctx, key, volumeID, "", nil)
+}
+
+func (r *entryRepository) FindOneByKeyAndVolumeIDAndAccountID(ctx context.Context, key string, volumeID, accountID uuid.UUID) (*entity.Entry, error) {
+	return r.findOneByKey(ctx, key, volumeID, " AND e.account_id = ?", []any{accountID})
+}
+
+func (r *entryRepository) FindByVolumeIDAndAccountID(ctx context.Context, volumeID, accountID uuid.UUID, prefix *string, depth *uint64, condition *repository.EntryCondition) (entries []*entity.Entry, err error) {
 	driver := transaction.GetDriver(ctx, r.db)
 
-	filterQuery := " volume_id = ? AND account_id = ?"
-	filterArguments := []any{volumeID, accountID}
+	anchorQuery := "SELECT id, CAST(CONCAT(?, name) AS CHAR(512)), 1 FROM entries WHERE volume_id = ? AND account_id = ? AND COALESCE(parent_id, '') = ''"
+	anchorArguments := []any{"", volumeID, accountID}
 
 	if prefix != nil {
-		filterQuery += " AND `key` LIKE ?"
-		filterArguments = append(filterArguments, *prefix+"/%")
+		parent, err := r.FindOneByKeyAndVolumeIDAndAccountID(ctx, *prefix, volumeID, accountID)
+		if err != nil {
+			if errors.Is(err, repository.ErrEntryNotFound) {
+				return []*entity.Entry{}, nil
+			}
+			return nil, err
+		}
+		anchorQuery = "SELECT id, CAST(CONCAT(?, name) AS CHAR(512)), 1 FROM entries WHERE volume_id = ? AND account_id = ? AND parent_id = ?"
+		anchorArguments = []any{parent.Key + "/", volumeID, accountID, parent.ID}
 	}
+
+	recursiveQuery := "SELECT e.id, CONCAT(p.`key`, '/', e.name), p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id"
+	var recursiveArguments []any
+
 	if depth != nil {
-		filterQuery += " AND LENGTH(`key`) - LENGTH(REPLACE(`key`, '/', '')) <= LENGTH(?) - LENGTH(REPLACE(?, '/', '')) + ?"
-		if prefix == nil {
-			filterArguments = append(filterArguments, "", "", *depth-1)
-		} else {
-			filterArguments = append(filterArguments, *prefix, *prefix, *depth)
+		if *depth == 0 {
+			return []*entity.Entry{}, nil
 		}
+		recursiveQuery += " WHERE p.depth < ?"
+		recursiveArguments = append(recursiveArguments, *depth)
 	}
 
-	rows, err := driver.QueryxContext(ctx, "SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE"+filterQuery+";", filterArguments...)
+	conditionQuery, conditionArguments := buildEntryCondition(condition)
+	arguments := append(append(anchorArguments, recursiveArguments...), conditionArguments...)
+
+	rows, err := driver.QueryxContext(ctx, "WITH RECURSIVE paths (id, `key`, depth) AS ("+anchorQuery+" UNION ALL "+recursiveQuery+") SELECT "+entryColumns+" FROM paths AS p INNER JOIN entries AS e ON e.id = p.id"+conditionQuery+";", arguments...)
 	if err != nil {
 		return nil, err
 	}
@@ -124,3 +199,127 @@ func (r *entryRepository) FindByVolumeIDAndAccountID(ctx context.Context, volume
 
 	return transformer.ToEntryEntities(models), nil
 }
+
+// NOTE: 親IDがuuid.Nilの場合はボリューム直下のエントリーを数える.
+func (r *entryRepository) CountByParentIDAndVolumeID(ctx context.Context, parentID, volumeID uuid.UUID) (uint64, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+
+	query := "SELECT COUNT(*) FROM entries WHERE volume_id = ? AND parent_id = ?;"
+	arguments := []any{volumeID, parentID}
+	if parentID == uuid.Nil {
+		query = "SELECT COUNT(*) FROM entries WHERE volume_id = ? AND COALESCE(parent_id, '') = '';"
+		arguments = arguments[:1]
+	}
+
+	var count uint64
+	if err := driver.QueryRowxContext(ctx, query, arguments...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *entryRepository) SumByVolumeID(ctx context.Context, volumeID uuid.UUID) (*entity.VolumeUsage, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+
+	var size, count uint64
+	if err := driver.QueryRowxContext(ctx, "SELECT COALESCE(SUM(size), 0), COUNT(*) FROM entries WHERE volume_id = ?;", volumeID).Scan(&size, &count); err != nil {
+		return nil, err
+	}
+	return entity.RestoreVolumeUsage(size, count), nil
+}
+
+// NOTE: キーを先頭から1階層ずつ辿りエントリーを特定する.
+func (r *entryRepository) findOneByKey(ctx context.Context, key string, volumeID uuid.UUID, filterQuery string, filterArguments []any) (*entity.Entry, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	depth := strings.Count(key, "/") + 1
+	arguments := append([]any{volumeID, key, depth, key, depth}, filterArguments...)
+
+	var model model.EntryModel
+	if err := driver.QueryRowxContext(ctx, "WITH RECURSIVE paths (id, `key`, depth) AS (SELECT id, CAST(name AS CHAR(512)), 1 FROM entries WHERE volume_id = ? AND COALESCE(parent_id, '') = '' AND name = SUBSTRING_INDEX(?, '/', 1) UNION ALL SELECT e.id, CONCAT(p.`key`, '/', e.name), p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id WHERE p.depth < ? AND e.name = SUBSTRING_INDEX(SUBSTRING_INDEX(?, '/', p.depth + 1), '/', -1)) SELECT "+entryColumns+" FROM paths AS p INNER JOIN entries AS e ON e.id = p.id WHERE p.depth = ?"+filterQuery+" LIMIT 1;", arguments...).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToEntryEntity(&model), nil
+}
+
+// NOTE: 処理済みの行は対象から外れるため, 更新件数が上限に満たなくなるまで繰り返す.
+func (r *entryRepository) execBatches(ctx context.Context, query string, arguments ...any) error {
+	driver := transaction.GetDriver(ctx, r.db)
+	for {
+		result, err := driver.ExecContext(ctx, query, append(arguments, entryBatchSize)...)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		progress.Add(ctx, uint64(n), 0)
+		if n < entryBatchSize {
+			return nil
+		}
+	}
+}
+
+// NOTE: 親エントリーを先に作成するため浅い階層から順に複製する.
+func (r *entryRepository) copyBatch(ctx context.Context, srcID, dstID, volumeID, salt uuid.UUID, offset int, now time.Time) (int64, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	batch := "(" + entryDescendantsQuery + "SELECT id, depth FROM paths ORDER BY depth, id LIMIT ? OFFSET ?) AS b"
+
+	result, err := driver.ExecContext(ctx, "INSERT INTO entries (id, account_id, volume_id, parent_id, name, size, type, encoding, encryption_key_id, scan_status, scan_signature, scanned_at, created_at, updated_at) SELECT "+copiedID("e.id")+", e.account_id, ?, IF(e.parent_id = ?, ?, "+copiedID("e.parent_id")+"), e.name, e.size, e.type, e.encoding, e.encryption_key_id, e.scan_status, e.scan_signature, e.scanned_at, ?, ? FROM "+batch+" INNER JOIN entries AS e ON e.id = b.id ORDER BY b.depth, b.id;", salt, volumeID, srcID, dstID, salt, now, now, srcID, entryBatchSize, offset)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	// NOTE: 子孫のメタデータと本文も同じIDで複製する.
+	if _, err := driver.ExecContext(ctx, "INSERT INTO entry_metadata (entry_id, "+entryMetadataColumns+") SELECT "+copiedID("d.entry_id")+", "+entryMetadataColumns+" FROM "+batch+" INNER JOIN entry_metadata AS d ON d.entry_id = b.id;", salt, srcID, entryBatchSize, offset); err != nil {
+		return 0, err
+	}
+	if _, err := driver.ExecContext(ctx, "INSERT INTO entry_contents (entry_id, content) SELECT "+copiedID("c.entry_id")+", c.content FROM "+batch+" INNER JOIN entry_contents AS c ON c.entry_id = b.id;", salt, srcID, entryBatchSize, offset); err != nil {
+		return 0, err
+	}
+
+	progress.Add(ctx, uint64(n), 0)
+	return n, nil
+}
+
+// NOTE: 条件を設定した場合はメタデータと内部結合し, メタデータを持たないエントリーを除外する.
+func buildEntryCondition(condition *repository.EntryCondition) (string, []any) {
+	if condition == nil {
+		return "", nil
+	}
+
+	var clauses []string
+	var args []any
+	if condition.TakenFrom != nil {
+		clauses = append(clauses, "m.taken_at >= ?")
+		args = append(args, *condition.TakenFrom)
+	}
+	if condition.TakenTo != nil {
+		clauses = append(clauses, "m.taken_at <= ?")
+		args = append(args, *condition.TakenTo)
+	}
+	if condition.Camera != "" {
+		clauses = append(clauses, "LOWER(CONCAT(m.camera_make, ' ', m.camera_model)) LIKE ?")
+		args = append(args, "%"+likeEscaper.Replace(strings.ToLower(condition.Camera))+"%")
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " INNER JOIN entry_metadata AS m ON m.entry_id = e.id WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// NOTE: ソルトとIDのハッシュをUUIDの形式に整える.
+func copiedID(column string) string {
+	return "INSERT(INSERT(INSERT(INSERT(MD5(CONCAT(?, " + column + ")), 21, 0, '-'), 17, 0, '-'), 13, 0, '-'), 9, 0, '-')"
+}
+
+func placeholders(placeholder string, n int) string {
+	return strings.TrimSuffix(strings.Repeat(placeholder+", ", n), ", ")
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredEntryContent = status.Error(code.Internal, "entry content is required")
+
+// NOTE: 最初の検索語の前後を抜粋として返却する.
+const (
+	entryContentSnippetOffset = 60
+	entryContentSnippetLength = 200
+)
+
+type entryContentRepository struct {
+	db *sqlx.DB
+}
+
+func NewEntryContentRepository(db *sqlx.DB) repository.EntryContentRepository {
+	return &entryContentRepository{
+		db: db,
+	}
+}
+
+func (r *entryContentRepository) Create(ctx context.Context, content *entity.EntryContent) error {
+	if content == nil {
+		return ErrRequiredEntryContent
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToEntryContentModel(content)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO entry_contents (entry_id, content) VALUES (:entry_id, :content);", model)
+	return err
+}
+
+func (r *entryContentRepository) Copy(ctx context.Context, entryID, newEntryID uuid.UUID) error {
+	driver := transaction.GetDriver(ctx, r.db)
+	_, err := driver.ExecContext(ctx, "INSERT INTO entry_contents (entry_id, content) SELECT ?, content FROM entry_contents WHERE entry_id = ?;", newEntryID, entryID)
+	return err
+}
+
+func (r *entryContentRepository) Search(ctx context.Context, volumeID, accountID uuid.UUID, terms []string, limit uint64) (_ []*entity.EntryContentMatch, err error) {
+	if len(terms) == 0 {
+		return []*entity.EntryContentMatch{}, nil
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	expression := booleanExpression(terms)
+
+	rows, err := driver.QueryxContext(ctx, "SELECT c.entry_id, SUBSTRING(c.content, GREATEST(LOCATE(?, c.content) - ?, 1), ?) AS snippet FROM entry_contents AS c INNER JOIN entries AS e ON e.id = c.entry_id WHERE e.volume_id = ? AND e.account_id = ? AND MATCH(c.content) AGAINST(? IN BOOLEAN MODE) ORDER BY MATCH(c.content) AGAINST(? IN BOOLEAN MODE) DESC LIMIT ?;", terms[0], entryContentSnippetOffset, entryContentSnippetLength, volumeID, accountID, expression, expression, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var matches []*model.EntryContentMatchModel
+	for rows.Next() {
+		var match model.EntryContentMatchModel
+		if err := rows.StructScan(&match); err != nil {
+			return nil, err
+		}
+		matches = append(matches, &match)
+	}
+	return transformer.ToEntryContentMatchEntities(matches), nil
+}
+
+// NOTE: 全ての検索語を含むよう, 各検索語を必須のフレーズとする.
+func booleanExpression(terms []string) string {
+	phrases := make([]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = `+"` + term + `"`
+	}
+	return strings.Join(phrases, " ")
+}
@@ -0,0 +1,189 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+func TestEntryContent_Create(t *testing.T) {
+	content := &entity.EntryContent{EntryID: uuid.New(), Content: "content"}
+
+	tests := []struct {
+		name              string
+		inputEntryContent *entity.EntryContent
+		expectError       error
+		setMockDB         func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:              "successfully inserted",
+			inputEntryContent: content,
+			expectError:       nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_contents (entry_id, content) VALUES (?, ?);")).
+					WithArgs(content.EntryID, content.Content).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:              "entry content is nil",
+			inputEntryContent: nil,
+			expectError:       database.ErrRequiredEntryContent,
+			setMockDB:         func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:              "insert error",
+			inputEntryContent: content,
+			expectError:       sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_contents (entry_id, content) VALUES (?, ?);")).
+					WithArgs(content.EntryID, content.Content).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryContentRepository(db)
+			if err := repo.Create(t.Context(), tt.inputEntryContent); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntryContent_Copy(t *testing.T) {
+	entryID := uuid.New()
+	newEntryID := uuid.New()
+
+	tests := []struct {
+		name        string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully copied",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_contents (entry_id, content) SELECT ?, content FROM entry_contents WHERE entry_id = ?;")).
+					WithArgs(newEntryID, entryID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "insert error",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_contents (entry_id, content) SELECT ?, content FROM entry_contents WHERE entry_id = ?;")).
+					WithArgs(newEntryID, entryID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryContentRepository(db)
+			if err := repo.Copy(t.Context(), entryID, newEntryID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntryContent_Search(t *testing.T) {
+	volumeID := uuid.New()
+	accountID := uuid.New()
+	match := &entity.EntryContentMatch{EntryID: uuid.New(), Snippet: "holos storage"}
+
+	searchQuery := "SELECT c.entry_id, SUBSTRING(c.content, GREATEST(LOCATE(?, c.content) - ?, 1), ?) AS snippet FROM entry_contents AS c INNER JOIN entries AS e ON e.id = c.entry_id WHERE e.volume_id = ? AND e.account_id = ? AND MATCH(c.content) AGAINST(? IN BOOLEAN MODE) ORDER BY MATCH(c.content) AGAINST(? IN BOOLEAN MODE) DESC LIMIT ?;"
+
+	tests := []struct {
+		name         string
+		inputTerms   []string
+		expectResult []*entity.EntryContentMatch
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			inputTerms:   []string{"holos", "storage"},
+			expectResult: []*entity.EntryContentMatch{match},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(searchQuery)).
+					WithArgs("holos", 60, 200, volumeID, accountID, `+"holos" +"storage"`, `+"holos" +"storage"`, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"entry_id", "snippet"}).AddRow(match.EntryID, match.Snippet)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "no terms",
+			inputTerms:   nil,
+			expectResult: []*entity.EntryContentMatch{},
+			expectError:  nil,
+			setMockDB:    func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:         "find error",
+			inputTerms:   []string{"holos"},
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(searchQuery)).
+					WithArgs("holos", 60, 200, volumeID, accountID, `+"holos"`, `+"holos"`, 10).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryContentRepository(db)
+			result, err := repo.Search(t.Context(), volumeID, accountID, tt.inputTerms, 10)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
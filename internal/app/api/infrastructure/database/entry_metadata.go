@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredEntryMetadata = status.Error(code.Internal, "entry metadata is required")
+
+const entryMetadataColumns = "width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album"
+
+type entryMetadataRepository struct {
+	db *sqlx.DB
+}
+
+func NewEntryMetadataRepository(db *sqlx.DB) repository.EntryMetadataRepository {
+	return &entryMetadataRepository{
+		db: db,
+	}
+}
+
+func (r *entryMetadataRepository) Create(ctx context.Context, metadata *entity.EntryMetadata) error {
+	if metadata == nil {
+		return ErrRequiredEntryMetadata
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToEntryMetadataModel(metadata)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO entry_metadata (entry_id, "+entryMetadataColumns+") VALUES (:entry_id, :width, :height, :taken_at, :camera_make, :camera_model, :latitude, :longitude, :page_count, :duration, :title, :artist, :album);", model)
+	return err
+}
+
+func (r *entryMetadataRepository) Copy(ctx context.Context, entryID, newEntryID uuid.UUID) error {
+	driver := transaction.GetDriver(ctx, r.db)
+	_, err := driver.ExecContext(ctx, "INSERT INTO entry_metadata (entry_id, "+entryMetadataColumns+") SELECT ?, "+entryMetadataColumns+" FROM entry_metadata WHERE entry_id = ?;", newEntryID, entryID)
+	return err
+}
+
+func (r *entryMetadataRepository) FindByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]*entity.EntryMetadata, error) {
+	var models []*model.EntryMetadataModel
+	for batch := range slices.Chunk(entryIDs, entryBatchSize) {
+		batchModels, err := r.findBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		models = append(models, batchModels...)
+	}
+	return transformer.ToEntryMetadataEntities(models), nil
+}
+
+func (r *entryMetadataRepository) findBatch(ctx context.Context, entryIDs []uuid.UUID) (models []*model.EntryMetadataModel, err error) {
+	driver := transaction.GetDriver(ctx, r.db)
+
+	arguments := make([]any, len(entryIDs))
+	for i, entryID := range entryIDs {
+		arguments[i] = entryID
+	}
+
+	rows, err := driver.QueryxContext(ctx, "SELECT entry_id, "+entryMetadataColumns+" FROM entry_metadata WHERE entry_id IN ("+placeholders("?", len(entryIDs))+");", arguments...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	for rows.Next() {
+		var model model.EntryMetadataModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return models, nil
+}
@@ -0,0 +1,194 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var entryMetadataColumns = []string{"entry_id", "width", "height", "taken_at", "camera_make", "camera_model", "latitude", "longitude", "page_count", "duration", "title", "artist", "album"}
+
+func TestEntryMetadata_Create(t *testing.T) {
+	takenAt := time.Now().UTC()
+	latitude, longitude := 35.5, 139.75
+	metadata := &entity.EntryMetadata{EntryID: uuid.New(), Width: 40, Height: 20, TakenAt: &takenAt, CameraMake: "Holos", CameraModel: "Camera 1", Latitude: &latitude, Longitude: &longitude}
+
+	tests := []struct {
+		name               string
+		inputEntryMetadata *entity.EntryMetadata
+		expectError        error
+		setMockDB          func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:               "successfully inserted",
+			inputEntryMetadata: metadata,
+			expectError:        nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_metadata (entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(metadata.EntryID, metadata.Width, metadata.Height, sql.NullTime{Time: takenAt, Valid: true}, metadata.CameraMake, metadata.CameraModel, sql.NullFloat64{Float64: latitude, Valid: true}, sql.NullFloat64{Float64: longitude, Valid: true}, metadata.PageCount, metadata.Duration, metadata.Title, metadata.Artist, metadata.Album).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:               "entry metadata is nil",
+			inputEntryMetadata: nil,
+			expectError:        database.ErrRequiredEntryMetadata,
+			setMockDB:          func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:               "insert error",
+			inputEntryMetadata: metadata,
+			expectError:        sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_metadata (entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(metadata.EntryID, metadata.Width, metadata.Height, sql.NullTime{Time: takenAt, Valid: true}, metadata.CameraMake, metadata.CameraModel, sql.NullFloat64{Float64: latitude, Valid: true}, sql.NullFloat64{Float64: longitude, Valid: true}, metadata.PageCount, metadata.Duration, metadata.Title, metadata.Artist, metadata.Album).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryMetadataRepository(db)
+			if err := repo.Create(t.Context(), tt.inputEntryMetadata); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntryMetadata_Copy(t *testing.T) {
+	entryID := uuid.New()
+	newEntryID := uuid.New()
+
+	tests := []struct {
+		name        string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully copied",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_metadata (entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album) SELECT ?, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album FROM entry_metadata WHERE entry_id = ?;")).
+					WithArgs(newEntryID, entryID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "insert error",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entry_metadata (entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album) SELECT ?, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album FROM entry_metadata WHERE entry_id = ?;")).
+					WithArgs(newEntryID, entryID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryMetadataRepository(db)
+			if err := repo.Copy(t.Context(), entryID, newEntryID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntryMetadata_FindByEntryIDs(t *testing.T) {
+	takenAt := time.Now().UTC()
+	photo := &entity.EntryMetadata{EntryID: uuid.New(), Width: 40, Height: 20, TakenAt: &takenAt, CameraMake: "Holos"}
+	document := &entity.EntryMetadata{EntryID: uuid.New(), PageCount: 12}
+
+	tests := []struct {
+		name          string
+		inputEntryIDs []uuid.UUID
+		expectResult  []*entity.EntryMetadata
+		expectError   error
+		setMockDB     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "successfully found",
+			inputEntryIDs: []uuid.UUID{photo.EntryID, document.EntryID},
+			expectResult:  []*entity.EntryMetadata{photo, document},
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album FROM entry_metadata WHERE entry_id IN (?, ?);")).
+					WithArgs(photo.EntryID, document.EntryID).
+					WillReturnRows(sqlmock.NewRows(entryMetadataColumns).
+						AddRow(photo.EntryID, photo.Width, photo.Height, takenAt, photo.CameraMake, "", nil, nil, 0, 0, "", "", "").
+						AddRow(document.EntryID, 0, 0, nil, "", "", nil, nil, document.PageCount, 0, "", "", "")).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "no entry ids",
+			inputEntryIDs: nil,
+			expectResult:  []*entity.EntryMetadata{},
+			expectError:   nil,
+			setMockDB:     func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:          "find error",
+			inputEntryIDs: []uuid.UUID{photo.EntryID},
+			expectResult:  nil,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album FROM entry_metadata WHERE entry_id IN (?);")).
+					WithArgs(photo.EntryID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryMetadataRepository(db)
+			result, err := repo.FindByEntryIDs(t.Context(), tt.inputEntryIDs)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
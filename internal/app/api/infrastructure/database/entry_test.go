@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,11 +19,16 @@ import (
 	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
 )
 
+var entryColumns = []string{"id", "account_id", "volume_id", "parent_id", "name", "key", "size", "type", "encoding", "encryption_key_id", "scan_status", "scan_signature", "scanned_at", "created_at", "updated_at"}
+
+const findOneQuery = "WITH RECURSIVE paths (id, `key`, depth) AS (SELECT id, CAST(name AS CHAR(512)), 1 FROM entries WHERE volume_id = ? AND COALESCE(parent_id, '') = '' AND name = SUBSTRING_INDEX(?, '/', 1) UNION ALL SELECT e.id, CONCAT(p.`key`, '/', e.name), p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id WHERE p.depth < ? AND e.name = SUBSTRING_INDEX(SUBSTRING_INDEX(?, '/', p.depth + 1), '/', -1)) SELECT e.id, e.account_id, e.volume_id, e.parent_id, e.name, p.`key`, e.size, e.type, e.encoding, e.encryption_key_id, e.scan_status, e.scan_signature, e.scanned_at, e.created_at, e.updated_at FROM paths AS p INNER JOIN entries AS e ON e.id = p.id WHERE p.depth = ?"
+
 func TestEntry_Create(t *testing.T) {
 	entry := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: uuid.New(),
 		VolumeID:  uuid.New(),
+		ParentID:  uuid.New(),
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
@@ -41,8 +47,8 @@ func TestEntry_Create(t *testing.T) {
 			inputEntry:  entry,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entries (id, account_id, volume_id, `key`, size, type, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?);")).
-					WithArgs(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entries (id, account_id, volume_id, parent_id, name, size, type, encoding, encryption_key_id, scan_status, scan_signature, scanned_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
 			},
@@ -58,8 +64,8 @@ func TestEntry_Create(t *testing.T) {
 			inputEntry:  entry,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entries (id, account_id, volume_id, `key`, size, type, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?);")).
-					WithArgs(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt).
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO entries (id, account_id, volume_id, parent_id, name, size, type, encoding, encryption_key_id, scan_status, scan_signature, scanned_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -89,6 +95,7 @@ func TestEntry_Update(t *testing.T) {
 		ID:        uuid.New(),
 		AccountID: uuid.New(),
 		VolumeID:  uuid.New(),
+		ParentID:  uuid.New(),
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
@@ -107,8 +114,8 @@ func TestEntry_Update(t *testing.T) {
 			inputEntry:  entry,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE entries SET account_id = ?, volume_id = ?, `key` = ?, size = ?, type = ?, updated_at = ? WHERE id = ? LIMIT 1;")).
-					WithArgs(entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.UpdatedAt, entry.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE entries SET account_id = ?, volume_id = ?, parent_id = ?, name = ?, size = ?, type = ?, encoding = ?, encryption_key_id = ?, scan_status = ?, scan_signature = ?, scanned_at = ?, updated_at = ? WHERE id = ? LIMIT 1;")).
+					WithArgs(entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.UpdatedAt, entry.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
 			},
@@ -124,8 +131,8 @@ func TestEntry_Update(t *testing.T) {
 			inputEntry:  entry,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta("UPDATE entries SET account_id = ?, volume_id = ?, `key` = ?, size = ?, type = ?, updated_at = ? WHERE id = ? LIMIT 1;")).
-					WithArgs(entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.UpdatedAt, entry.ID).
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE entries SET account_id = ?, volume_id = ?, parent_id = ?, name = ?, size = ?, type = ?, encoding = ?, encryption_key_id = ?, scan_status = ?, scan_signature = ?, scanned_at = ?, updated_at = ? WHERE id = ? LIMIT 1;")).
+					WithArgs(entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.UpdatedAt, entry.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -155,6 +162,7 @@ func TestEntry_Delete(t *testing.T) {
 		ID:        uuid.New(),
 		AccountID: uuid.New(),
 		VolumeID:  uuid.New(),
+		ParentID:  uuid.New(),
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
@@ -216,6 +224,327 @@ func TestEntry_Delete(t *testing.T) {
 	}
 }
 
+func TestEntry_DeleteByPrefix(t *testing.T) {
+	volumeID := uuid.New()
+	parent := &entity.Entry{ID: uuid.New(), AccountID: uuid.New(), VolumeID: volumeID, Key: "key", Type: "folder"}
+
+	descendantsQuery := "WITH RECURSIVE paths (id, depth) AS (SELECT id, 1 FROM entries WHERE parent_id = ? UNION ALL SELECT e.id, p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id) "
+	depthQuery := descendantsQuery + "SELECT COALESCE(MAX(depth), 0) FROM paths;"
+	deleteQuery := "DELETE FROM entries WHERE id IN (SELECT id FROM (" + descendantsQuery + "SELECT id FROM paths WHERE depth = ?) AS d) LIMIT ?;"
+
+	expectFindParent := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+			WithArgs(volumeID, "key", 1, "key", 1).
+			WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(parent.ID, parent.AccountID, parent.VolumeID, nil, "key", parent.Key, parent.Size, parent.Type, parent.Encoding, parent.EncryptionKeyID, parent.ScanStatus, parent.ScanSignature, parent.ScannedAt, parent.CreatedAt, parent.UpdatedAt)).
+			WillReturnError(nil)
+	}
+	expectFindDepth := func(mock sqlmock.Sqlmock, depth int) {
+		mock.ExpectQuery(regexp.QuoteMeta(depthQuery)).
+			WithArgs(parent.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"depth"}).AddRow(depth)).
+			WillReturnError(nil)
+	}
+
+	tests := []struct {
+		name        string
+		inputPrefix string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully deleted",
+			inputPrefix: "key",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFindParent(mock)
+				expectFindDepth(mock, 2)
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(parent.ID, 2, 1000).
+					WillReturnResult(sqlmock.NewResult(0, 1000)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(parent.ID, 2, 1000).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(parent.ID, 1, 1000).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "no descendants",
+			inputPrefix: "key",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFindParent(mock)
+				expectFindDepth(mock, 0)
+			},
+		},
+		{
+			name:        "prefix not found",
+			inputPrefix: "key",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+					WithArgs(volumeID, "key", 1, "key", 1).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "find depth error",
+			inputPrefix: "key",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFindParent(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(depthQuery)).
+					WithArgs(parent.ID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "delete error",
+			inputPrefix: "key",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFindParent(mock)
+				expectFindDepth(mock, 2)
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(parent.ID, 2, 1000).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryRepository(db)
+			if err := repo.DeleteByPrefix(t.Context(), tt.inputPrefix, volumeID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntry_CopyByPrefix(t *testing.T) {
+	volumeID := uuid.New()
+	dstVolumeID := uuid.New()
+	src := &entity.Entry{ID: uuid.New(), AccountID: uuid.New(), VolumeID: volumeID, Key: "key", Type: "folder"}
+	dst := &entity.Entry{ID: uuid.New(), AccountID: src.AccountID, VolumeID: dstVolumeID, Key: "key copy", Type: "folder"}
+
+	copiedID := func(column string) string {
+		return "INSERT(INSERT(INSERT(INSERT(MD5(CONCAT(?, " + column + ")), 21, 0, '-'), 17, 0, '-'), 13, 0, '-'), 9, 0, '-')"
+	}
+	batch := "(WITH RECURSIVE paths (id, depth) AS (SELECT id, 1 FROM entries WHERE parent_id = ? UNION ALL SELECT e.id, p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id) SELECT id, depth FROM paths ORDER BY depth, id LIMIT ? OFFSET ?) AS b"
+	insertQuery := "INSERT INTO entries (id, account_id, volume_id, parent_id, name, size, type, encoding, encryption_key_id, scan_status, scan_signature, scanned_at, created_at, updated_at) SELECT " + copiedID("e.id") + ", e.account_id, ?, IF(e.parent_id = ?, ?, " + copiedID("e.parent_id") + "), e.name, e.size, e.type, e.encoding, e.encryption_key_id, e.scan_status, e.scan_signature, e.scanned_at, ?, ? FROM " + batch + " INNER JOIN entries AS e ON e.id = b.id ORDER BY b.depth, b.id;"
+	insertMetadataQuery := "INSERT INTO entry_metadata (entry_id, width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album) SELECT " + copiedID("d.entry_id") + ", width, height, taken_at, camera_make, camera_model, latitude, longitude, page_count, duration, title, artist, album FROM " + batch + " INNER JOIN entry_metadata AS d ON d.entry_id = b.id;"
+	insertContentQuery := "INSERT INTO entry_contents (entry_id, content) SELECT " + copiedID("c.entry_id") + ", c.content FROM " + batch + " INNER JOIN entry_contents AS c ON c.entry_id = b.id;"
+
+	expectFind := func(mock sqlmock.Sqlmock, entry *entity.Entry, depth int) {
+		mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+			WithArgs(entry.VolumeID, entry.Key, depth, entry.Key, depth).
+			WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, nil, entry.Key, entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
+			WillReturnError(nil)
+	}
+	expectInsert := func(mock sqlmock.Sqlmock, offset int, n int64) {
+		mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+			WithArgs(sqlmock.AnyArg(), dstVolumeID, src.ID, dst.ID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), src.ID, 1000, offset).
+			WillReturnResult(sqlmock.NewResult(0, n)).
+			WillReturnError(nil)
+	}
+	expectInsertMetadata := func(mock sqlmock.Sqlmock, offset int) {
+		mock.ExpectExec(regexp.QuoteMeta(insertMetadataQuery)).
+			WithArgs(sqlmock.AnyArg(), src.ID, 1000, offset).
+			WillReturnResult(sqlmock.NewResult(0, 1)).
+			WillReturnError(nil)
+	}
+	expectInsertContent := func(mock sqlmock.Sqlmock, offset int) {
+		mock.ExpectExec(regexp.QuoteMeta(insertContentQuery)).
+			WithArgs(sqlmock.AnyArg(), src.ID, 1000, offset).
+			WillReturnResult(sqlmock.NewResult(0, 1)).
+			WillReturnError(nil)
+	}
+
+	tests := []struct {
+		name        string
+		inputSrc    string
+		inputDst    string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully copied",
+			inputSrc:    "key",
+			inputDst:    "key copy",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFind(mock, src, 1)
+				expectFind(mock, dst, 1)
+				expectInsert(mock, 0, 1000)
+				expectInsertMetadata(mock, 0)
+				expectInsertContent(mock, 0)
+				expectInsert(mock, 1000, 1)
+				expectInsertMetadata(mock, 1000)
+				expectInsertContent(mock, 1000)
+			},
+		},
+		{
+			name:        "source not found",
+			inputSrc:    "key",
+			inputDst:    "key copy",
+			expectError: repository.ErrEntryNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+					WithArgs(volumeID, "key", 1, "key", 1).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "insert error",
+			inputSrc:    "key",
+			inputDst:    "key copy",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFind(mock, src, 1)
+				expectFind(mock, dst, 1)
+				mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+					WithArgs(sqlmock.AnyArg(), dstVolumeID, src.ID, dst.ID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), src.ID, 1000, 0).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "insert metadata error",
+			inputSrc:    "key",
+			inputDst:    "key copy",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFind(mock, src, 1)
+				expectFind(mock, dst, 1)
+				expectInsert(mock, 0, 1)
+				mock.ExpectExec(regexp.QuoteMeta(insertMetadataQuery)).
+					WithArgs(sqlmock.AnyArg(), src.ID, 1000, 0).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "insert content error",
+			inputSrc:    "key",
+			inputDst:    "key copy",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFind(mock, src, 1)
+				expectFind(mock, dst, 1)
+				expectInsert(mock, 0, 1)
+				expectInsertMetadata(mock, 0)
+				mock.ExpectExec(regexp.QuoteMeta(insertContentQuery)).
+					WithArgs(sqlmock.AnyArg(), src.ID, 1000, 0).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryRepository(db)
+			if err := repo.CopyByPrefix(t.Context(), tt.inputSrc, volumeID, tt.inputDst, dstVolumeID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntry_MoveByPrefix(t *testing.T) {
+	volumeID := uuid.New()
+	newVolumeID := uuid.New()
+	parent := &entity.Entry{ID: uuid.New(), AccountID: uuid.New(), VolumeID: volumeID, Key: "key", Type: "folder"}
+
+	updateQuery := "UPDATE entries SET volume_id = ? WHERE volume_id <> ? AND id IN (SELECT id FROM (WITH RECURSIVE paths (id, depth) AS (SELECT id, 1 FROM entries WHERE parent_id = ? UNION ALL SELECT e.id, p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id) SELECT id FROM paths) AS d) LIMIT ?;"
+
+	expectFind := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+			WithArgs(volumeID, parent.Key, 1, parent.Key, 1).
+			WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(parent.ID, parent.AccountID, parent.VolumeID, nil, parent.Key, parent.Key, parent.Size, parent.Type, parent.Encoding, parent.EncryptionKeyID, parent.ScanStatus, parent.ScanSignature, parent.ScannedAt, parent.CreatedAt, parent.UpdatedAt)).
+			WillReturnError(nil)
+	}
+
+	tests := []struct {
+		name        string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully moved",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFind(mock)
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(newVolumeID, newVolumeID, parent.ID, 1000).
+					WillReturnResult(sqlmock.NewResult(0, 1000)).
+					WillReturnError(nil)
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(newVolumeID, newVolumeID, parent.ID, 1000).
+					WillReturnResult(sqlmock.NewResult(0, 2)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "not found",
+			expectError: repository.ErrEntryNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+					WithArgs(volumeID, "key", 1, "key", 1).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "update error",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				expectFind(mock)
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(newVolumeID, newVolumeID, parent.ID, 1000).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryRepository(db)
+			if err := repo.MoveByPrefix(t.Context(), "key", volumeID, newVolumeID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
 func TestEntry_FindOneByKeyAndVolumeID(t *testing.T) {
 	accountID := uuid.New()
 	volumeID := uuid.New()
@@ -223,6 +552,7 @@ func TestEntry_FindOneByKeyAndVolumeID(t *testing.T) {
 		ID:        uuid.New(),
 		AccountID: accountID,
 		VolumeID:  volumeID,
+		ParentID:  uuid.New(),
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
@@ -245,9 +575,9 @@ func TestEntry_FindOneByKeyAndVolumeID(t *testing.T) {
 			expectResult:  entry,
 			expectError:   nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? LIMIT 1;")).
-					WithArgs("key/sample.txt", volumeID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"}).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+					WithArgs(volumeID, "key/sample.txt", 2, "key/sample.txt", 2).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
@@ -258,9 +588,9 @@ func TestEntry_FindOneByKeyAndVolumeID(t *testing.T) {
 			expectResult:  nil,
 			expectError:   repository.ErrEntryNotFound,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? LIMIT 1;")).
-					WithArgs("key/sample.txt", volumeID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+					WithArgs(volumeID, "key/sample.txt", 2, "key/sample.txt", 2).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
 					WillReturnError(nil)
 			},
 		},
@@ -271,9 +601,9 @@ func TestEntry_FindOneByKeyAndVolumeID(t *testing.T) {
 			expectResult:  nil,
 			expectError:   sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? LIMIT 1;")).
-					WithArgs("key/sample.txt", volumeID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" LIMIT 1;")).
+					WithArgs(volumeID, "key/sample.txt", 2, "key/sample.txt", 2).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -308,6 +638,7 @@ func TestEntry_FindOneByKeyAndVolumeIDAndAccountID(t *testing.T) {
 		ID:        uuid.New(),
 		AccountID: accountID,
 		VolumeID:  volumeID,
+		ParentID:  uuid.New(),
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
@@ -332,9 +663,9 @@ func TestEntry_FindOneByKeyAndVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   entry,
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
-					WithArgs("key/sample.txt", volumeID, accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"}).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" AND e.account_id = ? LIMIT 1;")).
+					WithArgs(volumeID, "key/sample.txt", 2, "key/sample.txt", 2, accountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
@@ -346,9 +677,9 @@ func TestEntry_FindOneByKeyAndVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   nil,
 			expectError:    repository.ErrEntryNotFound,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
-					WithArgs("key/sample.txt", volumeID, accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" AND e.account_id = ? LIMIT 1;")).
+					WithArgs(volumeID, "key/sample.txt", 2, "key/sample.txt", 2, accountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
 					WillReturnError(nil)
 			},
 		},
@@ -360,9 +691,9 @@ func TestEntry_FindOneByKeyAndVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   nil,
 			expectError:    sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE `key` = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
-					WithArgs("key/sample.txt", volumeID, accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" AND e.account_id = ? LIMIT 1;")).
+					WithArgs(volumeID, "key/sample.txt", 2, "key/sample.txt", 2, accountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -391,10 +722,21 @@ func TestEntry_FindOneByKeyAndVolumeIDAndAccountID(t *testing.T) {
 }
 
 func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
-	entry := &entity.Entry{
+	parent := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: uuid.New(),
 		VolumeID:  uuid.New(),
+		Key:       "key",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: parent.AccountID,
+		VolumeID:  parent.VolumeID,
+		ParentID:  parent.ID,
 		Key:       "key/sample.txt",
 		Size:      4,
 		Type:      "text/plain; charset=utf-8",
@@ -402,12 +744,27 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
+	rootQuery := "WITH RECURSIVE paths (id, `key`, depth) AS (SELECT id, CAST(CONCAT(?, name) AS CHAR(512)), 1 FROM entries WHERE volume_id = ? AND account_id = ? AND COALESCE(parent_id, '') = '' UNION ALL SELECT e.id, CONCAT(p.`key`, '/', e.name), p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id"
+	prefixQuery := "WITH RECURSIVE paths (id, `key`, depth) AS (SELECT id, CAST(CONCAT(?, name) AS CHAR(512)), 1 FROM entries WHERE volume_id = ? AND account_id = ? AND parent_id = ? UNION ALL SELECT e.id, CONCAT(p.`key`, '/', e.name), p.depth + 1 FROM paths AS p INNER JOIN entries AS e ON e.parent_id = p.id"
+	selectQuery := ") SELECT e.id, e.account_id, e.volume_id, e.parent_id, e.name, p.`key`, e.size, e.type, e.encoding, e.encryption_key_id, e.scan_status, e.scan_signature, e.scanned_at, e.created_at, e.updated_at FROM paths AS p INNER JOIN entries AS e ON e.id = p.id;"
+
+	takenFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	takenTo := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+
+	expectFindParent := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" AND e.account_id = ? LIMIT 1;")).
+			WithArgs(parent.VolumeID, "key", 1, "key", 1, parent.AccountID).
+			WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(parent.ID, parent.AccountID, parent.VolumeID, nil, "key", parent.Key, parent.Size, parent.Type, parent.Encoding, parent.EncryptionKeyID, parent.ScanStatus, parent.ScanSignature, parent.ScannedAt, parent.CreatedAt, parent.UpdatedAt)).
+			WillReturnError(nil)
+	}
+
 	tests := []struct {
 		name           string
 		inputVolumeID  uuid.UUID
 		inputAccountID uuid.UUID
 		inputPrefix    *string
 		inputDepth     *uint64
+		inputCondition *repository.EntryCondition
 		expectResult   []*entity.Entry
 		expectError    error
 		setMockDB      func(mock sqlmock.Sqlmock)
@@ -421,9 +778,9 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   []*entity.Entry{entry},
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE volume_id = ? AND account_id = ?;")).
-					WithArgs(entry.VolumeID, entry.AccountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"}).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(rootQuery+selectQuery)).
+					WithArgs("", entry.VolumeID, entry.AccountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
@@ -436,9 +793,10 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   []*entity.Entry{entry},
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE volume_id = ? AND account_id = ? AND `key` LIKE ?;")).
-					WithArgs(entry.VolumeID, entry.AccountID, "key/%").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"}).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt)).
+				expectFindParent(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(prefixQuery+selectQuery)).
+					WithArgs("key/", entry.VolumeID, entry.AccountID, parent.ID).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
@@ -451,9 +809,9 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   []*entity.Entry{entry},
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE volume_id = ? AND account_id = ? AND LENGTH(`key`) - LENGTH(REPLACE(`key`, '/', '')) <= LENGTH(?) - LENGTH(REPLACE(?, '/', '')) + ?;")).
-					WithArgs(entry.VolumeID, entry.AccountID, "", "", 0).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"}).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(rootQuery+" WHERE p.depth < ?"+selectQuery)).
+					WithArgs("", entry.VolumeID, entry.AccountID, 1).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
@@ -466,9 +824,67 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   []*entity.Entry{entry},
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE volume_id = ? AND account_id = ? AND `key` LIKE ? AND LENGTH(`key`) - LENGTH(REPLACE(`key`, '/', '')) <= LENGTH(?) - LENGTH(REPLACE(?, '/', '')) + ?;")).
-					WithArgs(entry.VolumeID, entry.AccountID, "key/%", "key", "key", 1).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"}).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.Key, entry.Size, entry.Type, entry.CreatedAt, entry.UpdatedAt)).
+				expectFindParent(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(prefixQuery+" WHERE p.depth < ?"+selectQuery)).
+					WithArgs("key/", entry.VolumeID, entry.AccountID, parent.ID, 1).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "find by condition",
+			inputVolumeID:  entry.VolumeID,
+			inputAccountID: entry.AccountID,
+			inputPrefix:    nil,
+			inputDepth:     types.ToPointer(uint64(1)),
+			inputCondition: &repository.EntryCondition{TakenFrom: &takenFrom, TakenTo: &takenTo, Camera: "Holos_%"},
+			expectResult:   []*entity.Entry{entry},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(rootQuery+" WHERE p.depth < ?"+strings.TrimSuffix(selectQuery, ";")+" INNER JOIN entry_metadata AS m ON m.entry_id = e.id WHERE m.taken_at >= ? AND m.taken_at <= ? AND LOWER(CONCAT(m.camera_make, ' ', m.camera_model)) LIKE ?;")).
+					WithArgs("", entry.VolumeID, entry.AccountID, 1, takenFrom, takenTo, `%holos\_\%%`).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "empty condition",
+			inputVolumeID:  entry.VolumeID,
+			inputAccountID: entry.AccountID,
+			inputPrefix:    nil,
+			inputDepth:     nil,
+			inputCondition: &repository.EntryCondition{},
+			expectResult:   []*entity.Entry{entry},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(rootQuery+selectQuery)).
+					WithArgs("", entry.VolumeID, entry.AccountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns).AddRow(entry.ID, entry.AccountID, entry.VolumeID, entry.ParentID, "sample.txt", entry.Key, entry.Size, entry.Type, entry.Encoding, entry.EncryptionKeyID, entry.ScanStatus, entry.ScanSignature, entry.ScannedAt, entry.CreatedAt, entry.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:           "zero depth",
+			inputVolumeID:  entry.VolumeID,
+			inputAccountID: entry.AccountID,
+			inputPrefix:    nil,
+			inputDepth:     types.ToPointer(uint64(0)),
+			expectResult:   []*entity.Entry{},
+			expectError:    nil,
+			setMockDB:      func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:           "prefix not found",
+			inputVolumeID:  entry.VolumeID,
+			inputAccountID: entry.AccountID,
+			inputPrefix:    types.ToPointer("key"),
+			inputDepth:     nil,
+			expectResult:   []*entity.Entry{},
+			expectError:    nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(findOneQuery+" AND e.account_id = ? LIMIT 1;")).
+					WithArgs(entry.VolumeID, "key", 1, "key", 1, entry.AccountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
 					WillReturnError(nil)
 			},
 		},
@@ -481,9 +897,9 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   []*entity.Entry{},
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE volume_id = ? AND account_id = ?;")).
-					WithArgs(entry.VolumeID, entry.AccountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(rootQuery+selectQuery)).
+					WithArgs("", entry.VolumeID, entry.AccountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
 					WillReturnError(nil)
 			},
 		},
@@ -496,9 +912,140 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			expectResult:   nil,
 			expectError:    sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, `key`, size, type, created_at, updated_at FROM entries WHERE volume_id = ? AND account_id = ?;")).
-					WithArgs(entry.VolumeID, entry.AccountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "volume_id", "key", "size", "type", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(rootQuery+selectQuery)).
+					WithArgs("", entry.VolumeID, entry.AccountID).
+					WillReturnRows(sqlmock.NewRows(entryColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryRepository(db)
+			result, err := repo.FindByVolumeIDAndAccountID(t.Context(), tt.inputVolumeID, tt.inputAccountID, tt.inputPrefix, tt.inputDepth, tt.inputCondition)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntry_CountByParentIDAndVolumeID(t *testing.T) {
+	parentID := uuid.New()
+	volumeID := uuid.New()
+
+	tests := []struct {
+		name          string
+		inputParentID uuid.UUID
+		inputVolumeID uuid.UUID
+		expectResult  uint64
+		expectError   error
+		setMockDB     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "successfully counted",
+			inputParentID: parentID,
+			inputVolumeID: volumeID,
+			expectResult:  3,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM entries WHERE volume_id = ? AND parent_id = ?;")).
+					WithArgs(volumeID, parentID).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(3)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "successfully counted root",
+			inputParentID: uuid.Nil,
+			inputVolumeID: volumeID,
+			expectResult:  2,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM entries WHERE volume_id = ? AND COALESCE(parent_id, '') = '';")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows([]string{"COUNT(*)"}).AddRow(2)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "count error",
+			inputParentID: parentID,
+			inputVolumeID: volumeID,
+			expectResult:  0,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM entries WHERE volume_id = ? AND parent_id = ?;")).
+					WithArgs(volumeID, parentID).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+
+			tt.setMockDB(mock)
+
+			repo := database.NewEntryRepository(db)
+			result, err := repo.CountByParentIDAndVolumeID(t.Context(), tt.inputParentID, tt.inputVolumeID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectResult, result)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEntry_SumByVolumeID(t *testing.T) {
+	volumeID := uuid.New()
+
+	tests := []struct {
+		name          string
+		inputVolumeID uuid.UUID
+		expectResult  *entity.VolumeUsage
+		expectError   error
+		setMockDB     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "successfully summed",
+			inputVolumeID: volumeID,
+			expectResult:  &entity.VolumeUsage{Size: 1024, EntryCount: 3},
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(size), 0), COUNT(*) FROM entries WHERE volume_id = ?;")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows([]string{"COALESCE(SUM(size), 0)", "COUNT(*)"}).AddRow(1024, 3)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "sum error",
+			inputVolumeID: volumeID,
+			expectResult:  nil,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(size), 0), COUNT(*) FROM entries WHERE volume_id = ?;")).
+					WithArgs(volumeID).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -510,7 +1057,7 @@ func TestEntry_FindByVolumeIDAndAccountID(t *testing.T) {
 			tt.setMockDB(mock)
 
 			repo := database.NewEntryRepository(db)
-			result, err := repo.FindByVolumeIDAndAccountID(t.Context(), tt.inputVolumeID, tt.inputAccountID, tt.inputPrefix, tt.inputDepth)
+			result, err := repo.SumByVolumeID(t.Context(), tt.inputVolumeID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredImagePreset = status.Error(code.Internal, "image preset is required")
+
+type imagePresetRepository struct {
+	db *sqlx.DB
+}
+
+func NewImagePresetRepository(db *sqlx.DB) repository.ImagePresetRepository {
+	return &imagePresetRepository{
+		db: db,
+	}
+}
+
+func (r *imagePresetRepository) Create(ctx context.Context, preset *entity.ImagePreset) error {
+	if preset == nil {
+		return ErrRequiredImagePreset
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToImagePresetModel(preset)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO image_presets (id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at) VALUES (:id, :account_id, :volume_id, :name, :width, :height, :fit, :crop_x, :crop_y, :crop_width, :crop_height, :quality, :format, :created_at, :updated_at);", model)
+	return err
+}
+
+func (r *imagePresetRepository) Delete(ctx context.Context, preset *entity.ImagePreset) error {
+	if preset == nil {
+		return ErrRequiredImagePreset
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToImagePresetModel(preset)
+	_, err := driver.NamedExecContext(ctx, "DELETE FROM image_presets WHERE id = :id LIMIT 1;", model)
+	return err
+}
+
+func (r *imagePresetRepository) FindOneByIDAndVolumeIDAndAccountID(ctx context.Context, id, volumeID, accountID uuid.UUID) (*entity.ImagePreset, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.ImagePresetModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;", id, volumeID, accountID).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrImagePresetNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToImagePresetEntity(&model), nil
+}
+
+func (r *imagePresetRepository) FindOneByNameAndVolumeID(ctx context.Context, name string, volumeID uuid.UUID) (*entity.ImagePreset, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.ImagePresetModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE name = ? AND volume_id = ? LIMIT 1;", name, volumeID).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrImagePresetNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToImagePresetEntity(&model), nil
+}
+
+func (r *imagePresetRepository) FindByVolumeID(ctx context.Context, volumeID uuid.UUID) (presets []*entity.ImagePreset, err error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	rows, err := driver.QueryxContext(ctx, "SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE volume_id = ? ORDER BY name;", volumeID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.ImagePresetModel
+	for rows.Next() {
+		var model model.ImagePresetModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return transformer.ToImagePresetEntities(models), nil
+}
@@ -0,0 +1,364 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var imagePresetColumns = []string{"id", "account_id", "volume_id", "name", "width", "height", "fit", "crop_x", "crop_y", "crop_width", "crop_height", "quality", "format", "created_at", "updated_at"}
+
+func TestImagePreset_Create(t *testing.T) {
+	preset := &entity.ImagePreset{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		VolumeID:       uuid.New(),
+		Name:           "small",
+		Transformation: &entity.ImageTransformation{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		inputImagePreset *entity.ImagePreset
+		expectError      error
+		setMockDB        func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:             "successfully inserted",
+			inputImagePreset: preset,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO image_presets (id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(preset.ID, preset.AccountID, preset.VolumeID, preset.Name, preset.Transformation.Width, preset.Transformation.Height, preset.Transformation.Fit, preset.Transformation.CropX, preset.Transformation.CropY, preset.Transformation.CropWidth, preset.Transformation.CropHeight, preset.Transformation.Quality, preset.Transformation.Format, preset.CreatedAt, preset.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:             "image preset is nil",
+			inputImagePreset: nil,
+			expectError:      database.ErrRequiredImagePreset,
+			setMockDB:        func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:             "insert error",
+			inputImagePreset: preset,
+			expectError:      sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO image_presets (id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(preset.ID, preset.AccountID, preset.VolumeID, preset.Name, preset.Transformation.Width, preset.Transformation.Height, preset.Transformation.Fit, preset.Transformation.CropX, preset.Transformation.CropY, preset.Transformation.CropWidth, preset.Transformation.CropHeight, preset.Transformation.Quality, preset.Transformation.Format, preset.CreatedAt, preset.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewImagePresetRepository(db)
+			if err := repo.Create(t.Context(), tt.inputImagePreset); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestImagePreset_Delete(t *testing.T) {
+	preset := &entity.ImagePreset{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		VolumeID:       uuid.New(),
+		Name:           "small",
+		Transformation: &entity.ImageTransformation{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		inputImagePreset *entity.ImagePreset
+		expectError      error
+		setMockDB        func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:             "successfully deleted",
+			inputImagePreset: preset,
+			expectError:      nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM image_presets WHERE id = ? LIMIT 1;")).
+					WithArgs(preset.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:             "image preset is nil",
+			inputImagePreset: nil,
+			expectError:      database.ErrRequiredImagePreset,
+			setMockDB:        func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:             "delete error",
+			inputImagePreset: preset,
+			expectError:      sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM image_presets WHERE id = ? LIMIT 1;")).
+					WithArgs(preset.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewImagePresetRepository(db)
+			if err := repo.Delete(t.Context(), tt.inputImagePreset); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestImagePreset_FindOneByIDAndVolumeIDAndAccountID(t *testing.T) {
+	preset := &entity.ImagePreset{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		VolumeID:       uuid.New(),
+		Name:           "small",
+		Transformation: &entity.ImageTransformation{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.ImagePreset
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: preset,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(preset.ID, preset.VolumeID, preset.AccountID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns).AddRow(preset.ID, preset.AccountID, preset.VolumeID, preset.Name, preset.Transformation.Width, preset.Transformation.Height, preset.Transformation.Fit, preset.Transformation.CropX, preset.Transformation.CropY, preset.Transformation.CropWidth, preset.Transformation.CropHeight, preset.Transformation.Quality, preset.Transformation.Format, preset.CreatedAt, preset.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  repository.ErrImagePresetNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(preset.ID, preset.VolumeID, preset.AccountID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(preset.ID, preset.VolumeID, preset.AccountID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewImagePresetRepository(db)
+			result, err := repo.FindOneByIDAndVolumeIDAndAccountID(t.Context(), preset.ID, preset.VolumeID, preset.AccountID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestImagePreset_FindOneByNameAndVolumeID(t *testing.T) {
+	preset := &entity.ImagePreset{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		VolumeID:       uuid.New(),
+		Name:           "small",
+		Transformation: &entity.ImageTransformation{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.ImagePreset
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: preset,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE name = ? AND volume_id = ? LIMIT 1;")).
+					WithArgs(preset.Name, preset.VolumeID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns).AddRow(preset.ID, preset.AccountID, preset.VolumeID, preset.Name, preset.Transformation.Width, preset.Transformation.Height, preset.Transformation.Fit, preset.Transformation.CropX, preset.Transformation.CropY, preset.Transformation.CropWidth, preset.Transformation.CropHeight, preset.Transformation.Quality, preset.Transformation.Format, preset.CreatedAt, preset.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  repository.ErrImagePresetNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE name = ? AND volume_id = ? LIMIT 1;")).
+					WithArgs(preset.Name, preset.VolumeID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE name = ? AND volume_id = ? LIMIT 1;")).
+					WithArgs(preset.Name, preset.VolumeID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewImagePresetRepository(db)
+			result, err := repo.FindOneByNameAndVolumeID(t.Context(), preset.Name, preset.VolumeID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestImagePreset_FindByVolumeID(t *testing.T) {
+	preset := &entity.ImagePreset{
+		ID:             uuid.New(),
+		AccountID:      uuid.New(),
+		VolumeID:       uuid.New(),
+		Name:           "small",
+		Transformation: &entity.ImageTransformation{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult []*entity.ImagePreset
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: []*entity.ImagePreset{preset},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE volume_id = ? ORDER BY name;")).
+					WithArgs(preset.VolumeID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns).AddRow(preset.ID, preset.AccountID, preset.VolumeID, preset.Name, preset.Transformation.Width, preset.Transformation.Height, preset.Transformation.Fit, preset.Transformation.CropX, preset.Transformation.CropY, preset.Transformation.CropWidth, preset.Transformation.CropHeight, preset.Transformation.Quality, preset.Transformation.Format, preset.CreatedAt, preset.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, name, width, height, fit, crop_x, crop_y, crop_width, crop_height, quality, format, created_at, updated_at FROM image_presets WHERE volume_id = ? ORDER BY name;")).
+					WithArgs(preset.VolumeID).
+					WillReturnRows(sqlmock.NewRows(imagePresetColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewImagePresetRepository(db)
+			result, err := repo.FindByVolumeID(t.Context(), preset.VolumeID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredJob = status.Error(code.Internal, "job is required")
+
+type jobRepository struct {
+	db *sqlx.DB
+}
+
+func NewJobRepository(db *sqlx.DB) repository.JobRepository {
+	return &jobRepository{
+		db: db,
+	}
+}
+
+func (r *jobRepository) Create(ctx context.Context, job *entity.Job) error {
+	if job == nil {
+		return ErrRequiredJob
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToJobModel(job)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO jobs (id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at) VALUES (:id, :account_id, :type, :status, :volume_name, :key, :new_volume_name, :new_key, :conflict, :result_key, :error, :total_entries, :processed_entries, :total_bytes, :processed_bytes, :attempts, :run_at, :created_at, :updated_at);", model)
+	return err
+}
+
+func (r *jobRepository) Update(ctx context.Context, job *entity.Job) error {
+	if job == nil {
+		return ErrRequiredJob
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToJobModel(job)
+	_, err := driver.NamedExecContext(ctx, "UPDATE jobs SET status = :status, result_key = :result_key, error = :error, total_entries = :total_entries, processed_entries = :processed_entries, total_bytes = :total_bytes, processed_bytes = :processed_bytes, attempts = :attempts, run_at = :run_at, updated_at = :updated_at WHERE id = :id LIMIT 1;", model)
+	return err
+}
+
+// NOTE: 更新日時を実行中のジョブの生存確認にも利用する.
+func (r *jobRepository) AddProgress(ctx context.Context, id uuid.UUID, entries, bytes uint64) error {
+	driver := transaction.GetDriver(ctx, r.db)
+	_, err := driver.ExecContext(ctx, "UPDATE jobs SET processed_entries = processed_entries + ?, processed_bytes = processed_bytes + ?, updated_at = ? WHERE id = ? LIMIT 1;", entries, bytes, time.Now(), id)
+	return err
+}
+
+// NOTE: 実行中のジョブの進捗やキャンセルを上書きしないよう総量のみ更新する.
+func (r *jobRepository) SetTotal(ctx context.Context, id uuid.UUID, entries, bytes uint64) error {
+	driver := transaction.GetDriver(ctx, r.db)
+	_, err := driver.ExecContext(ctx, "UPDATE jobs SET total_entries = ?, total_bytes = ?, updated_at = ? WHERE id = ? LIMIT 1;", entries, bytes, time.Now(), id)
+	return err
+}
+
+func (r *jobRepository) FindOneByID(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.JobModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE id = ? LIMIT 1;", id).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToJobEntity(&model), nil
+}
+
+func (r *jobRepository) FindOneByIDAndAccountID(ctx context.Context, id, accountID uuid.UUID) (*entity.Job, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.JobModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE id = ? AND account_id = ? LIMIT 1;", id, accountID).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToJobEntity(&model), nil
+}
+
+// NOTE: 実行待ちのジョブに加え, 更新が途絶えた実行中のジョブも再実行の対象とする.
+func (r *jobRepository) FindOneRunnable(ctx context.Context, staleBefore time.Time) (*entity.Job, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.JobModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;", entity.JobStatusPending, time.Now(), entity.JobStatusRunning, staleBefore).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrJobNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToJobEntity(&model), nil
+}
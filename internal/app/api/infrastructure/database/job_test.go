@@ -0,0 +1,414 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var jobColumns = []string{"id", "account_id", "type", "status", "volume_name", "key", "new_volume_name", "new_key", "conflict", "result_key", "error", "total_entries", "processed_entries", "total_bytes", "processed_bytes", "attempts", "run_at", "created_at", "updated_at"}
+
+func newJobRows(job *entity.Job) *sqlmock.Rows {
+	return sqlmock.NewRows(jobColumns).AddRow(job.ID, job.AccountID, job.Type, job.Status, job.VolumeName, job.Key, job.NewVolumeName, job.NewKey, job.Conflict, job.ResultKey, job.Error, job.TotalEntries, job.ProcessedEntries, job.TotalBytes, job.ProcessedBytes, job.Attempts, job.RunAt, job.CreatedAt, job.UpdatedAt)
+}
+
+func TestJob_Create(t *testing.T) {
+	job := &entity.Job{
+		ID:         uuid.New(),
+		AccountID:  uuid.New(),
+		Type:       entity.JobTypeCopy,
+		Status:     entity.JobStatusPending,
+		VolumeName: "volume",
+		Key:        "key",
+		NewKey:     "new_key",
+		Conflict:   "merge",
+		RunAt:      time.Now(),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		inputJob    *entity.Job
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully inserted",
+			inputJob:    job,
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs (id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(job.ID, job.AccountID, job.Type, job.Status, job.VolumeName, job.Key, job.NewVolumeName, job.NewKey, job.Conflict, job.ResultKey, job.Error, job.TotalEntries, job.ProcessedEntries, job.TotalBytes, job.ProcessedBytes, job.Attempts, job.RunAt, job.CreatedAt, job.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "job is nil",
+			inputJob:    nil,
+			expectError: database.ErrRequiredJob,
+			setMockDB:   func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:        "insert error",
+			inputJob:    job,
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs (id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(job.ID, job.AccountID, job.Type, job.Status, job.VolumeName, job.Key, job.NewVolumeName, job.NewKey, job.Conflict, job.ResultKey, job.Error, job.TotalEntries, job.ProcessedEntries, job.TotalBytes, job.ProcessedBytes, job.Attempts, job.RunAt, job.CreatedAt, job.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewJobRepository(db)
+			if err := repo.Create(t.Context(), tt.inputJob); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJob_Update(t *testing.T) {
+	job := &entity.Job{
+		ID:         uuid.New(),
+		AccountID:  uuid.New(),
+		Type:       entity.JobTypeCopy,
+		Status:     entity.JobStatusSucceeded,
+		VolumeName: "volume",
+		Key:        "key",
+		NewKey:     "new_key",
+		ResultKey:  "new_key",
+		Attempts:   1,
+		RunAt:      time.Now(),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		inputJob    *entity.Job
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully updated",
+			inputJob:    job,
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE jobs SET status = ?, result_key = ?, error = ?, total_entries = ?, processed_entries = ?, total_bytes = ?, processed_bytes = ?, attempts = ?, run_at = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(job.Status, job.ResultKey, job.Error, job.TotalEntries, job.ProcessedEntries, job.TotalBytes, job.ProcessedBytes, job.Attempts, job.RunAt, job.UpdatedAt, job.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "job is nil",
+			inputJob:    nil,
+			expectError: database.ErrRequiredJob,
+			setMockDB:   func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:        "update error",
+			inputJob:    job,
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE jobs SET status = ?, result_key = ?, error = ?, total_entries = ?, processed_entries = ?, total_bytes = ?, processed_bytes = ?, attempts = ?, run_at = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(job.Status, job.ResultKey, job.Error, job.TotalEntries, job.ProcessedEntries, job.TotalBytes, job.ProcessedBytes, job.Attempts, job.RunAt, job.UpdatedAt, job.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewJobRepository(db)
+			if err := repo.Update(t.Context(), tt.inputJob); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJob_AddProgress(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully added",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE jobs SET processed_entries = processed_entries + ?, processed_bytes = processed_bytes + ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(2, 10, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "update error",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE jobs SET processed_entries = processed_entries + ?, processed_bytes = processed_bytes + ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(2, 10, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewJobRepository(db)
+			if err := repo.AddProgress(t.Context(), id, 2, 10); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJob_SetTotal(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name        string
+		expectError error
+		setMockDB   func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "successfully set",
+			expectError: nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE jobs SET total_entries = ?, total_bytes = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(2, 10, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:        "update error",
+			expectError: sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE jobs SET total_entries = ?, total_bytes = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(2, 10, sqlmock.AnyArg(), id).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewJobRepository(db)
+			if err := repo.SetTotal(t.Context(), id, 2, 10); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJob_FindOneByIDAndAccountID(t *testing.T) {
+	job := &entity.Job{
+		ID:         uuid.New(),
+		AccountID:  uuid.New(),
+		Type:       entity.JobTypeDelete,
+		Status:     entity.JobStatusPending,
+		VolumeName: "volume",
+		Key:        "key",
+		RunAt:      time.Now(),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.Job
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: job,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(job.ID, job.AccountID).
+					WillReturnRows(newJobRows(job)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  repository.ErrJobNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(job.ID, job.AccountID).
+					WillReturnRows(sqlmock.NewRows(jobColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(job.ID, job.AccountID).
+					WillReturnRows(sqlmock.NewRows(jobColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewJobRepository(db)
+			result, err := repo.FindOneByIDAndAccountID(t.Context(), job.ID, job.AccountID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJob_FindOneRunnable(t *testing.T) {
+	staleBefore := time.Now().Add(-time.Minute)
+	job := &entity.Job{
+		ID:         uuid.New(),
+		AccountID:  uuid.New(),
+		Type:       entity.JobTypeDelete,
+		Status:     entity.JobStatusPending,
+		VolumeName: "volume",
+		Key:        "key",
+		RunAt:      time.Now(),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.Job
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: job,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;")).
+					WithArgs(entity.JobStatusPending, sqlmock.AnyArg(), entity.JobStatusRunning, staleBefore).
+					WillReturnRows(newJobRows(job)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  repository.ErrJobNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;")).
+					WithArgs(entity.JobStatusPending, sqlmock.AnyArg(), entity.JobStatusRunning, staleBefore).
+					WillReturnRows(sqlmock.NewRows(jobColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, type, status, volume_name, `key`, new_volume_name, new_key, conflict, result_key, error, total_entries, processed_entries, total_bytes, processed_bytes, attempts, run_at, created_at, updated_at FROM jobs WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;")).
+					WithArgs(entity.JobStatusPending, sqlmock.AnyArg(), entity.JobStatusRunning, staleBefore).
+					WillReturnRows(sqlmock.NewRows(jobColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewJobRepository(db)
+			result, err := repo.FindOneRunnable(t.Context(), staleBefore)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogModel struct {
+	ID             uint64        `db:"id"`
+	RequestID      string        `db:"request_id"`
+	OwnerID        uuid.NullUUID `db:"owner_id"`
+	ActorID        uuid.NullUUID `db:"actor_id"`
+	CredentialType string        `db:"credential_type"`
+	Principal      string        `db:"principal"`
+	ClientIP       string        `db:"client_ip"`
+	UserAgent      string        `db:"user_agent"`
+	Operation      string        `db:"operation"`
+	VolumeName     string        `db:"volume_name"`
+	Key            string        `db:"key"`
+	NewVolumeName  string        `db:"new_volume_name"`
+	NewKey         string        `db:"new_key"`
+	Status         uint64        `db:"status"`
+	CreatedAt      time.Time     `db:"created_at"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeModel struct {
+	VolumeID  uuid.UUID     `db:"volume_id"`
+	Sequence  uint64        `db:"sequence"`
+	EventID   uuid.UUID     `db:"event_id"`
+	AccountID uuid.UUID     `db:"account_id"`
+	ActorID   uuid.NullUUID `db:"actor_id"`
+	Type      string        `db:"type"`
+	Key       string        `db:"key"`
+	NewKey    string        `db:"new_key"`
+	Size      uint64        `db:"size"`
+	CreatedAt time.Time     `db:"created_at"`
+}
@@ -1,18 +1,26 @@
 package model
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type EntryModel struct {
-	ID        uuid.UUID `db:"id"`
-	AccountID uuid.UUID `db:"account_id"`
-	VolumeID  uuid.UUID `db:"volume_id"`
-	Key       string    `db:"key"`
-	Size      uint64    `db:"size"`
-	Type      string    `db:"type"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID              uuid.UUID     `db:"id"`
+	AccountID       uuid.UUID     `db:"account_id"`
+	VolumeID        uuid.UUID     `db:"volume_id"`
+	ParentID        uuid.NullUUID `db:"parent_id"`
+	Name            string        `db:"name"`
+	Key             string        `db:"key"`
+	Size            uint64        `db:"size"`
+	Type            string        `db:"type"`
+	Encoding        string        `db:"encoding"`
+	EncryptionKeyID string        `db:"encryption_key_id"`
+	ScanStatus      string        `db:"scan_status"`
+	ScanSignature   string        `db:"scan_signature"`
+	ScannedAt       sql.NullTime  `db:"scanned_at"`
+	CreatedAt       time.Time     `db:"created_at"`
+	UpdatedAt       time.Time     `db:"updated_at"`
 }
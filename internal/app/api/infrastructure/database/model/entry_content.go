@@ -0,0 +1,13 @@
+package model
+
+import "github.com/google/uuid"
+
+type EntryContentModel struct {
+	EntryID uuid.UUID `db:"entry_id"`
+	Content string    `db:"content"`
+}
+
+type EntryContentMatchModel struct {
+	EntryID uuid.UUID `db:"entry_id"`
+	Snippet string    `db:"snippet"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+type EntryMetadataModel struct {
+	EntryID     uuid.UUID       `db:"entry_id"`
+	Width       uint64          `db:"width"`
+	Height      uint64          `db:"height"`
+	TakenAt     sql.NullTime    `db:"taken_at"`
+	CameraMake  string          `db:"camera_make"`
+	CameraModel string          `db:"camera_model"`
+	Latitude    sql.NullFloat64 `db:"latitude"`
+	Longitude   sql.NullFloat64 `db:"longitude"`
+	PageCount   uint64          `db:"page_count"`
+	Duration    uint64          `db:"duration"`
+	Title       string          `db:"title"`
+	Artist      string          `db:"artist"`
+	Album       string          `db:"album"`
+}
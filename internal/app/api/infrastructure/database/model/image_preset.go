@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImagePresetModel struct {
+	ID         uuid.UUID `db:"id"`
+	AccountID  uuid.UUID `db:"account_id"`
+	VolumeID   uuid.UUID `db:"volume_id"`
+	Name       string    `db:"name"`
+	Width      uint64    `db:"width"`
+	Height     uint64    `db:"height"`
+	Fit        string    `db:"fit"`
+	CropX      uint64    `db:"crop_x"`
+	CropY      uint64    `db:"crop_y"`
+	CropWidth  uint64    `db:"crop_width"`
+	CropHeight uint64    `db:"crop_height"`
+	Quality    uint64    `db:"quality"`
+	Format     string    `db:"format"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
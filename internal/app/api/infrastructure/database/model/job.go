@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobModel struct {
+	ID               uuid.UUID `db:"id"`
+	AccountID        uuid.UUID `db:"account_id"`
+	Type             string    `db:"type"`
+	Status           string    `db:"status"`
+	VolumeName       string    `db:"volume_name"`
+	Key              string    `db:"key"`
+	NewVolumeName    string    `db:"new_volume_name"`
+	NewKey           string    `db:"new_key"`
+	Conflict         string    `db:"conflict"`
+	ResultKey        string    `db:"result_key"`
+	Error            string    `db:"error"`
+	TotalEntries     uint64    `db:"total_entries"`
+	ProcessedEntries uint64    `db:"processed_entries"`
+	TotalBytes       uint64    `db:"total_bytes"`
+	ProcessedBytes   uint64    `db:"processed_bytes"`
+	Attempts         uint64    `db:"attempts"`
+	RunAt            time.Time `db:"run_at"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
@@ -7,10 +7,22 @@ import (
 )
 
 type VolumeModel struct {
-	ID        uuid.UUID `db:"id"`
-	AccountID uuid.UUID `db:"account_id"`
-	Name      string    `db:"name"`
-	IsPublic  bool      `db:"is_public"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID                  uuid.UUID `db:"id"`
+	AccountID           uuid.UUID `db:"account_id"`
+	Name                string    `db:"name"`
+	IsPublic            bool      `db:"is_public"`
+	Compression         string    `db:"compression"`
+	MaxFileSize         uint64    `db:"max_file_size"`
+	AllowedTypes        string    `db:"allowed_types"`
+	DeniedTypes         string    `db:"denied_types"`
+	AllowedExtensions   string    `db:"allowed_extensions"`
+	DeniedExtensions    string    `db:"denied_extensions"`
+	MaxKeyDepth         uint64    `db:"max_key_depth"`
+	MaxEntriesPerFolder uint64    `db:"max_entries_per_folder"`
+	MaxVolumeSize       uint64    `db:"max_volume_size"`
+	MaxVolumeEntries    uint64    `db:"max_volume_entries"`
+	IsDroppable         bool      `db:"is_droppable"`
+	DropPrefix          string    `db:"drop_prefix"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookModel struct {
+	ID        uuid.UUID `db:"id"`
+	AccountID uuid.UUID `db:"account_id"`
+	VolumeID  uuid.UUID `db:"volume_id"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Events    string    `db:"events"`
+	Prefix    string    `db:"prefix"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
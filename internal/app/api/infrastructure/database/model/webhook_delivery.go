@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryModel struct {
+	ID             uuid.UUID `db:"id"`
+	WebhookID      uuid.UUID `db:"webhook_id"`
+	EventID        uuid.UUID `db:"event_id"`
+	EventType      string    `db:"event_type"`
+	URL            string    `db:"url"`
+	Payload        []byte    `db:"payload"`
+	Secret         string    `db:"secret"`
+	Status         string    `db:"status"`
+	ResponseStatus uint64    `db:"response_status"`
+	Error          string    `db:"error"`
+	Attempts       uint64    `db:"attempts"`
+	RunAt          time.Time `db:"run_at"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
@@ -3,6 +3,8 @@ package transaction
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"log"
 
 	"github.com/jmoiron/sqlx"
 
@@ -21,7 +23,12 @@ func NewDBTransactionObject(db *sqlx.DB) transaction.TransactionObject {
 	}
 }
 
-func (to *transactionObject) Transaction(ctx context.Context, fn func(context.Context) error) (err error) {
+func (to *transactionObject) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	// NOTE: 入れ子のトランザクションは外側のトランザクションで実行する.
+	if _, ok := ctx.Value(transactionKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := to.db.Beginx()
 	if err != nil {
 		return err
@@ -29,13 +36,19 @@ func (to *transactionObject) Transaction(ctx context.Context, fn func(context.Co
 
 	defer func() {
 		if r := recover(); r != nil {
-			err = tx.Rollback()
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				log.Println(rollbackErr.Error())
+			}
+			panic(r)
 		}
 	}()
 
 	ctx = context.WithValue(ctx, transactionKey{}, tx)
 
 	if err := fn(ctx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
 		return err
 	}
 
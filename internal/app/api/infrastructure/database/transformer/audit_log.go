@@ -0,0 +1,56 @@
+package transformer
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToAuditLogModel(auditLog *entity.AuditLog) *model.AuditLogModel {
+	return &model.AuditLogModel{
+		ID:             auditLog.ID,
+		RequestID:      auditLog.RequestID,
+		OwnerID:        uuid.NullUUID{UUID: auditLog.OwnerID, Valid: auditLog.OwnerID != uuid.Nil},
+		ActorID:        uuid.NullUUID{UUID: auditLog.ActorID, Valid: auditLog.ActorID != uuid.Nil},
+		CredentialType: auditLog.CredentialType,
+		Principal:      auditLog.Principal,
+		ClientIP:       auditLog.ClientIP,
+		UserAgent:      auditLog.UserAgent,
+		Operation:      auditLog.Operation,
+		VolumeName:     auditLog.VolumeName,
+		Key:            auditLog.Key,
+		NewVolumeName:  auditLog.NewVolumeName,
+		NewKey:         auditLog.NewKey,
+		Status:         auditLog.Status,
+		CreatedAt:      auditLog.CreatedAt,
+	}
+}
+
+func ToAuditLogEntity(auditLog *model.AuditLogModel) *entity.AuditLog {
+	return entity.RestoreAuditLog(
+		auditLog.ID,
+		auditLog.RequestID,
+		auditLog.OwnerID.UUID,
+		auditLog.ActorID.UUID,
+		auditLog.CredentialType,
+		auditLog.Principal,
+		auditLog.ClientIP,
+		auditLog.UserAgent,
+		auditLog.Operation,
+		auditLog.VolumeName,
+		auditLog.Key,
+		auditLog.NewVolumeName,
+		auditLog.NewKey,
+		auditLog.Status,
+		auditLog.CreatedAt,
+	)
+}
+
+func ToAuditLogEntities(auditLogs []*model.AuditLogModel) []*entity.AuditLog {
+	entities := make([]*entity.AuditLog, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		entities[i] = ToAuditLogEntity(auditLog)
+	}
+	return entities
+}
@@ -0,0 +1,46 @@
+package transformer
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToChangeModel(change *entity.Change) *model.ChangeModel {
+	return &model.ChangeModel{
+		VolumeID:  change.VolumeID,
+		Sequence:  change.Sequence,
+		EventID:   change.EventID,
+		AccountID: change.AccountID,
+		ActorID:   uuid.NullUUID{UUID: change.ActorID, Valid: change.ActorID != uuid.Nil},
+		Type:      change.Type,
+		Key:       change.Key,
+		NewKey:    change.NewKey,
+		Size:      change.Size,
+		CreatedAt: change.CreatedAt,
+	}
+}
+
+func ToChangeEntity(change *model.ChangeModel) *entity.Change {
+	return entity.RestoreChange(
+		change.VolumeID,
+		change.Sequence,
+		change.EventID,
+		change.AccountID,
+		change.ActorID.UUID,
+		change.Type,
+		change.Key,
+		change.NewKey,
+		change.Size,
+		change.CreatedAt,
+	)
+}
+
+func ToChangeEntities(changes []*model.ChangeModel) []*entity.Change {
+	entities := make([]*entity.Change, len(changes))
+	for i, change := range changes {
+		entities[i] = ToChangeEntity(change)
+	}
+	return entities
+}
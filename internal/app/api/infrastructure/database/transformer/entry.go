@@ -1,21 +1,35 @@
 package transformer
 
 import (
+	"database/sql"
+
+	"github.com/google/uuid"
+
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
 )
 
 func ToEntryModel(entry *entity.Entry) *model.EntryModel {
-	return &model.EntryModel{
-		ID:        entry.ID,
-		AccountID: entry.AccountID,
-		VolumeID:  entry.VolumeID,
-		Key:       entry.Key,
-		Size:      entry.Size,
-		Type:      entry.Type,
-		CreatedAt: entry.CreatedAt,
-		UpdatedAt: entry.UpdatedAt,
+	result := &model.EntryModel{
+		ID:              entry.ID,
+		AccountID:       entry.AccountID,
+		VolumeID:        entry.VolumeID,
+		ParentID:        uuid.NullUUID{UUID: entry.ParentID, Valid: entry.ParentID != uuid.Nil},
+		Name:            entry.Name(),
+		Key:             entry.Key,
+		Size:            entry.Size,
+		Type:            entry.Type,
+		Encoding:        entry.Encoding,
+		EncryptionKeyID: entry.EncryptionKeyID,
+		ScanStatus:      entry.ScanStatus,
+		ScanSignature:   entry.ScanSignature,
+		CreatedAt:       entry.CreatedAt,
+		UpdatedAt:       entry.UpdatedAt,
+	}
+	if entry.ScannedAt != nil {
+		result.ScannedAt = sql.NullTime{Time: *entry.ScannedAt, Valid: true}
 	}
+	return result
 }
 
 func ToEntryEntity(entry *model.EntryModel) *entity.Entry {
@@ -23,9 +37,15 @@ func ToEntryEntity(entry *model.EntryModel) *entity.Entry {
 		entry.ID,
 		entry.AccountID,
 		entry.VolumeID,
+		entry.ParentID.UUID,
 		entry.Key,
 		entry.Size,
 		entry.Type,
+		entry.Encoding,
+		entry.EncryptionKeyID,
+		entry.ScanStatus,
+		entry.ScanSignature,
+		nullable(entry.ScannedAt.Time, entry.ScannedAt.Valid),
 		entry.CreatedAt,
 		entry.UpdatedAt,
 	)
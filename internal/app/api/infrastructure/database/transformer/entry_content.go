@@ -0,0 +1,25 @@
+package transformer
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToEntryContentModel(content *entity.EntryContent) *model.EntryContentModel {
+	return &model.EntryContentModel{
+		EntryID: content.EntryID,
+		Content: content.Content,
+	}
+}
+
+func ToEntryContentMatchEntity(match *model.EntryContentMatchModel) *entity.EntryContentMatch {
+	return entity.RestoreEntryContentMatch(match.EntryID, match.Snippet)
+}
+
+func ToEntryContentMatchEntities(matches []*model.EntryContentMatchModel) []*entity.EntryContentMatch {
+	entities := make([]*entity.EntryContentMatch, len(matches))
+	for i, match := range matches {
+		entities[i] = ToEntryContentMatchEntity(match)
+	}
+	return entities
+}
@@ -0,0 +1,64 @@
+package transformer
+
+import (
+	"database/sql"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToEntryMetadataModel(metadata *entity.EntryMetadata) *model.EntryMetadataModel {
+	result := &model.EntryMetadataModel{
+		EntryID:     metadata.EntryID,
+		Width:       metadata.Width,
+		Height:      metadata.Height,
+		CameraMake:  metadata.CameraMake,
+		CameraModel: metadata.CameraModel,
+		PageCount:   metadata.PageCount,
+		Duration:    metadata.Duration,
+		Title:       metadata.Title,
+		Artist:      metadata.Artist,
+		Album:       metadata.Album,
+	}
+	if metadata.TakenAt != nil {
+		result.TakenAt = sql.NullTime{Time: *metadata.TakenAt, Valid: true}
+	}
+	if metadata.Latitude != nil && metadata.Longitude != nil {
+		result.Latitude = sql.NullFloat64{Float64: *metadata.Latitude, Valid: true}
+		result.Longitude = sql.NullFloat64{Float64: *metadata.Longitude, Valid: true}
+	}
+	return result
+}
+
+func ToEntryMetadataEntity(metadata *model.EntryMetadataModel) *entity.EntryMetadata {
+	return entity.RestoreEntryMetadata(
+		metadata.EntryID,
+		metadata.Width,
+		metadata.Height,
+		nullable(metadata.TakenAt.Time, metadata.TakenAt.Valid),
+		metadata.CameraMake,
+		metadata.CameraModel,
+		nullable(metadata.Latitude.Float64, metadata.Latitude.Valid),
+		nullable(metadata.Longitude.Float64, metadata.Longitude.Valid),
+		metadata.PageCount,
+		metadata.Duration,
+		metadata.Title,
+		metadata.Artist,
+		metadata.Album,
+	)
+}
+
+func ToEntryMetadataEntities(metadata []*model.EntryMetadataModel) []*entity.EntryMetadata {
+	entities := make([]*entity.EntryMetadata, len(metadata))
+	for i, m := range metadata {
+		entities[i] = ToEntryMetadataEntity(m)
+	}
+	return entities
+}
+
+func nullable[T any](value T, valid bool) *T {
+	if !valid {
+		return nil
+	}
+	return &value
+}
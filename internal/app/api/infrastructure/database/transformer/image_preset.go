@@ -0,0 +1,56 @@
+package transformer
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToImagePresetModel(preset *entity.ImagePreset) *model.ImagePresetModel {
+	return &model.ImagePresetModel{
+		ID:         preset.ID,
+		AccountID:  preset.AccountID,
+		VolumeID:   preset.VolumeID,
+		Name:       preset.Name,
+		Width:      preset.Transformation.Width,
+		Height:     preset.Transformation.Height,
+		Fit:        preset.Transformation.Fit,
+		CropX:      preset.Transformation.CropX,
+		CropY:      preset.Transformation.CropY,
+		CropWidth:  preset.Transformation.CropWidth,
+		CropHeight: preset.Transformation.CropHeight,
+		Quality:    preset.Transformation.Quality,
+		Format:     preset.Transformation.Format,
+		CreatedAt:  preset.CreatedAt,
+		UpdatedAt:  preset.UpdatedAt,
+	}
+}
+
+func ToImagePresetEntity(preset *model.ImagePresetModel) *entity.ImagePreset {
+	return entity.RestoreImagePreset(
+		preset.ID,
+		preset.AccountID,
+		preset.VolumeID,
+		preset.Name,
+		entity.RestoreImageTransformation(
+			preset.Width,
+			preset.Height,
+			preset.Fit,
+			preset.CropX,
+			preset.CropY,
+			preset.CropWidth,
+			preset.CropHeight,
+			preset.Quality,
+			preset.Format,
+		),
+		preset.CreatedAt,
+		preset.UpdatedAt,
+	)
+}
+
+func ToImagePresetEntities(presets []*model.ImagePresetModel) []*entity.ImagePreset {
+	entities := make([]*entity.ImagePreset, len(presets))
+	for i, preset := range presets {
+		entities[i] = ToImagePresetEntity(preset)
+	}
+	return entities
+}
@@ -0,0 +1,54 @@
+package transformer
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToJobModel(job *entity.Job) *model.JobModel {
+	return &model.JobModel{
+		ID:               job.ID,
+		AccountID:        job.AccountID,
+		Type:             job.Type,
+		Status:           job.Status,
+		VolumeName:       job.VolumeName,
+		Key:              job.Key,
+		NewVolumeName:    job.NewVolumeName,
+		NewKey:           job.NewKey,
+		Conflict:         job.Conflict,
+		ResultKey:        job.ResultKey,
+		Error:            job.Error,
+		TotalEntries:     job.TotalEntries,
+		ProcessedEntries: job.ProcessedEntries,
+		TotalBytes:       job.TotalBytes,
+		ProcessedBytes:   job.ProcessedBytes,
+		Attempts:         job.Attempts,
+		RunAt:            job.RunAt,
+		CreatedAt:        job.CreatedAt,
+		UpdatedAt:        job.UpdatedAt,
+	}
+}
+
+func ToJobEntity(job *model.JobModel) *entity.Job {
+	return entity.RestoreJob(
+		job.ID,
+		job.AccountID,
+		job.Type,
+		job.Status,
+		job.VolumeName,
+		job.Key,
+		job.NewVolumeName,
+		job.NewKey,
+		job.Conflict,
+		job.ResultKey,
+		job.Error,
+		job.TotalEntries,
+		job.ProcessedEntries,
+		job.TotalBytes,
+		job.ProcessedBytes,
+		job.Attempts,
+		job.RunAt,
+		job.CreatedAt,
+		job.UpdatedAt,
+	)
+}
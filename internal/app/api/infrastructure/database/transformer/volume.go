@@ -1,18 +1,37 @@
 package transformer
 
 import (
+	"strings"
+
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
 )
 
+// NOTE: ポリシーの種別と拡張子はカンマ区切りで保存し, 指定がない場合は空文字とする.
 func ToVolumeModel(volume *entity.Volume) *model.VolumeModel {
+	policy := volume.Policy
+	if policy == nil {
+		policy = &entity.VolumePolicy{}
+	}
 	return &model.VolumeModel{
-		ID:        volume.ID,
-		AccountID: volume.AccountID,
-		Name:      volume.Name,
-		IsPublic:  volume.IsPublic,
-		CreatedAt: volume.CreatedAt,
-		UpdatedAt: volume.UpdatedAt,
+		ID:                  volume.ID,
+		AccountID:           volume.AccountID,
+		Name:                volume.Name,
+		IsPublic:            volume.IsPublic,
+		Compression:         volume.Compression,
+		MaxFileSize:         policy.MaxFileSize,
+		AllowedTypes:        strings.Join(policy.AllowedTypes, ","),
+		DeniedTypes:         strings.Join(policy.DeniedTypes, ","),
+		AllowedExtensions:   strings.Join(policy.AllowedExtensions, ","),
+		DeniedExtensions:    strings.Join(policy.DeniedExtensions, ","),
+		MaxKeyDepth:         policy.MaxKeyDepth,
+		MaxEntriesPerFolder: policy.MaxEntriesPerFolder,
+		MaxVolumeSize:       policy.MaxVolumeSize,
+		MaxVolumeEntries:    policy.MaxVolumeEntries,
+		IsDroppable:         volume.Drop != nil,
+		DropPrefix:          dropPrefix(volume.Drop),
+		CreatedAt:           volume.CreatedAt,
+		UpdatedAt:           volume.UpdatedAt,
 	}
 }
 
@@ -22,6 +41,19 @@ func ToVolumeEntity(volume *model.VolumeModel) *entity.Volume {
 		volume.AccountID,
 		volume.Name,
 		volume.IsPublic,
+		volume.Compression,
+		entity.RestoreVolumePolicy(
+			volume.MaxFileSize,
+			splitList(volume.AllowedTypes),
+			splitList(volume.DeniedTypes),
+			splitList(volume.AllowedExtensions),
+			splitList(volume.DeniedExtensions),
+			volume.MaxKeyDepth,
+			volume.MaxEntriesPerFolder,
+			volume.MaxVolumeSize,
+			volume.MaxVolumeEntries,
+		),
+		toVolumeDropEntity(volume),
 		volume.CreatedAt,
 		volume.UpdatedAt,
 	)
@@ -34,3 +66,24 @@ func ToVolumeEntities(volumes []*model.VolumeModel) []*entity.Volume {
 	}
 	return entities
 }
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func dropPrefix(drop *entity.VolumeDrop) string {
+	if drop == nil {
+		return ""
+	}
+	return drop.Prefix
+}
+
+func toVolumeDropEntity(volume *model.VolumeModel) *entity.VolumeDrop {
+	if !volume.IsDroppable {
+		return nil
+	}
+	return entity.RestoreVolumeDrop(volume.DropPrefix)
+}
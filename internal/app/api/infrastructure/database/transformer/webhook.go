@@ -0,0 +1,49 @@
+package transformer
+
+import (
+	"strings"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+// NOTE: イベントの種別はカンマ区切りで保存し, 指定がない場合は空文字とする.
+func ToWebhookModel(webhook *entity.Webhook) *model.WebhookModel {
+	return &model.WebhookModel{
+		ID:        webhook.ID,
+		AccountID: webhook.AccountID,
+		VolumeID:  webhook.VolumeID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    strings.Join(webhook.Events, ","),
+		Prefix:    webhook.Prefix,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func ToWebhookEntity(webhook *model.WebhookModel) *entity.Webhook {
+	var events []string
+	if webhook.Events != "" {
+		events = strings.Split(webhook.Events, ",")
+	}
+	return entity.RestoreWebhook(
+		webhook.ID,
+		webhook.AccountID,
+		webhook.VolumeID,
+		webhook.URL,
+		webhook.Secret,
+		events,
+		webhook.Prefix,
+		webhook.CreatedAt,
+		webhook.UpdatedAt,
+	)
+}
+
+func ToWebhookEntities(webhooks []*model.WebhookModel) []*entity.Webhook {
+	entities := make([]*entity.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		entities[i] = ToWebhookEntity(webhook)
+	}
+	return entities
+}
@@ -0,0 +1,52 @@
+package transformer
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+)
+
+func ToWebhookDeliveryModel(delivery *entity.WebhookDelivery) *model.WebhookDeliveryModel {
+	return &model.WebhookDeliveryModel{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		URL:            delivery.URL,
+		Payload:        delivery.Payload,
+		Secret:         delivery.Secret,
+		Status:         delivery.Status,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		Attempts:       delivery.Attempts,
+		RunAt:          delivery.RunAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func ToWebhookDeliveryEntity(delivery *model.WebhookDeliveryModel) *entity.WebhookDelivery {
+	return entity.RestoreWebhookDelivery(
+		delivery.ID,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.EventType,
+		delivery.URL,
+		delivery.Payload,
+		delivery.Secret,
+		delivery.Status,
+		delivery.ResponseStatus,
+		delivery.Error,
+		delivery.Attempts,
+		delivery.RunAt,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	)
+}
+
+func ToWebhookDeliveryEntities(deliveries []*model.WebhookDeliveryModel) []*entity.WebhookDelivery {
+	entities := make([]*entity.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		entities[i] = ToWebhookDeliveryEntity(delivery)
+	}
+	return entities
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
 )
 
+const volumeColumns = "id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at"
+
 var ErrRequiredVolume = status.Error(code.Internal, "volume is required")
 
 type volumeRepository struct {
@@ -36,7 +38,7 @@ func (r *volumeRepository) Create(ctx context.Context, volume *entity.Volume) er
 
 	driver := transaction.GetDriver(ctx, r.db)
 	model := transformer.ToVolumeModel(volume)
-	_, err := driver.NamedExecContext(ctx, "INSERT INTO volumes (id, account_id, name, is_public, created_at, updated_at) VALUES (:id, :account_id, :name, :is_public, :created_at, :updated_at);", model)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO volumes (id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at) VALUES (:id, :account_id, :name, :is_public, :compression, :max_file_size, :allowed_types, :denied_types, :allowed_extensions, :denied_extensions, :max_key_depth, :max_entries_per_folder, :max_volume_size, :max_volume_entries, :is_droppable, :drop_prefix, :created_at, :updated_at);", model)
 	return err
 }
 
@@ -47,7 +49,7 @@ func (r *volumeRepository) Update(ctx context.Context, volume *entity.Volume) er
 
 	driver := transaction.GetDriver(ctx, r.db)
 	model := transformer.ToVolumeModel(volume)
-	_, err := driver.NamedExecContext(ctx, "UPDATE volumes SET account_id = :account_id, name = :name, is_public = :is_public, updated_at = :updated_at WHERE id = :id LIMIT 1;", model)
+	_, err := driver.NamedExecContext(ctx, "UPDATE volumes SET account_id = :account_id, name = :name, is_public = :is_public, compression = :compression, max_file_size = :max_file_size, allowed_types = :allowed_types, denied_types = :denied_types, allowed_extensions = :allowed_extensions, denied_extensions = :denied_extensions, max_key_depth = :max_key_depth, max_entries_per_folder = :max_entries_per_folder, max_volume_size = :max_volume_size, max_volume_entries = :max_volume_entries, is_droppable = :is_droppable, drop_prefix = :drop_prefix, updated_at = :updated_at WHERE id = :id LIMIT 1;", model)
 	return err
 }
 
@@ -62,10 +64,10 @@ func (r *volumeRepository) Delete(ctx context.Context, volume *entity.Volume) er
 	return err
 }
 
-func (r *volumeRepository) FindOneByName(ctx context.Context, name string) (*entity.Volume, error) {
+func (r *volumeRepository) FindOneByNameAndAccountID(ctx context.Context, name string, accountID uuid.UUID) (*entity.Volume, error) {
 	driver := transaction.GetDriver(ctx, r.db)
 	var model model.VolumeModel
-	if err := driver.QueryRowxContext(ctx, `SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? LIMIT 1;`, name).StructScan(&model); err != nil {
+	if err := driver.QueryRowxContext(ctx, "SELECT "+volumeColumns+" FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;", name, accountID).StructScan(&model); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrVolumeNotFound
 		}
@@ -74,10 +76,10 @@ func (r *volumeRepository) FindOneByName(ctx context.Context, name string) (*ent
 	return transformer.ToVolumeEntity(&model), nil
 }
 
-func (r *volumeRepository) FindOneByNameAndAccountID(ctx context.Context, name string, accountID uuid.UUID) (*entity.Volume, error) {
+func (r *volumeRepository) FindOneByIDAndAccountID(ctx context.Context, id, accountID uuid.UUID) (*entity.Volume, error) {
 	driver := transaction.GetDriver(ctx, r.db)
 	var model model.VolumeModel
-	if err := driver.QueryRowxContext(ctx, `SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`, name, accountID).StructScan(&model); err != nil {
+	if err := driver.QueryRowxContext(ctx, "SELECT "+volumeColumns+" FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;", id, accountID).StructScan(&model); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrVolumeNotFound
 		}
@@ -86,21 +88,51 @@ func (r *volumeRepository) FindOneByNameAndAccountID(ctx context.Context, name s
 	return transformer.ToVolumeEntity(&model), nil
 }
 
-func (r *volumeRepository) FindOneByIDAndAccountID(ctx context.Context, id, accountID uuid.UUID) (*entity.Volume, error) {
+func (r *volumeRepository) FindByAccountID(ctx context.Context, accountID uuid.UUID) (volumes []*entity.Volume, err error) {
 	driver := transaction.GetDriver(ctx, r.db)
-	var model model.VolumeModel
-	if err := driver.QueryRowxContext(ctx, `SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`, id, accountID).StructScan(&model); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, repository.ErrVolumeNotFound
+	rows, err := driver.QueryxContext(ctx, "SELECT "+volumeColumns+" FROM volumes WHERE account_id = ?;", accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.VolumeModel
+	for rows.Next() {
+		var model model.VolumeModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
 		}
+		models = append(models, &model)
+	}
+	return transformer.ToVolumeEntities(models), nil
+}
+
+func (r *volumeRepository) FindByName(ctx context.Context, name string) (volumes []*entity.Volume, err error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	rows, err := driver.QueryxContext(ctx, "SELECT "+volumeColumns+" FROM volumes WHERE name = ?;", name)
+	if err != nil {
 		return nil, err
 	}
-	return transformer.ToVolumeEntity(&model), nil
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.VolumeModel
+	for rows.Next() {
+		var model model.VolumeModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return transformer.ToVolumeEntities(models), nil
 }
 
-func (r *volumeRepository) FindByAccountID(ctx context.Context, accountID uuid.UUID) (volumes []*entity.Volume, err error) {
+func (r *volumeRepository) FindAll(ctx context.Context) (volumes []*entity.Volume, err error) {
 	driver := transaction.GetDriver(ctx, r.db)
-	rows, err := driver.QueryxContext(ctx, `SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE account_id = ?;`, accountID)
+	rows, err := driver.QueryxContext(ctx, "SELECT "+volumeColumns+" FROM volumes;")
 	if err != nil {
 		return nil, err
 	}
@@ -23,6 +23,8 @@ func TestVolume_Create(t *testing.T) {
 		AccountID: uuid.New(),
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{MaxFileSize: 1024, AllowedTypes: []string{"image/*", "text/plain"}, DeniedExtensions: []string{"exe"}, MaxKeyDepth: 4, MaxEntriesPerFolder: 100, MaxVolumeSize: 1 << 30, MaxVolumeEntries: 10000},
+		Drop:      &entity.VolumeDrop{Prefix: "inbox"},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -38,8 +40,8 @@ func TestVolume_Create(t *testing.T) {
 			inputVolume: volume,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO volumes (id, account_id, name, is_public, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?);`)).
-					WithArgs(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.CreatedAt, volume.UpdatedAt).
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO volumes (id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`)).
+					WithArgs(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, volume.Policy.MaxFileSize, "image/*,text/plain", "", "", "exe", volume.Policy.MaxKeyDepth, volume.Policy.MaxEntriesPerFolder, volume.Policy.MaxVolumeSize, volume.Policy.MaxVolumeEntries, true, "inbox", volume.CreatedAt, volume.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
 			},
@@ -55,8 +57,8 @@ func TestVolume_Create(t *testing.T) {
 			inputVolume: volume,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO volumes (id, account_id, name, is_public, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?);`)).
-					WithArgs(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.CreatedAt, volume.UpdatedAt).
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO volumes (id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`)).
+					WithArgs(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, volume.Policy.MaxFileSize, "image/*,text/plain", "", "", "exe", volume.Policy.MaxKeyDepth, volume.Policy.MaxEntriesPerFolder, volume.Policy.MaxVolumeSize, volume.Policy.MaxVolumeEntries, true, "inbox", volume.CreatedAt, volume.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -87,6 +89,7 @@ func TestVolume_Update(t *testing.T) {
 		AccountID: uuid.New(),
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -102,8 +105,8 @@ func TestVolume_Update(t *testing.T) {
 			inputVolume: volume,
 			expectError: nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE volumes SET account_id = ?, name = ?, is_public = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
-					WithArgs(volume.AccountID, volume.Name, volume.IsPublic, volume.UpdatedAt, volume.ID).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE volumes SET account_id = ?, name = ?, is_public = ?, compression = ?, max_file_size = ?, allowed_types = ?, denied_types = ?, allowed_extensions = ?, denied_extensions = ?, max_key_depth = ?, max_entries_per_folder = ?, max_volume_size = ?, max_volume_entries = ?, is_droppable = ?, drop_prefix = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.UpdatedAt, volume.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(nil)
 			},
@@ -119,8 +122,8 @@ func TestVolume_Update(t *testing.T) {
 			inputVolume: volume,
 			expectError: sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE volumes SET account_id = ?, name = ?, is_public = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
-					WithArgs(volume.AccountID, volume.Name, volume.IsPublic, volume.UpdatedAt, volume.ID).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE volumes SET account_id = ?, name = ?, is_public = ?, compression = ?, max_file_size = ?, allowed_types = ?, denied_types = ?, allowed_extensions = ?, denied_extensions = ?, max_key_depth = ?, max_entries_per_folder = ?, max_volume_size = ?, max_volume_entries = ?, is_droppable = ?, drop_prefix = ?, updated_at = ? WHERE id = ? LIMIT 1;`)).
+					WithArgs(volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.UpdatedAt, volume.ID).
 					WillReturnResult(sqlmock.NewResult(1, 1)).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -151,6 +154,7 @@ func TestVolume_Delete(t *testing.T) {
 		AccountID: uuid.New(),
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -209,57 +213,62 @@ func TestVolume_Delete(t *testing.T) {
 	}
 }
 
-func TestVolume_FindOneByName(t *testing.T) {
+func TestVolume_FindOneByNameAndAccountID(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	tests := []struct {
-		name         string
-		inputName    string
-		expectResult *entity.Volume
-		expectError  error
-		setMockDB    func(mock sqlmock.Sqlmock)
+		name           string
+		inputName      string
+		inputAccountID uuid.UUID
+		expectResult   *entity.Volume
+		expectError    error
+		setMockDB      func(mock sqlmock.Sqlmock)
 	}{
 		{
-			name:         "successfully found",
-			inputName:    "name",
-			expectResult: volume,
-			expectError:  nil,
+			name:           "successfully found",
+			inputName:      "name",
+			inputAccountID: accountID,
+			expectResult:   volume,
+			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? LIMIT 1;`)).
-					WithArgs("name").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.CreatedAt, volume.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`)).
+					WithArgs("name", accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.CreatedAt, volume.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
 		{
-			name:         "not found",
-			inputName:    "name",
-			expectResult: nil,
-			expectError:  repository.ErrVolumeNotFound,
+			name:           "not found",
+			inputName:      "name",
+			inputAccountID: accountID,
+			expectResult:   nil,
+			expectError:    repository.ErrVolumeNotFound,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? LIMIT 1;`)).
-					WithArgs("name").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`)).
+					WithArgs("name", accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(nil)
 			},
 		},
 		{
-			name:         "find error",
-			inputName:    "name",
-			expectResult: nil,
-			expectError:  sql.ErrConnDone,
+			name:           "find error",
+			inputName:      "name",
+			inputAccountID: accountID,
+			expectResult:   nil,
+			expectError:    sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? LIMIT 1;`)).
-					WithArgs("name").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`)).
+					WithArgs("name", accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -271,7 +280,7 @@ func TestVolume_FindOneByName(t *testing.T) {
 			tt.setMockDB(mock)
 
 			repo := database.NewVolumeRepository(db)
-			result, err := repo.FindOneByName(t.Context(), tt.inputName)
+			result, err := repo.FindOneByNameAndAccountID(t.Context(), tt.inputName, tt.inputAccountID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -287,20 +296,22 @@ func TestVolume_FindOneByName(t *testing.T) {
 	}
 }
 
-func TestVolume_FindOneByNameAndAccountID(t *testing.T) {
+func TestVolume_FindOneByIDAndAccountID(t *testing.T) {
+	id := uuid.New()
 	accountID := uuid.New()
 	volume := &entity.Volume{
-		ID:        uuid.New(),
+		ID:        id,
 		AccountID: accountID,
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	tests := []struct {
 		name           string
-		inputName      string
+		inputID        uuid.UUID
 		inputAccountID uuid.UUID
 		expectResult   *entity.Volume
 		expectError    error
@@ -308,40 +319,40 @@ func TestVolume_FindOneByNameAndAccountID(t *testing.T) {
 	}{
 		{
 			name:           "successfully found",
-			inputName:      "name",
+			inputID:        id,
 			inputAccountID: accountID,
 			expectResult:   volume,
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`)).
-					WithArgs("name", accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.CreatedAt, volume.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`)).
+					WithArgs(id, accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.CreatedAt, volume.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
 		{
 			name:           "not found",
-			inputName:      "name",
+			inputID:        id,
 			inputAccountID: accountID,
 			expectResult:   nil,
 			expectError:    repository.ErrVolumeNotFound,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`)).
-					WithArgs("name", accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`)).
+					WithArgs(id, accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(nil)
 			},
 		},
 		{
 			name:           "find error",
-			inputName:      "name",
+			inputID:        id,
 			inputAccountID: accountID,
 			expectResult:   nil,
 			expectError:    sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE name = ? AND account_id = ? LIMIT 1;`)).
-					WithArgs("name", accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`)).
+					WithArgs(id, accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -353,7 +364,7 @@ func TestVolume_FindOneByNameAndAccountID(t *testing.T) {
 			tt.setMockDB(mock)
 
 			repo := database.NewVolumeRepository(db)
-			result, err := repo.FindOneByNameAndAccountID(t.Context(), tt.inputName, tt.inputAccountID)
+			result, err := repo.FindOneByIDAndAccountID(t.Context(), tt.inputID, tt.inputAccountID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -369,62 +380,58 @@ func TestVolume_FindOneByNameAndAccountID(t *testing.T) {
 	}
 }
 
-func TestVolume_FindOneByIDAndAccountID(t *testing.T) {
-	id := uuid.New()
+func TestVolume_FindByAccountID(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
-		ID:        id,
+		ID:        uuid.New(),
 		AccountID: accountID,
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	tests := []struct {
 		name           string
-		inputID        uuid.UUID
 		inputAccountID uuid.UUID
-		expectResult   *entity.Volume
+		expectResult   []*entity.Volume
 		expectError    error
 		setMockDB      func(mock sqlmock.Sqlmock)
 	}{
 		{
 			name:           "successfully found",
-			inputID:        id,
 			inputAccountID: accountID,
-			expectResult:   volume,
+			expectResult:   []*entity.Volume{volume},
 			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`)).
-					WithArgs(id, accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.CreatedAt, volume.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE account_id = ?;`)).
+					WithArgs(accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.CreatedAt, volume.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
 		{
 			name:           "not found",
-			inputID:        id,
 			inputAccountID: accountID,
-			expectResult:   nil,
-			expectError:    repository.ErrVolumeNotFound,
+			expectResult:   []*entity.Volume{},
+			expectError:    nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`)).
-					WithArgs(id, accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE account_id = ?;`)).
+					WithArgs(accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(nil)
 			},
 		},
 		{
 			name:           "find error",
-			inputID:        id,
 			inputAccountID: accountID,
 			expectResult:   nil,
 			expectError:    sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE id = ? AND account_id = ? LIMIT 1;`)).
-					WithArgs(id, accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE account_id = ?;`)).
+					WithArgs(accountID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -436,7 +443,7 @@ func TestVolume_FindOneByIDAndAccountID(t *testing.T) {
 			tt.setMockDB(mock)
 
 			repo := database.NewVolumeRepository(db)
-			result, err := repo.FindOneByIDAndAccountID(t.Context(), tt.inputID, tt.inputAccountID)
+			result, err := repo.FindByAccountID(t.Context(), tt.inputAccountID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -452,57 +459,58 @@ func TestVolume_FindOneByIDAndAccountID(t *testing.T) {
 	}
 }
 
-func TestVolume_FindByAccountID(t *testing.T) {
+func TestVolume_FindByName(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	tests := []struct {
-		name           string
-		inputAccountID uuid.UUID
-		expectResult   []*entity.Volume
-		expectError    error
-		setMockDB      func(mock sqlmock.Sqlmock)
+		name         string
+		inputName    string
+		expectResult []*entity.Volume
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
 	}{
 		{
-			name:           "successfully found",
-			inputAccountID: accountID,
-			expectResult:   []*entity.Volume{volume},
-			expectError:    nil,
+			name:         "successfully found",
+			inputName:    "name",
+			expectResult: []*entity.Volume{volume},
+			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE account_id = ?;`)).
-					WithArgs(accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.CreatedAt, volume.UpdatedAt)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE name = ?;`)).
+					WithArgs("name").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.CreatedAt, volume.UpdatedAt)).
 					WillReturnError(nil)
 			},
 		},
 		{
-			name:           "not found",
-			inputAccountID: accountID,
-			expectResult:   []*entity.Volume{},
-			expectError:    nil,
+			name:         "not found",
+			inputName:    "name",
+			expectResult: []*entity.Volume{},
+			expectError:  nil,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE account_id = ?;`)).
-					WithArgs(accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE name = ?;`)).
+					WithArgs("name").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(nil)
 			},
 		},
 		{
-			name:           "find error",
-			inputAccountID: accountID,
-			expectResult:   nil,
-			expectError:    sql.ErrConnDone,
+			name:         "find error",
+			inputName:    "name",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
 			setMockDB: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, created_at, updated_at FROM volumes WHERE account_id = ?;`)).
-					WithArgs(accountID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "created_at", "updated_at"})).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes WHERE name = ?;`)).
+					WithArgs("name").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
 					WillReturnError(sql.ErrConnDone)
 			},
 		},
@@ -514,7 +522,78 @@ func TestVolume_FindByAccountID(t *testing.T) {
 			tt.setMockDB(mock)
 
 			repo := database.NewVolumeRepository(db)
-			result, err := repo.FindByAccountID(t.Context(), tt.inputAccountID)
+			result, err := repo.FindByName(t.Context(), tt.inputName)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestVolume_FindAll(t *testing.T) {
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		Name:      "name",
+		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult []*entity.Volume
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: []*entity.Volume{volume},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes;`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"}).AddRow(volume.ID, volume.AccountID, volume.Name, volume.IsPublic, volume.Compression, 0, "", "", "", "", 0, 0, 0, 0, false, "", volume.CreatedAt, volume.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: []*entity.Volume{},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes;`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, account_id, name, is_public, compression, max_file_size, allowed_types, denied_types, allowed_extensions, denied_extensions, max_key_depth, max_entries_per_folder, max_volume_size, max_volume_entries, is_droppable, drop_prefix, created_at, updated_at FROM volumes;`)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "name", "is_public", "compression", "max_file_size", "allowed_types", "denied_types", "allowed_extensions", "denied_extensions", "max_key_depth", "max_entries_per_folder", "max_volume_size", "max_volume_entries", "is_droppable", "drop_prefix", "created_at", "updated_at"})).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+
+			tt.setMockDB(mock)
+
+			repo := database.NewVolumeRepository(db)
+			result, err := repo.FindAll(t.Context())
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
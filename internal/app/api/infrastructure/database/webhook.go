@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredWebhook = status.Error(code.Internal, "webhook is required")
+
+type webhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) repository.WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	if webhook == nil {
+		return ErrRequiredWebhook
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToWebhookModel(webhook)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO webhooks (id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at) VALUES (:id, :account_id, :volume_id, :url, :secret, :events, :prefix, :created_at, :updated_at);", model)
+	return err
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, webhook *entity.Webhook) error {
+	if webhook == nil {
+		return ErrRequiredWebhook
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToWebhookModel(webhook)
+	_, err := driver.NamedExecContext(ctx, "DELETE FROM webhooks WHERE id = :id LIMIT 1;", model)
+	return err
+}
+
+func (r *webhookRepository) FindOneByIDAndVolumeIDAndAccountID(ctx context.Context, id, volumeID, accountID uuid.UUID) (*entity.Webhook, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.WebhookModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;", id, volumeID, accountID).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToWebhookEntity(&model), nil
+}
+
+func (r *webhookRepository) FindByVolumeID(ctx context.Context, volumeID uuid.UUID) (webhooks []*entity.Webhook, err error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	rows, err := driver.QueryxContext(ctx, "SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE volume_id = ? ORDER BY created_at;", volumeID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.WebhookModel
+	for rows.Next() {
+		var model model.WebhookModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return transformer.ToWebhookEntities(models), nil
+}
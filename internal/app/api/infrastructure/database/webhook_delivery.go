@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/model"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/transformer"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrRequiredWebhookDelivery = status.Error(code.Internal, "webhook delivery is required")
+
+type webhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookDeliveryRepository(db *sqlx.DB) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if delivery == nil {
+		return ErrRequiredWebhookDelivery
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToWebhookDeliveryModel(delivery)
+	_, err := driver.NamedExecContext(ctx, "INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at) VALUES (:id, :webhook_id, :event_id, :event_type, :url, :payload, :secret, :status, :response_status, :error, :attempts, :run_at, :created_at, :updated_at);", model)
+	return err
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if delivery == nil {
+		return ErrRequiredWebhookDelivery
+	}
+
+	driver := transaction.GetDriver(ctx, r.db)
+	model := transformer.ToWebhookDeliveryModel(delivery)
+	_, err := driver.NamedExecContext(ctx, "UPDATE webhook_deliveries SET status = :status, response_status = :response_status, error = :error, attempts = :attempts, run_at = :run_at, updated_at = :updated_at WHERE id = :id LIMIT 1;", model)
+	return err
+}
+
+// NOTE: 送信待ちの配信に加え, 送信中のまま更新が途絶えた配信も再送の対象とする.
+func (r *webhookDeliveryRepository) FindOneRunnable(ctx context.Context, staleBefore time.Time) (*entity.WebhookDelivery, error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	var model model.WebhookDeliveryModel
+	if err := driver.QueryRowxContext(ctx, "SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;", entity.WebhookDeliveryStatusPending, time.Now(), entity.WebhookDeliveryStatusRunning, staleBefore).StructScan(&model); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return transformer.ToWebhookDeliveryEntity(&model), nil
+}
+
+func (r *webhookDeliveryRepository) FindByWebhookID(ctx context.Context, webhookID uuid.UUID, limit uint64) (deliveries []*entity.WebhookDelivery, err error) {
+	driver := transaction.GetDriver(ctx, r.db)
+	rows, err := driver.QueryxContext(ctx, "SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC LIMIT ?;", webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = rows.Close()
+	}()
+
+	var models []*model.WebhookDeliveryModel
+	for rows.Next() {
+		var model model.WebhookDeliveryModel
+		if err := rows.StructScan(&model); err != nil {
+			return nil, err
+		}
+		models = append(models, &model)
+	}
+	return transformer.ToWebhookDeliveryEntities(models), nil
+}
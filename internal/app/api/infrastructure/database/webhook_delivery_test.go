@@ -0,0 +1,281 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var webhookDeliveryColumns = []string{"id", "webhook_id", "event_id", "event_type", "url", "payload", "secret", "status", "response_status", "error", "attempts", "run_at", "created_at", "updated_at"}
+
+func newWebhookDelivery() *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: uuid.New(),
+		EventID:   uuid.New(),
+		EventType: entity.EventTypeEntryCreated,
+		URL:       "https://example.com/webhook",
+		Payload:   []byte(`{"type":"entry.created"}`),
+		Secret:    "secret",
+		Status:    entity.WebhookDeliveryStatusPending,
+		RunAt:     time.Now(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func newWebhookDeliveryRows(delivery *entity.WebhookDelivery) *sqlmock.Rows {
+	return sqlmock.NewRows(webhookDeliveryColumns).AddRow(delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.URL, delivery.Payload, delivery.Secret, delivery.Status, delivery.ResponseStatus, delivery.Error, delivery.Attempts, delivery.RunAt, delivery.CreatedAt, delivery.UpdatedAt)
+}
+
+func TestWebhookDelivery_Create(t *testing.T) {
+	delivery := newWebhookDelivery()
+
+	tests := []struct {
+		name          string
+		inputDelivery *entity.WebhookDelivery
+		expectError   error
+		setMockDB     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "successfully inserted",
+			inputDelivery: delivery,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.URL, delivery.Payload, delivery.Secret, delivery.Status, delivery.ResponseStatus, delivery.Error, delivery.Attempts, delivery.RunAt, delivery.CreatedAt, delivery.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "delivery is nil",
+			inputDelivery: nil,
+			expectError:   database.ErrRequiredWebhookDelivery,
+			setMockDB:     func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:          "insert error",
+			inputDelivery: delivery,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(delivery.ID, delivery.WebhookID, delivery.EventID, delivery.EventType, delivery.URL, delivery.Payload, delivery.Secret, delivery.Status, delivery.ResponseStatus, delivery.Error, delivery.Attempts, delivery.RunAt, delivery.CreatedAt, delivery.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookDeliveryRepository(db)
+			if err := repo.Create(t.Context(), tt.inputDelivery); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_Update(t *testing.T) {
+	delivery := newWebhookDelivery()
+	delivery.Status = entity.WebhookDeliveryStatusSucceeded
+	delivery.ResponseStatus = 200
+	delivery.Attempts = 1
+
+	tests := []struct {
+		name          string
+		inputDelivery *entity.WebhookDelivery
+		expectError   error
+		setMockDB     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:          "successfully updated",
+			inputDelivery: delivery,
+			expectError:   nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook_deliveries SET status = ?, response_status = ?, error = ?, attempts = ?, run_at = ?, updated_at = ? WHERE id = ? LIMIT 1;")).
+					WithArgs(delivery.Status, delivery.ResponseStatus, delivery.Error, delivery.Attempts, delivery.RunAt, delivery.UpdatedAt, delivery.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:          "delivery is nil",
+			inputDelivery: nil,
+			expectError:   database.ErrRequiredWebhookDelivery,
+			setMockDB:     func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:          "update error",
+			inputDelivery: delivery,
+			expectError:   sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE webhook_deliveries SET status = ?, response_status = ?, error = ?, attempts = ?, run_at = ?, updated_at = ? WHERE id = ? LIMIT 1;")).
+					WithArgs(delivery.Status, delivery.ResponseStatus, delivery.Error, delivery.Attempts, delivery.RunAt, delivery.UpdatedAt, delivery.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookDeliveryRepository(db)
+			if err := repo.Update(t.Context(), tt.inputDelivery); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_FindOneRunnable(t *testing.T) {
+	staleBefore := time.Now().Add(-time.Minute)
+	delivery := newWebhookDelivery()
+
+	tests := []struct {
+		name         string
+		expectResult *entity.WebhookDelivery
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: delivery,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;")).
+					WithArgs(entity.WebhookDeliveryStatusPending, sqlmock.AnyArg(), entity.WebhookDeliveryStatusRunning, staleBefore).
+					WillReturnRows(newWebhookDeliveryRows(delivery)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  repository.ErrWebhookDeliveryNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;")).
+					WithArgs(entity.WebhookDeliveryStatusPending, sqlmock.AnyArg(), entity.WebhookDeliveryStatusRunning, staleBefore).
+					WillReturnRows(sqlmock.NewRows(webhookDeliveryColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE (status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?) ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED;")).
+					WithArgs(entity.WebhookDeliveryStatusPending, sqlmock.AnyArg(), entity.WebhookDeliveryStatusRunning, staleBefore).
+					WillReturnRows(sqlmock.NewRows(webhookDeliveryColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookDeliveryRepository(db)
+			result, err := repo.FindOneRunnable(t.Context(), staleBefore)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery_FindByWebhookID(t *testing.T) {
+	delivery := newWebhookDelivery()
+
+	tests := []struct {
+		name         string
+		expectResult []*entity.WebhookDelivery
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: []*entity.WebhookDelivery{delivery},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC LIMIT ?;")).
+					WithArgs(delivery.WebhookID, uint64(100)).
+					WillReturnRows(newWebhookDeliveryRows(delivery)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, webhook_id, event_id, event_type, url, payload, secret, status, response_status, error, attempts, run_at, created_at, updated_at FROM webhook_deliveries WHERE webhook_id = ? ORDER BY created_at DESC LIMIT ?;")).
+					WithArgs(delivery.WebhookID, uint64(100)).
+					WillReturnRows(sqlmock.NewRows(webhookDeliveryColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookDeliveryRepository(db)
+			result, err := repo.FindByWebhookID(t.Context(), delivery.WebhookID, 100)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
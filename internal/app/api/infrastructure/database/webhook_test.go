@@ -0,0 +1,294 @@
+package database_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	mockDatabase "github.com/atsumarukun/holos-storage-api/test/mock/database"
+)
+
+var webhookColumns = []string{"id", "account_id", "volume_id", "url", "secret", "events", "prefix", "created_at", "updated_at"}
+
+func TestWebhook_Create(t *testing.T) {
+	webhook := &entity.Webhook{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		VolumeID:  uuid.New(),
+		URL:       "https://example.com/webhook",
+		Secret:    "secret",
+		Events:    []string{entity.EventTypeEntryCreated, entity.EventTypeEntryDeleted},
+		Prefix:    "key",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		inputWebhook *entity.Webhook
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully inserted",
+			inputWebhook: webhook,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhooks (id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(webhook.ID, webhook.AccountID, webhook.VolumeID, webhook.URL, webhook.Secret, "entry.created,entry.deleted", webhook.Prefix, webhook.CreatedAt, webhook.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "webhook is nil",
+			inputWebhook: nil,
+			expectError:  database.ErrRequiredWebhook,
+			setMockDB:    func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:         "insert error",
+			inputWebhook: webhook,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO webhooks (id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);")).
+					WithArgs(webhook.ID, webhook.AccountID, webhook.VolumeID, webhook.URL, webhook.Secret, "entry.created,entry.deleted", webhook.Prefix, webhook.CreatedAt, webhook.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookRepository(db)
+			if err := repo.Create(t.Context(), tt.inputWebhook); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhook_Delete(t *testing.T) {
+	webhook := &entity.Webhook{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		VolumeID:  uuid.New(),
+		URL:       "https://example.com/webhook",
+		Secret:    "secret",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		inputWebhook *entity.Webhook
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully deleted",
+			inputWebhook: webhook,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM webhooks WHERE id = ? LIMIT 1;")).
+					WithArgs(webhook.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "webhook is nil",
+			inputWebhook: nil,
+			expectError:  database.ErrRequiredWebhook,
+			setMockDB:    func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:         "delete error",
+			inputWebhook: webhook,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM webhooks WHERE id = ? LIMIT 1;")).
+					WithArgs(webhook.ID).
+					WillReturnResult(sqlmock.NewResult(1, 1)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookRepository(db)
+			if err := repo.Delete(t.Context(), tt.inputWebhook); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhook_FindOneByIDAndVolumeIDAndAccountID(t *testing.T) {
+	webhook := &entity.Webhook{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		VolumeID:  uuid.New(),
+		URL:       "https://example.com/webhook",
+		Secret:    "secret",
+		Events:    []string{entity.EventTypeEntryCreated},
+		Prefix:    "key",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult *entity.Webhook
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: webhook,
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(webhook.ID, webhook.VolumeID, webhook.AccountID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(webhook.ID, webhook.AccountID, webhook.VolumeID, webhook.URL, webhook.Secret, "entry.created", webhook.Prefix, webhook.CreatedAt, webhook.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "not found",
+			expectResult: nil,
+			expectError:  repository.ErrWebhookNotFound,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(webhook.ID, webhook.VolumeID, webhook.AccountID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE id = ? AND volume_id = ? AND account_id = ? LIMIT 1;")).
+					WithArgs(webhook.ID, webhook.VolumeID, webhook.AccountID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookRepository(db)
+			result, err := repo.FindOneByIDAndVolumeIDAndAccountID(t.Context(), webhook.ID, webhook.VolumeID, webhook.AccountID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestWebhook_FindByVolumeID(t *testing.T) {
+	volumeID := uuid.New()
+	webhook := &entity.Webhook{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		VolumeID:  volumeID,
+		URL:       "https://example.com/webhook",
+		Secret:    "secret",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name         string
+		expectResult []*entity.Webhook
+		expectError  error
+		setMockDB    func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:         "successfully found",
+			expectResult: []*entity.Webhook{webhook},
+			expectError:  nil,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE volume_id = ? ORDER BY created_at;")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns).AddRow(webhook.ID, webhook.AccountID, webhook.VolumeID, webhook.URL, webhook.Secret, "", webhook.Prefix, webhook.CreatedAt, webhook.UpdatedAt)).
+					WillReturnError(nil)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockDB: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT id, account_id, volume_id, url, secret, events, prefix, created_at, updated_at FROM webhooks WHERE volume_id = ? ORDER BY created_at;")).
+					WithArgs(volumeID).
+					WillReturnRows(sqlmock.NewRows(webhookColumns)).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := mockDatabase.NewMockDatabase(t)
+			defer db.Close()
+
+			tt.setMockDB(mock)
+
+			repo := database.NewWebhookRepository(db)
+			result, err := repo.FindByVolumeID(t.Context(), volumeID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
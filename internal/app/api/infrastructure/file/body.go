@@ -1,12 +1,31 @@
 package file
 
 import (
+	"context"
+	"errors"
 	"io"
+	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/spf13/afero"
 
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/progress"
+)
+
+// NOTE: キー及びボリューム名に利用できない文字を含めることでエントリーとの衝突を防ぐ.
+const (
+	trashPath   = "holos:trash/"
+	derivedPath = "holos:derived/"
+	tempPrefix  = "holos:tmp:"
+
+	trashSeparator = "-"
 )
 
 type bodyRepository struct {
@@ -21,49 +40,143 @@ func NewBodyRepository(fs afero.Fs, basePath string) repository.BodyRepository {
 	}
 }
 
-func (r *bodyRepository) Create(path string, reader io.Reader) (err error) {
+func (r *bodyRepository) Create(ctx context.Context, path string, reader io.Reader, _ *entity.BodyAttributes) error {
+	if err := r.recordCreation(ctx, path); err != nil {
+		return err
+	}
+
 	if err := r.fs.MkdirAll(r.basePath+filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
 
 	if reader == nil {
 		return r.fs.Mkdir(r.basePath+path, 0o755)
-	} else {
-		file, err := r.fs.Create(r.basePath + path)
-		if err != nil {
-			return err
-		}
-		defer func() {
-			if closeErr := file.Close(); closeErr != nil {
-				err = closeErr
-			}
-		}()
-
-		_, err = io.Copy(file, reader)
-		return err
 	}
+	return r.stage(ctx, path, reader)
 }
 
-func (r *bodyRepository) Update(src, dst string) error {
+func (r *bodyRepository) Update(ctx context.Context, src, dst string) error {
 	// NOTE: srcで指定されたpathが存在するか判定する.
-	_, err := r.fs.Stat(r.basePath + src)
+	_, err := r.fs.Stat(r.resolve(ctx, src))
 	if err != nil {
 		return err
 	}
 
+	if j := getJournal(ctx); j != nil {
+		if _, ok := j.staged[src]; ok {
+			return r.moveStaged(ctx, j, src, dst)
+		}
+	}
+
+	if err := r.prepareWrite(ctx, dst); err != nil {
+		return err
+	}
+
 	if err := r.fs.MkdirAll(r.basePath+filepath.Dir(dst), 0o755); err != nil {
 		return err
 	}
 
-	return r.fs.Rename(r.basePath+src, r.basePath+dst)
+	if err := r.fs.Rename(r.basePath+src, r.basePath+dst); err != nil {
+		return err
+	}
+	if err := r.deleteDerived(src); err != nil {
+		return err
+	}
+
+	if j := getJournal(ctx); j != nil {
+		j.addUndo(func() error {
+			return r.fs.Rename(r.basePath+dst, r.basePath+src)
+		})
+		j.restage(src, dst)
+	}
+	return nil
 }
 
-func (r *bodyRepository) Delete(path string) error {
-	return r.fs.RemoveAll(r.basePath + path)
+func (r *bodyRepository) Delete(ctx context.Context, path string) error {
+	if err := r.unstage(ctx, path); err != nil {
+		return err
+	}
+	return r.moveToTrash(ctx, path)
 }
 
-func (r *bodyRepository) Copy(src, dst string) error {
-	info, err := r.fs.Stat(r.basePath + src)
+func (r *bodyRepository) Copy(ctx context.Context, src, dst string) error {
+	if _, err := r.fs.Stat(r.resolve(ctx, src)); err != nil {
+		return err
+	}
+
+	if err := r.recordCreation(ctx, dst); err != nil {
+		return err
+	}
+
+	return r.copy(ctx, src, dst)
+}
+
+func (r *bodyRepository) FindOneByPath(ctx context.Context, path string, _ *entity.BodyAttributes) (io.ReadCloser, error) {
+	name := r.resolve(ctx, path)
+	info, err := r.fs.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return nil, nil
+	}
+
+	return r.fs.Open(name)
+}
+
+func (r *bodyRepository) FindByPath(_ context.Context, path string) ([]*entity.Body, error) {
+	root := r.basePath + path
+	bodies := []*entity.Body{}
+
+	if err := afero.Walk(r.fs, root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == root || strings.HasPrefix(info.Name(), tempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			bodies = append(bodies, entity.RestoreBody(filepath.ToSlash(rel), 0, true))
+		} else {
+			bodies = append(bodies, entity.RestoreBody(filepath.ToSlash(rel), uint64(info.Size()), false))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return bodies, nil
+}
+
+// NOTE: 派生コンテンツは再生成できるキャッシュのためトランザクションに記録しない.
+func (r *bodyRepository) CreateDerived(_ context.Context, path, name string, reader io.Reader) error {
+	dir := derivedPath + path
+	if err := r.fs.MkdirAll(r.basePath+dir, 0o755); err != nil {
+		return err
+	}
+	return r.writeFile(dir+"/"+name, reader)
+}
+
+func (r *bodyRepository) FindOneDerived(_ context.Context, path, name string) (io.ReadCloser, error) {
+	file, err := r.fs.Open(r.basePath + derivedPath + path + "/" + name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (r *bodyRepository) copy(ctx context.Context, src, dst string) error {
+	info, err := r.fs.Stat(r.resolve(ctx, src))
 	if err != nil {
 		return err
 	}
@@ -72,17 +185,17 @@ func (r *bodyRepository) Copy(src, dst string) error {
 		if err := r.fs.Mkdir(r.basePath+dst, 0o755); err != nil {
 			return err
 		}
-		entries, err := afero.ReadDir(r.fs, r.basePath+src)
+		names, err := r.readDirNames(ctx, src)
 		if err != nil {
 			return err
 		}
-		for _, entry := range entries {
-			if err := r.Copy(src+"/"+entry.Name(), dst+"/"+entry.Name()); err != nil {
+		for _, name := range names {
+			if err := r.copy(ctx, src+"/"+name, dst+"/"+name); err != nil {
 				return err
 			}
 		}
 	} else {
-		if err := r.copyFile(src, dst); err != nil {
+		if err := r.copyFile(ctx, src, dst); err != nil {
 			return err
 		}
 	}
@@ -90,40 +203,307 @@ func (r *bodyRepository) Copy(src, dst string) error {
 	return nil
 }
 
-func (r *bodyRepository) FindOneByPath(path string) (io.ReadCloser, error) {
-	info, err := r.fs.Stat(r.basePath + path)
+func (r *bodyRepository) copyFile(ctx context.Context, src, dst string) (err error) {
+	in, err := r.fs.Open(r.resolve(ctx, src))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := in.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	return r.stage(ctx, dst, progress.NewReader(ctx, in))
+}
+
+// NOTE: トランザクション内で書き込んだ一時ファイルは同じパスの既存のファイルより優先する.
+func (r *bodyRepository) resolve(ctx context.Context, path string) string {
+	if j := getJournal(ctx); j != nil {
+		if name, ok := j.staged[path]; ok {
+			return r.basePath + filepath.Dir(path) + "/" + name
+		}
+	}
+	return r.basePath + path
+}
+
+// NOTE: 一時ファイルに書き込んだ新しい子も含めて返却する.
+func (r *bodyRepository) readDirNames(ctx context.Context, path string) ([]string, error) {
+	entries, err := afero.ReadDir(r.fs, r.basePath+path)
 	if err != nil {
 		return nil, err
 	}
 
-	if info.IsDir() {
-		return nil, nil
+	var names []string
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), tempPrefix) {
+			names = append(names, entry.Name())
+		}
+	}
+	if j := getJournal(ctx); j != nil {
+		for _, staged := range j.stagedPaths() {
+			if filepath.Dir(staged) == path && !slices.Contains(names, filepath.Base(staged)) {
+				names = append(names, filepath.Base(staged))
+			}
+		}
+	}
+	return names, nil
+}
+
+// NOTE: トランザクション内では一時ファイルへの書き込みに留め, データベースのコミット直前に元のパスへ移動する.
+func (r *bodyRepository) stage(ctx context.Context, path string, reader io.Reader) error {
+	j := getJournal(ctx)
+	if j == nil {
+		if err := r.deleteDerived(path); err != nil {
+			return err
+		}
+		return r.writeFile(path, reader)
+	}
+
+	tmp, err := r.writeTempFile(filepath.Dir(path), reader)
+	if err != nil {
+		return err
+	}
+	if err := r.unstage(ctx, path); err != nil {
+		return errors.Join(err, r.fs.Remove(tmp))
 	}
 
-	return r.fs.Open(r.basePath + path)
+	if j.staged == nil {
+		j.staged = map[string]string{}
+		j.addPrepare(func() error {
+			return r.promote(ctx, j)
+		})
+		j.addDiscard(func() error {
+			return r.discard(j)
+		})
+	}
+	j.staged[path] = filepath.Base(tmp)
+	return nil
+}
+
+// NOTE: 上書きされるファイルはゴミ箱に退避し, ロールバック時に元に戻せるようにする.
+func (r *bodyRepository) promote(ctx context.Context, j *journal) error {
+	for _, path := range j.stagedPaths() {
+		name := j.staged[path]
+		if err := r.prepareWrite(ctx, path); err != nil {
+			return err
+		}
+		if err := r.fs.Rename(r.basePath+filepath.Dir(path)+"/"+name, r.basePath+path); err != nil {
+			return err
+		}
+		delete(j.staged, path)
+
+		if err := r.syncDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (r *bodyRepository) copyFile(src, dst string) (err error) {
-	in, err := r.fs.Open(r.basePath + src)
+func (r *bodyRepository) discard(j *journal) error {
+	var errs []error
+	for _, path := range j.stagedPaths() {
+		if err := r.fs.Remove(r.basePath + filepath.Dir(path) + "/" + j.staged[path]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+		delete(j.staged, path)
+	}
+	return errors.Join(errs...)
+}
+
+// NOTE: 削除するパス及びその配下に書き込んだ一時ファイルを破棄する.
+// 配下の一時ファイルはディレクトリと共にゴミ箱へ移動されるため, 記録のみ削除する.
+func (r *bodyRepository) unstage(ctx context.Context, path string) error {
+	j := getJournal(ctx)
+	if j == nil {
+		return nil
+	}
+
+	for _, staged := range j.stagedPaths() {
+		if staged == path {
+			if err := r.fs.Remove(r.basePath + filepath.Dir(staged) + "/" + j.staged[staged]); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			delete(j.staged, staged)
+		} else if strings.HasPrefix(staged, path+"/") {
+			delete(j.staged, staged)
+		}
+	}
+	return nil
+}
+
+// NOTE: 一時ファイルのみのパスを移動する場合は一時ファイルを移動先のディレクトリへ移動する.
+func (r *bodyRepository) moveStaged(ctx context.Context, j *journal, src, dst string) error {
+	name := j.staged[src]
+	delete(j.staged, src)
+
+	// NOTE: 上書き前のファイルは移動しないため, コミット時と同様にゴミ箱へ退避する.
+	if err := r.moveToTrash(ctx, src); err != nil {
+		return err
+	}
+	if err := r.recordCreation(ctx, dst); err != nil {
+		return err
+	}
+	if err := r.fs.MkdirAll(r.basePath+filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := r.unstage(ctx, dst); err != nil {
+		return err
+	}
+	if err := r.fs.Rename(r.basePath+filepath.Dir(src)+"/"+name, r.basePath+filepath.Dir(dst)+"/"+name); err != nil {
+		return err
+	}
+	j.staged[dst] = name
+	return nil
+}
+
+// NOTE: 書き込み途中のファイルが参照されないよう一時ファイルに書き込んでから移動する.
+func (r *bodyRepository) writeFile(path string, reader io.Reader) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := r.writeTempFile(dir, reader)
 	if err != nil {
 		return err
 	}
+
+	if err := r.fs.Rename(tmp, r.basePath+path); err != nil {
+		return errors.Join(err, r.fs.Remove(tmp))
+	}
+
+	return r.syncDir(dir)
+}
+
+func (r *bodyRepository) writeTempFile(dir string, reader io.Reader) (_ string, err error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	name := r.basePath + dir + "/" + tempPrefix + id.String()
+	file, err := r.fs.Create(name)
+	if err != nil {
+		return "", err
+	}
 	defer func() {
-		if closeErr := in.Close(); closeErr != nil {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
 			err = closeErr
 		}
+		if err != nil {
+			err = errors.Join(err, r.fs.Remove(name))
+		}
 	}()
 
-	out, err := r.fs.Create(r.basePath + dst)
+	if _, err := io.Copy(file, reader); err != nil {
+		return "", err
+	}
+	if err := file.Sync(); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// NOTE: 移動したファイルのエントリーを永続化するためディレクトリを同期する.
+func (r *bodyRepository) syncDir(dir string) (err error) {
+	file, err := r.fs.Open(r.basePath + dir)
 	if err != nil {
 		return err
 	}
 	defer func() {
-		if closeErr := out.Close(); closeErr != nil {
+		if closeErr := file.Close(); closeErr != nil {
 			err = closeErr
 		}
 	}()
 
-	_, err = io.Copy(out, in)
-	return err
+	return file.Sync()
+}
+
+// NOTE: 上書きされるファイルを退避し, ロールバック時に作成したパスを削除できるよう記録する.
+func (r *bodyRepository) prepareWrite(ctx context.Context, path string) error {
+	info, err := r.fs.Stat(r.basePath + path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil && !info.IsDir() {
+		if err := r.moveToTrash(ctx, path); err != nil {
+			return err
+		}
+	}
+
+	return r.recordCreation(ctx, path)
+}
+
+func (r *bodyRepository) recordCreation(ctx context.Context, path string) error {
+	j := getJournal(ctx)
+	if j == nil {
+		return nil
+	}
+
+	// NOTE: 存在しない最上位のパスを削除すれば作成した全てのパスを削除できる.
+	var current string
+	for part := range strings.SplitSeq(path, "/") {
+		current += part
+		exists, err := afero.Exists(r.fs, r.basePath+current)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			created := current
+			j.addUndo(func() error {
+				return r.fs.RemoveAll(r.basePath + created)
+			})
+			return nil
+		}
+		current += "/"
+	}
+	return nil
+}
+
+func (r *bodyRepository) moveToTrash(ctx context.Context, path string) error {
+	if err := r.deleteDerived(path); err != nil {
+		return err
+	}
+
+	j := getJournal(ctx)
+	if j == nil {
+		return r.fs.RemoveAll(r.basePath + path)
+	}
+
+	exists, err := afero.Exists(r.fs, r.basePath+path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	if err := r.fs.MkdirAll(r.basePath+trashPath, 0o755); err != nil {
+		return err
+	}
+
+	// NOTE: 整合性を保てなくなった退避先を削除できるよう, 退避した日時を名前に含める.
+	trash := r.basePath + trashPath + strconv.FormatInt(time.Now().Unix(), 10) + trashSeparator + id.String()
+	if err := r.fs.Rename(r.basePath+path, trash); err != nil {
+		return err
+	}
+
+	j.addUndo(func() error {
+		if err := r.fs.MkdirAll(r.basePath+filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		return r.fs.Rename(trash, r.basePath+path)
+	})
+	j.addPurge(func() error {
+		return r.fs.RemoveAll(trash)
+	})
+	return nil
+}
+
+// NOTE: 派生コンテンツは元のパスの配下に保存するため, フォルダの場合は子孫の派生コンテンツも削除される.
+func (r *bodyRepository) deleteDerived(path string) error {
+	return r.fs.RemoveAll(r.basePath + derivedPath + path)
 }
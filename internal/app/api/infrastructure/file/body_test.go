@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
 
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
 )
 
@@ -31,6 +34,21 @@ func checkExists(fs afero.Fs, paths []string, expect bool) error {
 	return nil
 }
 
+func checkNoTempFiles(fs afero.Fs) error {
+	return afero.Walk(fs, basePath, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if strings.HasPrefix(info.Name(), "holos:tmp:") {
+			return fmt.Errorf("%s is exists", name)
+		}
+		return nil
+	})
+}
+
 type errReader struct{}
 
 func (e *errReader) Read([]byte) (int, error) {
@@ -54,13 +72,16 @@ func TestBody_Create(t *testing.T) {
 			fs := afero.NewMemMapFs()
 
 			repo := file.NewBodyRepository(fs, basePath)
-			if err := repo.Create(tt.inputPath, tt.inputReader); !errors.Is(err, tt.expectError) {
+			if err := repo.Create(t.Context(), tt.inputPath, tt.inputReader, nil); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 
 			if err := checkExists(fs, tt.expectPaths, true); err != nil {
 				t.Error(err)
 			}
+			if err := checkNoTempFiles(fs); err != nil {
+				t.Error(err)
+			}
 		})
 	}
 }
@@ -114,6 +135,22 @@ func TestBody_Update(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:          "delete derived",
+			inputSrc:      "key/sample.txt",
+			inputDst:      "key/update.txt",
+			expectPaths:   []string{"key/update.txt"},
+			unexpectPaths: []string{"key/sample.txt", "holos:derived/key/sample.txt"},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"key/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+				if err := afero.WriteFile(fs, basePath+"holos:derived/key/sample.txt/thumbnail", []byte("derived"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
 		{
 			name:          "not found",
 			inputSrc:      "key/sample.txt",
@@ -131,7 +168,7 @@ func TestBody_Update(t *testing.T) {
 			tt.setMockFS(fs)
 
 			repo := file.NewBodyRepository(fs, basePath)
-			if err := repo.Update(tt.inputSrc, tt.inputDst); !errors.Is(err, tt.expectError) {
+			if err := repo.Update(t.Context(), tt.inputSrc, tt.inputDst); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 
@@ -178,6 +215,21 @@ func TestBody_Delete(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:          "delete derived",
+			inputPath:     "key",
+			expectPaths:   []string{},
+			unexpectPaths: []string{"key", "holos:derived/key", "holos:derived/key/sample.txt/thumbnail"},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"key/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+				if err := afero.WriteFile(fs, basePath+"holos:derived/key/sample.txt/thumbnail", []byte("derived"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
 		{
 			name:          "not found",
 			inputPath:     "key/sample.txt",
@@ -194,7 +246,7 @@ func TestBody_Delete(t *testing.T) {
 			tt.setMockFS(fs)
 
 			repo := file.NewBodyRepository(fs, basePath)
-			if err := repo.Delete(tt.inputPath); !errors.Is(err, tt.expectError) {
+			if err := repo.Delete(t.Context(), tt.inputPath); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 
@@ -261,7 +313,7 @@ func TestBody_Copy(t *testing.T) {
 			tt.setMockFS(fs)
 
 			repo := file.NewBodyRepository(fs, basePath)
-			if err := repo.Copy(tt.inputSrc, tt.inputDst); !errors.Is(err, tt.expectError) {
+			if err := repo.Copy(t.Context(), tt.inputSrc, tt.inputDst); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 
@@ -271,6 +323,9 @@ func TestBody_Copy(t *testing.T) {
 			if err := checkExists(fs, tt.unexpectPaths, false); err != nil {
 				t.Error(err)
 			}
+			if err := checkNoTempFiles(fs); err != nil {
+				t.Error(err)
+			}
 		})
 	}
 }
@@ -320,7 +375,7 @@ func TestBody_FindOneByPath(t *testing.T) {
 			tt.setMockFS(fs)
 
 			repo := file.NewBodyRepository(fs, basePath)
-			body, err := repo.FindOneByPath(tt.inputPath)
+			body, err := repo.FindOneByPath(t.Context(), tt.inputPath, nil)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -337,3 +392,160 @@ func TestBody_FindOneByPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBody_FindByPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		expectResult []*entity.Body
+		expectError  error
+		setMockFS    func(fs afero.Fs)
+	}{
+		{
+			name:      "find bodies",
+			inputPath: "volume",
+			expectResult: []*entity.Body{
+				{Path: "key", Size: 0, IsFolder: true},
+				{Path: "key/sample.txt", Size: 4, IsFolder: false},
+				{Path: "sample.txt", Size: 6, IsFolder: false},
+			},
+			expectError: nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"volume/key/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+				if err := afero.WriteFile(fs, basePath+"volume/sample.txt", []byte("sample"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:         "empty folder",
+			inputPath:    "volume",
+			expectResult: []*entity.Body{},
+			expectError:  nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := fs.MkdirAll(basePath+"volume", 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:         "not found",
+			inputPath:    "volume",
+			expectResult: nil,
+			expectError:  afero.ErrFileNotFound,
+			setMockFS:    func(afero.Fs) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			tt.setMockFS(fs)
+
+			repo := file.NewBodyRepository(fs, basePath)
+			result, err := repo.FindByPath(t.Context(), tt.inputPath)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestBody_CreateDerived(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputPath   string
+		inputName   string
+		inputReader io.Reader
+		expectPaths []string
+		expectError error
+	}{
+		{name: "create derived", inputPath: "key/sample.jpg", inputName: "thumbnail", inputReader: bytes.NewBufferString("test"), expectPaths: []string{"holos:derived/key/sample.jpg/thumbnail"}, expectError: nil},
+		{name: "create error", inputPath: "key/sample.jpg", inputName: "thumbnail", inputReader: &errReader{}, expectPaths: []string{}, expectError: io.ErrNoProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			repo := file.NewBodyRepository(fs, basePath)
+			if err := repo.CreateDerived(t.Context(), tt.inputPath, tt.inputName, tt.inputReader); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectPaths, true); err != nil {
+				t.Error(err)
+			}
+			if err := checkExists(fs, []string{"key"}, false); err != nil {
+				t.Error(err)
+			}
+			if err := checkNoTempFiles(fs); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestBody_FindOneDerived(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		inputName    string
+		expectResult []byte
+		expectError  error
+		setMockFS    func(fs afero.Fs)
+	}{
+		{
+			name:         "find derived",
+			inputPath:    "key/sample.jpg",
+			inputName:    "thumbnail",
+			expectResult: []byte("test"),
+			expectError:  nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"holos:derived/key/sample.jpg/thumbnail", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:         "not found",
+			inputPath:    "key/sample.jpg",
+			inputName:    "thumbnail",
+			expectResult: nil,
+			expectError:  nil,
+			setMockFS:    func(afero.Fs) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			tt.setMockFS(fs)
+
+			repo := file.NewBodyRepository(fs, basePath)
+			body, err := repo.FindOneDerived(t.Context(), tt.inputPath, tt.inputName)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectResult == nil {
+				if body != nil {
+					t.Error("derived is found")
+				}
+				return
+			}
+			result, err := io.ReadAll(body)
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package file
+
+import (
+	"context"
+	"io"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/compression"
+)
+
+type compressedBodyRepository struct {
+	bodyRepo repository.BodyRepository
+}
+
+// NOTE: 暗号化したボディは圧縮できないため, 暗号化のデコレータより外側で利用する.
+func NewCompressedBodyRepository(bodyRepo repository.BodyRepository) repository.BodyRepository {
+	return &compressedBodyRepository{
+		bodyRepo: bodyRepo,
+	}
+}
+
+// NOTE: 属性に指定された圧縮方式で圧縮して保存する.
+func (r *compressedBodyRepository) Create(ctx context.Context, path string, reader io.Reader, attributes *entity.BodyAttributes) error {
+	if reader == nil || attributes == nil || attributes.Encoding == "" {
+		return r.bodyRepo.Create(ctx, path, reader, attributes)
+	}
+
+	compressed, err := compression.Compress(attributes.Encoding, reader)
+	if err != nil {
+		return err
+	}
+	return r.bodyRepo.Create(ctx, path, compressed, attributes)
+}
+
+func (r *compressedBodyRepository) Update(ctx context.Context, src, dst string) error {
+	return r.bodyRepo.Update(ctx, src, dst)
+}
+
+func (r *compressedBodyRepository) Delete(ctx context.Context, path string) error {
+	return r.bodyRepo.Delete(ctx, path)
+}
+
+func (r *compressedBodyRepository) Copy(ctx context.Context, src, dst string) error {
+	return r.bodyRepo.Copy(ctx, src, dst)
+}
+
+// NOTE: 取得する圧縮方式が保存時の圧縮方式と一致する場合は展開せずに返却する.
+func (r *compressedBodyRepository) FindOneByPath(ctx context.Context, path string, attributes *entity.BodyAttributes) (io.ReadCloser, error) {
+	body, err := r.bodyRepo.FindOneByPath(ctx, path, attributes)
+	if err != nil || body == nil {
+		return body, err
+	}
+	if attributes == nil || attributes.Encoding == "" || attributes.Encoding == attributes.ContentEncoding {
+		return body, nil
+	}
+
+	decompressed, err := compression.Decompress(attributes.Encoding, body)
+	if err != nil {
+		if closeErr := body.Close(); closeErr != nil {
+			return nil, closeErr
+		}
+		return nil, err
+	}
+	return decompressed, nil
+}
+
+// NOTE: 圧縮方式はボディから判定できないため, 保存サイズのまま返却しエントリーに記録された圧縮方式を利用させる.
+func (r *compressedBodyRepository) FindByPath(ctx context.Context, path string) ([]*entity.Body, error) {
+	return r.bodyRepo.FindByPath(ctx, path)
+}
+
+func (r *compressedBodyRepository) CreateDerived(ctx context.Context, path, name string, reader io.Reader) error {
+	return r.bodyRepo.CreateDerived(ctx, path, name, reader)
+}
+
+func (r *compressedBodyRepository) FindOneDerived(ctx context.Context, path, name string) (io.ReadCloser, error) {
+	return r.bodyRepo.FindOneDerived(ctx, path, name)
+}
@@ -0,0 +1,126 @@
+package file_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/compression"
+)
+
+func TestCompressedBody_Create(t *testing.T) {
+	body := bytes.Repeat([]byte("test"), 1024)
+
+	tests := []struct {
+		name           string
+		inputPath      string
+		inputReader    io.Reader
+		inputEncoding  string
+		expectPaths    []string
+		expectResult   []byte
+		expectCompress bool
+		expectError    error
+	}{
+		{name: "create gzip file", inputPath: "key/sample.txt", inputReader: bytes.NewReader(body), inputEncoding: "gzip", expectPaths: []string{"key", "key/sample.txt"}, expectResult: body, expectCompress: true, expectError: nil},
+		{name: "create zstd file", inputPath: "key/sample.txt", inputReader: bytes.NewReader(body), inputEncoding: "zstd", expectPaths: []string{"key", "key/sample.txt"}, expectResult: body, expectCompress: true, expectError: nil},
+		{name: "create uncompressed file", inputPath: "key/sample.txt", inputReader: bytes.NewReader(body), inputEncoding: "", expectPaths: []string{"key", "key/sample.txt"}, expectResult: body, expectCompress: false, expectError: nil},
+		{name: "create folder", inputPath: "key", inputReader: nil, inputEncoding: "gzip", expectPaths: []string{"key"}, expectResult: nil, expectCompress: false, expectError: nil},
+		{name: "unsupported encoding", inputPath: "key/sample.txt", inputReader: bytes.NewReader(body), inputEncoding: "br", expectPaths: []string{}, expectResult: nil, expectCompress: false, expectError: compression.ErrUnsupportedEncoding},
+		{name: "create error", inputPath: "key/sample.txt", inputReader: &errReader{}, inputEncoding: "gzip", expectPaths: []string{}, expectResult: nil, expectCompress: false, expectError: io.ErrNoProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			repo := file.NewCompressedBodyRepository(file.NewBodyRepository(fs, basePath))
+			attributes := &entity.BodyAttributes{Encoding: tt.inputEncoding}
+			if err := repo.Create(t.Context(), tt.inputPath, tt.inputReader, attributes); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectPaths, true); err != nil {
+				t.Error(err)
+			}
+
+			if tt.expectResult != nil {
+				raw, err := afero.ReadFile(fs, basePath+tt.inputPath)
+				if err != nil {
+					t.Error(err)
+				}
+				if tt.expectCompress == bytes.Equal(raw, tt.expectResult) {
+					t.Errorf("\nexpect compressed: %v\ngot: %v", tt.expectCompress, raw)
+				}
+
+				body, err := repo.FindOneByPath(t.Context(), tt.inputPath, attributes)
+				if err != nil {
+					t.Error(err)
+				}
+				result, err := io.ReadAll(body)
+				if err != nil {
+					t.Error(err)
+				}
+				if err := body.Close(); err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCompressedBody_FindOneByPath(t *testing.T) {
+	body := bytes.Repeat([]byte("test"), 1024)
+
+	tests := []struct {
+		name                 string
+		inputStoredEncoding  string
+		inputEncoding        string
+		inputContentEncoding string
+		expectCompressed     bool
+		expectError          error
+	}{
+		{name: "decompress", inputStoredEncoding: "gzip", inputEncoding: "gzip", inputContentEncoding: "", expectCompressed: false, expectError: nil},
+		{name: "decompress other encoding", inputStoredEncoding: "gzip", inputEncoding: "gzip", inputContentEncoding: "zstd", expectCompressed: false, expectError: nil},
+		{name: "keep encoding", inputStoredEncoding: "zstd", inputEncoding: "zstd", inputContentEncoding: "zstd", expectCompressed: true, expectError: nil},
+		{name: "uncompressed", inputStoredEncoding: "", inputEncoding: "", inputContentEncoding: "gzip", expectCompressed: false, expectError: nil},
+		{name: "invalid body", inputStoredEncoding: "", inputEncoding: "gzip", inputContentEncoding: "", expectCompressed: false, expectError: gzip.ErrHeader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			repo := file.NewCompressedBodyRepository(file.NewBodyRepository(fs, basePath))
+			if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewReader(body), &entity.BodyAttributes{Encoding: tt.inputStoredEncoding}); err != nil {
+				t.Error(err)
+			}
+
+			reader, err := repo.FindOneByPath(t.Context(), "key/sample.txt", &entity.BodyAttributes{Encoding: tt.inputEncoding, ContentEncoding: tt.inputContentEncoding})
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			result, err := io.ReadAll(reader)
+			if err != nil {
+				t.Error(err)
+			}
+			if err := reader.Close(); err != nil {
+				t.Error(err)
+			}
+			if tt.expectCompressed == bytes.Equal(result, body) {
+				t.Errorf("\nexpect compressed: %v\ngot: %v", tt.expectCompressed, result)
+			}
+		})
+	}
+}
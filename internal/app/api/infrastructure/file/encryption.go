@@ -0,0 +1,554 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	encryptionMagic      = "HOLOSENC"
+	encryptionVersion    = 1
+	encryptionChunkSize  = 64 * 1024
+	encryptionDataKeyLen = 32
+	encryptionPrefixLen  = 7
+	encryptionTagLen     = 16
+	encryptionNonceLen   = 12
+	encryptionWrappedLen = encryptionNonceLen + encryptionDataKeyLen + encryptionTagLen
+
+	// NOTE: 暗号化した派生コンテンツは名前で区別し, 暗号化前に保存されたものは存在しないものとして再生成させる.
+	encryptedDerivedSuffix = ".enc"
+)
+
+var (
+	ErrMasterKeyNotFound  = status.Error(code.Internal, "master key not found")
+	ErrInvalidBodyHeader  = status.Error(code.Internal, "invalid encrypted body header")
+	ErrCorruptedBody      = status.Error(code.Internal, "encrypted body is corrupted")
+	ErrUnsupportedSeeking = status.Error(code.Internal, "body does not support seeking")
+)
+
+type MasterKey struct {
+	ID  string
+	Key []byte
+}
+
+type encryptedBodyRepository struct {
+	bodyRepo     repository.BodyRepository
+	currentKeyID string
+	masterKeys   map[string][]byte
+}
+
+func NewEncryptedBodyRepository(bodyRepo repository.BodyRepository, currentKeyID string, masterKeys []*MasterKey) repository.BodyRepository {
+	keys := make(map[string][]byte, len(masterKeys))
+	for _, key := range masterKeys {
+		keys[key.ID] = key.Key
+	}
+	return &encryptedBodyRepository{
+		bodyRepo:     bodyRepo,
+		currentKeyID: currentKeyID,
+		masterKeys:   keys,
+	}
+}
+
+// NOTE: 復号に利用するマスターキーIDを属性に設定し, エントリーに記録させる.
+func (r *encryptedBodyRepository) Create(ctx context.Context, path string, reader io.Reader, attributes *entity.BodyAttributes) error {
+	if reader == nil {
+		return r.bodyRepo.Create(ctx, path, nil, attributes)
+	}
+
+	encrypted, err := r.newEncryptReader(reader)
+	if err != nil {
+		return err
+	}
+	if err := r.bodyRepo.Create(ctx, path, encrypted, attributes); err != nil {
+		return err
+	}
+	if attributes != nil {
+		attributes.EncryptionKeyID = r.currentKeyID
+	}
+	return nil
+}
+
+func (r *encryptedBodyRepository) Update(ctx context.Context, src, dst string) error {
+	return r.bodyRepo.Update(ctx, src, dst)
+}
+
+func (r *encryptedBodyRepository) Delete(ctx context.Context, path string) error {
+	return r.bodyRepo.Delete(ctx, path)
+}
+
+func (r *encryptedBodyRepository) Copy(ctx context.Context, src, dst string) error {
+	// NOTE: ヘッダーにラップ済みのデータキーを含むため暗号文のままコピーできる.
+	return r.bodyRepo.Copy(ctx, src, dst)
+}
+
+// NOTE: マスターキーIDが記録されていないボディは暗号化前に保存されたものとして平文のまま返却する.
+func (r *encryptedBodyRepository) FindOneByPath(ctx context.Context, path string, attributes *entity.BodyAttributes) (io.ReadCloser, error) {
+	body, err := r.bodyRepo.FindOneByPath(ctx, path, attributes)
+	if err != nil || body == nil {
+		return body, err
+	}
+	if attributes == nil || attributes.EncryptionKeyID == "" {
+		return body, nil
+	}
+
+	decrypted, err := r.newDecryptReader(body, attributes.EncryptionKeyID)
+	if err != nil {
+		if closeErr := body.Close(); closeErr != nil {
+			return nil, closeErr
+		}
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+func (r *encryptedBodyRepository) FindByPath(ctx context.Context, path string) ([]*entity.Body, error) {
+	bodies, err := r.bodyRepo.FindByPath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, body := range bodies {
+		if body.IsFolder {
+			continue
+		}
+		if err := r.inspect(ctx, path+"/"+body.Path, body); err != nil {
+			return nil, err
+		}
+	}
+	return bodies, nil
+}
+
+func (r *encryptedBodyRepository) CreateDerived(ctx context.Context, path, name string, reader io.Reader) error {
+	encrypted, err := r.newEncryptReader(reader)
+	if err != nil {
+		return err
+	}
+	return r.bodyRepo.CreateDerived(ctx, path, name+encryptedDerivedSuffix, encrypted)
+}
+
+func (r *encryptedBodyRepository) FindOneDerived(ctx context.Context, path, name string) (io.ReadCloser, error) {
+	body, err := r.bodyRepo.FindOneDerived(ctx, path, name+encryptedDerivedSuffix)
+	if err != nil || body == nil {
+		return body, err
+	}
+
+	// NOTE: 派生コンテンツはエントリーを持たないため, ヘッダーのマスターキーIDで復号する.
+	decrypted, err := r.newDecryptReader(body, "")
+	if err != nil {
+		if closeErr := body.Close(); closeErr != nil {
+			return nil, closeErr
+		}
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+// NOTE: ファイルシステムの走査ではエントリーを参照できないため, マスターキーでデータキーを復号できたボディのみ暗号化済みとし,
+// マスターキーIDとヘッダーと認証タグを除いた平文のサイズを設定する.
+func (r *encryptedBodyRepository) inspect(ctx context.Context, path string, body *entity.Body) (err error) {
+	src, err := r.bodyRepo.FindOneByPath(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := src.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	header, err := readEncryptionHeader(src)
+	if errors.Is(err, ErrInvalidBodyHeader) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := r.unwrap(header); errors.Is(err, ErrCorruptedBody) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	size := encryptedPlaintextSize(int64(body.Size)-header.length, int64(header.chunkSize))
+	if size < 0 {
+		return ErrCorruptedBody
+	}
+	body.Size = uint64(size)
+	body.Attributes.EncryptionKeyID = header.keyID
+	return nil
+}
+
+func (r *encryptedBodyRepository) newEncryptReader(src io.Reader) (io.Reader, error) {
+	masterKey, ok := r.masterKeys[r.currentKeyID]
+	if !ok {
+		return nil, ErrMasterKeyNotFound
+	}
+
+	dataKey := make([]byte, encryptionDataKeyLen)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, encryptionPrefixLen)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	wrapped, err := wrapDataKey(masterKey, r.currentKeyID, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var header bytes.Buffer
+	header.WriteString(encryptionMagic)
+	header.WriteByte(encryptionVersion)
+	header.WriteByte(byte(len(r.currentKeyID)))
+	header.WriteString(r.currentKeyID)
+	header.Write(wrapped)
+	header.Write(prefix)
+	if err := binary.Write(&header, binary.BigEndian, uint32(encryptionChunkSize)); err != nil {
+		return nil, err
+	}
+
+	return &encryptReader{
+		src:    src,
+		aead:   aead,
+		prefix: prefix,
+		buf:    make([]byte, encryptionChunkSize+1),
+		out:    header.Bytes(),
+	}, nil
+}
+
+// NOTE: keyIDが空の場合はヘッダーのマスターキーIDで復号する.
+func (r *encryptedBodyRepository) newDecryptReader(src io.ReadCloser, keyID string) (io.ReadCloser, error) {
+	header, err := readEncryptionHeader(src)
+	if err != nil {
+		return nil, err
+	}
+	if keyID != "" && header.keyID != keyID {
+		return nil, ErrInvalidBodyHeader
+	}
+
+	dataKey, err := r.unwrap(header)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		src:       src,
+		aead:      aead,
+		prefix:    header.prefix,
+		chunkSize: header.chunkSize,
+		headerLen: header.length,
+		buf:       make([]byte, header.chunkSize+encryptionTagLen),
+		plainBuf:  make([]byte, header.chunkSize),
+		size:      -1,
+	}, nil
+}
+
+func (r *encryptedBodyRepository) unwrap(header *encryptionHeader) ([]byte, error) {
+	masterKey, ok := r.masterKeys[header.keyID]
+	if !ok {
+		return nil, ErrMasterKeyNotFound
+	}
+	return unwrapDataKey(masterKey, header.keyID, header.wrapped)
+}
+
+type encryptionHeader struct {
+	keyID     string
+	wrapped   []byte
+	prefix    []byte
+	chunkSize int
+	length    int64
+}
+
+func readEncryptionHeader(src io.Reader) (*encryptionHeader, error) {
+	fixed := make([]byte, len(encryptionMagic)+2)
+	if _, err := io.ReadFull(src, fixed); err != nil {
+		return nil, ErrInvalidBodyHeader
+	}
+	if string(fixed[:len(encryptionMagic)]) != encryptionMagic || fixed[len(encryptionMagic)] != encryptionVersion {
+		return nil, ErrInvalidBodyHeader
+	}
+	keyIDLen := int(fixed[len(encryptionMagic)+1])
+
+	rest := make([]byte, keyIDLen+encryptionWrappedLen+encryptionPrefixLen+4)
+	if _, err := io.ReadFull(src, rest); err != nil {
+		return nil, ErrInvalidBodyHeader
+	}
+	length := int64(len(fixed) + len(rest))
+	keyID := string(rest[:keyIDLen])
+	rest = rest[keyIDLen:]
+
+	chunkSize := int(binary.BigEndian.Uint32(rest[encryptionWrappedLen+encryptionPrefixLen:]))
+	if chunkSize <= 0 {
+		return nil, ErrInvalidBodyHeader
+	}
+
+	return &encryptionHeader{
+		keyID:     keyID,
+		wrapped:   rest[:encryptionWrappedLen],
+		prefix:    rest[encryptionWrappedLen : encryptionWrappedLen+encryptionPrefixLen],
+		chunkSize: chunkSize,
+		length:    length,
+	}, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func wrapDataKey(masterKey []byte, keyID string, dataKey []byte) ([]byte, error) {
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, encryptionNonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+func unwrapDataKey(masterKey []byte, keyID string, wrapped []byte) ([]byte, error) {
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := aead.Open(nil, wrapped[:encryptionNonceLen], wrapped[encryptionNonceLen:], []byte(keyID))
+	if err != nil {
+		return nil, ErrCorruptedBody
+	}
+	return dataKey, nil
+}
+
+func chunkNonce(prefix []byte, index uint32, last bool) []byte {
+	nonce := make([]byte, encryptionNonceLen)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encryptionPrefixLen:], index)
+	if last {
+		nonce[encryptionNonceLen-1] = 1
+	}
+	return nonce
+}
+
+type encryptReader struct {
+	src      io.Reader
+	aead     cipher.AEAD
+	prefix   []byte
+	buf      []byte
+	carry    int
+	index    uint32
+	out      []byte
+	finished bool
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.finished {
+			return 0, io.EOF
+		}
+		if err := r.sealNext(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *encryptReader) sealNext() error {
+	n, err := io.ReadFull(r.src, r.buf[r.carry:])
+	total := r.carry + n
+
+	var chunk []byte
+	switch {
+	case err == nil:
+		chunk = r.buf[:encryptionChunkSize]
+		r.finished = false
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		chunk = r.buf[:total]
+		r.finished = true
+	default:
+		return err
+	}
+
+	r.out = r.aead.Seal(r.out[:0], chunkNonce(r.prefix, r.index, r.finished), chunk, nil)
+	r.index++
+
+	// NOTE: 最終チャンクか判定するため1byte先読みした分を次のチャンクの先頭に移す.
+	r.carry = 0
+	if !r.finished {
+		r.buf[0] = r.buf[encryptionChunkSize]
+		r.carry = 1
+	}
+	return nil
+}
+
+type decryptReader struct {
+	src       io.ReadCloser
+	aead      cipher.AEAD
+	prefix    []byte
+	chunkSize int
+	headerLen int64
+	buf       []byte
+	plainBuf  []byte
+	plain     []byte
+	index     uint32
+	skip      int
+	size      int64
+	pos       int64
+	finished  bool
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.finished || (0 <= r.size && r.size <= r.pos) {
+			return 0, io.EOF
+		}
+		if err := r.openNext(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	r.pos += int64(n)
+	return n, nil
+}
+
+func (r *decryptReader) openNext() error {
+	n, err := io.ReadFull(r.src, r.buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	if n < encryptionTagLen {
+		return ErrCorruptedBody
+	}
+
+	last := n < len(r.buf)
+	plain, err := r.aead.Open(r.plainBuf[:0], chunkNonce(r.prefix, r.index, last), r.buf[:n], nil)
+	if err != nil && !last {
+		// NOTE: 平文がチャンクサイズちょうどで終わる場合は最終チャンクも満杯になる.
+		last = true
+		plain, err = r.aead.Open(r.plainBuf[:0], chunkNonce(r.prefix, r.index, last), r.buf[:n], nil)
+	}
+	if err != nil {
+		return ErrCorruptedBody
+	}
+
+	r.index++
+	r.finished = last
+	if r.skip < len(plain) {
+		r.plain = plain[r.skip:]
+	} else {
+		r.plain = nil
+	}
+	r.skip = 0
+	return nil
+}
+
+func (r *decryptReader) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := r.src.(io.Seeker)
+	if !ok {
+		return 0, ErrUnsupportedSeeking
+	}
+
+	size, err := r.plaintextSize(seeker)
+	if err != nil {
+		return 0, err
+	}
+
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = r.pos + offset
+	case io.SeekEnd:
+		pos = size + offset
+	default:
+		return 0, ErrUnsupportedSeeking
+	}
+	if pos < 0 {
+		return 0, ErrUnsupportedSeeking
+	}
+
+	// NOTE: チャンク境界にシークし, チャンク内のオフセットは復号後に読み飛ばす.
+	chunkLen := int64(r.chunkSize + encryptionTagLen)
+	index := pos / int64(r.chunkSize)
+	if math.MaxUint32 < index {
+		return 0, ErrUnsupportedSeeking
+	}
+	if _, err := seeker.Seek(r.headerLen+index*chunkLen, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	r.index = uint32(index)
+	r.skip = int(pos % int64(r.chunkSize))
+	r.plain = nil
+	r.finished = false
+	r.pos = pos
+	return pos, nil
+}
+
+func (r *decryptReader) Close() error {
+	return r.src.Close()
+}
+
+func (r *decryptReader) plaintextSize(seeker io.Seeker) (int64, error) {
+	if 0 <= r.size {
+		return r.size, nil
+	}
+
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	r.size = encryptedPlaintextSize(end-r.headerLen, int64(r.chunkSize))
+	if r.size < 0 {
+		return 0, ErrCorruptedBody
+	}
+	return r.size, nil
+}
+
+func encryptedPlaintextSize(ciphertextSize, chunkSize int64) int64 {
+	chunkLen := chunkSize + encryptionTagLen
+	chunks := (ciphertextSize + chunkLen - 1) / chunkLen
+	if chunks == 0 {
+		return -1
+	}
+	return ciphertextSize - chunks*encryptionTagLen
+}
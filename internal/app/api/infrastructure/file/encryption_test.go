@@ -0,0 +1,387 @@
+package file_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+)
+
+var (
+	oldMasterKey = &file.MasterKey{ID: "old", Key: bytes.Repeat([]byte{1}, 32)}
+	newMasterKey = &file.MasterKey{ID: "new", Key: bytes.Repeat([]byte{2}, 32)}
+)
+
+func TestEncryptedBody_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputPath    string
+		inputReader  io.Reader
+		inputKeyID   string
+		expectPaths  []string
+		expectKeyID  string
+		expectResult []byte
+		expectError  error
+	}{
+		{name: "create file", inputPath: "key/sample.txt", inputReader: bytes.NewBufferString("test"), inputKeyID: "new", expectPaths: []string{"key", "key/sample.txt"}, expectKeyID: "new", expectResult: []byte("test"), expectError: nil},
+		{name: "create empty file", inputPath: "key/sample.txt", inputReader: bytes.NewBufferString(""), inputKeyID: "new", expectPaths: []string{"key", "key/sample.txt"}, expectKeyID: "new", expectResult: []byte{}, expectError: nil},
+		{name: "create multi chunk file", inputPath: "key/sample.txt", inputReader: bytes.NewReader(bytes.Repeat([]byte("a"), 64*1024*2)), inputKeyID: "new", expectPaths: []string{"key", "key/sample.txt"}, expectKeyID: "new", expectResult: bytes.Repeat([]byte("a"), 64*1024*2), expectError: nil},
+		{name: "create folder", inputPath: "key", inputReader: nil, inputKeyID: "new", expectPaths: []string{"key"}, expectKeyID: "", expectResult: nil, expectError: nil},
+		{name: "unknown master key", inputPath: "key/sample.txt", inputReader: bytes.NewBufferString("test"), inputKeyID: "unknown", expectPaths: []string{}, expectKeyID: "", expectResult: nil, expectError: file.ErrMasterKeyNotFound},
+		{name: "create error", inputPath: "key/sample.txt", inputReader: &errReader{}, inputKeyID: "new", expectPaths: []string{}, expectKeyID: "", expectResult: nil, expectError: io.ErrNoProgress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), tt.inputKeyID, []*file.MasterKey{oldMasterKey, newMasterKey})
+			attributes := &entity.BodyAttributes{}
+			if err := repo.Create(t.Context(), tt.inputPath, tt.inputReader, attributes); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if attributes.EncryptionKeyID != tt.expectKeyID {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectKeyID, attributes.EncryptionKeyID)
+			}
+
+			if err := checkExists(fs, tt.expectPaths, true); err != nil {
+				t.Error(err)
+			}
+
+			if tt.expectResult != nil {
+				raw, err := afero.ReadFile(fs, basePath+tt.inputPath)
+				if err != nil {
+					t.Error(err)
+				}
+				if 0 < len(tt.expectResult) && bytes.Contains(raw, tt.expectResult) {
+					t.Error("body is stored in plaintext")
+				}
+
+				body, err := repo.FindOneByPath(t.Context(), tt.inputPath, attributes)
+				if err != nil {
+					t.Error(err)
+				}
+				result, err := io.ReadAll(body)
+				if err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptedBody_FindOneByPath(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputPath       string
+		inputAttributes *entity.BodyAttributes
+		expectResult    []byte
+		expectError     error
+		setMockFS       func(fs afero.Fs)
+	}{
+		{
+			name:            "find file",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{EncryptionKeyID: "new"},
+			expectResult:    []byte("test"),
+			expectError:     nil,
+			setMockFS: func(fs afero.Fs) {
+				repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{newMasterKey})
+				if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewBufferString("test"), nil); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "find file encrypted by rotated master key",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{EncryptionKeyID: "old"},
+			expectResult:    []byte("test"),
+			expectError:     nil,
+			setMockFS: func(fs afero.Fs) {
+				repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "old", []*file.MasterKey{oldMasterKey})
+				if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewBufferString("test"), nil); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "find plaintext file",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{},
+			expectResult:    []byte("test"),
+			expectError:     nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"key/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "find plaintext file starting with magic number",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{},
+			expectResult:    []byte("HOLOSENC\x01test"),
+			expectError:     nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"key/sample.txt", []byte("HOLOSENC\x01test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "find folder",
+			inputPath:       "key",
+			inputAttributes: &entity.BodyAttributes{},
+			expectResult:    nil,
+			expectError:     nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := fs.MkdirAll(basePath+"key", 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "recorded master key id mismatch",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{EncryptionKeyID: "old"},
+			expectResult:    nil,
+			expectError:     file.ErrInvalidBodyHeader,
+			setMockFS: func(fs afero.Fs) {
+				repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{newMasterKey})
+				if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewBufferString("test"), nil); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "encrypted file without recorded master key id",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{EncryptionKeyID: "new"},
+			expectResult:    nil,
+			expectError:     file.ErrInvalidBodyHeader,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"key/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "unknown master key",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{EncryptionKeyID: "unknown"},
+			expectResult:    nil,
+			expectError:     file.ErrMasterKeyNotFound,
+			setMockFS: func(fs afero.Fs) {
+				repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "unknown", []*file.MasterKey{{ID: "unknown", Key: bytes.Repeat([]byte{3}, 32)}})
+				if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewBufferString("test"), nil); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:            "not found",
+			inputPath:       "key/sample.txt",
+			inputAttributes: &entity.BodyAttributes{EncryptionKeyID: "new"},
+			expectResult:    nil,
+			expectError:     afero.ErrFileNotFound,
+			setMockFS:       func(afero.Fs) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			tt.setMockFS(fs)
+
+			repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{oldMasterKey, newMasterKey})
+			body, err := repo.FindOneByPath(t.Context(), tt.inputPath, tt.inputAttributes)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if tt.expectResult != nil {
+				result, err := io.ReadAll(body)
+				if err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptedBody_FindByPath(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{newMasterKey})
+	if err := repo.Create(t.Context(), "volume/key/sample.txt", bytes.NewBufferString("test"), nil); err != nil {
+		t.Error(err)
+	}
+	if err := repo.Create(t.Context(), "volume/large.txt", bytes.NewReader(bytes.Repeat([]byte("a"), 64*1024*2+1)), nil); err != nil {
+		t.Error(err)
+	}
+	if err := repo.Create(t.Context(), "volume/empty.txt", bytes.NewBufferString(""), nil); err != nil {
+		t.Error(err)
+	}
+	if err := afero.WriteFile(fs, basePath+"volume/plain.txt", []byte("plain"), 0o755); err != nil {
+		t.Error(err)
+	}
+	if err := afero.WriteFile(fs, basePath+"volume/magic.txt", []byte("HOLOSENC plain"), 0o755); err != nil {
+		t.Error(err)
+	}
+
+	result, err := repo.FindByPath(t.Context(), "volume")
+	if err != nil {
+		t.Error(err)
+	}
+
+	expect := []*entity.Body{
+		{Path: "empty.txt", Size: 0, IsFolder: false, Attributes: entity.BodyAttributes{EncryptionKeyID: "new"}},
+		{Path: "key", Size: 0, IsFolder: true},
+		{Path: "key/sample.txt", Size: 4, IsFolder: false, Attributes: entity.BodyAttributes{EncryptionKeyID: "new"}},
+		{Path: "large.txt", Size: 64*1024*2 + 1, IsFolder: false, Attributes: entity.BodyAttributes{EncryptionKeyID: "new"}},
+		{Path: "magic.txt", Size: 14, IsFolder: false},
+		{Path: "plain.txt", Size: 5, IsFolder: false},
+	}
+	if diff := cmp.Diff(expect, result); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestEncryptedBody_Derived(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{newMasterKey})
+	if err := repo.CreateDerived(t.Context(), "key/sample.jpg", "thumbnail", bytes.NewBufferString("test")); err != nil {
+		t.Error(err)
+	}
+
+	raw, err := afero.ReadFile(fs, basePath+"holos:derived/key/sample.jpg/thumbnail.enc")
+	if err != nil {
+		t.Error(err)
+	}
+	if bytes.Contains(raw, []byte("test")) {
+		t.Error("derived is stored in plaintext")
+	}
+
+	body, err := repo.FindOneDerived(t.Context(), "key/sample.jpg", "thumbnail")
+	if err != nil {
+		t.Error(err)
+	}
+	result, err := io.ReadAll(body)
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := cmp.Diff([]byte("test"), result); diff != "" {
+		t.Error(diff)
+	}
+
+	body, err = repo.FindOneDerived(t.Context(), "key/sample.jpg", "unknown")
+	if err != nil {
+		t.Error(err)
+	}
+	if body != nil {
+		t.Error("derived is found")
+	}
+
+	if err := afero.WriteFile(fs, basePath+"holos:derived/key/sample.jpg/plain", []byte("plain"), 0o755); err != nil {
+		t.Error(err)
+	}
+	body, err = repo.FindOneDerived(t.Context(), "key/sample.jpg", "plain")
+	if err != nil {
+		t.Error(err)
+	}
+	if body != nil {
+		t.Error("plaintext derived is found")
+	}
+}
+
+func TestEncryptedBody_Tampered(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{newMasterKey})
+	attributes := &entity.BodyAttributes{}
+	if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewBufferString("test"), attributes); err != nil {
+		t.Error(err)
+	}
+
+	raw, err := afero.ReadFile(fs, basePath+"key/sample.txt")
+	if err != nil {
+		t.Error(err)
+	}
+	raw[len(raw)-1] ^= 0xff
+	if err := afero.WriteFile(fs, basePath+"key/sample.txt", raw, 0o755); err != nil {
+		t.Error(err)
+	}
+
+	body, err := repo.FindOneByPath(t.Context(), "key/sample.txt", attributes)
+	if err != nil {
+		t.Error(err)
+	}
+	if _, err := io.ReadAll(body); !errors.Is(err, file.ErrCorruptedBody) {
+		t.Errorf("\nexpect: %v\ngot: %v", file.ErrCorruptedBody, err)
+	}
+}
+
+func TestEncryptedBody_Seek(t *testing.T) {
+	content := make([]byte, 64*1024*3+100)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+
+	tests := []struct {
+		name         string
+		inputOffset  int64
+		inputWhence  int
+		expectResult []byte
+	}{
+		{name: "seek start", inputOffset: 0, inputWhence: io.SeekStart, expectResult: content},
+		{name: "seek within first chunk", inputOffset: 10, inputWhence: io.SeekStart, expectResult: content[10:]},
+		{name: "seek chunk boundary", inputOffset: 64 * 1024, inputWhence: io.SeekStart, expectResult: content[64*1024:]},
+		{name: "seek within last chunk", inputOffset: 64*1024*3 + 50, inputWhence: io.SeekStart, expectResult: content[64*1024*3+50:]},
+		{name: "seek end", inputOffset: -30, inputWhence: io.SeekEnd, expectResult: content[len(content)-30:]},
+		{name: "seek eof", inputOffset: 0, inputWhence: io.SeekEnd, expectResult: []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			repo := file.NewEncryptedBodyRepository(file.NewBodyRepository(fs, basePath), "new", []*file.MasterKey{newMasterKey})
+			attributes := &entity.BodyAttributes{}
+			if err := repo.Create(t.Context(), "key/sample.txt", bytes.NewReader(content), attributes); err != nil {
+				t.Error(err)
+			}
+
+			body, err := repo.FindOneByPath(t.Context(), "key/sample.txt", attributes)
+			if err != nil {
+				t.Error(err)
+			}
+			seeker, ok := body.(io.ReadSeeker)
+			if !ok {
+				t.Fatal("body does not implement io.ReadSeeker")
+			}
+
+			if _, err := seeker.Seek(tt.inputOffset, tt.inputWhence); err != nil {
+				t.Error(err)
+			}
+			result, err := io.ReadAll(seeker)
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
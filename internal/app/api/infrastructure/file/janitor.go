@@ -0,0 +1,70 @@
+package file
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// NOTE: 書き込み中の一時ファイルを削除しないよう, 有効期限を過ぎたものだけを削除する.
+func RemoveStaleTempFiles(fs afero.Fs, basePath string, expiration time.Duration) error {
+	deadline := time.Now().Add(-expiration)
+
+	return afero.Walk(fs, basePath, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if name == basePath+strings.TrimSuffix(trashPath, "/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if strings.HasPrefix(info.Name(), tempPrefix) && info.ModTime().Before(deadline) {
+			return fs.Remove(name)
+		}
+		return nil
+	})
+}
+
+// NOTE: 停止によりコミット後の削除やロールバックが行われなかった退避先を削除する.
+// 実行中のトランザクションの退避先を削除しないよう, 退避した日時から有効期限を過ぎたものだけを削除する.
+func RemoveOrphanedTrash(fs afero.Fs, basePath string, expiration time.Duration) error {
+	deadline := time.Now().Add(-expiration)
+
+	entries, err := afero.ReadDir(fs, basePath+trashPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if trashedAt(entry).Before(deadline) {
+			if err := fs.RemoveAll(basePath + trashPath + entry.Name()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NOTE: 退避した日時を含まない名前は更新日時で判定する.
+func trashedAt(info os.FileInfo) time.Time {
+	prefix, _, ok := strings.Cut(info.Name(), trashSeparator)
+	if !ok {
+		return info.ModTime()
+	}
+	unix, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil {
+		return info.ModTime()
+	}
+	return time.Unix(unix, 0)
+}
@@ -0,0 +1,149 @@
+package file_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+)
+
+func TestJanitor_RemoveStaleTempFiles(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectPaths   []string
+		unexpectPaths []string
+		expectError   error
+		setMockFS     func(fs afero.Fs)
+	}{
+		{
+			name:          "remove stale temp files",
+			expectPaths:   []string{"volume/sample.txt", "volume/holos:tmp:fresh"},
+			unexpectPaths: []string{"volume/holos:tmp:stale", "volume/key/holos:tmp:stale"},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				old := time.Now().Add(-2 * time.Hour)
+				for path, modTime := range map[string]time.Time{
+					"volume/sample.txt":          old,
+					"volume/holos:tmp:fresh":     time.Now(),
+					"volume/holos:tmp:stale":     old,
+					"volume/key/holos:tmp:stale": old,
+				} {
+					if err := afero.WriteFile(fs, basePath+path, []byte("test"), 0o755); err != nil {
+						t.Error(err)
+					}
+					if err := fs.Chtimes(basePath+path, modTime, modTime); err != nil {
+						t.Error(err)
+					}
+				}
+			},
+		},
+		{
+			name:          "skip trash",
+			expectPaths:   []string{"holos:trash/holos:tmp:stale"},
+			unexpectPaths: []string{},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				old := time.Now().Add(-2 * time.Hour)
+				if err := afero.WriteFile(fs, basePath+"holos:trash/holos:tmp:stale", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+				if err := fs.Chtimes(basePath+"holos:trash/holos:tmp:stale", old, old); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			tt.setMockFS(fs)
+
+			if err := file.RemoveStaleTempFiles(fs, basePath, time.Hour); err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectPaths, true); err != nil {
+				t.Error(err)
+			}
+			if err := checkExists(fs, tt.unexpectPaths, false); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJanitor_RemoveOrphanedTrash(t *testing.T) {
+	stale := strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10)
+	fresh := strconv.FormatInt(time.Now().Unix(), 10)
+
+	tests := []struct {
+		name          string
+		expectPaths   []string
+		unexpectPaths []string
+		expectError   error
+		setMockFS     func(fs afero.Fs)
+	}{
+		{
+			name:          "remove orphaned trash",
+			expectPaths:   []string{"holos:trash/" + fresh + "-fresh", "volume/sample.txt"},
+			unexpectPaths: []string{"holos:trash/" + stale + "-stale", "holos:trash/" + stale + "-folder"},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				for _, path := range []string{"holos:trash/" + fresh + "-fresh", "holos:trash/" + stale + "-stale", "holos:trash/" + stale + "-folder/sample.txt", "volume/sample.txt"} {
+					if err := afero.WriteFile(fs, basePath+path, []byte("test"), 0o755); err != nil {
+						t.Error(err)
+					}
+				}
+			},
+		},
+		{
+			name:          "legacy trash",
+			expectPaths:   []string{"holos:trash/fresh"},
+			unexpectPaths: []string{"holos:trash/stale"},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				old := time.Now().Add(-2 * time.Hour)
+				for path, modTime := range map[string]time.Time{
+					"holos:trash/fresh": time.Now(),
+					"holos:trash/stale": old,
+				} {
+					if err := afero.WriteFile(fs, basePath+path, []byte("test"), 0o755); err != nil {
+						t.Error(err)
+					}
+					if err := fs.Chtimes(basePath+path, modTime, modTime); err != nil {
+						t.Error(err)
+					}
+				}
+			},
+		},
+		{
+			name:          "trash not found",
+			expectPaths:   []string{},
+			unexpectPaths: []string{},
+			expectError:   nil,
+			setMockFS:     func(afero.Fs) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			tt.setMockFS(fs)
+
+			if err := file.RemoveOrphanedTrash(fs, basePath, time.Hour); err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectPaths, true); err != nil {
+				t.Error(err)
+			}
+			if err := checkExists(fs, tt.unexpectPaths, false); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
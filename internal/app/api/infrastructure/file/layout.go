@@ -0,0 +1,47 @@
+package file
+
+import (
+	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+)
+
+// NOTE: キー及びボリューム名に利用できない文字を含めることでボリュームとの衝突を防ぐ.
+const (
+	layoutMarkerPath = "holos:layout"
+	migratePrefix    = "holos:migrate:"
+)
+
+// NOTE: ボリューム名をアカウントごとに一意としたため, ボディの保存先をボリューム名からアカウントID配下に移行する.
+// ボリューム名とアカウントIDが衝突しないよう, 一度ボリュームIDへ退避してから移動する.
+func MigrateVolumeLayout(fs afero.Fs, basePath string, volumes []*entity.Volume) error {
+	migrated, err := afero.Exists(fs, basePath+layoutMarkerPath)
+	if err != nil || migrated {
+		return err
+	}
+
+	for _, volume := range volumes {
+		if err := renameIfExists(fs, basePath+volume.Name, basePath+migratePrefix+volume.ID.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, volume := range volumes {
+		if err := fs.MkdirAll(basePath+volume.AccountID.String(), 0o755); err != nil {
+			return err
+		}
+		if err := renameIfExists(fs, basePath+migratePrefix+volume.ID.String(), basePath+volume.Path()); err != nil {
+			return err
+		}
+	}
+
+	return afero.WriteFile(fs, basePath+layoutMarkerPath, nil, 0o644)
+}
+
+func renameIfExists(fs afero.Fs, src, dst string) error {
+	exists, err := afero.Exists(fs, src)
+	if err != nil || !exists {
+		return err
+	}
+	return fs.Rename(src, dst)
+}
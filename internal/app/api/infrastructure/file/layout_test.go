@@ -0,0 +1,101 @@
+package file_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+)
+
+func TestLayout_MigrateVolumeLayout(t *testing.T) {
+	accountID := uuid.New()
+	otherAccountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	conflictVolume := &entity.Volume{ID: uuid.New(), AccountID: otherAccountID, Name: accountID.String()}
+	emptyVolume := &entity.Volume{ID: uuid.New(), AccountID: otherAccountID, Name: "empty"}
+
+	tests := []struct {
+		name          string
+		inputVolumes  []*entity.Volume
+		expectPaths   []string
+		unexpectPaths []string
+		expectError   error
+		setMockFS     func(fs afero.Fs)
+	}{
+		{
+			name:          "successfully migrated",
+			inputVolumes:  []*entity.Volume{volume, emptyVolume},
+			expectPaths:   []string{volume.Path() + "/key/sample.txt", "holos:layout"},
+			unexpectPaths: []string{"name", emptyVolume.Path()},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"name/key/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:          "volume name conflicts with account id",
+			inputVolumes:  []*entity.Volume{conflictVolume, volume},
+			expectPaths:   []string{volume.Path() + "/sample.txt", conflictVolume.Path() + "/other.txt"},
+			unexpectPaths: []string{accountID.String() + "/other.txt"},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"name/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+				if err := afero.WriteFile(fs, basePath+accountID.String()+"/other.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:          "resume interrupted migration",
+			inputVolumes:  []*entity.Volume{volume},
+			expectPaths:   []string{volume.Path() + "/sample.txt"},
+			unexpectPaths: []string{"holos:migrate:" + volume.ID.String()},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"holos:migrate:"+volume.ID.String()+"/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+		{
+			name:          "already migrated",
+			inputVolumes:  []*entity.Volume{volume},
+			expectPaths:   []string{"name/sample.txt"},
+			unexpectPaths: []string{volume.Path()},
+			expectError:   nil,
+			setMockFS: func(fs afero.Fs) {
+				if err := afero.WriteFile(fs, basePath+"holos:layout", nil, 0o644); err != nil {
+					t.Error(err)
+				}
+				if err := afero.WriteFile(fs, basePath+"name/sample.txt", []byte("test"), 0o755); err != nil {
+					t.Error(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			tt.setMockFS(fs)
+
+			if err := file.MigrateVolumeLayout(fs, basePath, tt.inputVolumes); err != tt.expectError {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectPaths, true); err != nil {
+				t.Error(err)
+			}
+			if err := checkExists(fs, tt.unexpectPaths, false); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+)
+
+type journalKey struct{}
+
+// NOTE: staged はトランザクション内で書き込んだパスと, 同じディレクトリに書き込んだ一時ファイル名を表す.
+type journal struct {
+	undos    []func() error
+	purges   []func() error
+	prepares []func() error
+	discards []func() error
+	staged   map[string]string
+}
+
+func getJournal(ctx context.Context) *journal {
+	if j, ok := ctx.Value(journalKey{}).(*journal); ok {
+		return j
+	}
+	return nil
+}
+
+func (j *journal) addUndo(fn func() error) {
+	j.undos = append(j.undos, fn)
+}
+
+func (j *journal) addPurge(fn func() error) {
+	j.purges = append(j.purges, fn)
+}
+
+func (j *journal) stagedPaths() []string {
+	paths := make([]string, 0, len(j.staged))
+	for path := range j.staged {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// NOTE: ディレクトリの移動に合わせて, 配下に書き込んだ一時ファイルの記録を移動先のパスに付け替える.
+func (j *journal) restage(src, dst string) {
+	for _, path := range j.stagedPaths() {
+		if strings.HasPrefix(path, src+"/") {
+			j.staged[dst+strings.TrimPrefix(path, src)] = j.staged[path]
+			delete(j.staged, path)
+		}
+	}
+}
+
+func (j *journal) addPrepare(fn func() error) {
+	j.prepares = append(j.prepares, fn)
+}
+
+func (j *journal) addDiscard(fn func() error) {
+	j.discards = append(j.discards, fn)
+}
+
+func (j *journal) prepare() error {
+	for _, prepare := range j.prepares {
+		if err := prepare(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NOTE: 一時ファイルはディレクトリの移動を取り消す前に削除する.
+func (j *journal) rollback() error {
+	var errs []error
+	for _, discard := range j.discards {
+		if err := discard(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(j.undos) - 1; 0 <= i; i-- {
+		if err := j.undos[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (j *journal) commit() error {
+	var errs []error
+	for _, purge := range j.purges {
+		if err := purge(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type transactionObject struct {
+	transactionObj transaction.TransactionObject
+}
+
+func NewTransactionObject(transactionObj transaction.TransactionObject) transaction.TransactionObject {
+	return &transactionObject{
+		transactionObj: transactionObj,
+	}
+}
+
+func (to *transactionObject) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	// NOTE: 入れ子のトランザクションは外側のジャーナルに記録し, コミット及びロールバックを外側に委ねる.
+	if getJournal(ctx) != nil {
+		return to.transactionObj.Transaction(ctx, fn)
+	}
+
+	j := &journal{}
+	ctx = context.WithValue(ctx, journalKey{}, j)
+
+	defer func() {
+		if r := recover(); r != nil {
+			if err := j.rollback(); err != nil {
+				log.Println(err.Error())
+			}
+			panic(r)
+		}
+	}()
+
+	// NOTE: データベースのコミット直前に一時ファイルを移動し, 失敗した場合はデータベースもロールバックする.
+	if err := to.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		return j.prepare()
+	}); err != nil {
+		if rollbackErr := j.rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+
+	// NOTE: データベースはコミット済みのため, ゴミ箱の削除に失敗しても記録のみ行う.
+	if err := j.commit(); err != nil {
+		log.Println(err.Error())
+	}
+	return nil
+}
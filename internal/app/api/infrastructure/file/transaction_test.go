@@ -0,0 +1,376 @@
+package file_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+)
+
+func TestTransaction_Transaction(t *testing.T) {
+	operate := func(repo repository.BodyRepository) func(context.Context) error {
+		return func(ctx context.Context) error {
+			if err := repo.Create(ctx, "volume/new/sample.txt", bytes.NewBufferString("new"), nil); err != nil {
+				return err
+			}
+			if err := repo.Create(ctx, "volume/overwrite.txt", bytes.NewBufferString("overwritten"), nil); err != nil {
+				return err
+			}
+			if err := repo.Update(ctx, "volume/src.txt", "volume/moved/dst.txt"); err != nil {
+				return err
+			}
+			if err := repo.Delete(ctx, "volume/delete"); err != nil {
+				return err
+			}
+			return repo.Copy(ctx, "volume/copy.txt", "volume/copy copy.txt")
+		}
+	}
+
+	tests := []struct {
+		name               string
+		inputFn            func(repository.BodyRepository) func(context.Context) error
+		expectError        error
+		expectExistsPaths  []string
+		expectMissingPaths []string
+		expectContents     map[string]string
+		setMockTransaction func(*mockTransaction.MockTransactionObject)
+	}{
+		{
+			name:               "commit",
+			inputFn:            operate,
+			expectError:        nil,
+			expectExistsPaths:  []string{"volume/new/sample.txt", "volume/moved/dst.txt", "volume/copy copy.txt"},
+			expectMissingPaths: []string{"volume/src.txt", "volume/delete"},
+			expectContents:     map[string]string{"volume/overwrite.txt": "overwritten"},
+			setMockTransaction: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+		},
+		{
+			name: "rollback on error",
+			inputFn: func(repo repository.BodyRepository) func(context.Context) error {
+				return func(ctx context.Context) error {
+					if err := operate(repo)(ctx); err != nil {
+						return err
+					}
+					return sql.ErrConnDone
+				}
+			},
+			expectError:        sql.ErrConnDone,
+			expectExistsPaths:  []string{"volume/src.txt", "volume/delete/sample.txt"},
+			expectMissingPaths: []string{"volume/new", "volume/moved", "volume/copy copy.txt"},
+			expectContents:     map[string]string{"volume/overwrite.txt": "original", "volume/delete/sample.txt": "delete"},
+			setMockTransaction: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:               "rollback on commit error",
+			inputFn:            operate,
+			expectError:        sql.ErrTxDone,
+			expectExistsPaths:  []string{"volume/src.txt", "volume/delete/sample.txt"},
+			expectMissingPaths: []string{"volume/new", "volume/moved", "volume/copy copy.txt"},
+			expectContents:     map[string]string{"volume/overwrite.txt": "original"},
+			setMockTransaction: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						if err := fn(ctx); err != nil {
+							return err
+						}
+						return sql.ErrTxDone
+					}).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for path, content := range map[string]string{
+				"volume/overwrite.txt":     "original",
+				"volume/src.txt":           "src",
+				"volume/delete/sample.txt": "delete",
+				"volume/copy.txt":          "copy",
+			} {
+				if err := afero.WriteFile(fs, basePath+path, []byte(content), 0o755); err != nil {
+					t.Error(err)
+				}
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inner := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransaction(inner)
+
+			repo := file.NewBodyRepository(fs, basePath)
+			transactionObj := file.NewTransactionObject(inner)
+			if err := transactionObj.Transaction(t.Context(), tt.inputFn(repo)); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectExistsPaths, true); err != nil {
+				t.Error(err)
+			}
+			if err := checkExists(fs, tt.expectMissingPaths, false); err != nil {
+				t.Error(err)
+			}
+			for path, content := range tt.expectContents {
+				body, err := repo.FindOneByPath(t.Context(), path, nil)
+				if err != nil {
+					t.Error(err)
+					continue
+				}
+				result, err := io.ReadAll(body)
+				if err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff(content, string(result)); diff != "" {
+					t.Error(diff)
+				}
+			}
+
+			trash, err := afero.ReadDir(fs, basePath+"holos:trash")
+			if err != nil {
+				t.Error(err)
+			}
+			if len(trash) != 0 {
+				t.Errorf("\nexpect: empty trash\ngot: %d items", len(trash))
+			}
+		})
+	}
+}
+
+func TestTransaction_Transaction_Staging(t *testing.T) {
+	readBody := func(ctx context.Context, repo repository.BodyRepository, path string) (string, error) {
+		body, err := repo.FindOneByPath(ctx, path, nil)
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		result, err := io.ReadAll(body)
+		return string(result), err
+	}
+
+	operate := func(fs afero.Fs, repo repository.BodyRepository) func(context.Context) error {
+		return func(ctx context.Context) error {
+			if err := repo.Create(ctx, "volume/overwrite.txt", bytes.NewBufferString("overwritten"), nil); err != nil {
+				return err
+			}
+			// NOTE: コミット前は元のパスの内容が変わらず, トランザクション内では書き込んだ内容を参照できる.
+			live, err := afero.ReadFile(fs, basePath+"volume/overwrite.txt")
+			if err != nil {
+				return err
+			}
+			if string(live) != "original" {
+				t.Errorf("\nexpect: original\ngot: %s", live)
+			}
+			if result, err := readBody(ctx, repo, "volume/overwrite.txt"); err != nil || result != "overwritten" {
+				t.Errorf("\nexpect: overwritten\ngot: %s, %v", result, err)
+			}
+
+			if err := repo.Create(ctx, "volume/dir/staged.txt", bytes.NewBufferString("staged"), nil); err != nil {
+				return err
+			}
+			if err := repo.Copy(ctx, "volume/dir", "volume/copied"); err != nil {
+				return err
+			}
+			if err := repo.Update(ctx, "volume/dir", "volume/moved"); err != nil {
+				return err
+			}
+			if err := repo.Create(ctx, "volume/renamed.txt", bytes.NewBufferString("renamed"), nil); err != nil {
+				return err
+			}
+			if err := repo.Update(ctx, "volume/renamed.txt", "volume/sub/renamed.txt"); err != nil {
+				return err
+			}
+			if err := repo.Create(ctx, "volume/deleted.txt", bytes.NewBufferString("deleted"), nil); err != nil {
+				return err
+			}
+			return repo.Delete(ctx, "volume/deleted.txt")
+		}
+	}
+
+	tests := []struct {
+		name               string
+		inputError         error
+		expectError        error
+		expectMissingPaths []string
+		expectContents     map[string]string
+	}{
+		{
+			name:               "commit",
+			inputError:         nil,
+			expectError:        nil,
+			expectMissingPaths: []string{"volume/dir", "volume/renamed.txt", "volume/deleted.txt"},
+			expectContents: map[string]string{
+				"volume/overwrite.txt":     "overwritten",
+				"volume/copied/staged.txt": "staged",
+				"volume/moved/staged.txt":  "staged",
+				"volume/moved/sample.txt":  "dir",
+				"volume/sub/renamed.txt":   "renamed",
+			},
+		},
+		{
+			name:               "rollback",
+			inputError:         sql.ErrConnDone,
+			expectError:        sql.ErrConnDone,
+			expectMissingPaths: []string{"volume/dir/staged.txt", "volume/copied", "volume/moved", "volume/sub", "volume/renamed.txt", "volume/deleted.txt"},
+			expectContents: map[string]string{
+				"volume/overwrite.txt":  "original",
+				"volume/dir/sample.txt": "dir",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for path, content := range map[string]string{
+				"volume/overwrite.txt":  "original",
+				"volume/dir/sample.txt": "dir",
+			} {
+				if err := afero.WriteFile(fs, basePath+path, []byte(content), 0o755); err != nil {
+					t.Error(err)
+				}
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inner := mockTransaction.NewMockTransactionObject(ctrl)
+			inner.
+				EXPECT().
+				Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1)
+
+			repo := file.NewBodyRepository(fs, basePath)
+			transactionObj := file.NewTransactionObject(inner)
+			if err := transactionObj.Transaction(t.Context(), func(ctx context.Context) error {
+				if err := operate(fs, repo)(ctx); err != nil {
+					return err
+				}
+				return tt.inputError
+			}); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, tt.expectMissingPaths, false); err != nil {
+				t.Error(err)
+			}
+			for path, content := range tt.expectContents {
+				result, err := readBody(t.Context(), repo, path)
+				if err != nil {
+					t.Error(err)
+					continue
+				}
+				if diff := cmp.Diff(content, result); diff != "" {
+					t.Error(diff)
+				}
+			}
+			if err := checkNoTempFiles(fs); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestTransaction_Transaction_Nested(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputError    error
+		expectError   error
+		expectExists  bool
+		expectContent string
+	}{
+		{
+			name:          "commit with outer transaction",
+			inputError:    nil,
+			expectError:   nil,
+			expectExists:  true,
+			expectContent: "nested",
+		},
+		{
+			name:         "rollback with outer transaction",
+			inputError:   sql.ErrConnDone,
+			expectError:  sql.ErrConnDone,
+			expectExists: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			inner := mockTransaction.NewMockTransactionObject(ctrl)
+			inner.
+				EXPECT().
+				Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(2)
+
+			repo := file.NewBodyRepository(fs, basePath)
+			transactionObj := file.NewTransactionObject(inner)
+			if err := transactionObj.Transaction(t.Context(), func(ctx context.Context) error {
+				if err := transactionObj.Transaction(ctx, func(ctx context.Context) error {
+					return repo.Create(ctx, "volume/nested.txt", bytes.NewBufferString("nested"), nil)
+				}); err != nil {
+					return err
+				}
+				// NOTE: 入れ子のトランザクションの終了時点ではコミットされない.
+				if err := checkExists(fs, []string{"volume/nested.txt"}, false); err != nil {
+					t.Error(err)
+				}
+				return tt.inputError
+			}); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if err := checkExists(fs, []string{"volume/nested.txt"}, tt.expectExists); err != nil {
+				t.Error(err)
+			}
+			if tt.expectExists {
+				content, err := afero.ReadFile(fs, basePath+"volume/nested.txt")
+				if err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff(tt.expectContent, string(content)); diff != "" {
+					t.Error(diff)
+				}
+			}
+			if err := checkNoTempFiles(fs); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+)
+
+type counter struct {
+	count     uint64
+	expiresAt time.Time
+}
+
+type memoryRepository struct {
+	mu       sync.Mutex
+	limit    uint64
+	window   time.Duration
+	counters map[string]*counter
+	prunedAt time.Time
+}
+
+// NOTE: プロセス内で固定の期間ごとに回数を数えるため, 複数のプロセスでは上限が共有されない.
+func NewMemoryRepository(limit uint64, window time.Duration) repository.RateLimitRepository {
+	return &memoryRepository{
+		limit:    limit,
+		window:   window,
+		counters: make(map[string]*counter),
+		prunedAt: time.Now(),
+	}
+}
+
+func (r *memoryRepository) Allow(_ context.Context, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.prune(now)
+
+	c, ok := r.counters[key]
+	if !ok || !now.Before(c.expiresAt) {
+		c = &counter{expiresAt: now.Add(r.window)}
+		r.counters[key] = c
+	}
+	if r.limit <= c.count {
+		return false, nil
+	}
+	c.count++
+	return true, nil
+}
+
+// NOTE: 期限切れの回数が蓄積しないよう, 期間ごとに削除する.
+func (r *memoryRepository) prune(now time.Time) {
+	if now.Sub(r.prunedAt) < r.window {
+		return
+	}
+	for key, c := range r.counters {
+		if !now.Before(c.expiresAt) {
+			delete(r.counters, key)
+		}
+	}
+	r.prunedAt = now
+}
@@ -0,0 +1,79 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/ratelimit"
+)
+
+func TestMemory_Allow(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputLimit    uint64
+		inputWindow   time.Duration
+		inputKeys     []string
+		inputWait     time.Duration
+		expectResults []bool
+	}{
+		{
+			name:          "under limit",
+			inputLimit:    2,
+			inputWindow:   time.Minute,
+			inputKeys:     []string{"192.0.2.1", "192.0.2.1"},
+			expectResults: []bool{true, true},
+		},
+		{
+			name:          "limit exceeded",
+			inputLimit:    2,
+			inputWindow:   time.Minute,
+			inputKeys:     []string{"192.0.2.1", "192.0.2.1", "192.0.2.1"},
+			expectResults: []bool{true, true, false},
+		},
+		{
+			name:          "counted per key",
+			inputLimit:    1,
+			inputWindow:   time.Minute,
+			inputKeys:     []string{"192.0.2.1", "192.0.2.2", "192.0.2.1"},
+			expectResults: []bool{true, true, false},
+		},
+		{
+			name:          "window expired",
+			inputLimit:    1,
+			inputWindow:   10 * time.Millisecond,
+			inputKeys:     []string{"192.0.2.1", "192.0.2.1"},
+			inputWait:     20 * time.Millisecond,
+			expectResults: []bool{true, true},
+		},
+		{
+			name:          "zero limit",
+			inputLimit:    0,
+			inputWindow:   time.Minute,
+			inputKeys:     []string{"192.0.2.1"},
+			expectResults: []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := ratelimit.NewMemoryRepository(tt.inputLimit, tt.inputWindow)
+
+			results := make([]bool, len(tt.inputKeys))
+			for i, key := range tt.inputKeys {
+				if 0 < i {
+					time.Sleep(tt.inputWait)
+				}
+				result, err := repo.Allow(t.Context(), key)
+				if err != nil {
+					t.Error(err)
+				}
+				results[i] = result
+			}
+
+			if diff := cmp.Diff(tt.expectResults, results); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+)
+
+const clamdChunkSize = 32 << 10
+
+type clamdRepository struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NOTE: clamdのINSTREAMコマンドでボディを送信する. networkは"tcp"または"unix"とする.
+func NewClamdRepository(network, address string, timeout time.Duration) repository.ScannerRepository {
+	return &clamdRepository{
+		network: network,
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (r *clamdRepository) Scan(ctx context.Context, body io.Reader) (_ string, err error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, r.network, r.address)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// NOTE: errに直接詰めると関数内のエラーがnilで上書きされるためエラー発生時のみ上書きする.
+		if e := conn.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	if err := conn.SetDeadline(time.Now().Add(r.timeout)); err != nil {
+		return "", err
+	}
+
+	// NOTE: 上限を超えた場合はclamdが応答して接続を閉じるため, 書き込みに失敗した場合も応答を読み取る.
+	sendErr := r.send(conn, body)
+	var opErr *net.OpError
+	if sendErr != nil && !errors.As(sendErr, &opErr) {
+		return "", sendErr
+	}
+
+	return r.receive(conn, sendErr)
+}
+
+func (r *clamdRepository) send(conn net.Conn, body io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := body.Read(buf[4:])
+		if 0 < n {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// NOTE: 長さが0のチャンクでストリームの終端を通知する.
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+func (r *clamdRepository) receive(conn net.Conn, sendErr error) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return "", errors.Join(sendErr, err)
+	}
+
+	signature, err := r.parse(reply)
+	if err != nil {
+		return "", err
+	}
+	if signature == "" && sendErr != nil {
+		return "", sendErr
+	}
+	return signature, nil
+}
+
+// NOTE: 応答は"stream: OK", "stream: <検出名> FOUND", "<理由> ERROR"のいずれかとなる.
+func (r *clamdRepository) parse(reply string) (string, error) {
+	reply = strings.TrimPrefix(strings.TrimRight(reply, "\x00\n"), "stream: ")
+	switch {
+	case reply == "OK":
+		return "", nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return strings.TrimSuffix(reply, " FOUND"), nil
+	default:
+		return "", errors.Join(repository.ErrScanFailed, errors.New(reply))
+	}
+}
@@ -0,0 +1,124 @@
+package scanner_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/scanner"
+)
+
+// NOTE: INSTREAMコマンドで受信したボディを検査し, 応答を返却するclamdを模倣する.
+func serveClamd(t *testing.T, listener net.Listener, reply func([]byte) string) {
+	t.Helper()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	command, err := reader.ReadString(0)
+	if err != nil || command != "zINSTREAM\x00" {
+		t.Errorf("unexpected command: %q", command)
+		return
+	}
+
+	var body bytes.Buffer
+	for {
+		var size uint32
+		// NOTE: ボディの読み込みに失敗した場合は終端を送信せずに接続が閉じられる.
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		if _, err := io.CopyN(&body, reader, int64(size)); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	conn.Write([]byte(reply(body.Bytes()) + "\x00"))
+}
+
+func TestClamd_Scan(t *testing.T) {
+	tests := []struct {
+		name         string
+		inputBody    io.Reader
+		expectResult string
+		expectError  error
+		reply        func([]byte) string
+	}{
+		{
+			name:         "clean",
+			inputBody:    strings.NewReader(strings.Repeat("a", 100<<10)),
+			expectResult: "",
+			expectError:  nil,
+			reply: func(body []byte) string {
+				if len(body) != 100<<10 {
+					return "stream: unexpected size ERROR"
+				}
+				return "stream: OK"
+			},
+		},
+		{
+			name:         "infected",
+			inputBody:    strings.NewReader("infected"),
+			expectResult: "Win.Test.EICAR_HDB-1",
+			expectError:  nil,
+			reply:        func([]byte) string { return "stream: Win.Test.EICAR_HDB-1 FOUND" },
+		},
+		{
+			name:         "scan error",
+			inputBody:    strings.NewReader("large"),
+			expectResult: "",
+			expectError:  repository.ErrScanFailed,
+			reply:        func([]byte) string { return "INSTREAM size limit exceeded. ERROR" },
+		},
+		{
+			name:         "read body error",
+			inputBody:    io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(io.ErrUnexpectedEOF)),
+			expectResult: "",
+			expectError:  io.ErrUnexpectedEOF,
+			reply:        func([]byte) string { return "stream: OK" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer listener.Close()
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				serveClamd(t, listener, tt.reply)
+			}()
+
+			repo := scanner.NewClamdRepository("tcp", listener.Addr().String(), time.Second)
+			result, err := repo.Scan(t.Context(), tt.inputBody)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+
+			listener.Close()
+			<-done
+		})
+	}
+}
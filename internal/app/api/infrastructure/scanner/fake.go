@@ -0,0 +1,44 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+)
+
+const EICARSignature = "Eicar-Test-Signature"
+
+// NOTE: ソースコード自体が検出されないよう分割して定義する.
+var eicar = []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+
+type fakeRepository struct{}
+
+// NOTE: テストと開発環境向けに, EICARテストファイルの文字列のみを検出する.
+func NewFakeRepository() repository.ScannerRepository {
+	return &fakeRepository{}
+}
+
+func (r *fakeRepository) Scan(ctx context.Context, body io.Reader) (string, error) {
+	buf := make([]byte, 0, len(eicar)-1+clamdChunkSize)
+	chunk := make([]byte, clamdChunkSize)
+	for ctx.Err() == nil {
+		n, err := body.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if bytes.Contains(buf, eicar) {
+			return EICARSignature, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		// NOTE: チャンクの境界をまたぐ文字列を検出するため, 末尾を次のチャンクと連結する.
+		buf = append(buf[:0], buf[max(0, len(buf)-len(eicar)+1):]...)
+	}
+	return "", ctx.Err()
+}
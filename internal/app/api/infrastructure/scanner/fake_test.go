@@ -0,0 +1,58 @@
+package scanner_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/scanner"
+)
+
+func TestFake_Scan(t *testing.T) {
+	eicar := `X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+	tests := []struct {
+		name         string
+		inputBody    io.Reader
+		expectResult string
+		expectError  error
+	}{
+		{
+			name:         "clean",
+			inputBody:    strings.NewReader("test"),
+			expectResult: "",
+			expectError:  nil,
+		},
+		{
+			name:         "infected",
+			inputBody:    strings.NewReader(eicar),
+			expectResult: scanner.EICARSignature,
+			expectError:  nil,
+		},
+		{
+			name:         "across chunks",
+			inputBody:    strings.NewReader(strings.Repeat("a", 32<<10-10) + eicar),
+			expectResult: scanner.EICARSignature,
+			expectError:  nil,
+		},
+		{
+			name:         "read error",
+			inputBody:    iotest.ErrReader(io.ErrUnexpectedEOF),
+			expectResult: "",
+			expectError:  io.ErrUnexpectedEOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := scanner.NewFakeRepository().Scan(t.Context(), tt.inputBody)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
@@ -2,46 +2,120 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/spf13/afero"
 
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/service"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/api"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database/pkg/transaction"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/ratelimit"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/scanner"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/middleware"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
 )
 
+// NOTE: 送信先の応答が遅い場合に他の配信が滞らないようにタイムアウトを設定する.
+const webhookTimeout = 10 * time.Second
+
+const scannerTimeout = 5 * time.Minute
+
 var (
 	authorizationMW middleware.AuthorizationMiddleware
+	auditMW         middleware.AuditMiddleware
+	normalizationMW middleware.NormalizationMiddleware
+
+	healthHdl      handler.HealthHandler
+	volumeHdl      handler.VolumeHandler
+	entryHdl       handler.EntryHandler
+	fsckHdl        handler.FsckHandler
+	jobHdl         handler.JobHandler
+	webhookHdl     handler.WebhookHandler
+	changeHdl      handler.ChangeHandler
+	auditLogHdl    handler.AuditLogHandler
+	imagePresetHdl handler.ImagePresetHandler
 
-	healthHdl handler.HealthHandler
-	volumeHdl handler.VolumeHandler
-	entryHdl  handler.EntryHandler
+	jobUC     usecase.JobUsecase
+	webhookUC usecase.WebhookUsecase
 )
 
 func inject(db *sqlx.DB, fs afero.Fs, config *serverConfig) {
-	transactionObj := transaction.NewDBTransactionObject(db)
+	transactionObj := file.NewTransactionObject(transaction.NewDBTransactionObject(db))
 
 	accountRepo := api.NewAccountRepository(&http.Client{}, "http://account-api:8000/authorization")
 	volumeRepo := database.NewVolumeRepository(db)
 	entryRepo := database.NewEntryRepository(db)
-	bodyRepo := file.NewBodyRepository(fs, config.fileSystem.BasePath)
+	entryMetadataRepo := database.NewEntryMetadataRepository(db)
+	entryContentRepo := database.NewEntryContentRepository(db)
+	bodyRepo := newBodyRepository(fs, &config.fileSystem)
+	scannerRepo := newScannerRepository(&config.scanner)
+	jobRepo := database.NewJobRepository(db)
+	changeRepo := database.NewChangeRepository(db)
+	auditLogRepo := database.NewAuditLogRepository(db)
+	webhookRepo := database.NewWebhookRepository(db)
+	webhookDeliveryRepo := database.NewWebhookDeliveryRepository(db)
+	webhookEndpointRepo := api.NewWebhookEndpointRepository(api.NewWebhookClient(webhookTimeout))
+	imagePresetRepo := database.NewImagePresetRepository(db)
+	rateLimitRepo := ratelimit.NewMemoryRepository(config.drop.RateLimit, config.drop.RateWindow)
 
 	volumeServ := service.NewVolumeService(volumeRepo, entryRepo)
 	entryServ := service.NewEntryService(entryRepo)
+	eventServ := service.NewEventService(changeRepo, webhookRepo, webhookDeliveryRepo)
 
-	authorizationUC := usecase.NewAuthorizationUsecase(accountRepo, volumeRepo)
-	volumeUC := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ)
-	entryUC := usecase.NewEntryUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, entryServ)
+	authorizationUC := usecase.NewAuthorizationUsecase(accountRepo, volumeRepo, rateLimitRepo)
+	volumeUC := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ, eventServ)
+	entryUC := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, scannerRepo, entryServ, eventServ, config.scanner.Action)
+	fsckUC := usecase.NewFsckUsecase(transactionObj, volumeRepo, entryRepo, bodyRepo, entryServ)
+	jobUC = usecase.NewJobUsecase(transactionObj, jobRepo, entryUC)
+	webhookUC = usecase.NewWebhookUsecase(transactionObj, webhookRepo, webhookDeliveryRepo, webhookEndpointRepo, volumeRepo)
+	changeUC := usecase.NewChangeUsecase(transactionObj, changeRepo, volumeRepo)
+	auditLogUC := usecase.NewAuditLogUsecase(transactionObj, auditLogRepo, config.auditLog.AdminIDs)
+	imageUC := usecase.NewImageUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, imagePresetRepo)
 
 	authorizationMW = middleware.NewAuthorizationMiddleware(authorizationUC)
+	auditMW = middleware.NewAuditMiddleware(auditLogUC)
+	normalizationMW = middleware.NewNormalizationMiddleware()
 
 	healthHdl = handler.NewHealthHandler()
 	volumeHdl = handler.NewVolumeHandler(volumeUC)
-	entryHdl = handler.NewEntryHandler(entryUC)
+	entryHdl = handler.NewEntryHandler(entryUC, jobUC, imageUC)
+	fsckHdl = handler.NewFsckHandler(fsckUC)
+	jobHdl = handler.NewJobHandler(jobUC)
+	webhookHdl = handler.NewWebhookHandler(webhookUC)
+	changeHdl = handler.NewChangeHandler(changeUC)
+	auditLogHdl = handler.NewAuditLogHandler(auditLogUC)
+	imagePresetHdl = handler.NewImagePresetHandler(imageUC)
+}
+
+func newBodyRepository(fs afero.Fs, config *fileSystemConfig) repository.BodyRepository {
+	bodyRepo := file.NewBodyRepository(fs, config.BasePath)
+	if config.EncryptionKeyID != "" {
+		bodyRepo = file.NewEncryptedBodyRepository(bodyRepo, config.EncryptionKeyID, toMasterKeys(config.EncryptionKeys))
+	}
+	return file.NewCompressedBodyRepository(bodyRepo)
+}
+
+// NOTE: 設定されていない場合はスキャンしない.
+func newScannerRepository(config *scannerConfig) repository.ScannerRepository {
+	switch config.Network {
+	case "":
+		return nil
+	case "fake":
+		return scanner.NewFakeRepository()
+	default:
+		return scanner.NewClamdRepository(config.Network, config.Address, scannerTimeout)
+	}
+}
+
+func toMasterKeys(keys map[string][]byte) []*file.MasterKey {
+	masterKeys := make([]*file.MasterKey, 0, len(keys))
+	for id, key := range keys {
+		masterKeys = append(masterKeys, &file.MasterKey{ID: id, Key: key})
+	}
+	return masterKeys
 }
@@ -0,0 +1,51 @@
+package builder
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+// NOTE: 所有者を特定できない認可の失敗は所有者を, 匿名の操作は操作者を省略する.
+func ToAuditLogResponse(auditLog *dto.AuditLogDTO) *schema.AuditLogResponse {
+	var ownerID, actorID *uuid.UUID
+	if auditLog.OwnerID != uuid.Nil {
+		ownerID = &auditLog.OwnerID
+	}
+	if auditLog.ActorID != uuid.Nil {
+		actorID = &auditLog.ActorID
+	}
+	return &schema.AuditLogResponse{
+		ID:             auditLog.ID,
+		RequestID:      auditLog.RequestID,
+		OwnerID:        ownerID,
+		ActorID:        actorID,
+		CredentialType: auditLog.CredentialType,
+		Principal:      auditLog.Principal,
+		ClientIP:       auditLog.ClientIP,
+		UserAgent:      auditLog.UserAgent,
+		Operation:      auditLog.Operation,
+		VolumeName:     auditLog.VolumeName,
+		Key:            auditLog.Key,
+		NewVolumeName:  auditLog.NewVolumeName,
+		NewKey:         auditLog.NewKey,
+		Status:         auditLog.Status,
+		CreatedAt:      auditLog.CreatedAt,
+	}
+}
+
+func ToAuditLogResponses(auditLogs []*dto.AuditLogDTO) []*schema.AuditLogResponse {
+	responses := make([]*schema.AuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		responses[i] = ToAuditLogResponse(auditLog)
+	}
+	return responses
+}
+
+func ToAuditLogsResponse(auditLogs []*dto.AuditLogDTO, next uint64) *schema.AuditLogsResponse {
+	return &schema.AuditLogsResponse{
+		AuditLogs:  ToAuditLogResponses(auditLogs),
+		NextCursor: next,
+	}
+}
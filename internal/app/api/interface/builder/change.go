@@ -0,0 +1,42 @@
+package builder
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+// NOTE: 匿名の操作は操作者を省略する.
+func ToChangeResponse(change *dto.ChangeDTO) *schema.ChangeResponse {
+	var actorID *uuid.UUID
+	if change.ActorID != uuid.Nil {
+		actorID = &change.ActorID
+	}
+	return &schema.ChangeResponse{
+		Sequence:  change.Sequence,
+		EventID:   change.EventID,
+		Type:      change.Type,
+		Key:       change.Key,
+		NewKey:    change.NewKey,
+		Size:      change.Size,
+		ActorID:   actorID,
+		CreatedAt: change.CreatedAt,
+	}
+}
+
+func ToChangeResponses(changes []*dto.ChangeDTO) []*schema.ChangeResponse {
+	responses := make([]*schema.ChangeResponse, len(changes))
+	for i, change := range changes {
+		responses[i] = ToChangeResponse(change)
+	}
+	return responses
+}
+
+func ToDeltaResponse(delta *dto.DeltaDTO) *schema.DeltaResponse {
+	return &schema.DeltaResponse{
+		Changes:   ToChangeResponses(delta.Changes),
+		NextToken: delta.NextToken,
+		HasMore:   delta.HasMore,
+	}
+}
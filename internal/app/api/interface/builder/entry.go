@@ -1,20 +1,66 @@
 package builder
 
 import (
+	"net/http"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
 )
 
+// NOTE: 一括操作で成功した場合は個別のエンドポイントと同じステータスコードとする.
+var entryOperationStatusCodes = map[string]int{
+	usecase.EntryOperationCreateFolder: http.StatusCreated,
+	usecase.EntryOperationDelete:       http.StatusNoContent,
+	usecase.EntryOperationMove:         http.StatusOK,
+	usecase.EntryOperationCopy:         http.StatusOK,
+}
+
 func ToEntryResponse(entry *dto.EntryDTO) *schema.EntryResponse {
 	return &schema.EntryResponse{
 		Key:       entry.Key,
 		Size:      entry.Size,
 		Type:      entry.Type,
+		Metadata:  ToEntryMetadataResponse(entry.Metadata),
+		Scan:      ToEntryScanResponse(entry.Scan),
+		Snippet:   entry.Snippet,
 		CreatedAt: entry.CreatedAt,
 		UpdatedAt: entry.UpdatedAt,
 	}
 }
 
+func ToEntryScanResponse(scan *dto.EntryScanDTO) *schema.EntryScanResponse {
+	if scan == nil {
+		return nil
+	}
+	return &schema.EntryScanResponse{
+		Status:    scan.Status,
+		Signature: scan.Signature,
+		ScannedAt: scan.ScannedAt,
+	}
+}
+
+func ToEntryMetadataResponse(metadata *dto.EntryMetadataDTO) *schema.EntryMetadataResponse {
+	if metadata == nil {
+		return nil
+	}
+	return &schema.EntryMetadataResponse{
+		Width:       metadata.Width,
+		Height:      metadata.Height,
+		TakenAt:     metadata.TakenAt,
+		CameraMake:  metadata.CameraMake,
+		CameraModel: metadata.CameraModel,
+		Latitude:    metadata.Latitude,
+		Longitude:   metadata.Longitude,
+		PageCount:   metadata.PageCount,
+		DurationMS:  metadata.Duration,
+		Title:       metadata.Title,
+		Artist:      metadata.Artist,
+		Album:       metadata.Album,
+	}
+}
+
 func ToEntryResponses(entries []*dto.EntryDTO) []*schema.EntryResponse {
 	responses := make([]*schema.EntryResponse, len(entries))
 	for i, entry := range entries {
@@ -22,3 +68,66 @@ func ToEntryResponses(entries []*dto.EntryDTO) []*schema.EntryResponse {
 	}
 	return responses
 }
+
+func ToEntryOperationResponse(entry *dto.EntryDTO, results []*dto.EntryResultDTO) *schema.EntryOperationResponse {
+	return &schema.EntryOperationResponse{
+		EntryResponse: ToEntryResponse(entry),
+		Results:       ToEntryResultResponses(results),
+	}
+}
+
+func ToEntryResultResponses(results []*dto.EntryResultDTO) []*schema.EntryResultResponse {
+	responses := make([]*schema.EntryResultResponse, len(results))
+	for i, result := range results {
+		responses[i] = &schema.EntryResultResponse{
+			Key:    result.Key,
+			Result: result.Result,
+		}
+	}
+	return responses
+}
+
+func ToEntryOperationDTOs(operations []*schema.EntryOperationRequest) []*dto.EntryOperationDTO {
+	dtos := make([]*dto.EntryOperationDTO, len(operations))
+	for i, operation := range operations {
+		dtos[i] = &dto.EntryOperationDTO{
+			Type:          operation.Op,
+			Key:           operation.Key,
+			NewVolumeName: operation.NewVolumeName,
+			NewKey:        operation.NewKey,
+			Conflict:      operation.Conflict,
+		}
+	}
+	return dtos
+}
+
+func ToBatchEntryResponse(results []*dto.EntryOperationResultDTO) *schema.BatchEntryResponse {
+	responses := make([]*schema.EntryOperationResultResponse, len(results))
+	for i, result := range results {
+		responses[i] = ToEntryOperationResultResponse(result)
+	}
+	return &schema.BatchEntryResponse{Results: responses}
+}
+
+func ToEntryOperationResultResponse(result *dto.EntryOperationResultDTO) *schema.EntryOperationResultResponse {
+	if result.Error != nil {
+		statusCode, message := errors.Resolve(result.Error)
+		return &schema.EntryOperationResultResponse{
+			Op:     result.Type,
+			Status: statusCode,
+			Error:  &schema.ErrorResponse{Message: message},
+		}
+	}
+
+	response := &schema.EntryOperationResultResponse{
+		Op:     result.Type,
+		Status: entryOperationStatusCodes[result.Type],
+	}
+	if result.Entry != nil {
+		response.Entry = ToEntryResponse(result.Entry)
+	}
+	if len(result.Results) != 0 {
+		response.Results = ToEntryResultResponses(result.Results)
+	}
+	return response
+}
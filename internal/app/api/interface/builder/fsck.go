@@ -0,0 +1,31 @@
+package builder
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToFsckResponse(report *dto.FsckReportDTO) *schema.FsckResponse {
+	return &schema.FsckResponse{
+		VolumeName: report.VolumeName,
+		Issues:     ToFsckIssueResponses(report.Issues),
+	}
+}
+
+func ToFsckIssueResponse(issue *dto.FsckIssueDTO) *schema.FsckIssueResponse {
+	return &schema.FsckIssueResponse{
+		Category: issue.Category,
+		Key:      issue.Key,
+		Expected: issue.Expected,
+		Actual:   issue.Actual,
+		Repaired: issue.Repaired,
+	}
+}
+
+func ToFsckIssueResponses(issues []*dto.FsckIssueDTO) []*schema.FsckIssueResponse {
+	responses := make([]*schema.FsckIssueResponse, len(issues))
+	for i, issue := range issues {
+		responses[i] = ToFsckIssueResponse(issue)
+	}
+	return responses
+}
@@ -0,0 +1,48 @@
+package builder
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToImageTransformationDTO(transformation *schema.ImageTransformationSchema) *dto.ImageTransformationDTO {
+	return &dto.ImageTransformationDTO{
+		Width:      transformation.Width,
+		Height:     transformation.Height,
+		Fit:        transformation.Fit,
+		CropX:      transformation.CropX,
+		CropY:      transformation.CropY,
+		CropWidth:  transformation.CropWidth,
+		CropHeight: transformation.CropHeight,
+		Quality:    transformation.Quality,
+		Format:     transformation.Format,
+	}
+}
+
+func ToImagePresetResponse(preset *dto.ImagePresetDTO) *schema.ImagePresetResponse {
+	return &schema.ImagePresetResponse{
+		ID:   preset.ID,
+		Name: preset.Name,
+		ImageTransformationSchema: schema.ImageTransformationSchema{
+			Width:      preset.Transformation.Width,
+			Height:     preset.Transformation.Height,
+			Fit:        preset.Transformation.Fit,
+			CropX:      preset.Transformation.CropX,
+			CropY:      preset.Transformation.CropY,
+			CropWidth:  preset.Transformation.CropWidth,
+			CropHeight: preset.Transformation.CropHeight,
+			Quality:    preset.Transformation.Quality,
+			Format:     preset.Transformation.Format,
+		},
+		CreatedAt: preset.CreatedAt,
+		UpdatedAt: preset.UpdatedAt,
+	}
+}
+
+func ToImagePresetResponses(presets []*dto.ImagePresetDTO) []*schema.ImagePresetResponse {
+	responses := make([]*schema.ImagePresetResponse, len(presets))
+	for i, preset := range presets {
+		responses[i] = ToImagePresetResponse(preset)
+	}
+	return responses
+}
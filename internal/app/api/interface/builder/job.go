@@ -0,0 +1,30 @@
+package builder
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToJobResponse(job *dto.JobDTO) *schema.JobResponse {
+	return &schema.JobResponse{
+		ID:            job.ID,
+		Type:          job.Type,
+		Status:        job.Status,
+		VolumeName:    job.VolumeName,
+		Key:           job.Key,
+		NewVolumeName: job.NewVolumeName,
+		NewKey:        job.NewKey,
+		Conflict:      job.Conflict,
+		ResultKey:     job.ResultKey,
+		Error:         job.Error,
+		Progress: &schema.JobProgressResponse{
+			TotalEntries:     job.TotalEntries,
+			ProcessedEntries: job.ProcessedEntries,
+			TotalBytes:       job.TotalBytes,
+			ProcessedBytes:   job.ProcessedBytes,
+		},
+		Attempts:  job.Attempts,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+}
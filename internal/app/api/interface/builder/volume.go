@@ -7,13 +7,76 @@ import (
 
 func ToVolumeResponse(volume *dto.VolumeDTO) *schema.VolumeResponse {
 	return &schema.VolumeResponse{
-		Name:      volume.Name,
-		IsPublic:  volume.IsPublic,
-		CreatedAt: volume.CreatedAt,
-		UpdatedAt: volume.UpdatedAt,
+		OwnerID:     volume.AccountID,
+		Name:        volume.Name,
+		IsPublic:    volume.IsPublic,
+		Compression: volume.Compression,
+		Policy:      toVolumePolicySchema(volume.Policy),
+		Drop:        toVolumeDropSchema(volume.Drop),
+		CreatedAt:   volume.CreatedAt,
+		UpdatedAt:   volume.UpdatedAt,
 	}
 }
 
+func ToVolumePolicyDTO(policy *schema.VolumePolicySchema) *dto.VolumePolicyDTO {
+	if policy == nil {
+		return nil
+	}
+	return &dto.VolumePolicyDTO{
+		MaxFileSize:         policy.MaxFileSize,
+		AllowedTypes:        policy.AllowedTypes,
+		DeniedTypes:         policy.DeniedTypes,
+		AllowedExtensions:   policy.AllowedExtensions,
+		DeniedExtensions:    policy.DeniedExtensions,
+		MaxKeyDepth:         policy.MaxKeyDepth,
+		MaxEntriesPerFolder: policy.MaxEntriesPerFolder,
+		MaxVolumeSize:       policy.MaxVolumeSize,
+		MaxVolumeEntries:    policy.MaxVolumeEntries,
+	}
+}
+
+func toVolumePolicySchema(policy *dto.VolumePolicyDTO) *schema.VolumePolicySchema {
+	if policy == nil {
+		policy = &dto.VolumePolicyDTO{}
+	}
+	return &schema.VolumePolicySchema{
+		MaxFileSize:         policy.MaxFileSize,
+		AllowedTypes:        toPolicyList(policy.AllowedTypes),
+		DeniedTypes:         toPolicyList(policy.DeniedTypes),
+		AllowedExtensions:   toPolicyList(policy.AllowedExtensions),
+		DeniedExtensions:    toPolicyList(policy.DeniedExtensions),
+		MaxKeyDepth:         policy.MaxKeyDepth,
+		MaxEntriesPerFolder: policy.MaxEntriesPerFolder,
+		MaxVolumeSize:       policy.MaxVolumeSize,
+		MaxVolumeEntries:    policy.MaxVolumeEntries,
+	}
+}
+
+func ToVolumeDropDTO(drop *schema.VolumeDropSchema) *dto.VolumeDropDTO {
+	if drop == nil {
+		return nil
+	}
+	return &dto.VolumeDropDTO{
+		Prefix: drop.Prefix,
+	}
+}
+
+func toVolumeDropSchema(drop *dto.VolumeDropDTO) *schema.VolumeDropSchema {
+	if drop == nil {
+		return nil
+	}
+	return &schema.VolumeDropSchema{
+		Prefix: drop.Prefix,
+	}
+}
+
+func toPolicyList(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func ToVolumeResponses(volumes []*dto.VolumeDTO) []*schema.VolumeResponse {
 	responses := make([]*schema.VolumeResponse, len(volumes))
 	for i, volume := range volumes {
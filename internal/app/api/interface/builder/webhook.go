@@ -0,0 +1,59 @@
+package builder
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+// NOTE: 署名の鍵は作成時のみ返却する.
+func ToCreatedWebhookResponse(webhook *dto.WebhookDTO) *schema.WebhookResponse {
+	response := ToWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	return response
+}
+
+func ToWebhookResponse(webhook *dto.WebhookDTO) *schema.WebhookResponse {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &schema.WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		Prefix:    webhook.Prefix,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func ToWebhookResponses(webhooks []*dto.WebhookDTO) []*schema.WebhookResponse {
+	responses := make([]*schema.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		responses[i] = ToWebhookResponse(webhook)
+	}
+	return responses
+}
+
+func ToWebhookDeliveryResponse(delivery *dto.WebhookDeliveryDTO) *schema.WebhookDeliveryResponse {
+	return &schema.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		Attempts:       delivery.Attempts,
+		RunAt:          delivery.RunAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func ToWebhookDeliveryResponses(deliveries []*dto.WebhookDeliveryDTO) []*schema.WebhookDeliveryResponse {
+	responses := make([]*schema.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = ToWebhookDeliveryResponse(delivery)
+	}
+	return responses
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+const (
+	defaultAuditLogLimit = 100
+	maxAuditLogLimit     = 1000
+	auditLogExportLimit  = 1000
+)
+
+type AuditLogHandler interface {
+	Search(*gin.Context)
+	SearchAll(*gin.Context)
+	Export(*gin.Context)
+}
+
+type auditLogHandler struct {
+	auditLogUC usecase.AuditLogUsecase
+}
+
+func NewAuditLogHandler(auditLogUC usecase.AuditLogUsecase) AuditLogHandler {
+	return &auditLogHandler{
+		auditLogUC: auditLogUC,
+	}
+}
+
+func (h *auditLogHandler) Search(c *gin.Context) {
+	h.search(c, h.auditLogUC.Search)
+}
+
+func (h *auditLogHandler) SearchAll(c *gin.Context) {
+	h.search(c, h.auditLogUC.SearchAll)
+}
+
+func (h *auditLogHandler) search(c *gin.Context, search func(context.Context, uuid.UUID, *dto.AuditLogConditionDTO, uint64, uint64) ([]*dto.AuditLogDTO, uint64, error)) {
+	condition, err := h.parseCondition(c)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	cursor, err := parseUintQuery(c, "cursor", 0, "invalid cursor")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	limit, err := parseUintQuery(c, "limit", defaultAuditLogLimit, "invalid limit")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+	if limit == 0 || maxAuditLogLimit < limit {
+		errors.Handle(c, status.Error(code.BadRequest, "invalid limit"))
+		return
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	auditLogs, next, err := search(ctx, accountID, condition, cursor, limit)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToAuditLogsResponse(auditLogs, next))
+}
+
+// NOTE: 全件をメモリに保持しないよう, 一定件数ずつ取得して1行1件のJSONで書き出す.
+func (h *auditLogHandler) Export(c *gin.Context) {
+	condition, err := h.parseCondition(c)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	var cursor uint64
+	for {
+		auditLogs, next, err := h.auditLogUC.Search(ctx, accountID, condition, cursor, auditLogExportLimit)
+		if err != nil {
+			if !c.Writer.Written() {
+				errors.Handle(c, err)
+				return
+			}
+			log.Println(err.Error())
+			return
+		}
+
+		if !c.Writer.Written() {
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Header("Content-Disposition", `attachment; filename="audit-logs.ndjson"`)
+			c.Status(http.StatusOK)
+			c.Writer.WriteHeaderNow()
+		}
+
+		encoder := json.NewEncoder(c.Writer)
+		for _, auditLog := range auditLogs {
+			if err := encoder.Encode(builder.ToAuditLogResponse(auditLog)); err != nil {
+				log.Println(err.Error())
+				return
+			}
+		}
+		c.Writer.Flush()
+
+		if next == 0 {
+			return
+		}
+		cursor = next
+	}
+}
+
+func (h *auditLogHandler) parseCondition(c *gin.Context) (*dto.AuditLogConditionDTO, error) {
+	condition := &dto.AuditLogConditionDTO{
+		VolumeName: c.Query("volume_name"),
+		Operation:  c.Query("operation"),
+		ClientIP:   c.Query("client_ip"),
+		Principal:  c.Query("principal"),
+	}
+
+	if val := c.Query("actor_id"); val != "" {
+		actorID, err := uuid.Parse(val)
+		if err != nil {
+			return nil, status.Error(code.BadRequest, "invalid actor id")
+		}
+		condition.ActorID = &actorID
+	}
+
+	since, err := parseTimeQuery(c, "since", "invalid since")
+	if err != nil {
+		return nil, err
+	}
+	condition.Since = since
+
+	until, err := parseTimeQuery(c, "until", "invalid until")
+	if err != nil {
+		return nil, err
+	}
+	condition.Until = until
+
+	return condition, nil
+}
+
+func parseUintQuery(c *gin.Context, name string, defaultValue uint64, message string) (uint64, error) {
+	val := c.Query(name)
+	if val == "" {
+		return defaultValue, nil
+	}
+
+	v, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, status.Error(code.BadRequest, message)
+	}
+	return v, nil
+}
+
+func parseTimeQuery(c *gin.Context, name, message string) (*time.Time, error) {
+	val := c.Query(name)
+	if val == "" {
+		return nil, nil
+	}
+
+	v, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, status.Error(code.BadRequest, message)
+	}
+	return &v, nil
+}
@@ -0,0 +1,324 @@
+package handler_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestAuditLog_Search(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	auditLogDTO := &dto.AuditLogDTO{
+		ID:             2,
+		RequestID:      "request",
+		OwnerID:        accountID,
+		ActorID:        accountID,
+		CredentialType: "session",
+		ClientIP:       "192.0.2.1",
+		UserAgent:      "agent",
+		Operation:      "entry.delete",
+		VolumeName:     "volume",
+		Key:            "key",
+		Status:         204,
+		CreatedAt:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name              string
+		inputQuery        string
+		expectCode        int
+		expectResponse    []byte
+		setMockAuditLogUC func(*mockUsecase.MockAuditLogUsecase)
+	}{
+		{
+			name:           "successfully got",
+			inputQuery:     fmt.Sprintf("?actor_id=%s&volume_name=volume&operation=entry.delete&since=2026-10-01T00:00:00Z&cursor=5&limit=1", accountID),
+			expectCode:     http.StatusOK,
+			expectResponse: fmt.Appendf(nil, `{"audit_logs":[{"id":2,"request_id":"request","owner_id":"%s","actor_id":"%s","credential_type":"session","client_ip":"192.0.2.1","user_agent":"agent","operation":"entry.delete","volume_name":"volume","key":"key","status":204,"created_at":"2026-10-19T00:00:00Z"}],"next_cursor":2}`, accountID, accountID),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Search(gomock.Any(), accountID, &dto.AuditLogConditionDTO{ActorID: &accountID, VolumeName: "volume", Operation: "entry.delete", Since: &since}, uint64(5), uint64(1)).
+					Return([]*dto.AuditLogDTO{auditLogDTO}, uint64(2), nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "default condition",
+			inputQuery:     "",
+			expectCode:     http.StatusOK,
+			expectResponse: []byte(`{"audit_logs":[]}`),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Search(gomock.Any(), accountID, &dto.AuditLogConditionDTO{}, uint64(0), uint64(100)).
+					Return([]*dto.AuditLogDTO{}, uint64(0), nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "invalid actor id",
+			inputQuery:        "?actor_id=invalid",
+			expectCode:        http.StatusBadRequest,
+			expectResponse:    []byte(`{"message":"invalid actor id"}`),
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+		{
+			name:              "invalid since",
+			inputQuery:        "?since=invalid",
+			expectCode:        http.StatusBadRequest,
+			expectResponse:    []byte(`{"message":"invalid since"}`),
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+		{
+			name:              "invalid until",
+			inputQuery:        "?until=invalid",
+			expectCode:        http.StatusBadRequest,
+			expectResponse:    []byte(`{"message":"invalid until"}`),
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+		{
+			name:              "invalid cursor",
+			inputQuery:        "?cursor=invalid",
+			expectCode:        http.StatusBadRequest,
+			expectResponse:    []byte(`{"message":"invalid cursor"}`),
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+		{
+			name:              "invalid limit",
+			inputQuery:        "?limit=1001",
+			expectCode:        http.StatusBadRequest,
+			expectResponse:    []byte(`{"message":"invalid limit"}`),
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+		{
+			name:           "search error",
+			inputQuery:     "",
+			expectCode:     http.StatusInternalServerError,
+			expectResponse: []byte(`{"message":"internal server error"}`),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Search(gomock.Any(), accountID, gomock.Any(), uint64(0), uint64(100)).
+					Return(nil, uint64(0), sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(t.Context(), "GET", "audit-logs"+tt.inputQuery, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+			tt.setMockAuditLogUC(auditLogUC)
+
+			hdl := handler.NewAuditLogHandler(auditLogUC)
+			hdl.Search(c)
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAuditLog_SearchAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	auditLogDTO := &dto.AuditLogDTO{
+		ID:             3,
+		RequestID:      "request",
+		CredentialType: "session",
+		Principal:      "0123456789abcdef",
+		ClientIP:       "192.0.2.1",
+		UserAgent:      "agent",
+		Operation:      "entry.get",
+		VolumeName:     "volume",
+		Key:            "key",
+		Status:         401,
+		CreatedAt:      time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name              string
+		inputQuery        string
+		expectCode        int
+		expectResponse    []byte
+		setMockAuditLogUC func(*mockUsecase.MockAuditLogUsecase)
+	}{
+		{
+			name:           "successfully got",
+			inputQuery:     "?client_ip=192.0.2.1&principal=0123456789abcdef",
+			expectCode:     http.StatusOK,
+			expectResponse: []byte(`{"audit_logs":[{"id":3,"request_id":"request","credential_type":"session","principal":"0123456789abcdef","client_ip":"192.0.2.1","user_agent":"agent","operation":"entry.get","volume_name":"volume","key":"key","status":401,"created_at":"2026-10-19T00:00:00Z"}]}`),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					SearchAll(gomock.Any(), accountID, &dto.AuditLogConditionDTO{ClientIP: "192.0.2.1", Principal: "0123456789abcdef"}, uint64(0), uint64(100)).
+					Return([]*dto.AuditLogDTO{auditLogDTO}, uint64(0), nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "forbidden",
+			inputQuery:     "",
+			expectCode:     http.StatusForbidden,
+			expectResponse: []byte(`{"message":"forbidden"}`),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					SearchAll(gomock.Any(), accountID, gomock.Any(), uint64(0), uint64(100)).
+					Return(nil, uint64(0), usecase.ErrForbidden).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(t.Context(), "GET", "admin/audit-logs"+tt.inputQuery, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+			tt.setMockAuditLogUC(auditLogUC)
+
+			hdl := handler.NewAuditLogHandler(auditLogUC)
+			hdl.SearchAll(c)
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Export(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	createdAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		inputQuery        string
+		expectCode        int
+		expectResponse    []byte
+		setMockAuditLogUC func(*mockUsecase.MockAuditLogUsecase)
+	}{
+		{
+			name:           "successfully exported",
+			inputQuery:     "?operation=entry.get",
+			expectCode:     http.StatusOK,
+			expectResponse: []byte(`{"id":2,"request_id":"request2","credential_type":"anonymous","client_ip":"","user_agent":"","operation":"entry.get","status":200,"created_at":"2026-10-19T00:00:00Z"}` + "\n" + `{"id":1,"request_id":"request1","credential_type":"anonymous","client_ip":"","user_agent":"","operation":"entry.get","status":403,"created_at":"2026-10-19T00:00:00Z"}` + "\n"),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				gomock.InOrder(
+					auditLogUC.
+						EXPECT().
+						Search(gomock.Any(), accountID, &dto.AuditLogConditionDTO{Operation: "entry.get"}, uint64(0), uint64(1000)).
+						Return([]*dto.AuditLogDTO{{ID: 2, RequestID: "request2", CredentialType: "anonymous", Operation: "entry.get", Status: 200, CreatedAt: createdAt}}, uint64(2), nil).
+						Times(1),
+					auditLogUC.
+						EXPECT().
+						Search(gomock.Any(), accountID, &dto.AuditLogConditionDTO{Operation: "entry.get"}, uint64(2), uint64(1000)).
+						Return([]*dto.AuditLogDTO{{ID: 1, RequestID: "request1", CredentialType: "anonymous", Operation: "entry.get", Status: 403, CreatedAt: createdAt}}, uint64(0), nil).
+						Times(1),
+				)
+			},
+		},
+		{
+			name:              "invalid condition",
+			inputQuery:        "?until=invalid",
+			expectCode:        http.StatusBadRequest,
+			expectResponse:    []byte(`{"message":"invalid until"}`),
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+		{
+			name:           "search error",
+			inputQuery:     "",
+			expectCode:     http.StatusInternalServerError,
+			expectResponse: []byte(`{"message":"internal server error"}`),
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Search(gomock.Any(), accountID, gomock.Any(), uint64(0), uint64(1000)).
+					Return(nil, uint64(0), sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(t.Context(), "GET", "audit-logs/export"+tt.inputQuery, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+			tt.setMockAuditLogUC(auditLogUC)
+
+			hdl := handler.NewAuditLogHandler(auditLogUC)
+			hdl.Export(c)
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
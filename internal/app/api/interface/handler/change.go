@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+// NOTE: 中継するプロキシに接続を切断されないよう, 変更がない間も一定間隔でコメントを送信する.
+const (
+	changePollInterval      = time.Second
+	changeKeepAliveInterval = 15 * time.Second
+	changeStreamLimit       = 100
+)
+
+const (
+	defaultChangeDeltaLimit = 100
+	maxChangeDeltaLimit     = 1000
+)
+
+type ChangeHandler interface {
+	Stream(*gin.Context)
+	GetDelta(*gin.Context)
+}
+
+type changeHandler struct {
+	changeUC usecase.ChangeUsecase
+}
+
+func NewChangeHandler(changeUC usecase.ChangeUsecase) ChangeHandler {
+	return &changeHandler{
+		changeUC: changeUC,
+	}
+}
+
+func (h *changeHandler) Stream(c *gin.Context) {
+	volumeName := c.Param("name")
+	prefix := c.Query("prefix")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	sequence, err := h.getLastSequence(c, accountID, volumeName)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	ticker := time.NewTicker(changePollInterval)
+	defer ticker.Stop()
+
+	sentAt := time.Now()
+	for {
+		changes, next, err := h.changeUC.GetChanges(ctx, accountID, volumeName, sequence, prefix, changeStreamLimit)
+		if err != nil {
+			if !c.Writer.Written() {
+				errors.Handle(c, err)
+				return
+			}
+			log.Println(err.Error())
+			return
+		}
+		sequence = next
+
+		if sentAt, err = h.send(c, changes, sentAt); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *changeHandler) GetDelta(c *gin.Context) {
+	volumeName := c.Param("name")
+	token := c.Query("token")
+
+	limit := uint64(defaultChangeDeltaLimit)
+	if val := c.Query("limit"); val != "" {
+		l, err := strconv.ParseUint(val, 10, 64)
+		if err != nil || l == 0 || maxChangeDeltaLimit < l {
+			errors.Handle(c, status.Error(code.BadRequest, "invalid limit"))
+			return
+		}
+		limit = l
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	delta, err := h.changeUC.GetDelta(ctx, accountID, volumeName, token, limit)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToDeltaResponse(delta))
+}
+
+func (h *changeHandler) send(c *gin.Context, changes []*dto.ChangeDTO, sentAt time.Time) (time.Time, error) {
+	for _, change := range changes {
+		c.Render(-1, sse.Event{
+			Id:    strconv.FormatUint(change.Sequence, 10),
+			Event: change.Type,
+			Data:  builder.ToChangeResponse(change),
+		})
+	}
+	if 0 < len(changes) {
+		sentAt = time.Now()
+	} else if changeKeepAliveInterval <= time.Since(sentAt) {
+		if _, err := c.Writer.WriteString(":\n\n"); err != nil {
+			return sentAt, err
+		}
+		sentAt = time.Now()
+	}
+	c.Writer.Flush()
+	return sentAt, nil
+}
+
+// NOTE: 再接続時は Last-Event-ID の続きから, 初回の接続時は接続以降の変更を送信する.
+func (h *changeHandler) getLastSequence(c *gin.Context, accountID uuid.UUID, volumeName string) (uint64, error) {
+	if val := c.GetHeader("Last-Event-ID"); val != "" {
+		sequence, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return 0, status.Error(code.BadRequest, "invalid last event id")
+		}
+		return sequence, nil
+	}
+	return h.changeUC.GetLatestSequence(c.Request.Context(), accountID, volumeName)
+}
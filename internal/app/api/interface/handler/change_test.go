@@ -0,0 +1,261 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestChange_Stream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	changeDTO := &dto.ChangeDTO{
+		Sequence:  6,
+		EventID:   uuid.New(),
+		AccountID: accountID,
+		ActorID:   accountID,
+		Type:      "entry.created",
+		Key:       "dir/key",
+		Size:      10,
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		inputLastEventID string
+		expectCode       int
+		expectResponse   []byte
+		setMockChangeUC  func(*mockUsecase.MockChangeUsecase, context.CancelFunc)
+	}{
+		{
+			name:             "resumed from last event id",
+			inputLastEventID: "5",
+			expectCode:       http.StatusOK,
+			expectResponse:   fmt.Appendf(nil, "id:6\nevent:entry.created\ndata:{\"sequence\":6,\"event_id\":\"%s\",\"type\":\"entry.created\",\"key\":\"dir/key\",\"size\":10,\"actor_id\":\"%s\",\"created_at\":\"%s\"}\n\n", changeDTO.EventID, accountID, changeDTO.CreatedAt.Format(time.RFC3339Nano)),
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase, cancel context.CancelFunc) {
+				changeUC.
+					EXPECT().
+					GetChanges(gomock.Any(), accountID, "volume", uint64(5), "dir", uint64(100)).
+					DoAndReturn(func(context.Context, uuid.UUID, string, uint64, string, uint64) ([]*dto.ChangeDTO, uint64, error) {
+						cancel()
+						return []*dto.ChangeDTO{changeDTO}, 6, nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:             "started from latest sequence",
+			inputLastEventID: "",
+			expectCode:       http.StatusOK,
+			expectResponse:   nil,
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase, cancel context.CancelFunc) {
+				gomock.InOrder(
+					changeUC.
+						EXPECT().
+						GetLatestSequence(gomock.Any(), accountID, "volume").
+						Return(uint64(5), nil).
+						Times(1),
+					changeUC.
+						EXPECT().
+						GetChanges(gomock.Any(), accountID, "volume", uint64(5), "dir", uint64(100)).
+						DoAndReturn(func(context.Context, uuid.UUID, string, uint64, string, uint64) ([]*dto.ChangeDTO, uint64, error) {
+							cancel()
+							return []*dto.ChangeDTO{}, 5, nil
+						}).
+						Times(1),
+				)
+			},
+		},
+		{
+			name:             "invalid last event id",
+			inputLastEventID: "invalid",
+			expectCode:       http.StatusBadRequest,
+			expectResponse:   []byte(`{"message":"invalid last event id"}`),
+			setMockChangeUC:  func(*mockUsecase.MockChangeUsecase, context.CancelFunc) {},
+		},
+		{
+			name:             "volume not found",
+			inputLastEventID: "",
+			expectCode:       http.StatusNotFound,
+			expectResponse:   []byte(`{"message":"volume not found"}`),
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase, _ context.CancelFunc) {
+				changeUC.
+					EXPECT().
+					GetLatestSequence(gomock.Any(), accountID, "volume").
+					Return(uint64(0), repository.ErrVolumeNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "volumes/volume/events?prefix=dir", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			if tt.inputLastEventID != "" {
+				c.Request.Header.Set("Last-Event-ID", tt.inputLastEventID)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			changeUC := mockUsecase.NewMockChangeUsecase(ctrl)
+			tt.setMockChangeUC(changeUC, cancel)
+
+			hdl := handler.NewChangeHandler(changeUC)
+			hdl.Stream(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestChange_GetDelta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	eventID := uuid.New()
+	createdAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		inputQuery      string
+		expectCode      int
+		expectResponse  []byte
+		setMockChangeUC func(*mockUsecase.MockChangeUsecase)
+	}{
+		{
+			name:           "successfully got",
+			inputQuery:     "?token=token&limit=10",
+			expectCode:     http.StatusOK,
+			expectResponse: fmt.Appendf(nil, `{"changes":[{"sequence":4,"event_id":"%s","type":"entry.deleted","key":"key","size":0,"actor_id":"%s","created_at":"2026-10-19T00:00:00Z"}],"next_token":"next","has_more":true}`, eventID, accountID),
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase) {
+				changeUC.
+					EXPECT().
+					GetDelta(gomock.Any(), accountID, "volume", "token", uint64(10)).
+					Return(&dto.DeltaDTO{
+						Changes:   []*dto.ChangeDTO{{Sequence: 4, EventID: eventID, AccountID: accountID, ActorID: accountID, Type: "entry.deleted", Key: "key", CreatedAt: createdAt}},
+						NextToken: "next",
+						HasMore:   true,
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "default limit",
+			inputQuery:     "",
+			expectCode:     http.StatusOK,
+			expectResponse: []byte(`{"changes":[],"next_token":"next","has_more":false}`),
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase) {
+				changeUC.
+					EXPECT().
+					GetDelta(gomock.Any(), accountID, "volume", "", uint64(100)).
+					Return(&dto.DeltaDTO{Changes: []*dto.ChangeDTO{}, NextToken: "next"}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "invalid limit",
+			inputQuery:      "?limit=0",
+			expectCode:      http.StatusBadRequest,
+			expectResponse:  []byte(`{"message":"invalid limit"}`),
+			setMockChangeUC: func(*mockUsecase.MockChangeUsecase) {},
+		},
+		{
+			name:            "limit exceeded",
+			inputQuery:      "?limit=1001",
+			expectCode:      http.StatusBadRequest,
+			expectResponse:  []byte(`{"message":"invalid limit"}`),
+			setMockChangeUC: func(*mockUsecase.MockChangeUsecase) {},
+		},
+		{
+			name:           "invalid token",
+			inputQuery:     "?token=invalid",
+			expectCode:     http.StatusBadRequest,
+			expectResponse: []byte(`{"message":"invalid change token"}`),
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase) {
+				changeUC.
+					EXPECT().
+					GetDelta(gomock.Any(), accountID, "volume", "invalid", uint64(100)).
+					Return(nil, entity.ErrInvalidChangeToken).
+					Times(1)
+			},
+		},
+		{
+			name:           "volume not found",
+			inputQuery:     "",
+			expectCode:     http.StatusNotFound,
+			expectResponse: []byte(`{"message":"volume not found"}`),
+			setMockChangeUC: func(changeUC *mockUsecase.MockChangeUsecase) {
+				changeUC.
+					EXPECT().
+					GetDelta(gomock.Any(), accountID, "volume", "", uint64(100)).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(t.Context(), "GET", "volumes/volume/changes"+tt.inputQuery, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			changeUC := mockUsecase.NewMockChangeUsecase(ctrl)
+			tt.setMockChangeUC(changeUC)
+
+			hdl := handler.NewChangeHandler(changeUC)
+			hdl.GetDelta(c)
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"mime/multipart"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -13,12 +14,33 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/audit"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/compression"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/thumbnail"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+const (
+	batchModeBestEffort = "best_effort"
+)
+
+// NOTE: multipart/form-dataの境界やヘッダーを考慮してContent-Lengthから差し引く.
+const maxMultipartOverhead = 64 << 10
+
+// NOTE: いずれかのクエリを指定した場合に画像を変換して返却する.
+var imageQueries = []string{"preset", "w", "h", "fit", "crop", "q", "format"}
+
+const invalidImageQueryMessage = "invalid image transformation query"
+
+var (
+	errInvalidImageQuery = status.Error(code.BadRequest, invalidImageQueryMessage)
+	errImagePresetQuery  = status.Error(code.BadRequest, "image preset cannot be combined with other queries")
 )
 
 type EntryHandler interface {
@@ -26,6 +48,7 @@ type EntryHandler interface {
 	Update(*gin.Context)
 	Delete(*gin.Context)
 	Copy(*gin.Context)
+	Batch(*gin.Context)
 	GetMeta(*gin.Context)
 	GetOne(*gin.Context)
 	Search(*gin.Context)
@@ -33,20 +56,30 @@ type EntryHandler interface {
 
 type entryHandler struct {
 	entryUC usecase.EntryUsecase
+	jobUC   usecase.JobUsecase
+	imageUC usecase.ImageUsecase
 }
 
-func NewEntryHandler(entryUC usecase.EntryUsecase) EntryHandler {
+func NewEntryHandler(entryUC usecase.EntryUsecase, jobUC usecase.JobUsecase, imageUC usecase.ImageUsecase) EntryHandler {
 	return &entryHandler{
 		entryUC: entryUC,
+		jobUC:   jobUC,
+		imageUC: imageUC,
 	}
 }
 
 func (h *entryHandler) Create(c *gin.Context) {
+	if err := h.validateContentLength(c); err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
 	var req schema.CreateEntryRequest
 	if err := c.ShouldBind(&req); err != nil {
 		errors.Handle(c, status.Error(code.BadRequest, "failed to parse multipart/form-data"))
 		return
 	}
+	audit.SetTarget(c, c.Param("volumeName"), req.Key)
 
 	fileHeader, err := c.FormFile("file")
 	if err != nil && !errs.Is(err, http.ErrMissingFile) {
@@ -70,7 +103,13 @@ func (h *entryHandler) Create(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	entry, err := h.entryUC.Create(ctx, accountID, volumeName, req.Key, size, file)
+	// NOTE: 匿名の場合は認可で投稿を許可されたボリュームへの投稿として扱う.
+	create := h.entryUC.Create
+	if c.GetBool("isAnonymous") {
+		create = h.entryUC.Drop
+	}
+
+	entry, err := create(ctx, accountID, volumeName, req.Key, size, declaredType(fileHeader), file)
 	if err != nil {
 		errors.Handle(c, err)
 		return
@@ -85,6 +124,7 @@ func (h *entryHandler) Update(c *gin.Context) {
 		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
 		return
 	}
+	audit.SetDestination(c, req.VolumeName, req.Key)
 
 	volumeName := c.Param("volumeName")
 	key := strings.TrimPrefix(c.Param("key"), "/")
@@ -95,15 +135,19 @@ func (h *entryHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if h.respondAsync(c, accountID, usecase.JobTypeUpdate, volumeName, key, req.VolumeName, req.Key, req.Conflict) {
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	entry, err := h.entryUC.Update(ctx, accountID, volumeName, key, req.Key)
+	entry, results, err := h.entryUC.Update(ctx, accountID, volumeName, key, req.VolumeName, req.Key, req.Conflict)
 	if err != nil {
 		errors.Handle(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, builder.ToEntryResponse(entry))
+	c.JSON(http.StatusOK, builder.ToEntryOperationResponse(entry, results))
 }
 
 func (h *entryHandler) Delete(c *gin.Context) {
@@ -116,6 +160,10 @@ func (h *entryHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	if h.respondAsync(c, accountID, usecase.JobTypeDelete, volumeName, key, "", "", "") {
+		return
+	}
+
 	ctx := c.Request.Context()
 
 	if err := h.entryUC.Delete(ctx, accountID, volumeName, key); err != nil {
@@ -127,6 +175,14 @@ func (h *entryHandler) Delete(c *gin.Context) {
 }
 
 func (h *entryHandler) Copy(c *gin.Context) {
+	// NOTE: ボディを省略した場合は同じボリュームの同じキーに名前を変更して複製する.
+	var req schema.CopyEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errs.Is(err, io.EOF) {
+		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
+		return
+	}
+	audit.SetDestination(c, req.VolumeName, req.Key)
+
 	volumeName := c.Param("volumeName")
 	key := strings.TrimPrefix(c.Param("key"), "/")
 
@@ -136,15 +192,52 @@ func (h *entryHandler) Copy(c *gin.Context) {
 		return
 	}
 
+	if h.respondAsync(c, accountID, usecase.JobTypeCopy, volumeName, key, req.VolumeName, req.Key, req.Conflict) {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	entry, results, err := h.entryUC.Copy(ctx, accountID, volumeName, key, req.VolumeName, req.Key, req.Conflict)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToEntryOperationResponse(entry, results))
+}
+
+func (h *entryHandler) Batch(c *gin.Context) {
+	var req schema.BatchEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
+		return
+	}
+
+	volumeName := c.Param("volumeName")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	entry, err := h.entryUC.Copy(ctx, accountID, volumeName, key)
+	// NOTE: 実行方式を省略した場合はすべての操作を1つのトランザクションで実行する.
+	atomic := req.Mode != batchModeBestEffort
+	results, err := h.entryUC.Batch(ctx, accountID, volumeName, atomic, builder.ToEntryOperationDTOs(req.Operations))
 	if err != nil {
 		errors.Handle(c, err)
 		return
 	}
+	for _, result := range results {
+		if result.Error != nil {
+			log.Println(result.Error)
+		}
+	}
 
-	c.JSON(http.StatusOK, builder.ToEntryResponse(entry))
+	c.JSON(http.StatusOK, builder.ToBatchEntryResponse(results))
 }
 
 func (h *entryHandler) GetMeta(c *gin.Context) {
@@ -189,9 +282,14 @@ func (h *entryHandler) GetOne(c *gin.Context) {
 		return
 	}
 
+	if h.getDerived(c, accountID, volumeName, key) {
+		return
+	}
+
 	ctx := c.Request.Context()
 
-	entry, body, err := h.entryUC.GetOne(ctx, accountID, volumeName, key)
+	encodings := h.acceptedEncodings(c.GetHeader("Accept-Encoding"))
+	entry, body, err := h.entryUC.GetOne(ctx, accountID, volumeName, key, encodings)
 	if err != nil {
 		errors.Handle(c, err)
 		return
@@ -205,6 +303,8 @@ func (h *entryHandler) GetOne(c *gin.Context) {
 		return
 	}
 
+	h.setContentEncoding(c, entry, encodings)
+
 	defer func() {
 		if err := body.Close(); err != nil {
 			errors.Handle(c, err)
@@ -212,15 +312,11 @@ func (h *entryHandler) GetOne(c *gin.Context) {
 		}
 	}()
 
-	c.Header("Content-Length", strconv.FormatUint(entry.Size, 10))
 	c.Header("Content-Type", entry.Type)
 	c.Header("Last-Modified", entry.UpdatedAt.Format(http.TimeFormat))
 	c.Header("Holos-Entry-Type", entry.Type)
 
-	if _, err := io.Copy(c.Writer, body); err != nil {
-		errors.Handle(c, err)
-		return
-	}
+	h.writeBody(c, entry, body)
 }
 
 func (h *entryHandler) Search(c *gin.Context) {
@@ -241,6 +337,12 @@ func (h *entryHandler) Search(c *gin.Context) {
 		depth = &d
 	}
 
+	condition, err := h.parseCondition(c)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
 	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
 	if err != nil {
 		errors.Handle(c, err)
@@ -249,7 +351,7 @@ func (h *entryHandler) Search(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	entries, err := h.entryUC.Search(ctx, accountID, volumeName, prefix, depth)
+	entries, err := h.entryUC.Search(ctx, accountID, volumeName, prefix, depth, condition)
 	if err != nil {
 		errors.Handle(c, err)
 		return
@@ -258,6 +360,254 @@ func (h *entryHandler) Search(c *gin.Context) {
 	c.JSON(http.StatusOK, map[string][]*schema.EntryResponse{"entries": builder.ToEntryResponses(entries)})
 }
 
+func (h *entryHandler) parseCondition(c *gin.Context) (*dto.EntryConditionDTO, error) {
+	takenFrom, err := parseTimeQuery(c, "taken_from", "invalid taken from")
+	if err != nil {
+		return nil, err
+	}
+
+	takenTo, err := parseTimeQuery(c, "taken_to", "invalid taken to")
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.EntryConditionDTO{
+		TakenFrom: takenFrom,
+		TakenTo:   takenTo,
+		Camera:    c.Query("camera"),
+		Query:     c.Query("q"),
+	}, nil
+}
+
+// NOTE: サムネイルまたは画像の変換を指定された場合は派生コンテンツを返却する.
+func (h *entryHandler) getDerived(c *gin.Context, accountID uuid.UUID, volumeName, key string) bool {
+	if size := c.Query("thumbnail"); size != "" {
+		h.getThumbnail(c, accountID, volumeName, key, size)
+		return true
+	}
+	if slices.ContainsFunc(imageQueries, func(name string) bool { return c.Query(name) != "" }) {
+		h.getImage(c, accountID, volumeName, key)
+		return true
+	}
+	return false
+}
+
+// NOTE: サムネイルは変換後の形式で返却するため, エントリーの圧縮形式に関わらず展開して生成する.
+func (h *entryHandler) getThumbnail(c *gin.Context, accountID uuid.UUID, volumeName, key, size string) {
+	width, height, err := h.parseThumbnailSize(size)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	result, body, err := h.entryUC.GetThumbnail(ctx, accountID, volumeName, key, width, height)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+	defer func() {
+		if err := body.Close(); err != nil {
+			errors.Handle(c, err)
+			return
+		}
+	}()
+
+	c.Header("Content-Type", result.Type)
+	c.Header("ETag", strconv.Quote(result.ETag))
+	c.Header("Last-Modified", result.UpdatedAt.Format(http.TimeFormat))
+
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		errors.Handle(c, err)
+		return
+	}
+}
+
+func (h *entryHandler) getImage(c *gin.Context, accountID uuid.UUID, volumeName, key string) {
+	preset, transformation, err := h.parseImageTransformation(c)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	result, body, err := h.imageUC.Transform(ctx, accountID, volumeName, key, preset, transformation)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+	defer func() {
+		if err := body.Close(); err != nil {
+			errors.Handle(c, err)
+			return
+		}
+	}()
+
+	c.Header("Content-Type", result.Type)
+	c.Header("ETag", strconv.Quote(result.ETag))
+	c.Header("Last-Modified", result.UpdatedAt.Format(http.TimeFormat))
+
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		errors.Handle(c, err)
+		return
+	}
+}
+
+// NOTE: プリセットを指定した場合は他のクエリを指定できない.
+func (h *entryHandler) parseImageTransformation(c *gin.Context) (string, *dto.ImageTransformationDTO, error) {
+	if preset := c.Query("preset"); preset != "" {
+		if slices.ContainsFunc(imageQueries[1:], func(name string) bool { return c.Query(name) != "" }) {
+			return "", nil, errImagePresetQuery
+		}
+		return preset, nil, nil
+	}
+
+	width, err := parseUintQuery(c, "w", 0, invalidImageQueryMessage)
+	if err != nil {
+		return "", nil, err
+	}
+	height, err := parseUintQuery(c, "h", 0, invalidImageQueryMessage)
+	if err != nil {
+		return "", nil, err
+	}
+	quality, err := parseUintQuery(c, "q", 0, invalidImageQueryMessage)
+	if err != nil {
+		return "", nil, err
+	}
+	transformation := &dto.ImageTransformationDTO{Width: width, Height: height, Fit: c.Query("fit"), Quality: quality, Format: c.Query("format")}
+
+	if crop := c.Query("crop"); crop != "" {
+		values, err := h.parseImageCrop(crop)
+		if err != nil {
+			return "", nil, err
+		}
+		transformation.CropX, transformation.CropY, transformation.CropWidth, transformation.CropHeight = values[0], values[1], values[2], values[3]
+	}
+	return "", transformation, nil
+}
+
+func (h *entryHandler) parseImageCrop(crop string) ([4]uint64, error) {
+	var values [4]uint64
+	parts := strings.Split(crop, ",")
+	if len(parts) != len(values) {
+		return values, errInvalidImageQuery
+	}
+	for i, part := range parts {
+		v, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return values, errInvalidImageQuery
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (h *entryHandler) parseThumbnailSize(size string) (uint64, uint64, error) {
+	w, hgt, ok := strings.Cut(size, "x")
+	if !ok {
+		return 0, 0, thumbnail.ErrInvalidSize
+	}
+	width, err := strconv.ParseUint(w, 10, 64)
+	if err != nil {
+		return 0, 0, thumbnail.ErrInvalidSize
+	}
+	height, err := strconv.ParseUint(hgt, 10, 64)
+	if err != nil {
+		return 0, 0, thumbnail.ErrInvalidSize
+	}
+	return width, height, nil
+}
+
+// NOTE: Prefer: respond-async が指定された場合はジョブを登録して即座に応答する.
+func (h *entryHandler) respondAsync(c *gin.Context, accountID uuid.UUID, jobType, volumeName, key, newVolumeName, newKey, conflict string) bool {
+	if !h.prefersAsync(c.Request.Header.Values("Prefer")) {
+		return false
+	}
+
+	ctx := c.Request.Context()
+
+	job, err := h.jobUC.Create(ctx, accountID, jobType, volumeName, key, newVolumeName, newKey, conflict)
+	if err != nil {
+		errors.Handle(c, err)
+		return true
+	}
+
+	c.Header("Location", "/jobs/"+job.ID.String())
+	c.Header("Preference-Applied", "respond-async")
+	c.JSON(http.StatusAccepted, builder.ToJobResponse(job))
+	return true
+}
+
+func (h *entryHandler) prefersAsync(headers []string) bool {
+	for _, header := range headers {
+		for value := range strings.SplitSeq(header, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(value), ";")
+			if strings.EqualFold(strings.TrimSpace(name), "respond-async") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NOTE: 圧縮形式を受け付ける場合はボディが圧縮されたまま返却される.
+func (h *entryHandler) setContentEncoding(c *gin.Context, entry *dto.EntryDTO, encodings []string) {
+	if entry.Encoding == "" {
+		return
+	}
+
+	c.Header("Vary", "Accept-Encoding")
+	if slices.Contains(encodings, entry.Encoding) {
+		c.Header("Content-Encoding", entry.Encoding)
+		c.Header("Holos-Entry-Size", strconv.FormatUint(entry.Size, 10))
+	}
+}
+
+func (h *entryHandler) acceptedEncodings(header string) []string {
+	var encodings []string
+	for _, encoding := range []string{compression.EncodingZstd, compression.EncodingGzip} {
+		if h.acceptsEncoding(header, encoding) {
+			encodings = append(encodings, encoding)
+		}
+	}
+	return encodings
+}
+
+// NOTE: シークできるボディはRangeリクエストに応じて部分的に返却する.
+// 圧縮済みのボディを返却する場合は論理サイズと一致しないため, Rangeリクエストに応じずContent-Lengthも省略する.
+func (h *entryHandler) writeBody(c *gin.Context, entry *dto.EntryDTO, body io.Reader) {
+	if c.Writer.Header().Get("Content-Encoding") == "" {
+		if seeker, ok := body.(io.ReadSeeker); ok {
+			http.ServeContent(c.Writer, c.Request, "", entry.UpdatedAt, seeker)
+			return
+		}
+		c.Header("Content-Length", strconv.FormatUint(entry.Size, 10))
+	}
+
+	if _, err := io.Copy(c.Writer, body); err != nil {
+		errors.Handle(c, err)
+		return
+	}
+}
+
+func (h *entryHandler) acceptsEncoding(header, encoding string) bool {
+	for value := range strings.SplitSeq(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(value), ";")
+		if name != encoding && name != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
 func (h *entryHandler) openFile(fileHeader *multipart.FileHeader) (uint64, multipart.File, error) {
 	if fileHeader == nil {
 		return 0, nil, nil
@@ -274,3 +624,24 @@ func (h *entryHandler) openFile(fileHeader *multipart.FileHeader) (uint64, multi
 
 	return uint64(fileHeader.Size), file, nil
 }
+
+// NOTE: ボディを読み込む前にボリュームのポリシーでサイズを検証する.
+func (h *entryHandler) validateContentLength(c *gin.Context) error {
+	if c.Request.ContentLength <= maxMultipartOverhead {
+		return nil
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		return err
+	}
+
+	return h.entryUC.ValidateSize(c.Request.Context(), accountID, c.Param("volumeName"), uint64(c.Request.ContentLength-maxMultipartOverhead))
+}
+
+func declaredType(fileHeader *multipart.FileHeader) string {
+	if fileHeader == nil {
+		return ""
+	}
+	return fileHeader.Header.Get("Content-Type")
+}
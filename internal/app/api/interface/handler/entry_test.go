@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"database/sql"
 	"fmt"
 	"io"
@@ -19,6 +20,9 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
 	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
 )
@@ -60,6 +64,7 @@ func TestEntry_Create(t *testing.T) {
 		name                  string
 		buildRequestBody      func(*testing.T) (io.Reader, string)
 		hasAccountIDInContext bool
+		isAnonymous           bool
 		expectCode            int
 		expectResponse        []byte
 		setMockEntryUC        func(*mockUsecase.MockEntryUsecase)
@@ -73,7 +78,22 @@ func TestEntry_Create(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), "key/sample.txt", uint64(4), "application/octet-stream", gomock.Any()).
+					Return(entryDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "successfully dropped",
+			buildRequestBody:      buildMultipartBody,
+			hasAccountIDInContext: true,
+			isAnonymous:           true,
+			expectCode:            http.StatusCreated,
+			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s"}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Drop(gomock.Any(), gomock.Any(), gomock.Any(), "key/sample.txt", uint64(4), "application/octet-stream", gomock.Any()).
 					Return(entryDTO, nil).
 					Times(1)
 			},
@@ -86,6 +106,22 @@ func TestEntry_Create(t *testing.T) {
 			expectResponse:        []byte(`{"message":"failed to parse multipart/form-data"}`),
 			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
 		},
+		{
+			name: "content too large",
+			buildRequestBody: func(*testing.T) (io.Reader, string) {
+				return bytes.NewReader(make([]byte, 64<<10+5)), "multipart/form-data"
+			},
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusRequestEntityTooLarge,
+			expectResponse:        []byte(`{"message":"content too large"}`),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					ValidateSize(gomock.Any(), accountID, gomock.Any(), uint64(5)).
+					Return(status.Error(code.ContentTooLarge, "entry is too large")).
+					Times(1)
+			},
+		},
 		{
 			name:                  "account id not set",
 			buildRequestBody:      buildMultipartBody,
@@ -103,7 +139,7 @@ func TestEntry_Create(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -126,6 +162,7 @@ func TestEntry_Create(t *testing.T) {
 			if tt.hasAccountIDInContext {
 				c.Set("accountID", accountID)
 			}
+			c.Set("isAnonymous", tt.isAnonymous)
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
@@ -133,7 +170,7 @@ func TestEntry_Create(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
 			hdl.Create(c)
 
 			c.Writer.WriteHeaderNow()
@@ -163,6 +200,7 @@ func TestEntry_Update(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	resultDTOs := []*dto.EntryResultDTO{{Key: "key/sample.txt", Result: "overwritten"}}
 
 	tests := []struct {
 		name                  string
@@ -174,15 +212,15 @@ func TestEntry_Update(t *testing.T) {
 	}{
 		{
 			name:                  "successfully updated",
-			requestBody:           []byte(`{"key": "update/sample.txt"}`),
+			requestBody:           []byte(`{"key": "key/sample.txt", "conflict": "overwrite"}`),
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusOK,
-			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s"}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s","results":[{"key":"key/sample.txt","result":"overwritten"}]}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entryDTO, nil).
+					Update(gomock.Any(), accountID, "volume", "key/sample.txt", "", "key/sample.txt", "overwrite").
+					Return(entryDTO, resultDTOs, nil).
 					Times(1)
 			},
 		},
@@ -211,8 +249,8 @@ func TestEntry_Update(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
@@ -243,7 +281,7 @@ func TestEntry_Update(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
 			hdl.Update(c)
 
 			c.Writer.WriteHeaderNow()
@@ -331,7 +369,97 @@ func TestEntry_Delete(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
+			hdl.Delete(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_DeleteAsync(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	jobDTO := &dto.JobDTO{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		Type:       "delete",
+		Status:     "pending",
+		VolumeName: "volume",
+		Key:        "key/sample.txt",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name           string
+		expectCode     int
+		expectLocation string
+		expectResponse []byte
+		setMockJobUC   func(*mockUsecase.MockJobUsecase)
+	}{
+		{
+			name:           "successfully accepted",
+			expectCode:     http.StatusAccepted,
+			expectLocation: "/jobs/" + jobDTO.ID.String(),
+			expectResponse: fmt.Appendf(nil, `{"id":"%s","type":"delete","status":"pending","volume_name":"volume","key":"key/sample.txt","progress":{"total_entries":0,"processed_entries":0,"total_bytes":0,"processed_bytes":0},"attempts":0,"created_at":"%s","updated_at":"%s"}`, jobDTO.ID, jobDTO.CreatedAt.Format(time.RFC3339Nano), jobDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, "delete", "volume", "key/sample.txt", "", "", "").
+					Return(jobDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "create error",
+			expectCode:     http.StatusInternalServerError,
+			expectResponse: []byte(`{"message":"internal server error"}`),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, "delete", "volume", "key/sample.txt", "", "", "").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "DELETE", "entries/volume/key/sample.txt", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Request.Header.Add("Prefer", "wait=10, respond-async")
+			c.Params = append(
+				c.Params,
+				gin.Param{Key: "volumeName", Value: "volume"},
+				gin.Param{Key: "key", Value: "key/sample.txt"},
+			)
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
+			jobUC := mockUsecase.NewMockJobUsecase(ctrl)
+			tt.setMockJobUC(jobUC)
+
+			hdl := handler.NewEntryHandler(entryUC, jobUC, nil)
 			hdl.Delete(c)
 
 			c.Writer.WriteHeaderNow()
@@ -340,6 +468,10 @@ func TestEntry_Delete(t *testing.T) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
 			}
 
+			if location := w.Header().Get("Location"); location != tt.expectLocation {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectLocation, location)
+			}
+
 			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
 				t.Error(diff)
 			}
@@ -361,9 +493,12 @@ func TestEntry_Copy(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	resultDTOs := []*dto.EntryResultDTO{{Key: "key/sample copy.txt", Result: "renamed"}}
 
 	tests := []struct {
 		name                  string
+		inputKey              string
+		requestBody           []byte
 		hasAccountIDInContext bool
 		expectCode            int
 		expectResponse        []byte
@@ -371,19 +506,59 @@ func TestEntry_Copy(t *testing.T) {
 	}{
 		{
 			name:                  "successfully copied",
+			inputKey:              "key/sample.txt",
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusOK,
-			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s"}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s","results":[{"key":"key/sample copy.txt","result":"renamed"}]}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Copy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entryDTO, nil).
+					Copy(gomock.Any(), accountID, "volume", "key/sample.txt", "", "", "").
+					Return(entryDTO, resultDTOs, nil).
 					Times(1)
 			},
 		},
+		{
+			name:                  "successfully copied to explicit destination",
+			inputKey:              "key/sample.txt",
+			requestBody:           []byte(`{"volume_name": "other", "key": "key/sample copy.txt", "conflict": "rename"}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s","results":[{"key":"key/sample copy.txt","result":"renamed"}]}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Copy(gomock.Any(), accountID, "volume", "key/sample.txt", "other", "key/sample copy.txt", "rename").
+					Return(entryDTO, resultDTOs, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "copy entry named batch",
+			inputKey:              "/batch",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        fmt.Appendf(nil, `{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s","results":[{"key":"key/sample copy.txt","result":"renamed"}]}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Copy(gomock.Any(), accountID, "volume", "batch", "", "", "").
+					Return(entryDTO, resultDTOs, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid request",
+			inputKey:              "key/sample.txt",
+			requestBody:           []byte(`{"volume_name":`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"failed to parse json"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+		},
 		{
 			name:                  "account id not set",
+			inputKey:              "key/sample.txt",
 			hasAccountIDInContext: false,
 			expectCode:            http.StatusInternalServerError,
 			expectResponse:        []byte(`{"message":"internal server error"}`),
@@ -391,14 +566,15 @@ func TestEntry_Copy(t *testing.T) {
 		},
 		{
 			name:                  "copy error",
+			inputKey:              "key/sample.txt",
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusInternalServerError,
 			expectResponse:        []byte(`{"message":"internal server error"}`),
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Copy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Copy(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
@@ -410,14 +586,14 @@ func TestEntry_Copy(t *testing.T) {
 
 			c, _ := gin.CreateTestContext(w)
 			var err error
-			c.Request, err = http.NewRequestWithContext(ctx, "POST", "entries/volume/key/sample.txt", http.NoBody)
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "entries/volume/key/sample.txt", bytes.NewReader(tt.requestBody))
 			if err != nil {
 				t.Error(err)
 			}
 			c.Params = append(
 				c.Params,
 				gin.Param{Key: "volumeName", Value: "volume"},
-				gin.Param{Key: "key", Value: "key/sample.txt"},
+				gin.Param{Key: "key", Value: tt.inputKey},
 			)
 			if tt.hasAccountIDInContext {
 				c.Set("accountID", accountID)
@@ -429,7 +605,7 @@ func TestEntry_Copy(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
 			hdl.Copy(c)
 
 			c.Writer.WriteHeaderNow()
@@ -445,6 +621,165 @@ func TestEntry_Copy(t *testing.T) {
 	}
 }
 
+func TestEntry_Batch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	entryDTO := &dto.EntryDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  uuid.New(),
+		Key:       "folder",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	operationDTOs := []*dto.EntryOperationDTO{
+		{Type: usecase.EntryOperationCreateFolder, Key: "folder"},
+		{Type: usecase.EntryOperationDelete, Key: "key/sample.txt"},
+	}
+	requestBody := []byte(`{"operations":[{"op":"create_folder","key":"folder"},{"op":"delete","key":"key/sample.txt"}]}`)
+
+	tests := []struct {
+		name                  string
+		requestBody           []byte
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockEntryUC        func(*mockUsecase.MockEntryUsecase)
+	}{
+		{
+			name:                  "successfully executed",
+			requestBody:           requestBody,
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        fmt.Appendf(nil, `{"results":[{"op":"create_folder","status":201,"entry":{"key":"%s","size":%d,"type":"%s","created_at":"%s","updated_at":"%s"}},{"op":"delete","status":204}]}`, entryDTO.Key, entryDTO.Size, entryDTO.Type, entryDTO.CreatedAt.Format(time.RFC3339Nano), entryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Batch(gomock.Any(), accountID, "volume", true, operationDTOs).
+					Return([]*dto.EntryOperationResultDTO{
+						{Type: usecase.EntryOperationCreateFolder, Entry: entryDTO},
+						{Type: usecase.EntryOperationDelete},
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "partially failed with best effort",
+			requestBody:           []byte(`{"mode":"best_effort","operations":[{"op":"move","key":"key/sample.txt","new_key":"other.txt","conflict":"overwrite"},{"op":"delete","key":"key/sample.txt"}]}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        []byte(`{"results":[{"op":"move","status":200,"results":[{"key":"other.txt","result":"overwritten"}]},{"op":"delete","status":404,"error":{"message":"entry not found"}}]}`),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Batch(gomock.Any(), accountID, "volume", false, []*dto.EntryOperationDTO{
+						{Type: usecase.EntryOperationMove, Key: "key/sample.txt", NewKey: "other.txt", Conflict: "overwrite"},
+						{Type: usecase.EntryOperationDelete, Key: "key/sample.txt"},
+					}).
+					Return([]*dto.EntryOperationResultDTO{
+						{Type: usecase.EntryOperationMove, Results: []*dto.EntryResultDTO{{Key: "other.txt", Result: "overwritten"}}},
+						{Type: usecase.EntryOperationDelete, Error: status.Error(code.NotFound, "entry not found")},
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "rolled back",
+			requestBody:           requestBody,
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        []byte(`{"results":[{"op":"create_folder","status":424,"error":{"message":"operation was rolled back because another operation failed"}},{"op":"delete","status":500,"error":{"message":"internal server error"}}]}`),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Batch(gomock.Any(), accountID, "volume", true, operationDTOs).
+					Return([]*dto.EntryOperationResultDTO{
+						{Type: usecase.EntryOperationCreateFolder, Error: usecase.ErrEntryOperationRolledBack},
+						{Type: usecase.EntryOperationDelete, Error: sql.ErrConnDone},
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid request",
+			requestBody:           []byte(`{"operations":[]}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"failed to parse json"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+		},
+		{
+			name:                  "invalid mode",
+			requestBody:           []byte(`{"mode":"invalid","operations":[{"op":"delete","key":"key/sample.txt"}]}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"failed to parse json"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+		},
+		{
+			name:                  "account id not set",
+			requestBody:           requestBody,
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+		},
+		{
+			name:                  "batch error",
+			requestBody:           requestBody,
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Batch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "batch/entries/volume", bytes.NewReader(tt.requestBody))
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "volumeName", Value: "volume"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
+			tt.setMockEntryUC(entryUC)
+
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
+			hdl.Batch(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestEntry_GetMeta(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -551,7 +886,7 @@ func TestEntry_GetMeta(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
 			hdl.GetMeta(c)
 
 			c.Writer.WriteHeaderNow()
@@ -582,6 +917,36 @@ func TestEntry_GetOne(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	compressedEntryDTO := &dto.EntryDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volumeID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		Encoding:  "gzip",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	zstdEntryDTO := &dto.EntryDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volumeID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		Encoding:  "zstd",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	var compressedBody bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressedBody)
+	if _, err := gzipWriter.Write([]byte("test")); err != nil {
+		t.Error(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Error(err)
+	}
 	folderEntryDTO := &dto.EntryDTO{
 		ID:        uuid.New(),
 		AccountID: accountID,
@@ -593,13 +958,28 @@ func TestEntry_GetOne(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
+	thumbnailDTO := &dto.ThumbnailDTO{
+		Type:      "image/png",
+		ETag:      "etag",
+		UpdatedAt: time.Now(),
+	}
+	imageDTO := &dto.ImageDTO{
+		Type:      "image/jpeg",
+		ETag:      "etag-key",
+		UpdatedAt: time.Now(),
+	}
+
 	tests := []struct {
 		name                  string
+		inputAcceptEncoding   string
+		inputRange            string
+		inputQuery            string
 		hasAccountIDInContext bool
 		expectCode            int
 		expectHeader          http.Header
 		expectResponse        []byte
 		setMockEntryUC        func(*mockUsecase.MockEntryUsecase)
+		setMockImageUC        func(*mockUsecase.MockImageUsecase)
 	}{
 		{
 			name:                  "successfully got a file",
@@ -610,10 +990,123 @@ func TestEntry_GetOne(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(fileEntryDTO, io.NopCloser(bytes.NewReader([]byte("test"))), nil).
 					Times(1)
 			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a compressed file",
+			inputAcceptEncoding:   "gzip, deflate",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Encoding": {"gzip"}, "Content-Type": {compressedEntryDTO.Type}, "Holos-Entry-Size": {strconv.FormatUint(compressedEntryDTO.Size, 10)}, "Holos-Entry-Type": {compressedEntryDTO.Type}, "Last-Modified": {compressedEntryDTO.UpdatedAt.Format(http.TimeFormat)}, "Vary": {"Accept-Encoding"}},
+			expectResponse:        compressedBody.Bytes(),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), []string{"gzip"}).
+					Return(compressedEntryDTO, io.NopCloser(bytes.NewReader(compressedBody.Bytes())), nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a zstd compressed file",
+			inputAcceptEncoding:   "*",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Encoding": {"zstd"}, "Content-Type": {zstdEntryDTO.Type}, "Holos-Entry-Size": {strconv.FormatUint(zstdEntryDTO.Size, 10)}, "Holos-Entry-Type": {zstdEntryDTO.Type}, "Last-Modified": {zstdEntryDTO.UpdatedAt.Format(http.TimeFormat)}, "Vary": {"Accept-Encoding"}},
+			expectResponse:        []byte("compressed"),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), []string{"zstd", "gzip"}).
+					Return(zstdEntryDTO, io.NopCloser(bytes.NewReader([]byte("compressed"))), nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a decompressed file",
+			inputAcceptEncoding:   "gzip;q=0",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Length": {strconv.FormatUint(compressedEntryDTO.Size, 10)}, "Content-Type": {compressedEntryDTO.Type}, "Holos-Entry-Type": {compressedEntryDTO.Type}, "Last-Modified": {compressedEntryDTO.UpdatedAt.Format(http.TimeFormat)}, "Vary": {"Accept-Encoding"}},
+			expectResponse:        []byte("test"),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), nil).
+					Return(compressedEntryDTO, io.NopCloser(bytes.NewReader([]byte("test"))), nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a seekable file",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Accept-Ranges": {"bytes"}, "Content-Length": {strconv.FormatUint(fileEntryDTO.Size, 10)}, "Content-Type": {fileEntryDTO.Type}, "Holos-Entry-Type": {fileEntryDTO.Type}, "Last-Modified": {fileEntryDTO.UpdatedAt.UTC().Format(http.TimeFormat)}},
+			expectResponse:        []byte("test"),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fileEntryDTO, &readSeekCloser{ReadSeeker: bytes.NewReader([]byte("test"))}, nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a range of a file",
+			inputRange:            "bytes=1-2",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusPartialContent,
+			expectHeader:          http.Header{"Accept-Ranges": {"bytes"}, "Content-Length": {"2"}, "Content-Range": {"bytes 1-2/4"}, "Content-Type": {fileEntryDTO.Type}, "Holos-Entry-Type": {fileEntryDTO.Type}, "Last-Modified": {fileEntryDTO.UpdatedAt.UTC().Format(http.TimeFormat)}},
+			expectResponse:        []byte("es"),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fileEntryDTO, &readSeekCloser{ReadSeeker: bytes.NewReader([]byte("test"))}, nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "range not satisfiable",
+			inputRange:            "bytes=10-",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusRequestedRangeNotSatisfiable,
+			expectHeader:          http.Header{"Content-Range": {"bytes */4"}, "Content-Type": {"text/plain; charset=utf-8"}, "Holos-Entry-Type": {fileEntryDTO.Type}, "X-Content-Type-Options": {"nosniff"}},
+			expectResponse:        []byte("invalid range: failed to overlap\n"),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(fileEntryDTO, &readSeekCloser{ReadSeeker: bytes.NewReader([]byte("test"))}, nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "ignore range of a compressed file",
+			inputAcceptEncoding:   "gzip",
+			inputRange:            "bytes=1-2",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Encoding": {"gzip"}, "Content-Type": {compressedEntryDTO.Type}, "Holos-Entry-Size": {strconv.FormatUint(compressedEntryDTO.Size, 10)}, "Holos-Entry-Type": {compressedEntryDTO.Type}, "Last-Modified": {compressedEntryDTO.UpdatedAt.Format(http.TimeFormat)}, "Vary": {"Accept-Encoding"}},
+			expectResponse:        compressedBody.Bytes(),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(compressedEntryDTO, &readSeekCloser{ReadSeeker: bytes.NewReader(compressedBody.Bytes())}, nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
 		},
 		{
 			name:                  "successfully got a folder",
@@ -624,10 +1117,131 @@ func TestEntry_GetOne(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(folderEntryDTO, nil, nil).
 					Times(1)
 			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a thumbnail",
+			inputQuery:            "thumbnail=100x200",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Type": {thumbnailDTO.Type}, "Etag": {`"etag"`}, "Last-Modified": {thumbnailDTO.UpdatedAt.Format(http.TimeFormat)}},
+			expectResponse:        []byte("thumbnail"),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetThumbnail(gomock.Any(), gomock.Any(), "volume", "key/sample.txt", uint64(100), uint64(200)).
+					Return(thumbnailDTO, io.NopCloser(bytes.NewReader([]byte("thumbnail"))), nil).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "invalid thumbnail size",
+			inputQuery:            "thumbnail=100",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			expectResponse:        []byte(`{"message":"invalid thumbnail size"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "get thumbnail error",
+			inputQuery:            "thumbnail=100x200",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetThumbnail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "successfully got a transformed image",
+			inputQuery:            "w=100&h=100&fit=cover&crop=1,2,30,40&q=80&format=jpeg",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Type": {imageDTO.Type}, "Etag": {`"etag-key"`}, "Last-Modified": {imageDTO.UpdatedAt.Format(http.TimeFormat)}},
+			expectResponse:        []byte("image"),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					Transform(gomock.Any(), gomock.Any(), "volume", "key/sample.txt", "", &dto.ImageTransformationDTO{Width: 100, Height: 100, Fit: "cover", CropX: 1, CropY: 2, CropWidth: 30, CropHeight: 40, Quality: 80, Format: "jpeg"}).
+					Return(imageDTO, io.NopCloser(bytes.NewReader([]byte("image"))), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "successfully got an image with preset",
+			inputQuery:            "preset=square",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectHeader:          http.Header{"Content-Type": {imageDTO.Type}, "Etag": {`"etag-key"`}, "Last-Modified": {imageDTO.UpdatedAt.Format(http.TimeFormat)}},
+			expectResponse:        []byte("image"),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					Transform(gomock.Any(), gomock.Any(), "volume", "key/sample.txt", "square", nil).
+					Return(imageDTO, io.NopCloser(bytes.NewReader([]byte("image"))), nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "preset with other queries",
+			inputQuery:            "preset=square&w=100",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			expectResponse:        []byte(`{"message":"image preset cannot be combined with other queries"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "invalid crop",
+			inputQuery:            "crop=1,2,3",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			expectResponse:        []byte(`{"message":"invalid image transformation query"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "invalid width",
+			inputQuery:            "w=-1",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			expectResponse:        []byte(`{"message":"invalid image transformation query"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "transformation is not allowed",
+			inputQuery:            "w=100",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusForbidden,
+			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			expectResponse:        []byte(`{"message":"forbidden"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil, usecase.ErrImageTransformationNotAllowed).
+					Times(1)
+			},
 		},
 		{
 			name:                  "account id not set",
@@ -636,6 +1250,7 @@ func TestEntry_GetOne(t *testing.T) {
 			expectHeader:          http.Header{"Content-Type": {"application/json; charset=utf-8"}},
 			expectResponse:        []byte(`{"message":"internal server error"}`),
 			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
 		},
 		{
 			name:                  "get error",
@@ -646,10 +1261,11 @@ func TestEntry_GetOne(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					GetOne(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, nil, sql.ErrConnDone).
 					Times(1)
 			},
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
 		},
 	}
 	for _, tt := range tests {
@@ -663,6 +1279,13 @@ func TestEntry_GetOne(t *testing.T) {
 			if err != nil {
 				t.Error(err)
 			}
+			c.Request.URL.RawQuery = tt.inputQuery
+			if tt.inputAcceptEncoding != "" {
+				c.Request.Header.Set("Accept-Encoding", tt.inputAcceptEncoding)
+			}
+			if tt.inputRange != "" {
+				c.Request.Header.Set("Range", tt.inputRange)
+			}
 			c.Params = append(
 				c.Params,
 				gin.Param{Key: "volumeName", Value: "volume"},
@@ -678,7 +1301,10 @@ func TestEntry_GetOne(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			imageUC := mockUsecase.NewMockImageUsecase(ctrl)
+			tt.setMockImageUC(imageUC)
+
+			hdl := handler.NewEntryHandler(entryUC, nil, imageUC)
 			hdl.GetOne(c)
 
 			c.Writer.WriteHeaderNow()
@@ -712,9 +1338,35 @@ func TestEntry_Search(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	takenAt := time.Date(2026, 10, 19, 3, 34, 56, 0, time.UTC)
+	photoDTO := &dto.EntryDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  uuid.New(),
+		Key:       "key/sample.jpg",
+		Size:      4,
+		Type:      "image/jpeg",
+		Metadata:  &dto.EntryMetadataDTO{Width: 40, Height: 20, TakenAt: &takenAt, CameraMake: "Holos"},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	takenFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	takenTo := time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC)
+	snippetDTO := &dto.EntryDTO{
+		ID:        entryDTO.ID,
+		AccountID: entryDTO.AccountID,
+		VolumeID:  entryDTO.VolumeID,
+		Key:       entryDTO.Key,
+		Size:      entryDTO.Size,
+		Type:      entryDTO.Type,
+		Snippet:   "test content",
+		CreatedAt: entryDTO.CreatedAt,
+		UpdatedAt: entryDTO.UpdatedAt,
+	}
 
 	tests := []struct {
 		name                  string
+		inputQuery            string
 		hasAccountIDInContext bool
 		expectCode            int
 		expectResponse        []byte
@@ -728,11 +1380,55 @@ func TestEntry_Search(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &dto.EntryConditionDTO{}).
 					Return([]*dto.EntryDTO{entryDTO}, nil).
 					Times(1)
 			},
 		},
+		{
+			name:                  "successfully searched by metadata",
+			inputQuery:            "?taken_from=2026-10-01T00:00:00Z&taken_to=2026-10-31T23:59:59Z&camera=holos",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        fmt.Appendf(nil, `{"entries":[{"key":"%s","size":%d,"type":"%s","metadata":{"width":40,"height":20,"taken_at":"2026-10-19T03:34:56Z","camera_make":"Holos"},"created_at":"%s","updated_at":"%s"}]}`, photoDTO.Key, photoDTO.Size, photoDTO.Type, photoDTO.CreatedAt.Format(time.RFC3339Nano), photoDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &dto.EntryConditionDTO{TakenFrom: &takenFrom, TakenTo: &takenTo, Camera: "holos"}).
+					Return([]*dto.EntryDTO{photoDTO}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "successfully searched by content",
+			inputQuery:            "?q=test+content",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        fmt.Appendf(nil, `{"entries":[{"key":"%s","size":%d,"type":"%s","snippet":"test content","created_at":"%s","updated_at":"%s"}]}`, snippetDTO.Key, snippetDTO.Size, snippetDTO.Type, snippetDTO.CreatedAt.Format(time.RFC3339Nano), snippetDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &dto.EntryConditionDTO{Query: "test content"}).
+					Return([]*dto.EntryDTO{snippetDTO}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid taken from",
+			inputQuery:            "?taken_from=2026-10-01",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"invalid taken from"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+		},
+		{
+			name:                  "invalid taken to",
+			inputQuery:            "?taken_to=invalid",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"invalid taken to"}`),
+			setMockEntryUC:        func(*mockUsecase.MockEntryUsecase) {},
+		},
 		{
 			name:                  "not found",
 			hasAccountIDInContext: true,
@@ -741,7 +1437,7 @@ func TestEntry_Search(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &dto.EntryConditionDTO{}).
 					Return([]*dto.EntryDTO{}, nil).
 					Times(1)
 			},
@@ -761,7 +1457,7 @@ func TestEntry_Search(t *testing.T) {
 			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
 				entryUC.
 					EXPECT().
-					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Search(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &dto.EntryConditionDTO{}).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -774,7 +1470,7 @@ func TestEntry_Search(t *testing.T) {
 
 			c, _ := gin.CreateTestContext(w)
 			var err error
-			c.Request, err = http.NewRequestWithContext(ctx, "GET", "/entries/volume", http.NoBody)
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "/entries/volume"+tt.inputQuery, http.NoBody)
 			if err != nil {
 				t.Error(err)
 			}
@@ -792,7 +1488,7 @@ func TestEntry_Search(t *testing.T) {
 			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
 			tt.setMockEntryUC(entryUC)
 
-			hdl := handler.NewEntryHandler(entryUC)
+			hdl := handler.NewEntryHandler(entryUC, nil, nil)
 			hdl.Search(c)
 
 			c.Writer.WriteHeaderNow()
@@ -807,3 +1503,11 @@ func TestEntry_Search(t *testing.T) {
 		})
 	}
 }
+
+type readSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (r *readSeekCloser) Close() error {
+	return nil
+}
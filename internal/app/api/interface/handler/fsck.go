@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+type FsckHandler interface {
+	Check(*gin.Context)
+	Repair(*gin.Context)
+	CheckTypes(*gin.Context)
+	RepairTypes(*gin.Context)
+}
+
+type fsckHandler struct {
+	fsckUC usecase.FsckUsecase
+}
+
+func NewFsckHandler(fsckUC usecase.FsckUsecase) FsckHandler {
+	return &fsckHandler{
+		fsckUC: fsckUC,
+	}
+}
+
+func (h *fsckHandler) Check(c *gin.Context) {
+	h.handle(c, h.fsckUC.Check, false)
+}
+
+func (h *fsckHandler) Repair(c *gin.Context) {
+	h.handle(c, h.fsckUC.Check, true)
+}
+
+func (h *fsckHandler) CheckTypes(c *gin.Context) {
+	h.handle(c, h.fsckUC.DetectTypes, false)
+}
+
+func (h *fsckHandler) RepairTypes(c *gin.Context) {
+	h.handle(c, h.fsckUC.DetectTypes, true)
+}
+
+func (h *fsckHandler) handle(c *gin.Context, check func(context.Context, uuid.UUID, string, bool) (*dto.FsckReportDTO, error), repair bool) {
+	name := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	report, err := check(ctx, accountID, name, repair)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToFsckResponse(report))
+}
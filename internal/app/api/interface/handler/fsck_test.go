@@ -0,0 +1,378 @@
+package handler_test
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestFsck_Check(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	reportDTO := &dto.FsckReportDTO{
+		VolumeName: "name",
+		Issues: []*dto.FsckIssueDTO{
+			{Category: "size_mismatch", Key: "sample.txt", Expected: "10", Actual: "4", Repaired: false},
+			{Category: "missing_body", Key: "missing.txt", Repaired: false},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockFsckUC         func(*mockUsecase.MockFsckUsecase)
+	}{
+		{
+			name:                  "successfully checked",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        []byte(`{"volume_name":"name","issues":[{"category":"size_mismatch","key":"sample.txt","expected":"10","actual":"4","repaired":false},{"category":"missing_body","key":"missing.txt","repaired":false}]}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					Check(gomock.Any(), accountID, "name", false).
+					Return(reportDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "account id not set",
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC:         func(*mockUsecase.MockFsckUsecase) {},
+		},
+		{
+			name:                  "check error",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					Check(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "/volumes/name/fsck", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "name"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fsckUC := mockUsecase.NewMockFsckUsecase(ctrl)
+			tt.setMockFsckUC(fsckUC)
+
+			hdl := handler.NewFsckHandler(fsckUC)
+			hdl.Check(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFsck_Repair(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	reportDTO := &dto.FsckReportDTO{
+		VolumeName: "name",
+		Issues: []*dto.FsckIssueDTO{
+			{Category: "orphaned_body", Key: "orphan.txt", Actual: "file", Repaired: true},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockFsckUC         func(*mockUsecase.MockFsckUsecase)
+	}{
+		{
+			name:                  "successfully repaired",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        []byte(`{"volume_name":"name","issues":[{"category":"orphaned_body","key":"orphan.txt","actual":"file","repaired":true}]}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					Check(gomock.Any(), accountID, "name", true).
+					Return(reportDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "account id not set",
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC:         func(*mockUsecase.MockFsckUsecase) {},
+		},
+		{
+			name:                  "repair error",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					Check(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "/volumes/name/fsck", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "name"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fsckUC := mockUsecase.NewMockFsckUsecase(ctrl)
+			tt.setMockFsckUC(fsckUC)
+
+			hdl := handler.NewFsckHandler(fsckUC)
+			hdl.Repair(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFsck_CheckTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	reportDTO := &dto.FsckReportDTO{
+		VolumeName: "name",
+		Issues: []*dto.FsckIssueDTO{
+			{Category: "type_mismatch", Key: "sample.csv", Expected: "text/plain; charset=utf-8", Actual: "text/csv; charset=utf-8", Repaired: false},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockFsckUC         func(*mockUsecase.MockFsckUsecase)
+	}{
+		{
+			name:                  "successfully detected",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        []byte(`{"volume_name":"name","issues":[{"category":"type_mismatch","key":"sample.csv","expected":"text/plain; charset=utf-8","actual":"text/csv; charset=utf-8","repaired":false}]}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					DetectTypes(gomock.Any(), accountID, "name", false).
+					Return(reportDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "account id not set",
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC:         func(*mockUsecase.MockFsckUsecase) {},
+		},
+		{
+			name:                  "detect types error",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					DetectTypes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "/volumes/name/content-types", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "name"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fsckUC := mockUsecase.NewMockFsckUsecase(ctrl)
+			tt.setMockFsckUC(fsckUC)
+
+			hdl := handler.NewFsckHandler(fsckUC)
+			hdl.CheckTypes(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFsck_RepairTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	reportDTO := &dto.FsckReportDTO{
+		VolumeName: "name",
+		Issues: []*dto.FsckIssueDTO{
+			{Category: "type_mismatch", Key: "sample.csv", Expected: "text/plain; charset=utf-8", Actual: "text/csv; charset=utf-8", Repaired: true},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockFsckUC         func(*mockUsecase.MockFsckUsecase)
+	}{
+		{
+			name:                  "successfully repaired",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        []byte(`{"volume_name":"name","issues":[{"category":"type_mismatch","key":"sample.csv","expected":"text/plain; charset=utf-8","actual":"text/csv; charset=utf-8","repaired":true}]}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					DetectTypes(gomock.Any(), accountID, "name", true).
+					Return(reportDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "account id not set",
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC:         func(*mockUsecase.MockFsckUsecase) {},
+		},
+		{
+			name:                  "repair error",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockFsckUC: func(fsckUC *mockUsecase.MockFsckUsecase) {
+				fsckUC.
+					EXPECT().
+					DetectTypes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "/volumes/name/content-types", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "name"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fsckUC := mockUsecase.NewMockFsckUsecase(ctrl)
+			tt.setMockFsckUC(fsckUC)
+
+			hdl := handler.NewFsckHandler(fsckUC)
+			hdl.RepairTypes(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
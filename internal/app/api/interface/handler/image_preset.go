@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+)
+
+type ImagePresetHandler interface {
+	Create(*gin.Context)
+	Delete(*gin.Context)
+	GetAll(*gin.Context)
+}
+
+type imagePresetHandler struct {
+	imageUC usecase.ImageUsecase
+}
+
+func NewImagePresetHandler(imageUC usecase.ImageUsecase) ImagePresetHandler {
+	return &imagePresetHandler{
+		imageUC: imageUC,
+	}
+}
+
+func (h *imagePresetHandler) Create(c *gin.Context) {
+	var req schema.CreateImagePresetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
+		return
+	}
+
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	preset, err := h.imageUC.CreatePreset(ctx, accountID, volumeName, req.Name, builder.ToImageTransformationDTO(&req.ImageTransformationSchema))
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, builder.ToImagePresetResponse(preset))
+}
+
+func (h *imagePresetHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "invalid image preset id"))
+		return
+	}
+
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.imageUC.DeletePreset(ctx, accountID, volumeName, id); err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *imagePresetHandler) GetAll(c *gin.Context) {
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	presets, err := h.imageUC.GetPresets(ctx, accountID, volumeName)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string][]*schema.ImagePresetResponse{"image_presets": builder.ToImagePresetResponses(presets)})
+}
@@ -0,0 +1,291 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestImagePreset_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	presetDTO := &dto.ImagePresetDTO{
+		ID:             uuid.New(),
+		AccountID:      accountID,
+		VolumeID:       uuid.New(),
+		Name:           "small",
+		Transformation: &dto.ImageTransformationDTO{Width: 100, Fit: "contain", Quality: 85},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		inputBody             []byte
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockImageUC        func(*mockUsecase.MockImageUsecase)
+	}{
+		{
+			name:                  "successfully created",
+			inputBody:             []byte(`{"name":"small","width":100}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusCreated,
+			expectResponse:        fmt.Appendf(nil, `{"id":"%s","name":"small","width":100,"height":0,"fit":"contain","crop_x":0,"crop_y":0,"crop_width":0,"crop_height":0,"quality":85,"format":"","created_at":"%s","updated_at":"%s"}`, presetDTO.ID, presetDTO.CreatedAt.Format(time.RFC3339Nano), presetDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					CreatePreset(gomock.Any(), accountID, "volume", "small", &dto.ImageTransformationDTO{Width: 100}).
+					Return(presetDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid request",
+			inputBody:             []byte(`{"name":`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"failed to parse json"}`),
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "account id not set",
+			inputBody:             []byte(`{"name":"small"}`),
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockImageUC:        func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:                  "already exists",
+			inputBody:             []byte(`{"name":"small"}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusConflict,
+			expectResponse:        []byte(`{"message":"image preset already exists"}`),
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					CreatePreset(gomock.Any(), accountID, "volume", "small", gomock.Any()).
+					Return(nil, usecase.ErrImagePresetAlreadyExists).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "volumes/volume/image-presets", bytes.NewBuffer(tt.inputBody))
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			imageUC := mockUsecase.NewMockImageUsecase(ctrl)
+			tt.setMockImageUC(imageUC)
+
+			hdl := handler.NewImagePresetHandler(imageUC)
+			hdl.Create(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestImagePreset_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	id := uuid.New()
+
+	tests := []struct {
+		name           string
+		inputID        string
+		expectCode     int
+		expectResponse []byte
+		setMockImageUC func(*mockUsecase.MockImageUsecase)
+	}{
+		{
+			name:           "successfully deleted",
+			inputID:        id.String(),
+			expectCode:     http.StatusNoContent,
+			expectResponse: nil,
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					DeletePreset(gomock.Any(), accountID, "volume", id).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "invalid id",
+			inputID:        "invalid",
+			expectCode:     http.StatusBadRequest,
+			expectResponse: []byte(`{"message":"invalid image preset id"}`),
+			setMockImageUC: func(*mockUsecase.MockImageUsecase) {},
+		},
+		{
+			name:           "not found",
+			inputID:        id.String(),
+			expectCode:     http.StatusNotFound,
+			expectResponse: []byte(`{"message":"image preset not found"}`),
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					DeletePreset(gomock.Any(), accountID, "volume", id).
+					Return(repository.ErrImagePresetNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "DELETE", "volumes/volume/image-presets/"+tt.inputID, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"}, gin.Param{Key: "id", Value: tt.inputID})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			imageUC := mockUsecase.NewMockImageUsecase(ctrl)
+			tt.setMockImageUC(imageUC)
+
+			hdl := handler.NewImagePresetHandler(imageUC)
+			hdl.Delete(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestImagePreset_GetAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	presetDTO := &dto.ImagePresetDTO{
+		ID:             uuid.New(),
+		AccountID:      accountID,
+		VolumeID:       uuid.New(),
+		Name:           "square",
+		Transformation: &dto.ImageTransformationDTO{Width: 100, Height: 100, Fit: "cover", Quality: 80, Format: "jpeg"},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name           string
+		expectCode     int
+		expectResponse []byte
+		setMockImageUC func(*mockUsecase.MockImageUsecase)
+	}{
+		{
+			name:           "successfully got",
+			expectCode:     http.StatusOK,
+			expectResponse: fmt.Appendf(nil, `{"image_presets":[{"id":"%s","name":"square","width":100,"height":100,"fit":"cover","crop_x":0,"crop_y":0,"crop_width":0,"crop_height":0,"quality":80,"format":"jpeg","created_at":"%s","updated_at":"%s"}]}`, presetDTO.ID, presetDTO.CreatedAt.Format(time.RFC3339Nano), presetDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					GetPresets(gomock.Any(), accountID, "volume").
+					Return([]*dto.ImagePresetDTO{presetDTO}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "find error",
+			expectCode:     http.StatusInternalServerError,
+			expectResponse: []byte(`{"message":"internal server error"}`),
+			setMockImageUC: func(imageUC *mockUsecase.MockImageUsecase) {
+				imageUC.
+					EXPECT().
+					GetPresets(gomock.Any(), accountID, "volume").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "volumes/volume/image-presets", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			imageUC := mockUsecase.NewMockImageUsecase(ctrl)
+			tt.setMockImageUC(imageUC)
+
+			hdl := handler.NewImagePresetHandler(imageUC)
+			hdl.GetAll(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
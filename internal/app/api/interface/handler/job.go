@@ -0,0 +1,110 @@
+package handler
+
+import (
+	errs "errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+)
+
+type JobHandler interface {
+	Scan(*gin.Context)
+	GetOne(*gin.Context)
+	Cancel(*gin.Context)
+}
+
+type jobHandler struct {
+	jobUC usecase.JobUsecase
+}
+
+func NewJobHandler(jobUC usecase.JobUsecase) JobHandler {
+	return &jobHandler{
+		jobUC: jobUC,
+	}
+}
+
+// NOTE: ボリューム全体を再スキャンする場合があるため, 常にジョブとして実行する.
+func (h *jobHandler) Scan(c *gin.Context) {
+	volumeName := c.Param("name")
+
+	var req schema.ScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errs.Is(err, io.EOF) {
+		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
+		return
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	job, err := h.jobUC.Create(ctx, accountID, usecase.JobTypeScan, volumeName, req.Key, "", "", "")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.Header("Location", "/jobs/"+job.ID.String())
+	c.JSON(http.StatusAccepted, builder.ToJobResponse(job))
+}
+
+func (h *jobHandler) GetOne(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "invalid job id"))
+		return
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	job, err := h.jobUC.GetOne(ctx, accountID, id)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToJobResponse(job))
+}
+
+func (h *jobHandler) Cancel(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "invalid job id"))
+		return
+	}
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	job, err := h.jobUC.Cancel(ctx, accountID, id)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builder.ToJobResponse(job))
+}
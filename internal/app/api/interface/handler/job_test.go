@@ -0,0 +1,341 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestJob_Scan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	jobDTO := &dto.JobDTO{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		Type:       "scan",
+		Status:     "pending",
+		VolumeName: "volume",
+		Key:        "key",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		inputRequest          []byte
+		hasAccountIDInContext bool
+		expectCode            int
+		expectLocation        string
+		expectResponse        []byte
+		setMockJobUC          func(*mockUsecase.MockJobUsecase)
+	}{
+		{
+			name:                  "successfully created",
+			inputRequest:          []byte(`{"key": "key"}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusAccepted,
+			expectLocation:        "/jobs/" + jobDTO.ID.String(),
+			expectResponse:        fmt.Appendf(nil, `{"id":"%s","type":"scan","status":"pending","volume_name":"volume","key":"key","progress":{"total_entries":0,"processed_entries":0,"total_bytes":0,"processed_bytes":0},"attempts":0,"created_at":"%s","updated_at":"%s"}`, jobDTO.ID, jobDTO.CreatedAt.Format(time.RFC3339Nano), jobDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, usecase.JobTypeScan, "volume", "key", "", "", "").
+					Return(jobDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "successfully created without body",
+			inputRequest:          nil,
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusAccepted,
+			expectLocation:        "/jobs/" + jobDTO.ID.String(),
+			expectResponse:        fmt.Appendf(nil, `{"id":"%s","type":"scan","status":"pending","volume_name":"volume","key":"key","progress":{"total_entries":0,"processed_entries":0,"total_bytes":0,"processed_bytes":0},"attempts":0,"created_at":"%s","updated_at":"%s"}`, jobDTO.ID, jobDTO.CreatedAt.Format(time.RFC3339Nano), jobDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, usecase.JobTypeScan, "volume", "", "", "", "").
+					Return(jobDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid request",
+			inputRequest:          []byte(`{"key": 1}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"failed to parse json"}`),
+			setMockJobUC:          func(*mockUsecase.MockJobUsecase) {},
+		},
+		{
+			name:                  "account id not set",
+			inputRequest:          []byte(`{"key": "key"}`),
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockJobUC:          func(*mockUsecase.MockJobUsecase) {},
+		},
+		{
+			name:                  "create error",
+			inputRequest:          []byte(`{"key": "key"}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, usecase.JobTypeScan, "volume", "key", "", "", "").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "volumes/volume/scans", bytes.NewBuffer(tt.inputRequest))
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			jobUC := mockUsecase.NewMockJobUsecase(ctrl)
+			tt.setMockJobUC(jobUC)
+
+			hdl := handler.NewJobHandler(jobUC)
+			hdl.Scan(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+			if location := w.Header().Get("Location"); location != tt.expectLocation {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectLocation, location)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestJob_GetOne(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	jobDTO := &dto.JobDTO{
+		ID:               uuid.New(),
+		AccountID:        accountID,
+		Type:             "copy",
+		Status:           "running",
+		VolumeName:       "volume",
+		Key:              "key",
+		TotalEntries:     10,
+		ProcessedEntries: 3,
+		TotalBytes:       100,
+		ProcessedBytes:   30,
+		Attempts:         1,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		inputID               string
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockJobUC          func(*mockUsecase.MockJobUsecase)
+	}{
+		{
+			name:                  "successfully got",
+			inputID:               jobDTO.ID.String(),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusOK,
+			expectResponse:        fmt.Appendf(nil, `{"id":"%s","type":"copy","status":"running","volume_name":"volume","key":"key","progress":{"total_entries":10,"processed_entries":3,"total_bytes":100,"processed_bytes":30},"attempts":1,"created_at":"%s","updated_at":"%s"}`, jobDTO.ID, jobDTO.CreatedAt.Format(time.RFC3339Nano), jobDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					GetOne(gomock.Any(), accountID, jobDTO.ID).
+					Return(jobDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid id",
+			inputID:               "invalid",
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"invalid job id"}`),
+			setMockJobUC:          func(*mockUsecase.MockJobUsecase) {},
+		},
+		{
+			name:                  "account id not set",
+			inputID:               jobDTO.ID.String(),
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockJobUC:          func(*mockUsecase.MockJobUsecase) {},
+		},
+		{
+			name:                  "not found",
+			inputID:               jobDTO.ID.String(),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusNotFound,
+			expectResponse:        []byte(`{"message":"job not found"}`),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					GetOne(gomock.Any(), accountID, jobDTO.ID).
+					Return(nil, repository.ErrJobNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "jobs/"+tt.inputID, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: tt.inputID})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			jobUC := mockUsecase.NewMockJobUsecase(ctrl)
+			tt.setMockJobUC(jobUC)
+
+			hdl := handler.NewJobHandler(jobUC)
+			hdl.GetOne(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestJob_Cancel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	jobDTO := &dto.JobDTO{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		Type:       "delete",
+		Status:     "cancelled",
+		VolumeName: "volume",
+		Key:        "key",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	tests := []struct {
+		name           string
+		expectCode     int
+		expectResponse []byte
+		setMockJobUC   func(*mockUsecase.MockJobUsecase)
+	}{
+		{
+			name:           "successfully cancelled",
+			expectCode:     http.StatusOK,
+			expectResponse: fmt.Appendf(nil, `{"id":"%s","type":"delete","status":"cancelled","volume_name":"volume","key":"key","progress":{"total_entries":0,"processed_entries":0,"total_bytes":0,"processed_bytes":0},"attempts":0,"created_at":"%s","updated_at":"%s"}`, jobDTO.ID, jobDTO.CreatedAt.Format(time.RFC3339Nano), jobDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Cancel(gomock.Any(), accountID, jobDTO.ID).
+					Return(jobDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "cancel error",
+			expectCode:     http.StatusInternalServerError,
+			expectResponse: []byte(`{"message":"internal server error"}`),
+			setMockJobUC: func(jobUC *mockUsecase.MockJobUsecase) {
+				jobUC.
+					EXPECT().
+					Cancel(gomock.Any(), accountID, jobDTO.ID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "DELETE", "jobs/"+jobDTO.ID.String(), http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "id", Value: jobDTO.ID.String()})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			jobUC := mockUsecase.NewMockJobUsecase(ctrl)
+			tt.setMockJobUC(jobUC)
+
+			hdl := handler.NewJobHandler(jobUC)
+			hdl.Cancel(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/audit"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
@@ -39,6 +40,7 @@ func (h *volumeHandler) Create(c *gin.Context) {
 		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
 		return
 	}
+	audit.SetTarget(c, req.Name, "")
 
 	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
 	if err != nil {
@@ -48,7 +50,7 @@ func (h *volumeHandler) Create(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	volume, err := h.volumeUC.Create(ctx, accountID, req.Name, req.IsPublic)
+	volume, err := h.volumeUC.Create(ctx, accountID, req.Name, req.IsPublic, req.Compression, builder.ToVolumePolicyDTO(req.Policy), builder.ToVolumeDropDTO(req.Drop))
 	if err != nil {
 		errors.Handle(c, err)
 		return
@@ -63,6 +65,7 @@ func (h *volumeHandler) Update(c *gin.Context) {
 		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
 		return
 	}
+	audit.SetDestination(c, req.Name, "")
 
 	name := c.Param("name")
 
@@ -74,7 +77,7 @@ func (h *volumeHandler) Update(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	volume, err := h.volumeUC.Update(ctx, accountID, name, req.Name, req.IsPublic)
+	volume, err := h.volumeUC.Update(ctx, accountID, name, req.Name, req.IsPublic, req.Compression, builder.ToVolumePolicyDTO(req.Policy), builder.ToVolumeDropDTO(req.Drop))
 	if err != nil {
 		errors.Handle(c, err)
 		return
@@ -24,12 +24,14 @@ func TestVolume_Create(t *testing.T) {
 
 	accountID := uuid.New()
 	volumeDTO := &dto.VolumeDTO{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		Name:      "name",
-		IsPublic:  false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Name:        "name",
+		IsPublic:    false,
+		Compression: "gzip",
+		Policy:      &dto.VolumePolicyDTO{MaxFileSize: 1024, AllowedTypes: []string{"image/*"}},
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	tests := []struct {
@@ -42,14 +44,14 @@ func TestVolume_Create(t *testing.T) {
 	}{
 		{
 			name:                  "successfully created",
-			requestBody:           []byte(`{"name":"name","is_public":false}`),
+			requestBody:           []byte(`{"name":"name","is_public":false,"policy":{"max_file_size":1024,"allowed_types":["image/*"]}}`),
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusCreated,
-			expectResponse:        fmt.Appendf(nil, `{"name":"%s","is_public":%t,"created_at":"%s","updated_at":"%s"}`, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			expectResponse:        fmt.Appendf(nil, `{"owner_id":"%s","name":"%s","is_public":%t,"compression":"%s","policy":{"max_file_size":1024,"allowed_types":["image/*"],"denied_types":[],"allowed_extensions":[],"denied_extensions":[],"max_key_depth":0,"max_entries_per_folder":0,"max_volume_size":0,"max_volume_entries":0},"drop":null,"created_at":"%s","updated_at":"%s"}`, volumeDTO.AccountID, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.Compression, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
 			setMockVolumeUC: func(volumeUC *mockUsecase.MockVolumeUsecase) {
 				volumeUC.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), volumeDTO.Policy, gomock.Any()).
 					Return(volumeDTO, nil).
 					Times(1)
 			},
@@ -79,7 +81,7 @@ func TestVolume_Create(t *testing.T) {
 			setMockVolumeUC: func(volumeUC *mockUsecase.MockVolumeUsecase) {
 				volumeUC.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -127,12 +129,13 @@ func TestVolume_Update(t *testing.T) {
 
 	accountID := uuid.New()
 	volumeDTO := &dto.VolumeDTO{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		Name:      "name",
-		IsPublic:  false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Name:        "name",
+		IsPublic:    false,
+		Compression: "gzip",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	tests := []struct {
@@ -148,11 +151,11 @@ func TestVolume_Update(t *testing.T) {
 			requestBody:           []byte(`{"name": "name", "is_public": false}`),
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusOK,
-			expectResponse:        fmt.Appendf(nil, `{"name":"%s","is_public":%t,"created_at":"%s","updated_at":"%s"}`, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			expectResponse:        fmt.Appendf(nil, `{"owner_id":"%s","name":"%s","is_public":%t,"compression":"%s","policy":{"max_file_size":0,"allowed_types":[],"denied_types":[],"allowed_extensions":[],"denied_extensions":[],"max_key_depth":0,"max_entries_per_folder":0,"max_volume_size":0,"max_volume_entries":0},"drop":null,"created_at":"%s","updated_at":"%s"}`, volumeDTO.AccountID, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.Compression, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
 			setMockVolumeUC: func(volumeUC *mockUsecase.MockVolumeUsecase) {
 				volumeUC.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(volumeDTO, nil).
 					Times(1)
 			},
@@ -182,7 +185,7 @@ func TestVolume_Update(t *testing.T) {
 			setMockVolumeUC: func(volumeUC *mockUsecase.MockVolumeUsecase) {
 				volumeUC.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -315,12 +318,13 @@ func TestVolume_GetOne(t *testing.T) {
 
 	accountID := uuid.New()
 	volumeDTO := &dto.VolumeDTO{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		Name:      "name",
-		IsPublic:  false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Name:        "name",
+		IsPublic:    false,
+		Compression: "gzip",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	tests := []struct {
@@ -334,7 +338,7 @@ func TestVolume_GetOne(t *testing.T) {
 			name:                  "successfully got one",
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusOK,
-			expectResponse:        fmt.Appendf(nil, `{"name":"%s","is_public":%t,"created_at":"%s","updated_at":"%s"}`, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			expectResponse:        fmt.Appendf(nil, `{"owner_id":"%s","name":"%s","is_public":%t,"compression":"%s","policy":{"max_file_size":0,"allowed_types":[],"denied_types":[],"allowed_extensions":[],"denied_extensions":[],"max_key_depth":0,"max_entries_per_folder":0,"max_volume_size":0,"max_volume_entries":0},"drop":null,"created_at":"%s","updated_at":"%s"}`, volumeDTO.AccountID, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.Compression, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
 			setMockVolumeUC: func(volumeUC *mockUsecase.MockVolumeUsecase) {
 				volumeUC.
 					EXPECT().
@@ -407,12 +411,13 @@ func TestVolume_GetAll(t *testing.T) {
 
 	accountID := uuid.New()
 	volumeDTO := &dto.VolumeDTO{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		Name:      "name",
-		IsPublic:  false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Name:        "name",
+		IsPublic:    false,
+		Compression: "gzip",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
 	tests := []struct {
@@ -426,7 +431,7 @@ func TestVolume_GetAll(t *testing.T) {
 			name:                  "successfully got all",
 			hasAccountIDInContext: true,
 			expectCode:            http.StatusOK,
-			expectResponse:        fmt.Appendf(nil, `{"volumes":[{"name":"%s","is_public":%t,"created_at":"%s","updated_at":"%s"}]}`, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			expectResponse:        fmt.Appendf(nil, `{"volumes":[{"owner_id":"%s","name":"%s","is_public":%t,"compression":"%s","policy":{"max_file_size":0,"allowed_types":[],"denied_types":[],"allowed_extensions":[],"denied_extensions":[],"max_key_depth":0,"max_entries_per_folder":0,"max_volume_size":0,"max_volume_entries":0},"drop":null,"created_at":"%s","updated_at":"%s"}]}`, volumeDTO.AccountID, volumeDTO.Name, volumeDTO.IsPublic, volumeDTO.Compression, volumeDTO.CreatedAt.Format(time.RFC3339Nano), volumeDTO.UpdatedAt.Format(time.RFC3339Nano)),
 			setMockVolumeUC: func(volumeUC *mockUsecase.MockVolumeUsecase) {
 				volumeUC.
 					EXPECT().
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/builder"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/schema"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+)
+
+type WebhookHandler interface {
+	Create(*gin.Context)
+	Delete(*gin.Context)
+	GetAll(*gin.Context)
+	GetDeliveries(*gin.Context)
+}
+
+type webhookHandler struct {
+	webhookUC usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUC usecase.WebhookUsecase) WebhookHandler {
+	return &webhookHandler{
+		webhookUC: webhookUC,
+	}
+}
+
+func (h *webhookHandler) Create(c *gin.Context) {
+	var req schema.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "failed to parse json"))
+		return
+	}
+
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	webhook, err := h.webhookUC.Create(ctx, accountID, volumeName, req.URL, req.Events, req.Prefix)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, builder.ToCreatedWebhookResponse(webhook))
+}
+
+func (h *webhookHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "invalid webhook id"))
+		return
+	}
+
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if err := h.webhookUC.Delete(ctx, accountID, volumeName, id); err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *webhookHandler) GetAll(c *gin.Context) {
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	webhooks, err := h.webhookUC.GetAll(ctx, accountID, volumeName)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string][]*schema.WebhookResponse{"webhooks": builder.ToWebhookResponses(webhooks)})
+}
+
+func (h *webhookHandler) GetDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errors.Handle(c, status.Error(code.BadRequest, "invalid webhook id"))
+		return
+	}
+
+	volumeName := c.Param("name")
+
+	accountID, err := parameter.GetContextParameter[uuid.UUID](c, "accountID")
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	deliveries, err := h.webhookUC.GetDeliveries(ctx, accountID, volumeName, id)
+	if err != nil {
+		errors.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string][]*schema.WebhookDeliveryResponse{"deliveries": builder.ToWebhookDeliveryResponses(deliveries)})
+}
@@ -0,0 +1,389 @@
+package handler_test
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/handler"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestWebhook_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	webhookDTO := &dto.WebhookDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  uuid.New(),
+		URL:       "https://example.com",
+		Secret:    "secret",
+		Events:    []string{"entry.created"},
+		Prefix:    "dir",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		inputBody             []byte
+		hasAccountIDInContext bool
+		expectCode            int
+		expectResponse        []byte
+		setMockWebhookUC      func(*mockUsecase.MockWebhookUsecase)
+	}{
+		{
+			name:                  "successfully created",
+			inputBody:             []byte(`{"url":"https://example.com","events":["entry.created"],"prefix":"dir"}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusCreated,
+			expectResponse:        fmt.Appendf(nil, `{"id":"%s","url":"https://example.com","secret":"secret","events":["entry.created"],"prefix":"dir","created_at":"%s","updated_at":"%s"}`, webhookDTO.ID, webhookDTO.CreatedAt.Format(time.RFC3339Nano), webhookDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, "volume", "https://example.com", []string{"entry.created"}, "dir").
+					Return(webhookDTO, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid request",
+			inputBody:             []byte(`{"url":`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusBadRequest,
+			expectResponse:        []byte(`{"message":"failed to parse json"}`),
+			setMockWebhookUC:      func(*mockUsecase.MockWebhookUsecase) {},
+		},
+		{
+			name:                  "account id not set",
+			inputBody:             []byte(`{"url":"https://example.com"}`),
+			hasAccountIDInContext: false,
+			expectCode:            http.StatusInternalServerError,
+			expectResponse:        []byte(`{"message":"internal server error"}`),
+			setMockWebhookUC:      func(*mockUsecase.MockWebhookUsecase) {},
+		},
+		{
+			name:                  "invalid url",
+			inputBody:             []byte(`{"url":"example.com"}`),
+			hasAccountIDInContext: true,
+			expectCode:            http.StatusUnprocessableEntity,
+			expectResponse:        []byte(`{"message":"unprocessable content"}`),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					Create(gomock.Any(), accountID, "volume", "example.com", gomock.Any(), "").
+					Return(nil, entity.ErrInvalidWebhookURL).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "POST", "volumes/volume/webhooks", bytes.NewBuffer(tt.inputBody))
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			if tt.hasAccountIDInContext {
+				c.Set("accountID", accountID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookUC := mockUsecase.NewMockWebhookUsecase(ctrl)
+			tt.setMockWebhookUC(webhookUC)
+
+			hdl := handler.NewWebhookHandler(webhookUC)
+			hdl.Create(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_Delete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	id := uuid.New()
+
+	tests := []struct {
+		name             string
+		inputID          string
+		expectCode       int
+		expectResponse   []byte
+		setMockWebhookUC func(*mockUsecase.MockWebhookUsecase)
+	}{
+		{
+			name:           "successfully deleted",
+			inputID:        id.String(),
+			expectCode:     http.StatusNoContent,
+			expectResponse: nil,
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", id).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "invalid id",
+			inputID:          "invalid",
+			expectCode:       http.StatusBadRequest,
+			expectResponse:   []byte(`{"message":"invalid webhook id"}`),
+			setMockWebhookUC: func(*mockUsecase.MockWebhookUsecase) {},
+		},
+		{
+			name:           "not found",
+			inputID:        id.String(),
+			expectCode:     http.StatusNotFound,
+			expectResponse: []byte(`{"message":"webhook not found"}`),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", id).
+					Return(repository.ErrWebhookNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "DELETE", "volumes/volume/webhooks/"+tt.inputID, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"}, gin.Param{Key: "id", Value: tt.inputID})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookUC := mockUsecase.NewMockWebhookUsecase(ctrl)
+			tt.setMockWebhookUC(webhookUC)
+
+			hdl := handler.NewWebhookHandler(webhookUC)
+			hdl.Delete(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetAll(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	webhookDTO := &dto.WebhookDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  uuid.New(),
+		URL:       "https://example.com",
+		Secret:    "secret",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		expectCode       int
+		expectResponse   []byte
+		setMockWebhookUC func(*mockUsecase.MockWebhookUsecase)
+	}{
+		{
+			name:           "successfully got",
+			expectCode:     http.StatusOK,
+			expectResponse: fmt.Appendf(nil, `{"webhooks":[{"id":"%s","url":"https://example.com","events":[],"prefix":"","created_at":"%s","updated_at":"%s"}]}`, webhookDTO.ID, webhookDTO.CreatedAt.Format(time.RFC3339Nano), webhookDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					GetAll(gomock.Any(), accountID, "volume").
+					Return([]*dto.WebhookDTO{webhookDTO}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "find error",
+			expectCode:     http.StatusInternalServerError,
+			expectResponse: []byte(`{"message":"internal server error"}`),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					GetAll(gomock.Any(), accountID, "volume").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "volumes/volume/webhooks", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookUC := mockUsecase.NewMockWebhookUsecase(ctrl)
+			tt.setMockWebhookUC(webhookUC)
+
+			hdl := handler.NewWebhookHandler(webhookUC)
+			hdl.GetAll(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+	id := uuid.New()
+	deliveryDTO := &dto.WebhookDeliveryDTO{
+		ID:             uuid.New(),
+		WebhookID:      id,
+		EventID:        uuid.New(),
+		EventType:      "entry.deleted",
+		Status:         "pending",
+		ResponseStatus: 500,
+		Error:          "webhook endpoint rejected the delivery",
+		Attempts:       1,
+		RunAt:          time.Now(),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name             string
+		inputID          string
+		expectCode       int
+		expectResponse   []byte
+		setMockWebhookUC func(*mockUsecase.MockWebhookUsecase)
+	}{
+		{
+			name:           "successfully got",
+			inputID:        id.String(),
+			expectCode:     http.StatusOK,
+			expectResponse: fmt.Appendf(nil, `{"deliveries":[{"id":"%s","event_id":"%s","event_type":"entry.deleted","status":"pending","response_status":500,"error":"webhook endpoint rejected the delivery","attempts":1,"run_at":"%s","created_at":"%s","updated_at":"%s"}]}`, deliveryDTO.ID, deliveryDTO.EventID, deliveryDTO.RunAt.Format(time.RFC3339Nano), deliveryDTO.CreatedAt.Format(time.RFC3339Nano), deliveryDTO.UpdatedAt.Format(time.RFC3339Nano)),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					GetDeliveries(gomock.Any(), accountID, "volume", id).
+					Return([]*dto.WebhookDeliveryDTO{deliveryDTO}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "invalid id",
+			inputID:          "invalid",
+			expectCode:       http.StatusBadRequest,
+			expectResponse:   []byte(`{"message":"invalid webhook id"}`),
+			setMockWebhookUC: func(*mockUsecase.MockWebhookUsecase) {},
+		},
+		{
+			name:           "not found",
+			inputID:        id.String(),
+			expectCode:     http.StatusNotFound,
+			expectResponse: []byte(`{"message":"webhook not found"}`),
+			setMockWebhookUC: func(webhookUC *mockUsecase.MockWebhookUsecase) {
+				webhookUC.
+					EXPECT().
+					GetDeliveries(gomock.Any(), accountID, "volume", id).
+					Return(nil, repository.ErrWebhookNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "volumes/volume/webhooks/"+tt.inputID+"/deliveries", http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = append(c.Params, gin.Param{Key: "name", Value: "volume"}, gin.Param{Key: "id", Value: tt.inputID})
+			c.Set("accountID", accountID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookUC := mockUsecase.NewMockWebhookUsecase(ctrl)
+			tt.setMockWebhookUC(webhookUC)
+
+			hdl := handler.NewWebhookHandler(webhookUC)
+			hdl.GetDeliveries(c)
+
+			c.Writer.WriteHeaderNow()
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectResponse, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
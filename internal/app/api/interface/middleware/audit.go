@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"context"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/audit"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+const (
+	requestIDHeader    = "X-Request-Id"
+	maxRequestIDLength = 255
+)
+
+// NOTE: 認可に失敗した場合も操作を判別できるよう, ハンドラーではなくルートから操作を判定する.
+var operations = map[string]string{
+	"POST /volumes":                                    "volume.create",
+	"GET /volumes":                                     "volume.list",
+	"PUT /volumes/:name":                               "volume.update",
+	"DELETE /volumes/:name":                            "volume.delete",
+	"GET /volumes/:name":                               "volume.get",
+	"GET /volumes/:name/fsck":                          "volume.fsck.check",
+	"POST /volumes/:name/fsck":                         "volume.fsck.repair",
+	"GET /volumes/:name/content-types":                 "volume.content_type.check",
+	"POST /volumes/:name/content-types":                "volume.content_type.repair",
+	"POST /volumes/:name/scans":                        "volume.scan.create",
+	"POST /volumes/:name/webhooks":                     "webhook.create",
+	"GET /volumes/:name/webhooks":                      "webhook.list",
+	"DELETE /volumes/:name/webhooks/:id":               "webhook.delete",
+	"GET /volumes/:name/webhooks/:id/deliveries":       "webhook.delivery.list",
+	"GET /volumes/:name/events":                        "change.stream",
+	"GET /volumes/:name/changes":                       "change.list",
+	"POST /volumes/:name/image-presets":                "image_preset.create",
+	"GET /volumes/:name/image-presets":                 "image_preset.list",
+	"DELETE /volumes/:name/image-presets/:id":          "image_preset.delete",
+	"POST /entries/:volumeName":                        usecase.OperationCreateEntry,
+	"GET /entries/:volumeName":                         "entry.search",
+	"POST /entries/:volumeName/*key":                   "entry.copy",
+	"PUT /entries/:volumeName/*key":                    "entry.update",
+	"DELETE /entries/:volumeName/*key":                 "entry.delete",
+	"HEAD /entries/:volumeName/*key":                   usecase.OperationHeadEntry,
+	"GET /entries/:volumeName/*key":                    usecase.OperationGetEntry,
+	"POST /batch/entries/:volumeName":                  "entry.batch",
+	"POST /accounts/:ownerID/entries/:volumeName":      usecase.OperationCreateEntry,
+	"HEAD /accounts/:ownerID/entries/:volumeName/*key": usecase.OperationHeadEntry,
+	"GET /accounts/:ownerID/entries/:volumeName/*key":  usecase.OperationGetEntry,
+	"GET /jobs/:id":                                    "job.get",
+	"DELETE /jobs/:id":                                 "job.cancel",
+	"GET /audit-logs":                                  "audit_log.list",
+	"GET /audit-logs/export":                           "audit_log.export",
+	"GET /admin/audit-logs":                            "audit_log.admin.list",
+}
+
+type AuditMiddleware interface {
+	Record(*gin.Context)
+}
+
+type auditMiddleware struct {
+	auditLogUC usecase.AuditLogUsecase
+}
+
+func NewAuditMiddleware(auditLogUC usecase.AuditLogUsecase) AuditMiddleware {
+	return &auditMiddleware{
+		auditLogUC: auditLogUC,
+	}
+}
+
+// NOTE: 記録に失敗しても応答は変更せず, ログの出力のみを行う.
+func (m *auditMiddleware) Record(c *gin.Context) {
+	requestID := c.GetHeader(requestIDHeader)
+	if requestID == "" || maxRequestIDLength < len(requestID) {
+		requestID = uuid.NewString()
+	}
+	c.Header(requestIDHeader, requestID)
+
+	c.Next()
+
+	operation, ok := resolveAuditOperation(c)
+	if !ok {
+		return
+	}
+
+	volumeName, key, newVolumeName, newKey := audit.GetTarget(c)
+	// NOTE: 接続元IPの転送元ヘッダーは信頼するプロキシからの接続の場合のみ利用される.
+	auditLog := &dto.AuditLogDTO{
+		RequestID:      requestID,
+		OwnerID:        getOwnerID(c),
+		ActorID:        getUUID(c, "actorID"),
+		CredentialType: entity.ResolveCredentialType(c.GetHeader("Authorization")),
+		Principal:      entity.ResolvePrincipal(c.GetHeader("Authorization")),
+		ClientIP:       c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+		Operation:      operation,
+		VolumeName:     volumeName,
+		Key:            key,
+		NewVolumeName:  newVolumeName,
+		NewKey:         newKey,
+		Status:         uint64(c.Writer.Status()),
+	}
+
+	// NOTE: 切断やストリームの終了でリクエストのコンテキストが終了していても記録する.
+	ctx := context.WithoutCancel(c.Request.Context())
+	if err := m.auditLogUC.Record(ctx, auditLog); err != nil {
+		log.Println(err.Error())
+	}
+}
+
+func resolveOperation(c *gin.Context) (string, bool) {
+	operation, ok := operations[c.Request.Method+" "+c.FullPath()]
+	return operation, ok
+}
+
+// NOTE: 操作が定義されていないルートは認可で拒否されるため, 拒否を記録できるようルートを操作とする.
+func resolveAuditOperation(c *gin.Context) (string, bool) {
+	if operation, ok := resolveOperation(c); ok {
+		return operation, true
+	}
+	if c.FullPath() == "" {
+		return "", false
+	}
+	return c.Request.Method + " " + c.FullPath(), true
+}
+
+// NOTE: 認可に失敗した場合は所有者を指定したパスからのみ所有者を補完する.
+func getOwnerID(c *gin.Context) uuid.UUID {
+	if id := getUUID(c, "accountID"); id != uuid.Nil {
+		return id
+	}
+	id, _ := resolveOwnerID(c)
+	return id
+}
+
+func getUUID(c *gin.Context, name string) uuid.UUID {
+	if id, ok := c.Value(name).(uuid.UUID); ok {
+		return id
+	}
+	return uuid.Nil
+}
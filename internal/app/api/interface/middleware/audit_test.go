@@ -0,0 +1,339 @@
+package middleware_test
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/middleware"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/audit"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestAudit_Record(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accountID := uuid.New()
+
+	tests := []struct {
+		name              string
+		inputPath         string
+		inputRequestID    string
+		inputAuthorize    func(*gin.Context)
+		expectCode        int
+		expectRequestID   string
+		setMockAuditLogUC func(*mockUsecase.MockAuditLogUsecase)
+	}{
+		{
+			name:           "successfully recorded",
+			inputPath:      "/entries/volume/dir/key",
+			inputRequestID: "request",
+			inputAuthorize: func(c *gin.Context) {
+				c.Set("accountID", accountID)
+				c.Set("actorID", accountID)
+			},
+			expectCode:      http.StatusOK,
+			expectRequestID: "request",
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Record(gomock.Any(), &dto.AuditLogDTO{
+						RequestID:      "request",
+						OwnerID:        accountID,
+						ActorID:        accountID,
+						CredentialType: entity.CredentialTypeSession,
+						Principal:      "0e045a2938edf961",
+						ClientIP:       "192.0.2.1",
+						UserAgent:      "agent",
+						Operation:      "entry.update",
+						VolumeName:     "volume",
+						Key:            "dir/key",
+						NewVolumeName:  "other",
+						NewKey:         "new",
+						Status:         http.StatusOK,
+					}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "authorization failed",
+			inputPath:      "/entries/volume/dir/key",
+			inputRequestID: "request",
+			inputAuthorize: func(c *gin.Context) {
+				c.AbortWithStatus(http.StatusForbidden)
+			},
+			expectCode:      http.StatusForbidden,
+			expectRequestID: "request",
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Record(gomock.Any(), &dto.AuditLogDTO{
+						RequestID:      "request",
+						CredentialType: entity.CredentialTypeSession,
+						Principal:      "0e045a2938edf961",
+						ClientIP:       "192.0.2.1",
+						UserAgent:      "agent",
+						Operation:      "entry.update",
+						VolumeName:     "volume",
+						Key:            "dir/key",
+						Status:         http.StatusForbidden,
+					}).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "record error",
+			inputPath:      "/entries/volume/dir/key",
+			inputRequestID: "request",
+			inputAuthorize: func(c *gin.Context) {
+				c.Set("accountID", accountID)
+				c.Set("actorID", accountID)
+			},
+			expectCode:      http.StatusOK,
+			expectRequestID: "request",
+			setMockAuditLogUC: func(auditLogUC *mockUsecase.MockAuditLogUsecase) {
+				auditLogUC.
+					EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:              "unregistered route",
+			inputPath:         "/unknown",
+			inputRequestID:    "request",
+			inputAuthorize:    func(*gin.Context) {},
+			expectCode:        http.StatusNotFound,
+			expectRequestID:   "request",
+			setMockAuditLogUC: func(*mockUsecase.MockAuditLogUsecase) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+			tt.setMockAuditLogUC(auditLogUC)
+
+			mw := middleware.NewAuditMiddleware(auditLogUC)
+
+			r := gin.New()
+			if err := r.SetTrustedProxies(nil); err != nil {
+				t.Error(err)
+			}
+			r.Use(mw.Record)
+			r.Use(tt.inputAuthorize)
+			r.PUT("/entries/:volumeName/*key", func(c *gin.Context) {
+				audit.SetDestination(c, "other", "new")
+				c.Status(http.StatusOK)
+			})
+
+			req, err := http.NewRequestWithContext(t.Context(), "PUT", tt.inputPath, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			req.RemoteAddr = "192.0.2.1:1234"
+			req.Header.Set("X-Forwarded-For", "198.51.100.1")
+			req.Header.Set("Authorization", "Session 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS")
+			req.Header.Set("User-Agent", "agent")
+			req.Header.Set("X-Request-Id", tt.inputRequestID)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.expectCode {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectCode, w.Code)
+			}
+
+			if diff := cmp.Diff(tt.expectRequestID, w.Header().Get("X-Request-Id")); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAudit_Record_GenerateRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var recorded *dto.AuditLogDTO
+	auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+	auditLogUC.
+		EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, auditLog *dto.AuditLogDTO) error {
+			recorded = auditLog
+			return nil
+		}).
+		Times(1)
+
+	mw := middleware.NewAuditMiddleware(auditLogUC)
+
+	r := gin.New()
+	r.Use(mw.Record)
+	r.GET("/volumes", func(c *gin.Context) {
+		c.Status(http.StatusUnauthorized)
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", "/volumes", http.NoBody)
+	if err != nil {
+		t.Error(err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	requestID := w.Header().Get("X-Request-Id")
+	if _, err := uuid.Parse(requestID); err != nil {
+		t.Errorf("request id is not generated: %s", requestID)
+	}
+	if recorded == nil || recorded.RequestID != requestID || recorded.CredentialType != entity.CredentialTypeAnonymous || recorded.Operation != "volume.list" {
+		t.Errorf("unexpected audit log: %+v", recorded)
+	}
+}
+
+func TestAudit_Record_OwnerPath(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ownerID := uuid.New()
+
+	var recorded *dto.AuditLogDTO
+	auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+	auditLogUC.
+		EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, auditLog *dto.AuditLogDTO) error {
+			recorded = auditLog
+			return nil
+		}).
+		Times(1)
+
+	mw := middleware.NewAuditMiddleware(auditLogUC)
+
+	r := gin.New()
+	r.Use(mw.Record)
+	r.GET("/accounts/:ownerID/entries/:volumeName/*key", func(c *gin.Context) {
+		c.Status(http.StatusForbidden)
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", "/accounts/"+ownerID.String()+"/entries/volume/key", http.NoBody)
+	if err != nil {
+		t.Error(err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if recorded == nil || recorded.OwnerID != ownerID || recorded.ActorID != uuid.Nil || recorded.VolumeName != "volume" || recorded.Operation != "entry.get" {
+		t.Errorf("unexpected audit log: %+v", recorded)
+	}
+}
+
+func TestAudit_Record_Batch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		inputPath       string
+		expectOperation string
+		expectKey       string
+	}{
+		{name: "batch", inputPath: "/batch/entries/volume", expectOperation: "entry.batch", expectKey: ""},
+		{name: "copy entry named batch", inputPath: "/entries/volume/batch", expectOperation: "entry.copy", expectKey: "batch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var recorded *dto.AuditLogDTO
+			auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+			auditLogUC.
+				EXPECT().
+				Record(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, auditLog *dto.AuditLogDTO) error {
+					recorded = auditLog
+					return nil
+				}).
+				Times(1)
+
+			mw := middleware.NewAuditMiddleware(auditLogUC)
+
+			r := gin.New()
+			r.Use(mw.Record)
+			r.POST("/entries/:volumeName/*key", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			r.POST("/batch/entries/:volumeName", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, err := http.NewRequestWithContext(t.Context(), "POST", tt.inputPath, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if recorded == nil || recorded.Operation != tt.expectOperation || recorded.VolumeName != "volume" || recorded.Key != tt.expectKey {
+				t.Errorf("unexpected audit log: %+v", recorded)
+			}
+		})
+	}
+}
+
+func TestAudit_Record_UndefinedOperation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var recorded *dto.AuditLogDTO
+	auditLogUC := mockUsecase.NewMockAuditLogUsecase(ctrl)
+	auditLogUC.
+		EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, auditLog *dto.AuditLogDTO) error {
+			recorded = auditLog
+			return nil
+		}).
+		Times(1)
+
+	mw := middleware.NewAuditMiddleware(auditLogUC)
+
+	r := gin.New()
+	r.Use(mw.Record)
+	r.GET("/undefined/:id", func(c *gin.Context) {
+		c.Status(http.StatusForbidden)
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", "/undefined/1", http.NoBody)
+	if err != nil {
+		t.Error(err)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if recorded == nil || recorded.Operation != "GET /undefined/:id" || recorded.Status != http.StatusForbidden {
+		t.Errorf("unexpected audit log: %+v", recorded)
+	}
+}
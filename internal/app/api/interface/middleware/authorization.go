@@ -2,11 +2,18 @@ package middleware
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/parameter"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/actor"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
 )
 
+var ErrUndefinedOperation = status.Error(code.Forbidden, "operation is not defined")
+
 type AuthorizationMiddleware interface {
 	Authorize(*gin.Context)
 }
@@ -25,11 +32,24 @@ func (m *authorizationMiddleware) Authorize(c *gin.Context) {
 	credential := c.Request.Header.Get("Authorization")
 	volumeName := c.Param("volumeName")
 	key := c.Param("key")
-	method := c.Request.Method
+	// NOTE: 操作が定義されていないルートは操作に応じた認可ができないため拒否する.
+	operation, ok := resolveOperation(c)
+	if !ok && c.FullPath() != "" {
+		errors.Handle(c, ErrUndefinedOperation)
+		c.Abort()
+		return
+	}
+
+	ownerID, err := resolveOwnerID(c)
+	if err != nil {
+		errors.Handle(c, err)
+		c.Abort()
+		return
+	}
 
 	ctx := c.Request.Context()
 
-	account, err := m.authorizationUC.Authorize(ctx, credential, volumeName, key, method)
+	account, err := m.authorizationUC.Authorize(ctx, credential, ownerID, volumeName, key, operation, c.ClientIP())
 	if err != nil {
 		errors.Handle(c, err)
 		c.Abort()
@@ -37,5 +57,18 @@ func (m *authorizationMiddleware) Authorize(c *gin.Context) {
 	}
 
 	c.Set("accountID", account.ID)
+	c.Set("isAnonymous", account.IsAnonymous)
+	if !account.IsAnonymous {
+		c.Set("actorID", account.ID)
+		c.Request = c.Request.WithContext(actor.WithID(ctx, account.ID))
+	}
 	c.Next()
 }
+
+// NOTE: 所有者を指定しないパスの場合はuuid.Nilを返却する.
+func resolveOwnerID(c *gin.Context) (uuid.UUID, error) {
+	if c.Param("ownerID") == "" {
+		return uuid.Nil, nil
+	}
+	return parameter.GetPathParameter[uuid.UUID](c, "ownerID")
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/middleware"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/actor"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
 	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
 )
@@ -26,7 +28,10 @@ func TestAuthorization_Authorize(t *testing.T) {
 	tests := []struct {
 		name                   string
 		authorizationHeader    string
+		inputParams            gin.Params
 		expectResult           uuid.UUID
+		expectActorID          uuid.UUID
+		expectIsAnonymous      bool
 		expectError            []byte
 		setMockAuthorizationUC func(*mockUsecase.MockAuthorizationUsecase)
 	}{
@@ -34,14 +39,52 @@ func TestAuthorization_Authorize(t *testing.T) {
 			name:                "session token is set",
 			authorizationHeader: "Session 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS",
 			expectResult:        accountDTO.ID,
+			expectActorID:       accountDTO.ID,
 			expectError:         nil,
 			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
 				authorizationUC.EXPECT().
-					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(accountDTO, nil).
 					Times(1)
 			},
 		},
+		{
+			name:                "anonymous account",
+			authorizationHeader: "",
+			expectResult:        accountDTO.ID,
+			expectActorID:       uuid.Nil,
+			expectIsAnonymous:   true,
+			expectError:         nil,
+			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
+				authorizationUC.EXPECT().
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&dto.AccountDTO{ID: accountDTO.ID, IsAnonymous: true}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "owner specified",
+			authorizationHeader: "",
+			inputParams:         gin.Params{{Key: "ownerID", Value: accountDTO.ID.String()}},
+			expectResult:        accountDTO.ID,
+			expectActorID:       uuid.Nil,
+			expectIsAnonymous:   true,
+			expectError:         nil,
+			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
+				authorizationUC.EXPECT().
+					Authorize(gomock.Any(), gomock.Any(), accountDTO.ID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&dto.AccountDTO{ID: accountDTO.ID, IsAnonymous: true}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "invalid owner id",
+			authorizationHeader:    "",
+			inputParams:            gin.Params{{Key: "ownerID", Value: "invalid"}},
+			expectResult:           uuid.Nil,
+			expectError:            []byte(`{"message":"invalid UUID length: 7"}`),
+			setMockAuthorizationUC: func(*mockUsecase.MockAuthorizationUsecase) {},
+		},
 		{
 			name:                "session token not set",
 			authorizationHeader: "",
@@ -49,7 +92,7 @@ func TestAuthorization_Authorize(t *testing.T) {
 			expectError:         []byte(`{"message":"unauthorized"}`),
 			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
 				authorizationUC.EXPECT().
-					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, repository.ErrUnauthorized).
 					Times(1)
 			},
@@ -61,7 +104,7 @@ func TestAuthorization_Authorize(t *testing.T) {
 			expectError:         []byte(`{"message":"internal server error"}`),
 			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
 				authorizationUC.EXPECT().
-					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Authorize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, http.ErrServerClosed).
 					Times(1)
 			},
@@ -79,6 +122,7 @@ func TestAuthorization_Authorize(t *testing.T) {
 				t.Error(err)
 			}
 			c.Request.Header.Add("Authorization", tt.authorizationHeader)
+			c.Params = tt.inputParams
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
@@ -95,9 +139,139 @@ func TestAuthorization_Authorize(t *testing.T) {
 				t.Error(diff)
 			}
 
+			actorID, _ := c.Get("actorID")
+			actorResult, _ := actorID.(uuid.UUID)
+			if diff := cmp.Diff(actorResult, tt.expectActorID); diff != "" {
+				t.Error(diff)
+			}
+
+			if id := actor.ID(c.Request.Context()); id != tt.expectActorID {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectActorID, id)
+			}
+
+			if isAnonymous := c.GetBool("isAnonymous"); isAnonymous != tt.expectIsAnonymous {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectIsAnonymous, isAnonymous)
+			}
+
 			if diff := cmp.Diff(tt.expectError, w.Body.Bytes()); diff != "" {
 				t.Error(diff)
 			}
 		})
 	}
 }
+
+func TestAuthorization_Authorize_UndefinedOperation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authorizationUC := mockUsecase.NewMockAuthorizationUsecase(ctrl)
+	mw := middleware.NewAuthorizationMiddleware(authorizationUC)
+
+	r := gin.New()
+	r.Use(mw.Authorize)
+	r.GET("/undefined", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", "/undefined", http.NoBody)
+	if err != nil {
+		t.Error(err)
+	}
+	req.Header.Set("Authorization", "Session 1Ty1HKTPKTt8xEi-_3HTbWf2SCHOdqOS")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("\nexpect: %v\ngot: %v", http.StatusForbidden, w.Code)
+	}
+	if diff := cmp.Diff([]byte(`{"message":"forbidden"}`), w.Body.Bytes()); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestAuthorization_Authorize_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ownerID := uuid.New()
+	accountDTO := &dto.AccountDTO{ID: ownerID, IsAnonymous: true}
+
+	tests := []struct {
+		name                   string
+		inputTrustedProxies    []string
+		inputForwardedFor      []string
+		expectCodes            []int
+		setMockAuthorizationUC func(*mockUsecase.MockAuthorizationUsecase)
+	}{
+		{
+			name:                "spoofed forwarded for",
+			inputTrustedProxies: nil,
+			inputForwardedFor:   []string{"198.51.100.1", "198.51.100.2"},
+			expectCodes:         []int{http.StatusOK, http.StatusTooManyRequests},
+			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
+				authorizationUC.EXPECT().
+					Authorize(gomock.Any(), "", ownerID, "volume", "", gomock.Any(), "192.0.2.1").
+					Return(accountDTO, nil).
+					Times(1)
+				authorizationUC.EXPECT().
+					Authorize(gomock.Any(), "", ownerID, "volume", "", gomock.Any(), "192.0.2.1").
+					Return(nil, usecase.ErrTooManyRequests).
+					Times(1)
+			},
+		},
+		{
+			name:                "trusted proxy",
+			inputTrustedProxies: []string{"192.0.2.1"},
+			inputForwardedFor:   []string{"198.51.100.1", "198.51.100.2"},
+			expectCodes:         []int{http.StatusOK, http.StatusOK},
+			setMockAuthorizationUC: func(authorizationUC *mockUsecase.MockAuthorizationUsecase) {
+				authorizationUC.EXPECT().
+					Authorize(gomock.Any(), "", ownerID, "volume", "", gomock.Any(), "198.51.100.1").
+					Return(accountDTO, nil).
+					Times(1)
+				authorizationUC.EXPECT().
+					Authorize(gomock.Any(), "", ownerID, "volume", "", gomock.Any(), "198.51.100.2").
+					Return(accountDTO, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authorizationUC := mockUsecase.NewMockAuthorizationUsecase(ctrl)
+			tt.setMockAuthorizationUC(authorizationUC)
+
+			mw := middleware.NewAuthorizationMiddleware(authorizationUC)
+
+			r := gin.New()
+			if err := r.SetTrustedProxies(tt.inputTrustedProxies); err != nil {
+				t.Error(err)
+			}
+			r.Use(mw.Authorize)
+			r.POST("/accounts/:ownerID/entries/:volumeName", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			for i, forwardedFor := range tt.inputForwardedFor {
+				req, err := http.NewRequestWithContext(t.Context(), "POST", "/accounts/"+ownerID.String()+"/entries/volume", http.NoBody)
+				if err != nil {
+					t.Error(err)
+				}
+				req.RemoteAddr = "192.0.2.1:1234"
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				if w.Code != tt.expectCodes[i] {
+					t.Errorf("\nexpect: %v\ngot: %v", tt.expectCodes[i], w.Code)
+				}
+			}
+		})
+	}
+}
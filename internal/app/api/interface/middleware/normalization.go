@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/pkg/errors"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrInvalidPathEncoding = status.Error(code.BadRequest, "path is not valid utf-8")
+
+type NormalizationMiddleware interface {
+	Normalize(*gin.Context)
+}
+
+type normalizationMiddleware struct{}
+
+func NewNormalizationMiddleware() NormalizationMiddleware {
+	return &normalizationMiddleware{}
+}
+
+// NOTE: 異なる表現で入力された名前でも保存時と同じキーで参照できるよう, エンティティと同じくNFCに正規化する.
+func (m *normalizationMiddleware) Normalize(c *gin.Context) {
+	for i, param := range c.Params {
+		if !utf8.ValidString(param.Value) {
+			errors.Handle(c, ErrInvalidPathEncoding)
+			c.Abort()
+			return
+		}
+		c.Params[i].Value = norm.NFC.String(param.Value)
+	}
+
+	query := c.Request.URL.Query()
+	for _, values := range query {
+		for i, value := range values {
+			values[i] = norm.NFC.String(value)
+		}
+	}
+	c.Request.URL.RawQuery = query.Encode()
+}
@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/interface/middleware"
+)
+
+func TestNormalization_Normalize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		inputParams   gin.Params
+		inputQuery    string
+		expectParams  gin.Params
+		expectPrefix  string
+		expectAborted bool
+		expectError   []byte
+	}{
+		{
+			name:          "already normalized",
+			inputParams:   gin.Params{{Key: "volumeName", Value: "ボリューム"}, {Key: "key", Value: "/フォルダ/sample.txt"}},
+			inputQuery:    "prefix=%E3%83%95%E3%82%A9%E3%83%AB%E3%83%80",
+			expectParams:  gin.Params{{Key: "volumeName", Value: "ボリューム"}, {Key: "key", Value: "/フォルダ/sample.txt"}},
+			expectPrefix:  "フォルダ",
+			expectAborted: false,
+			expectError:   nil,
+		},
+		{
+			name:          "decomposed characters",
+			inputParams:   gin.Params{{Key: "volumeName", Value: "\u30ab\u3099"}, {Key: "key", Value: "/\u30ab\u3099.txt"}},
+			inputQuery:    "prefix=%E3%82%AB%E3%82%99",
+			expectParams:  gin.Params{{Key: "volumeName", Value: "\u30ac"}, {Key: "key", Value: "/\u30ac.txt"}},
+			expectPrefix:  "\u30ac",
+			expectAborted: false,
+			expectError:   nil,
+		},
+		{
+			name:          "invalid utf-8",
+			inputParams:   gin.Params{{Key: "volumeName", Value: "volume"}, {Key: "key", Value: "/\xff.txt"}},
+			inputQuery:    "",
+			expectParams:  gin.Params{{Key: "volumeName", Value: "volume"}, {Key: "key", Value: "/\xff.txt"}},
+			expectPrefix:  "",
+			expectAborted: true,
+			expectError:   []byte(`{"message":"path is not valid utf-8"}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			var err error
+			c.Request, err = http.NewRequestWithContext(ctx, "GET", "/entries?"+tt.inputQuery, http.NoBody)
+			if err != nil {
+				t.Error(err)
+			}
+			c.Params = tt.inputParams
+
+			mw := middleware.NewNormalizationMiddleware()
+			mw.Normalize(c)
+
+			if diff := cmp.Diff(tt.expectParams, c.Params); diff != "" {
+				t.Error(diff)
+			}
+
+			if prefix := c.Query("prefix"); prefix != tt.expectPrefix {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectPrefix, prefix)
+			}
+
+			if c.IsAborted() != tt.expectAborted {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectAborted, c.IsAborted())
+			}
+
+			if diff := cmp.Diff(tt.expectError, w.Body.Bytes()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	volumeNameKey    = "auditVolumeName"
+	keyKey           = "auditKey"
+	newVolumeNameKey = "auditNewVolumeName"
+	newKeyKey        = "auditNewKey"
+)
+
+// NOTE: 対象がパスパラメータに含まれない操作は, ハンドラーでボディから取得した対象を設定する.
+func SetTarget(c *gin.Context, volumeName, key string) {
+	c.Set(volumeNameKey, volumeName)
+	c.Set(keyKey, key)
+}
+
+func SetDestination(c *gin.Context, volumeName, key string) {
+	c.Set(newVolumeNameKey, volumeName)
+	c.Set(newKeyKey, key)
+}
+
+func GetTarget(c *gin.Context) (volumeName, key, newVolumeName, newKey string) {
+	volumeName = c.Param("volumeName")
+	if volumeName == "" {
+		volumeName = c.Param("name")
+	}
+	key = strings.TrimPrefix(c.Param("key"), "/")
+
+	if v, ok := c.Value(volumeNameKey).(string); ok {
+		volumeName = v
+	}
+	if v, ok := c.Value(keyKey).(string); ok {
+		key = v
+	}
+	return volumeName, key, c.GetString(newVolumeNameKey), c.GetString(newKeyKey)
+}
@@ -21,23 +21,32 @@ var responseMap = map[code.StatusCode]response{
 	code.Forbidden:            {code: http.StatusForbidden, message: "forbidden"},
 	code.NotFound:             {code: http.StatusNotFound, message: "not found"},
 	code.Conflict:             {code: http.StatusConflict, message: "conflict"},
+	code.ContentTooLarge:      {code: http.StatusRequestEntityTooLarge, message: "content too large"},
+	code.UnsupportedMediaType: {code: http.StatusUnsupportedMediaType, message: "unsupported media type"},
 	code.UnprocessableContent: {code: http.StatusUnprocessableEntity, message: "unprocessable content"},
+	code.FailedDependency:     {code: http.StatusFailedDependency, message: "failed dependency"},
+	code.MalwareDetected:      {code: http.StatusUnprocessableEntity, message: "malware detected"},
+	code.TooManyRequests:      {code: http.StatusTooManyRequests, message: "too many requests"},
 	code.Internal:             {code: http.StatusInternalServerError, message: "internal server error"},
 }
 
 func Handle(c *gin.Context, err error) {
 	log.Println(err)
 
+	statusCode, message := Resolve(err)
+	c.JSON(statusCode, map[string]string{"message": message})
+}
+
+// NOTE: 一括操作のように応答の一部としてエラーを返却する場合に利用する.
+func Resolve(err error) (int, string) {
 	if v, ok := err.(*status.Status); ok {
 		resp := responseMap[v.Code()]
-		if v.Code() == code.BadRequest || v.Code() == code.NotFound || v.Code() == code.Conflict {
-			c.JSON(resp.code, map[string]string{"message": v.Message()})
-			return
+		if v.Code() == code.BadRequest || v.Code() == code.NotFound || v.Code() == code.Conflict || v.Code() == code.FailedDependency {
+			return resp.code, v.Message()
 		}
-		c.JSON(resp.code, map[string]string{"message": resp.message})
-		return
+		return resp.code, resp.message
 	}
-	c.JSON(http.StatusInternalServerError, map[string]string{"message": "internal server error"})
+	return http.StatusInternalServerError, "internal server error"
 }
 
 func GetStatusCode(err error) int {
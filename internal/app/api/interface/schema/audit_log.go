@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogResponse struct {
+	ID             uint64     `json:"id"`
+	RequestID      string     `json:"request_id"`
+	OwnerID        *uuid.UUID `json:"owner_id,omitempty"`
+	ActorID        *uuid.UUID `json:"actor_id,omitempty"`
+	CredentialType string     `json:"credential_type"`
+	Principal      string     `json:"principal,omitempty"`
+	ClientIP       string     `json:"client_ip"`
+	UserAgent      string     `json:"user_agent"`
+	Operation      string     `json:"operation"`
+	VolumeName     string     `json:"volume_name,omitempty"`
+	Key            string     `json:"key,omitempty"`
+	NewVolumeName  string     `json:"new_volume_name,omitempty"`
+	NewKey         string     `json:"new_key,omitempty"`
+	Status         uint64     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type AuditLogsResponse struct {
+	AuditLogs  []*AuditLogResponse `json:"audit_logs"`
+	NextCursor uint64              `json:"next_cursor,omitempty"`
+}
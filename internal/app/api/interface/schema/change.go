@@ -0,0 +1,24 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeResponse struct {
+	Sequence  uint64     `json:"sequence"`
+	EventID   uuid.UUID  `json:"event_id"`
+	Type      string     `json:"type"`
+	Key       string     `json:"key"`
+	NewKey    string     `json:"new_key,omitempty"`
+	Size      uint64     `json:"size"`
+	ActorID   *uuid.UUID `json:"actor_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type DeltaResponse struct {
+	Changes   []*ChangeResponse `json:"changes"`
+	NextToken string            `json:"next_token"`
+	HasMore   bool              `json:"has_more"`
+}
@@ -7,13 +7,84 @@ type CreateEntryRequest struct {
 }
 
 type UpdateEntryRequest struct {
-	Key string `json:"key"`
+	VolumeName string `json:"volume_name"`
+	Key        string `json:"key"`
+	Conflict   string `json:"conflict"`
+}
+
+type CopyEntryRequest struct {
+	VolumeName string `json:"volume_name"`
+	Key        string `json:"key"`
+	Conflict   string `json:"conflict"`
 }
 
 type EntryResponse struct {
-	Key       string    `json:"key"`
-	Size      uint64    `json:"size"`
-	Type      string    `json:"type"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Key       string                 `json:"key"`
+	Size      uint64                 `json:"size"`
+	Type      string                 `json:"type"`
+	Metadata  *EntryMetadataResponse `json:"metadata,omitempty"`
+	Scan      *EntryScanResponse     `json:"scan,omitempty"`
+	Snippet   string                 `json:"snippet,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+type EntryScanResponse struct {
+	Status    string    `json:"status"`
+	Signature string    `json:"signature,omitempty"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+type EntryMetadataResponse struct {
+	Width       uint64     `json:"width,omitempty"`
+	Height      uint64     `json:"height,omitempty"`
+	TakenAt     *time.Time `json:"taken_at,omitempty"`
+	CameraMake  string     `json:"camera_make,omitempty"`
+	CameraModel string     `json:"camera_model,omitempty"`
+	Latitude    *float64   `json:"latitude,omitempty"`
+	Longitude   *float64   `json:"longitude,omitempty"`
+	PageCount   uint64     `json:"page_count,omitempty"`
+	DurationMS  uint64     `json:"duration_ms,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Artist      string     `json:"artist,omitempty"`
+	Album       string     `json:"album,omitempty"`
+}
+
+type EntryOperationResponse struct {
+	*EntryResponse
+	Results []*EntryResultResponse `json:"results"`
+}
+
+type EntryResultResponse struct {
+	Key    string `json:"key"`
+	Result string `json:"result"`
+}
+
+type BatchEntryRequest struct {
+	Mode       string                   `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Operations []*EntryOperationRequest `json:"operations" binding:"required,min=1,max=1000,dive,required"`
+}
+
+type EntryOperationRequest struct {
+	Op            string `json:"op" binding:"required"`
+	Key           string `json:"key" binding:"required"`
+	NewVolumeName string `json:"new_volume_name"`
+	NewKey        string `json:"new_key"`
+	Conflict      string `json:"conflict"`
+}
+
+type BatchEntryResponse struct {
+	Results []*EntryOperationResultResponse `json:"results"`
+}
+
+type EntryOperationResultResponse struct {
+	Op      string                 `json:"op"`
+	Status  int                    `json:"status"`
+	Entry   *EntryResponse         `json:"entry,omitempty"`
+	Results []*EntryResultResponse `json:"results,omitempty"`
+	Error   *ErrorResponse         `json:"error,omitempty"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"message"`
 }
@@ -0,0 +1,14 @@
+package schema
+
+type FsckResponse struct {
+	VolumeName string               `json:"volume_name"`
+	Issues     []*FsckIssueResponse `json:"issues"`
+}
+
+type FsckIssueResponse struct {
+	Category string `json:"category"`
+	Key      string `json:"key"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Repaired bool   `json:"repaired"`
+}
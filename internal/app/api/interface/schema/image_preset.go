@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImageTransformationSchema struct {
+	Width      uint64 `json:"width"`
+	Height     uint64 `json:"height"`
+	Fit        string `json:"fit"`
+	CropX      uint64 `json:"crop_x"`
+	CropY      uint64 `json:"crop_y"`
+	CropWidth  uint64 `json:"crop_width"`
+	CropHeight uint64 `json:"crop_height"`
+	Quality    uint64 `json:"quality"`
+	Format     string `json:"format"`
+}
+
+type CreateImagePresetRequest struct {
+	Name string `json:"name"`
+	ImageTransformationSchema
+}
+
+type ImagePresetResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	ImageTransformationSchema
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScanRequest struct {
+	Key string `json:"key"`
+}
+
+type JobResponse struct {
+	ID            uuid.UUID            `json:"id"`
+	Type          string               `json:"type"`
+	Status        string               `json:"status"`
+	VolumeName    string               `json:"volume_name"`
+	Key           string               `json:"key"`
+	NewVolumeName string               `json:"new_volume_name,omitempty"`
+	NewKey        string               `json:"new_key,omitempty"`
+	Conflict      string               `json:"conflict,omitempty"`
+	ResultKey     string               `json:"result_key,omitempty"`
+	Error         string               `json:"error,omitempty"`
+	Progress      *JobProgressResponse `json:"progress"`
+	Attempts      uint64               `json:"attempts"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+type JobProgressResponse struct {
+	TotalEntries     uint64 `json:"total_entries"`
+	ProcessedEntries uint64 `json:"processed_entries"`
+	TotalBytes       uint64 `json:"total_bytes"`
+	ProcessedBytes   uint64 `json:"processed_bytes"`
+}
@@ -2,21 +2,49 @@ package schema
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
+type VolumePolicySchema struct {
+	MaxFileSize         uint64   `json:"max_file_size"`
+	AllowedTypes        []string `json:"allowed_types"`
+	DeniedTypes         []string `json:"denied_types"`
+	AllowedExtensions   []string `json:"allowed_extensions"`
+	DeniedExtensions    []string `json:"denied_extensions"`
+	MaxKeyDepth         uint64   `json:"max_key_depth"`
+	MaxEntriesPerFolder uint64   `json:"max_entries_per_folder"`
+	MaxVolumeSize       uint64   `json:"max_volume_size"`
+	MaxVolumeEntries    uint64   `json:"max_volume_entries"`
+}
+
+type VolumeDropSchema struct {
+	Prefix string `json:"prefix"`
+}
+
 type CreateVolumeRequest struct {
-	Name     string `json:"name"`
-	IsPublic bool   `json:"is_public"`
+	Name        string              `json:"name"`
+	IsPublic    bool                `json:"is_public"`
+	Compression string              `json:"compression"`
+	Policy      *VolumePolicySchema `json:"policy"`
+	Drop        *VolumeDropSchema   `json:"drop"`
 }
 
 type UpdateVolumeRequest struct {
-	Name     string `json:"name"`
-	IsPublic bool   `json:"is_public"`
+	Name        string              `json:"name"`
+	IsPublic    bool                `json:"is_public"`
+	Compression string              `json:"compression"`
+	Policy      *VolumePolicySchema `json:"policy"`
+	Drop        *VolumeDropSchema   `json:"drop"`
 }
 
 type VolumeResponse struct {
-	Name      string    `json:"name"`
-	IsPublic  bool      `json:"is_public"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	OwnerID     uuid.UUID           `json:"owner_id"`
+	Name        string              `json:"name"`
+	IsPublic    bool                `json:"is_public"`
+	Compression string              `json:"compression"`
+	Policy      *VolumePolicySchema `json:"policy"`
+	Drop        *VolumeDropSchema   `json:"drop"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
 }
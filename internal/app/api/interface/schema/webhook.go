@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Prefix string   `json:"prefix"`
+}
+
+type WebhookResponse struct {
+	ID        uuid.UUID `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	Prefix    string    `json:"prefix"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID `json:"id"`
+	EventID        uuid.UUID `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	Status         string    `json:"status"`
+	ResponseStatus uint64    `json:"response_status,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	Attempts       uint64    `json:"attempts"`
+	RunAt          time.Time `json:"run_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package actor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type actorKey struct{}
+
+func WithID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey{}, id)
+}
+
+// NOTE: 匿名の操作など操作者が設定されていない場合はuuid.Nilを返却する.
+func ID(ctx context.Context) uuid.UUID {
+	if id, ok := ctx.Value(actorKey{}).(uuid.UUID); ok {
+		return id
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,34 @@
+package actor_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/actor"
+)
+
+func TestActor_ID(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		withActor bool
+		expect    uuid.UUID
+	}{
+		{name: "with actor", withActor: true, expect: id},
+		{name: "without actor", withActor: false, expect: uuid.Nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.withActor {
+				ctx = actor.WithID(ctx, id)
+			}
+
+			if result := actor.ID(ctx); result != tt.expect {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expect, result)
+			}
+		})
+	}
+}
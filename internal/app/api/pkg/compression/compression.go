@@ -0,0 +1,119 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+var ErrUnsupportedEncoding = status.Error(code.Internal, "unsupported encoding")
+
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+const chunkSize = 32 * 1024
+
+func Supports(encoding string) bool {
+	return encoding == EncodingGzip || encoding == EncodingZstd
+}
+
+func Compress(encoding string, reader io.Reader) (io.Reader, error) {
+	switch encoding {
+	case "":
+		return reader, nil
+	case EncodingGzip:
+		r := &compressReader{
+			src:   reader,
+			chunk: make([]byte, chunkSize),
+		}
+		r.writer = gzip.NewWriter(&r.buf)
+		return r, nil
+	case EncodingZstd:
+		r := &compressReader{
+			src:   reader,
+			chunk: make([]byte, chunkSize),
+		}
+		writer, err := zstd.NewWriter(&r.buf)
+		if err != nil {
+			return nil, err
+		}
+		r.writer = writer
+		return r, nil
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+}
+
+func Decompress(encoding string, body io.ReadCloser) (io.ReadCloser, error) {
+	switch encoding {
+	case "":
+		return body, nil
+	case EncodingGzip:
+		reader, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressReader{ReadCloser: reader, src: body}, nil
+	case EncodingZstd:
+		reader, err := zstd.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressReader{ReadCloser: reader.IOReadCloser(), src: body}, nil
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+}
+
+type compressReader struct {
+	src      io.Reader
+	chunk    []byte
+	buf      bytes.Buffer
+	writer   io.WriteCloser
+	finished bool
+}
+
+func (r *compressReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.finished {
+			return 0, io.EOF
+		}
+
+		n, err := r.src.Read(r.chunk)
+		if 0 < n {
+			if _, err := r.writer.Write(r.chunk[:n]); err != nil {
+				return 0, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			if err := r.writer.Close(); err != nil {
+				return 0, err
+			}
+			r.finished = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+
+	return r.buf.Read(p)
+}
+
+type decompressReader struct {
+	io.ReadCloser
+	src io.Closer
+}
+
+func (r *decompressReader) Close() error {
+	if err := r.ReadCloser.Close(); err != nil {
+		return err
+	}
+	return r.src.Close()
+}
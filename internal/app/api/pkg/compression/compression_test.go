@@ -0,0 +1,62 @@
+package compression_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/compression"
+)
+
+func TestCompression(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputEncoding string
+		inputBody     []byte
+		expectError   error
+	}{
+		{name: "identity", inputEncoding: "", inputBody: []byte("test"), expectError: nil},
+		{name: "gzip", inputEncoding: "gzip", inputBody: bytes.Repeat([]byte("test"), 100000), expectError: nil},
+		{name: "gzip empty", inputEncoding: "gzip", inputBody: []byte{}, expectError: nil},
+		{name: "zstd", inputEncoding: "zstd", inputBody: bytes.Repeat([]byte("test"), 100000), expectError: nil},
+		{name: "zstd empty", inputEncoding: "zstd", inputBody: []byte{}, expectError: nil},
+		{name: "unsupported encoding", inputEncoding: "br", inputBody: []byte("test"), expectError: compression.ErrUnsupportedEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed, err := compression.Compress(tt.inputEncoding, bytes.NewReader(tt.inputBody))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			encoded, err := io.ReadAll(compressed)
+			if err != nil {
+				t.Error(err)
+			}
+			if tt.inputEncoding != "" && len(tt.inputBody) < len(encoded) && 100 < len(tt.inputBody) {
+				t.Error("body is not compressed")
+			}
+
+			decompressed, err := compression.Decompress(tt.inputEncoding, io.NopCloser(bytes.NewReader(encoded)))
+			if err != nil {
+				t.Error(err)
+			}
+			result, err := io.ReadAll(decompressed)
+			if err != nil {
+				t.Error(err)
+			}
+			if err := decompressed.Close(); err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(tt.inputBody, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package contenttype
+
+import (
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// NOTE: mimetypeが判定に利用する既定の長さと合わせる.
+const ReadLimit = 3072
+
+// NOTE: マジックナンバーで詳細な種別を判定できない場合のみ, 申告された種別または拡張子で補完する.
+var genericTypes = []string{
+	"application/octet-stream",
+	"text/plain",
+	"application/zip",
+	"application/x-ole-storage",
+}
+
+// NOTE: ブラウザで実行されうる種別は, マジックナンバーで判定された場合を除き採用しない.
+var activeTypes = map[string]bool{
+	"text/html":                true,
+	"application/xhtml+xml":    true,
+	"image/svg+xml":            true,
+	"text/xml":                 true,
+	"application/xml":          true,
+	"text/javascript":          true,
+	"application/javascript":   true,
+	"application/x-javascript": true,
+	"application/ecmascript":   true,
+	"text/ecmascript":          true,
+}
+
+var extensionTypes = map[string]string{
+	".csv":      "text/csv",
+	".tsv":      "text/tab-separated-values",
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".yaml":     "application/yaml",
+	".yml":      "application/yaml",
+	".toml":     "application/toml",
+	".json":     "application/json",
+	".ndjson":   "application/x-ndjson",
+	".geojson":  "application/geo+json",
+	".docx":     "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx":     "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx":     "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":      "application/vnd.oasis.opendocument.text",
+	".ods":      "application/vnd.oasis.opendocument.spreadsheet",
+	".odp":      "application/vnd.oasis.opendocument.presentation",
+	".epub":     "application/epub+zip",
+	".jar":      "application/jar",
+	".apk":      "application/vnd.android.package-archive",
+	".doc":      "application/msword",
+	".xls":      "application/vnd.ms-excel",
+	".ppt":      "application/vnd.ms-powerpoint",
+	".msg":      "application/vnd.ms-outlook",
+}
+
+// NOTE: ボディの先頭, キーの拡張子, 申告された種別から種別を判定する.
+func Detect(data []byte, key, declared string) string {
+	detected := mimetype.Detect(data)
+	node := mimetype.Lookup(mediaType(detected.String()))
+	if node == nil || !isGeneric(node) {
+		return detected.String()
+	}
+
+	for _, candidate := range []string{declared, byExtension(key)} {
+		if contentType, ok := refine(detected.String(), node, candidate); ok {
+			return contentType
+		}
+	}
+	return detected.String()
+}
+
+func byExtension(key string) string {
+	ext := strings.ToLower(path.Ext(key))
+	if ext == "" {
+		return ""
+	}
+	if contentType, ok := extensionTypes[ext]; ok {
+		return contentType
+	}
+	return mime.TypeByExtension(ext)
+}
+
+func isGeneric(node *mimetype.MIME) bool {
+	for _, genericType := range genericTypes {
+		if node.Is(genericType) {
+			return true
+		}
+	}
+	return false
+}
+
+func refine(detected string, node *mimetype.MIME, candidate string) (string, bool) {
+	candidate = mediaType(candidate)
+	if candidate == "" || activeTypes[candidate] || node.Is(candidate) || !isCompatible(node, candidate) {
+		return "", false
+	}
+
+	// NOTE: テキストの場合は判定された文字コードを引き継ぐ.
+	if _, params, err := mime.ParseMediaType(detected); err == nil && params["charset"] != "" && strings.HasPrefix(candidate, "text/") {
+		return mime.FormatMediaType(candidate, map[string]string{"charset": params["charset"]}), true
+	}
+	return candidate, true
+}
+
+// NOTE: mimetypeが判定できる種別はマジックナンバーの判定結果の子孫である場合のみ, 判定できない種別は常に互換とみなす.
+func isCompatible(node *mimetype.MIME, candidate string) bool {
+	candidateNode := mimetype.Lookup(candidate)
+	if candidateNode == nil {
+		return true
+	}
+	if node.Parent() == nil {
+		return false
+	}
+	for parent := candidateNode.Parent(); parent != nil; parent = parent.Parent() {
+		if parent == node {
+			return true
+		}
+	}
+	return false
+}
+
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || strings.Count(mediaType, "/") != 1 {
+		return ""
+	}
+	return mediaType
+}
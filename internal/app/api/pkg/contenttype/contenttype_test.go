@@ -0,0 +1,135 @@
+package contenttype_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/contenttype"
+)
+
+func encodeZip(t *testing.T, names ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range names {
+		if _, err := writer.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputData     []byte
+		inputKey      string
+		inputDeclared string
+		expectResult  string
+	}{
+		{
+			name:          "magic number",
+			inputData:     []byte("%PDF-1.4\n"),
+			inputKey:      "sample.txt",
+			inputDeclared: "text/plain",
+			expectResult:  "application/pdf",
+		},
+		{
+			name:          "magic number takes precedence over active type",
+			inputData:     []byte("<!DOCTYPE html><html></html>"),
+			inputKey:      "index.html",
+			inputDeclared: "",
+			expectResult:  "text/html; charset=utf-8",
+		},
+		{
+			name:          "text refined by extension",
+			inputData:     []byte("# title\n"),
+			inputKey:      "dir/README.md",
+			inputDeclared: "",
+			expectResult:  "text/markdown; charset=utf-8",
+		},
+		{
+			name:          "text refined by declared type",
+			inputData:     []byte("key: value\n"),
+			inputKey:      "sample",
+			inputDeclared: "application/yaml",
+			expectResult:  "application/yaml",
+		},
+		{
+			name:          "declared type takes precedence over extension",
+			inputData:     []byte("key = \"value\"\n"),
+			inputKey:      "sample.md",
+			inputDeclared: "application/toml",
+			expectResult:  "application/toml",
+		},
+		{
+			name:          "incompatible declared type",
+			inputData:     []byte("# title\n"),
+			inputKey:      "sample.md",
+			inputDeclared: "image/png",
+			expectResult:  "text/markdown; charset=utf-8",
+		},
+		{
+			name:          "active declared type",
+			inputData:     []byte("alert(1)\n"),
+			inputKey:      "sample",
+			inputDeclared: "text/html",
+			expectResult:  "text/plain; charset=utf-8",
+		},
+		{
+			name:          "active extension",
+			inputData:     []byte("alert(1)\n"),
+			inputKey:      "sample.svg",
+			inputDeclared: "",
+			expectResult:  "text/plain; charset=utf-8",
+		},
+		{
+			name:          "zip refined by extension",
+			inputData:     encodeZip(t, "content.txt"),
+			inputKey:      "sample.docx",
+			inputDeclared: "application/octet-stream",
+			expectResult:  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		},
+		{
+			name:          "zip with incompatible extension",
+			inputData:     encodeZip(t, "content.txt"),
+			inputKey:      "sample.pdf",
+			inputDeclared: "",
+			expectResult:  "application/zip",
+		},
+		{
+			name:          "binary refined by unknown declared type",
+			inputData:     []byte{0x00, 0x01, 0x02, 0x03},
+			inputKey:      "sample",
+			inputDeclared: "application/x-holos; version=1",
+			expectResult:  "application/x-holos",
+		},
+		{
+			name:          "binary with known extension",
+			inputData:     []byte{0x00, 0x01, 0x02, 0x03},
+			inputKey:      "sample.png",
+			inputDeclared: "",
+			expectResult:  "application/octet-stream",
+		},
+		{
+			name:          "invalid declared type",
+			inputData:     []byte{0x00, 0x01, 0x02, 0x03},
+			inputKey:      "sample",
+			inputDeclared: "invalid",
+			expectResult:  "application/octet-stream",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := contenttype.Detect(tt.inputData, tt.inputKey, tt.inputDeclared); result != tt.expectResult {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package fulltext
+
+import (
+	"mime"
+	"strings"
+	"unicode"
+)
+
+const (
+	// NOTE: Office文書はZIPの末尾に目次を持つため, 上限までのボディ全体を保持する.
+	maxBodySize = 8 << 20
+	maxTextSize = 1 << 20
+)
+
+type extractor func(data []byte, truncated bool) string
+
+var extractors = map[string]extractor{
+	"application/pdf": extractPDF,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   extractOffice(isEntryName("word/document.xml")),
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         extractOffice(isEntryName("xl/sharedStrings.xml")),
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": extractOffice(isSlide),
+	"application/vnd.oasis.opendocument.text":                                   extractOffice(isEntryName("content.xml")),
+	"application/vnd.oasis.opendocument.spreadsheet":                            extractOffice(isEntryName("content.xml")),
+	"application/vnd.oasis.opendocument.presentation":                           extractOffice(isEntryName("content.xml")),
+}
+
+var textTypes = map[string]bool{
+	"application/json":     true,
+	"application/x-ndjson": true,
+	"application/yaml":     true,
+	"application/toml":     true,
+	"application/xml":      true,
+}
+
+// NOTE: ボディの書き込みと同時に本文の抽出に必要な範囲を記録する.
+type Recorder struct {
+	data      []byte
+	truncated bool
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Write(p []byte) (int, error) {
+	n := min(maxBodySize-len(r.data), len(p))
+	r.data = append(r.data, p[:n]...)
+	if n < len(p) {
+		r.truncated = true
+	}
+	return len(p), nil
+}
+
+// NOTE: 対応していない形式や本文を抽出できない場合は空文字を返却する.
+func (r *Recorder) Extract(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	extract := extractorOf(mediaType)
+	if extract == nil {
+		return ""
+	}
+	return normalize(extract(r.data, r.truncated))
+}
+
+func extractorOf(mediaType string) extractor {
+	if extract, ok := extractors[mediaType]; ok {
+		return extract
+	}
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") || textTypes[mediaType] {
+		return extractText
+	}
+	return nil
+}
+
+func extractText(data []byte, _ bool) string {
+	return string(data[:min(len(data), maxTextSize)])
+}
+
+// NOTE: 不正なUTF-8と制御文字を除き, 連続する空白を1つにまとめる.
+func normalize(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
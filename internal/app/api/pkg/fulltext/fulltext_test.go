@@ -0,0 +1,126 @@
+package fulltext_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"strings"
+	"testing"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/fulltext"
+)
+
+func encodeZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodePDF(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zlib.NewWriter(&buf)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n4 0 obj << /Length 0 /Filter /FlateDecode >> stream\n")
+	data = append(data, buf.Bytes()...)
+	return append(data, "\nendstream\nendobj\n%%EOF"...)
+}
+
+func TestRecorder_Extract(t *testing.T) {
+	docx := encodeZip(t, map[string]string{
+		"[Content_Types].xml": `<Types/>`,
+		"word/document.xml":   `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:t>World</w:t></w:r></w:p><w:p><w:r><w:t>Second</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	tests := []struct {
+		name             string
+		inputData        []byte
+		inputContentType string
+		expectResult     string
+	}{
+		{
+			name:             "text",
+			inputData:        []byte("hello\x00\n\n  world\n"),
+			inputContentType: "text/plain; charset=utf-8",
+			expectResult:     "hello world",
+		},
+		{
+			name:             "json",
+			inputData:        []byte(`{"key": "value"}`),
+			inputContentType: "application/json",
+			expectResult:     `{"key": "value"}`,
+		},
+		{
+			name:             "docx",
+			inputData:        docx,
+			inputContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			expectResult:     "HelloWorld Second",
+		},
+		{
+			name: "xlsx",
+			inputData: encodeZip(t, map[string]string{
+				"xl/sharedStrings.xml": `<sst><si><t>Name</t></si><si><t>価格</t></si></sst>`,
+			}),
+			inputContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			expectResult:     "Name 価格",
+		},
+		{
+			name:             "pdf",
+			inputData:        encodePDF(t, "BT /F1 12 Tf 72 712 Td (Hello \\(PDF\\)) Tj 0 -14 Td [(Wor) -20 (ld)] TJ (\\376\\377\\060\\102) Tj ET\n(outside) Tj"),
+			inputContentType: "application/pdf",
+			expectResult:     "Hello (PDF) Worldあ",
+		},
+		{
+			name:             "truncated office document",
+			inputData:        append(docx, make([]byte, 8<<20)...),
+			inputContentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			expectResult:     "",
+		},
+		{
+			name:             "truncated text",
+			inputData:        []byte(strings.Repeat("a", 2<<20)),
+			inputContentType: "text/plain",
+			expectResult:     strings.Repeat("a", 1<<20),
+		},
+		{
+			name:             "unsupported type",
+			inputData:        []byte("\x89PNG"),
+			inputContentType: "image/png",
+			expectResult:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := fulltext.NewRecorder()
+			if _, err := bytes.NewReader(tt.inputData).WriteTo(recorder); err != nil {
+				t.Fatal(err)
+			}
+
+			if result := recorder.Extract(tt.inputContentType); result != tt.expectResult {
+				t.Errorf("\nexpect: %.100v\ngot: %.100v", tt.expectResult, result)
+			}
+		})
+	}
+}
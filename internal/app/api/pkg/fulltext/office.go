@@ -0,0 +1,80 @@
+package fulltext
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// NOTE: 段落や表のセル等の区切りとなる要素の終了時に空白を挿入する.
+var separatorElements = map[string]bool{
+	"p":   true,
+	"h":   true,
+	"si":  true,
+	"tab": true,
+	"br":  true,
+}
+
+func isEntryName(name string) func(string) bool {
+	return func(entryName string) bool {
+		return entryName == name
+	}
+}
+
+func isSlide(entryName string) bool {
+	return strings.HasPrefix(entryName, "ppt/slides/slide") && strings.HasSuffix(entryName, ".xml")
+}
+
+// NOTE: 途中までしか記録できていないZIPは目次を読めないため抽出しない.
+func extractOffice(match func(string) bool) extractor {
+	return func(data []byte, truncated bool) string {
+		if truncated {
+			return ""
+		}
+
+		reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return ""
+		}
+
+		var builder strings.Builder
+		for _, file := range reader.File {
+			if match(file.Name) && builder.Len() < maxTextSize {
+				extractXMLFile(&builder, file)
+			}
+		}
+		return builder.String()
+	}
+}
+
+func extractXMLFile(builder *strings.Builder, file *zip.File) {
+	reader, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	// NOTE: 展開後のサイズを制限して圧縮爆弾を防ぐ.
+	extractXML(builder, io.LimitReader(reader, maxBodySize))
+}
+
+func extractXML(builder *strings.Builder, reader io.Reader) {
+	decoder := xml.NewDecoder(reader)
+	for builder.Len() < maxTextSize {
+		token, err := decoder.Token()
+		if err != nil {
+			return
+		}
+
+		switch token := token.(type) {
+		case xml.CharData:
+			builder.Write(token)
+		case xml.EndElement:
+			if separatorElements[token.Name.Local] {
+				builder.WriteByte(' ')
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package fulltext
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// NOTE: オブジェクトの辞書とストリームの組を抽出する. 圧縮されたオブジェクトストリームは対応しない.
+var pdfStreamPattern = regexp.MustCompile(`(?s)obj(.*?)stream\r?\n(.*?)endstream`)
+
+var pdfEscapes = map[byte]byte{
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+	'b':  '\b',
+	'f':  '\f',
+	'(':  '(',
+	')':  ')',
+	'\\': '\\',
+}
+
+// NOTE: 文字を移動する演算子は単語の区切りとみなす.
+var pdfSeparators = []string{"ET", "Td", "TD", "T*", "Tm"}
+
+func extractPDF(data []byte, _ bool) string {
+	var builder strings.Builder
+	for _, match := range pdfStreamPattern.FindAllSubmatch(data, -1) {
+		if maxTextSize <= builder.Len() {
+			break
+		}
+		if content, ok := decodePDFStream(match[1], match[2]); ok {
+			extractPDFText(&builder, content)
+		}
+	}
+	return builder.String()
+}
+
+func decodePDFStream(dictionary, data []byte) ([]byte, bool) {
+	if !bytes.Contains(dictionary, []byte("/Filter")) {
+		return data, true
+	}
+	if !bytes.Contains(dictionary, []byte("/FlateDecode")) {
+		return nil, false
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+
+	// NOTE: 末尾が欠けたストリームも展開できた範囲を利用する.
+	content, _ := io.ReadAll(io.LimitReader(reader, maxBodySize))
+	return content, 0 < len(content)
+}
+
+// NOTE: テキストオブジェクト(BT〜ET)内の文字列のみを本文とする.
+func extractPDFText(builder *strings.Builder, content []byte) {
+	inText := false
+	for i := 0; i < len(content); i++ {
+		switch {
+		case content[i] == '(':
+			var text string
+			text, i = readPDFString(content, i+1)
+			if inText {
+				builder.WriteString(text)
+			}
+		case isPDFOperator(content, i, "BT"):
+			inText = true
+		case isPDFSeparator(content, i):
+			inText = inText && !isPDFOperator(content, i, "ET")
+			builder.WriteByte(' ')
+		}
+	}
+}
+
+func isPDFSeparator(content []byte, i int) bool {
+	for _, separator := range pdfSeparators {
+		if isPDFOperator(content, i, separator) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPDFOperator(content []byte, i int, operator string) bool {
+	if !bytes.HasPrefix(content[i:], []byte(operator)) {
+		return false
+	}
+	end := i + len(operator)
+	return (i == 0 || isPDFDelimiter(content[i-1])) && (end == len(content) || isPDFDelimiter(content[end]))
+}
+
+func isPDFDelimiter(c byte) bool {
+	return bytes.IndexByte([]byte(" \t\r\n\f\x00()<>[]{}/%"), c) != -1
+}
+
+// NOTE: 対応する閉じ括弧までを読み込み, 閉じ括弧の位置を返却する.
+func readPDFString(content []byte, start int) (string, int) {
+	var buf []byte
+	depth := 1
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			var escaped []byte
+			escaped, i = readPDFEscape(content, i+1)
+			buf = append(buf, escaped...)
+			continue
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			return decodePDFText(buf), i
+		}
+		buf = append(buf, content[i])
+	}
+	return decodePDFText(buf), len(content)
+}
+
+func readPDFEscape(content []byte, i int) ([]byte, int) {
+	if len(content) <= i {
+		return nil, i
+	}
+	if c, ok := pdfEscapes[content[i]]; ok {
+		return []byte{c}, i
+	}
+	if isOctal(content[i]) {
+		return readPDFOctal(content, i)
+	}
+
+	// NOTE: 改行のエスケープは行の継続とみなし, 未定義のエスケープは文字をそのまま扱う.
+	switch {
+	case bytes.HasPrefix(content[i:], []byte("\r\n")):
+		return nil, i + 1
+	case content[i] == '\r' || content[i] == '\n':
+		return nil, i
+	default:
+		return []byte{content[i]}, i
+	}
+}
+
+func readPDFOctal(content []byte, i int) ([]byte, int) {
+	var value byte
+	end := i
+	for ; end < min(i+3, len(content)) && isOctal(content[end]); end++ {
+		value = value<<3 | (content[end] - '0')
+	}
+	return []byte{value}, end - 1
+}
+
+func isOctal(c byte) bool {
+	return '0' <= c && c <= '7'
+}
+
+// NOTE: BOM付きのUTF-16BE以外はPDFDocEncodingとみなし, Latin-1として扱う.
+func decodePDFText(data []byte) string {
+	if bytes.HasPrefix(data, []byte{0xFE, 0xFF}) {
+		units := make([]uint16, 0, len(data)/2)
+		for i := 2; i+1 < len(data); i += 2 {
+			units = append(units, binary.BigEndian.Uint16(data[i:]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	runes := make([]rune, len(data))
+	for i, c := range data {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,212 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	MaxDimension   = 2048
+	DefaultQuality = 85
+
+	// NOTE: 展開後に巨大になる画像によるメモリの枯渇を防ぐ.
+	maxBytes  = 64 << 20
+	maxPixels = 50_000_000
+)
+
+const (
+	FitContain = "contain"
+	FitCover   = "cover"
+	FitFill    = "fill"
+)
+
+var (
+	ErrUnsupportedImage = status.Error(code.UnprocessableContent, "image format is not supported")
+	ErrImageTooLarge    = status.Error(code.UnprocessableContent, "image is too large")
+	ErrInvalidCrop      = status.Error(code.UnprocessableContent, "crop is out of the image")
+)
+
+// NOTE: WebP等のエンコーダを持たない形式はPNGで出力する.
+var defaultFormats = map[string]string{
+	"image/jpeg": "image/jpeg",
+	"image/png":  "image/png",
+	"image/gif":  "image/png",
+	"image/webp": "image/png",
+}
+
+type Options struct {
+	Width   int
+	Height  int
+	Fit     string
+	Crop    image.Rectangle
+	Quality int
+	Format  string
+}
+
+func Supports(contentType string) bool {
+	_, ok := defaultFormats[contentType]
+	return ok
+}
+
+func DefaultFormat(contentType string) string {
+	return defaultFormats[contentType]
+}
+
+// NOTE: 切り抜き, 縮小はEXIFの向きを反映した表示時の座標で指定する.
+func Transform(reader io.Reader, contentType string, options *Options) ([]byte, error) {
+	if !Supports(contentType) {
+		return nil, ErrUnsupportedImage
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if maxBytes < len(data) {
+		return nil, ErrImageTooLarge
+	}
+
+	src, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	orientation := 1
+	if contentType == "image/jpeg" {
+		orientation = readOrientation(data)
+	}
+
+	display := src.Bounds().Size()
+	if swapsAxes(orientation) {
+		display = image.Pt(display.Y, display.X)
+	}
+
+	region, err := crop(display, options.Crop)
+	if err != nil {
+		return nil, err
+	}
+	region, size := layout(region, options)
+
+	dst := scale(src, toSource(region, display, orientation), size, orientation)
+	return encode(orient(dst, orientation), options.Format, options.Quality)
+}
+
+func decode(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupportedImage
+	}
+	if maxPixels/config.Width < config.Height {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	return img, nil
+}
+
+// NOTE: 切り抜く範囲は画像内に切り詰め, 画像と重ならない場合はエラーとする.
+func crop(display image.Point, rect image.Rectangle) (image.Rectangle, error) {
+	bounds := image.Rectangle{Max: display}
+	if rect.Empty() {
+		return bounds, nil
+	}
+
+	region := rect.Intersect(bounds)
+	if region.Empty() {
+		return image.Rectangle{}, ErrInvalidCrop
+	}
+	return region, nil
+}
+
+// NOTE: 出力する範囲と大きさを決定する. 範囲に収める場合は拡大しない.
+func layout(region image.Rectangle, options *Options) (image.Rectangle, image.Point) {
+	width, height := options.Width, options.Height
+	if width == 0 {
+		width = MaxDimension
+	}
+	if height == 0 {
+		height = MaxDimension
+	}
+
+	switch options.Fit {
+	case FitFill:
+		return region, image.Pt(width, height)
+	case FitCover:
+		return cover(region, width, height), image.Pt(width, height)
+	default:
+		return region, fit(region.Size(), width, height)
+	}
+}
+
+// NOTE: 出力の縦横比に合わせて中央を切り抜く.
+func cover(region image.Rectangle, width, height int) image.Rectangle {
+	size := region.Size()
+	if height*size.X <= width*size.Y {
+		h := max((size.X*height+width/2)/width, 1)
+		y := region.Min.Y + (size.Y-h)/2
+		return image.Rect(region.Min.X, y, region.Max.X, y+h)
+	}
+	w := max((size.Y*width+height/2)/height, 1)
+	x := region.Min.X + (size.X-w)/2
+	return image.Rect(x, region.Min.Y, x+w, region.Max.Y)
+}
+
+func fit(size image.Point, width, height int) image.Point {
+	if size.X <= width && size.Y <= height {
+		return size
+	}
+
+	// NOTE: 縮小率の小さい辺に合わせ, 他方の辺は四捨五入する.
+	if size.X*height <= size.Y*width {
+		return image.Pt(max((size.X*height+size.Y/2)/size.Y, 1), height)
+	}
+	return image.Pt(width, max((size.Y*width+size.X/2)/size.X, 1))
+}
+
+// NOTE: 回転前の元画像の向きで縮小し, 回転は縮小後に行う.
+func scale(src image.Image, region image.Rectangle, size image.Point, orientation int) *image.RGBA {
+	if swapsAxes(orientation) {
+		size = image.Pt(size.Y, size.X)
+	}
+
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, region.Add(src.Bounds().Min), draw.Src, nil)
+	return dst
+}
+
+func encode(img image.Image, format string, quality int) ([]byte, error) {
+	if quality == 0 {
+		quality = DefaultQuality
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "image/jpeg":
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+	case "image/png":
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedImage
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,264 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"testing"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/imaging"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// NOTE: 左半分を赤, 右半分を青とした画像を生成する.
+func newImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			if x < width/2 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// NOTE: SOIの直後にOrientationのみを含むEXIFのAPP1セグメントを挿入したJPEGを生成する.
+func encodeJPEG(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0)
+	tiff = binary.BigEndian.AppendUint32(tiff, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	result := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	result = binary.BigEndian.AppendUint16(result, uint16(len(segment)+2))
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return 0xC000 < r && g < 0x4000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r < 0x4000 && g < 0x4000 && 0xC000 < b
+}
+
+func TestImaging_Transform(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputData        []byte
+		inputContentType string
+		inputOptions     *imaging.Options
+		expectWidth      int
+		expectHeight     int
+		expectFormat     string
+		expectLeftRed    bool
+		expectRightBlue  bool
+		expectError      error
+	}{
+		{
+			name:             "contain",
+			inputData:        encodePNG(t, newImage(400, 200)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Width: 100, Fit: imaging.FitContain, Format: "image/png"},
+			expectWidth:      100,
+			expectHeight:     50,
+			expectFormat:     "png",
+			expectLeftRed:    true,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "contain without size",
+			inputData:        encodePNG(t, newImage(40, 20)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Format: "image/png"},
+			expectWidth:      40,
+			expectHeight:     20,
+			expectFormat:     "png",
+			expectLeftRed:    true,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "cover",
+			inputData:        encodePNG(t, newImage(400, 200)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Width: 100, Height: 100, Fit: imaging.FitCover, Format: "image/png"},
+			expectWidth:      100,
+			expectHeight:     100,
+			expectFormat:     "png",
+			expectLeftRed:    true,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "fill",
+			inputData:        encodePNG(t, newImage(400, 200)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Width: 50, Height: 100, Fit: imaging.FitFill, Format: "image/png"},
+			expectWidth:      50,
+			expectHeight:     100,
+			expectFormat:     "png",
+			expectLeftRed:    true,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "crop",
+			inputData:        encodePNG(t, newImage(400, 200)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Crop: image.Rect(0, 0, 200, 200), Format: "image/png"},
+			expectWidth:      200,
+			expectHeight:     200,
+			expectFormat:     "png",
+			expectLeftRed:    true,
+			expectRightBlue:  false,
+			expectError:      nil,
+		},
+		{
+			name:             "crop is clipped",
+			inputData:        encodePNG(t, newImage(400, 200)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Crop: image.Rect(300, 0, 1000, 1000), Format: "image/png"},
+			expectWidth:      100,
+			expectHeight:     200,
+			expectFormat:     "png",
+			expectLeftRed:    false,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "crop with exif orientation",
+			inputData:        encodeJPEG(t, newImage(64, 32), 6),
+			inputContentType: "image/jpeg",
+			inputOptions:     &imaging.Options{Crop: image.Rect(0, 32, 32, 64), Format: "image/jpeg"},
+			expectWidth:      32,
+			expectHeight:     32,
+			expectFormat:     "jpeg",
+			expectLeftRed:    false,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "convert png to jpeg",
+			inputData:        encodePNG(t, newImage(40, 20)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Quality: 90, Format: "image/jpeg"},
+			expectWidth:      40,
+			expectHeight:     20,
+			expectFormat:     "jpeg",
+			expectLeftRed:    true,
+			expectRightBlue:  true,
+			expectError:      nil,
+		},
+		{
+			name:             "decode webp",
+			inputData:        readFile(t, "testdata/sample.webp"),
+			inputContentType: "image/webp",
+			inputOptions:     &imaging.Options{Width: 30, Format: imaging.DefaultFormat("image/webp")},
+			expectWidth:      30,
+			expectHeight:     40,
+			expectFormat:     "png",
+			expectError:      nil,
+		},
+		{
+			name:             "crop out of image",
+			inputData:        encodePNG(t, newImage(400, 200)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Crop: image.Rect(500, 500, 600, 600), Format: "image/png"},
+			expectError:      imaging.ErrInvalidCrop,
+		},
+		{
+			name:             "unsupported content type",
+			inputData:        []byte("test"),
+			inputContentType: "text/plain; charset=utf-8",
+			inputOptions:     &imaging.Options{Format: "image/png"},
+			expectError:      imaging.ErrUnsupportedImage,
+		},
+		{
+			name:             "unsupported format",
+			inputData:        encodePNG(t, newImage(40, 20)),
+			inputContentType: "image/png",
+			inputOptions:     &imaging.Options{Format: "image/webp"},
+			expectError:      imaging.ErrUnsupportedImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := imaging.Transform(bytes.NewReader(tt.inputData), tt.inputContentType, tt.inputOptions)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			img, format, err := image.Decode(bytes.NewReader(result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.expectFormat {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectFormat, format)
+			}
+			if img.Bounds().Dx() != tt.expectWidth || img.Bounds().Dy() != tt.expectHeight {
+				t.Errorf("\nexpect: %dx%d\ngot: %dx%d", tt.expectWidth, tt.expectHeight, img.Bounds().Dx(), img.Bounds().Dy())
+			}
+
+			left, right := img.At(2, tt.expectHeight/2), img.At(tt.expectWidth-3, tt.expectHeight/2)
+			if tt.expectLeftRed != isRed(left) {
+				t.Errorf("\nexpect red: %v\ngot: %v", tt.expectLeftRed, left)
+			}
+			if tt.expectRightBlue != isBlue(right) {
+				t.Errorf("\nexpect blue: %v\ngot: %v", tt.expectRightBlue, right)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const (
+	markerSOI  = 0xD8
+	markerSOS  = 0xDA
+	markerAPP1 = 0xE1
+
+	tagOrientation = 0x0112
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// NOTE: EXIFの向きに応じて元画像の座標を表示時の座標に変換する.
+var transforms = map[int]func(x, y, w, h int) (int, int){
+	2: func(x, y, w, _ int) (int, int) { return w - 1 - x, y },
+	3: func(x, y, w, h int) (int, int) { return w - 1 - x, h - 1 - y },
+	4: func(x, y, _, h int) (int, int) { return x, h - 1 - y },
+	5: func(x, y, _, _ int) (int, int) { return y, x },
+	6: func(x, y, _, h int) (int, int) { return h - 1 - y, x },
+	7: func(x, y, w, h int) (int, int) { return h - 1 - y, w - 1 - x },
+	8: func(x, y, w, _ int) (int, int) { return y, w - 1 - x },
+}
+
+// NOTE: 90度回転以外の変換は自身が逆変換となる.
+var inverses = map[int]int{6: 8, 8: 6}
+
+func swapsAxes(orientation int) bool {
+	return 5 <= orientation && orientation <= 8
+}
+
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	transform, ok := transforms[orientation]
+	if !ok {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if swapsAxes(orientation) {
+		dst = image.NewRGBA(image.Rect(0, 0, h, w))
+	}
+
+	for y := range h {
+		for x := range w {
+			dx, dy := transform(x, y, w, h)
+			dst.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+	return dst
+}
+
+// NOTE: 表示時の座標の矩形を元画像の座標の矩形に変換する.
+func toSource(rect image.Rectangle, display image.Point, orientation int) image.Rectangle {
+	inverse := orientation
+	if v, ok := inverses[orientation]; ok {
+		inverse = v
+	}
+	transform, ok := transforms[inverse]
+	if !ok {
+		return rect
+	}
+
+	x0, y0 := transform(rect.Min.X, rect.Min.Y, display.X, display.Y)
+	x1, y1 := transform(rect.Max.X-1, rect.Max.Y-1, display.X, display.Y)
+	return image.Rect(min(x0, x1), min(y0, y1), max(x0, x1)+1, max(y0, y1)+1)
+}
+
+// NOTE: 向きを取得できない場合は変換しないよう1を返却する.
+func readOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != markerSOI {
+		return 1
+	}
+
+	tiff := findExif(data)
+	if tiff == nil {
+		return 1
+	}
+	return readTIFFOrientation(tiff)
+}
+
+func findExif(data []byte) []byte {
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF || data[i+1] == markerSOS {
+			return nil
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || len(data) < i+2+length {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if data[i+1] == markerAPP1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+func readTIFFOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	order := byteOrder(tiff)
+	if order == nil {
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || len(tiff) < offset+2 {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for i := range count {
+		entry := offset + 2 + i*12
+		if len(tiff) < entry+12 {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != tagOrientation {
+			continue
+		}
+		if value := int(order.Uint16(tiff[entry+8:])); 1 <= value && value <= 8 {
+			return value
+		}
+		return 1
+	}
+	return 1
+}
+
+func byteOrder(tiff []byte) binary.ByteOrder {
+	switch string(tiff[:2]) {
+	case "II":
+		return binary.LittleEndian
+	case "MM":
+		return binary.BigEndian
+	default:
+		return nil
+	}
+}
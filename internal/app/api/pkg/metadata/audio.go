@@ -0,0 +1,145 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	id3HeaderSize      = 10
+	id3FlagExtended    = 0x40
+	id3EncodingLatin1  = 0
+	id3EncodingUTF16   = 1
+	id3EncodingUTF16BE = 2
+	id3EncodingUTF8    = 3
+)
+
+func extractID3(metadata *Metadata, head, _ []byte) {
+	if len(head) < id3HeaderSize || string(head[:3]) != "ID3" {
+		return
+	}
+
+	version := head[3]
+	if version != 3 && version != 4 {
+		return
+	}
+
+	end := min(id3HeaderSize+syncsafe(head[6:10]), len(head))
+	for _, frame := range readID3Frames(head[:end], version, head[5]) {
+		applyID3Frame(metadata, frame.id, decodeID3Text(frame.value))
+	}
+}
+
+type id3Frame struct {
+	id    string
+	value []byte
+}
+
+// NOTE: v2.4のみフレームサイズが同期安全整数となる.
+func readID3Frames(tag []byte, version, flags byte) []*id3Frame {
+	position := id3HeaderSize
+	if flags&id3FlagExtended != 0 && position+4 <= len(tag) {
+		if version == 4 {
+			position += syncsafe(tag[position : position+4])
+		} else {
+			position += 4 + int(binary.BigEndian.Uint32(tag[position:]))
+		}
+	}
+
+	var frames []*id3Frame
+	for position+id3HeaderSize <= len(tag) && tag[position] != 0 {
+		size := int(binary.BigEndian.Uint32(tag[position+4:]))
+		if version == 4 {
+			size = syncsafe(tag[position+4 : position+8])
+		}
+		start := position + id3HeaderSize
+		if size < 0 || len(tag) < start+size {
+			break
+		}
+		frames = append(frames, &id3Frame{id: string(tag[position : position+4]), value: tag[start : start+size]})
+		position = start + size
+	}
+	return frames
+}
+
+func applyID3Frame(metadata *Metadata, id, text string) {
+	switch id {
+	case "TIT2":
+		metadata.Title = text
+	case "TPE1":
+		metadata.Artist = text
+	case "TALB":
+		metadata.Album = text
+	case "TLEN":
+		if duration, err := strconv.ParseUint(text, 10, 64); err == nil {
+			metadata.Duration = duration
+		}
+	}
+}
+
+func decodeID3Text(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	var text string
+	switch value[0] {
+	case id3EncodingLatin1:
+		runes := make([]rune, len(value)-1)
+		for i, b := range value[1:] {
+			runes[i] = rune(b)
+		}
+		text = string(runes)
+	case id3EncodingUTF16:
+		text = decodeUTF16(value[1:], true)
+	case id3EncodingUTF16BE:
+		text = decodeUTF16(value[1:], false)
+	case id3EncodingUTF8:
+		text = string(value[1:])
+	}
+	return strings.TrimSpace(strings.TrimRight(text, "\x00"))
+}
+
+func decodeUTF16(data []byte, bom bool) string {
+	var order binary.ByteOrder = binary.BigEndian
+	if bom && 2 <= len(data) {
+		if data[0] == 0xFF && data[1] == 0xFE {
+			order = binary.LittleEndian
+		}
+		data = data[2:]
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+func syncsafe(data []byte) int {
+	return int(data[0]&0x7F)<<21 | int(data[1]&0x7F)<<14 | int(data[2]&0x7F)<<7 | int(data[3]&0x7F)
+}
+
+// NOTE: 再生時間はミリ秒とし, dataチャンクのサイズとfmtチャンクのバイトレートから算出する.
+func extractWAV(metadata *Metadata, head, _ []byte) {
+	if len(head) < 12 || string(head[:4]) != "RIFF" || string(head[8:12]) != "WAVE" {
+		return
+	}
+
+	var byteRate uint64
+	for position := 12; position+8 <= len(head); {
+		id := head[position : position+4]
+		size := uint64(binary.LittleEndian.Uint32(head[position+4:]))
+		switch {
+		case bytes.Equal(id, []byte("fmt ")) && position+20 <= len(head):
+			byteRate = uint64(binary.LittleEndian.Uint32(head[position+16:]))
+		case bytes.Equal(id, []byte("data")) && byteRate != 0:
+			metadata.Duration = size * 1000 / byteRate
+			return
+		}
+		position += 8 + int(size+size%2)
+	}
+}
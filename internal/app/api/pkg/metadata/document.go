@@ -0,0 +1,27 @@
+package metadata
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// NOTE: ページツリーの各ノードは配下のページ数を持つため, 最大値をルートのページ数とみなす.
+var pdfPagesPattern = regexp.MustCompile(`/Type\s*/Pages\b[^>]*?/Count\s+(\d+)|/Count\s+(\d+)[^>]*?/Type\s*/Pages\b`)
+
+func extractPDF(metadata *Metadata, head, tail []byte) {
+	metadata.PageCount = max(countPDFPages(head), countPDFPages(tail))
+}
+
+func countPDFPages(data []byte) uint64 {
+	var count uint64
+	for _, match := range pdfPagesPattern.FindAllSubmatch(data, -1) {
+		value := match[1]
+		if value == nil {
+			value = match[2]
+		}
+		if n, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+			count = max(count, n)
+		}
+	}
+	return count
+}
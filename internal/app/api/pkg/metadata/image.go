@@ -0,0 +1,150 @@
+package metadata
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	markerSOI  = 0xD8
+	markerSOS  = 0xDA
+	markerAPP1 = 0xE1
+)
+
+const (
+	tagMake               = 0x010F
+	tagModel              = 0x0110
+	tagOrientation        = 0x0112
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+
+	tagGPSLatitudeRef  = 0x0001
+	tagGPSLatitude     = 0x0002
+	tagGPSLongitudeRef = 0x0003
+	tagGPSLongitude    = 0x0004
+)
+
+const (
+	exifTimeLayout       = "2006:01:02 15:04:05"
+	exifOffsetTimeLayout = "2006:01:02 15:04:05-07:00"
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+func extractImage(metadata *Metadata, head, _ []byte) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(head))
+	if err != nil {
+		return
+	}
+	metadata.Width = uint64(config.Width)
+	metadata.Height = uint64(config.Height)
+}
+
+func extractJPEG(metadata *Metadata, head, tail []byte) {
+	extractImage(metadata, head, tail)
+
+	tiff := newTIFF(findExif(head))
+	if tiff == nil {
+		return
+	}
+
+	ifd0 := tiff.readIFD(tiff.firstIFD())
+	metadata.CameraMake = tiff.string(ifd0[tagMake])
+	metadata.CameraModel = tiff.string(ifd0[tagModel])
+
+	// NOTE: 90度回転する向きでは表示時の縦横を返却する.
+	if orientation, ok := tiff.uint(ifd0[tagOrientation]); ok && 5 <= orientation && orientation <= 8 {
+		metadata.Width, metadata.Height = metadata.Height, metadata.Width
+	}
+
+	if offset, ok := tiff.uint(ifd0[tagExifIFD]); ok {
+		metadata.TakenAt = readTakenAt(tiff, tiff.readIFD(int(offset)))
+	}
+	if offset, ok := tiff.uint(ifd0[tagGPSIFD]); ok {
+		metadata.Latitude, metadata.Longitude = readLocation(tiff, tiff.readIFD(int(offset)))
+	}
+}
+
+func isJPEG(data []byte) bool {
+	return 2 <= len(data) && data[0] == 0xFF && data[1] == markerSOI
+}
+
+func findExif(data []byte) []byte {
+	if !isJPEG(data) {
+		return nil
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF || data[i+1] == markerSOS {
+			return nil
+		}
+
+		length := int(data[i+2])<<8 | int(data[i+3])
+		if length < 2 || len(data) < i+2+length {
+			return nil
+		}
+		segment := data[i+4 : i+2+length]
+		if data[i+1] == markerAPP1 && bytes.HasPrefix(segment, exifHeader) {
+			return segment[len(exifHeader):]
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+// NOTE: タイムゾーンが記録されていない場合はUTCとみなす.
+func readTakenAt(tiff *tiff, ifd map[uint16]*ifdEntry) *time.Time {
+	value := tiff.string(ifd[tagDateTimeOriginal])
+	if value == "" {
+		return nil
+	}
+
+	takenAt, err := time.ParseInLocation(exifTimeLayout, value, time.UTC)
+	if offset := tiff.string(ifd[tagOffsetTimeOriginal]); offset != "" {
+		takenAt, err = time.Parse(exifOffsetTimeLayout, value+offset)
+	}
+	if err != nil {
+		return nil
+	}
+
+	takenAt = takenAt.UTC()
+	return &takenAt
+}
+
+func readLocation(tiff *tiff, ifd map[uint16]*ifdEntry) (*float64, *float64) {
+	latitude, ok := readCoordinate(tiff, ifd[tagGPSLatitude], tiff.string(ifd[tagGPSLatitudeRef]), "S", 90)
+	if !ok {
+		return nil, nil
+	}
+	longitude, ok := readCoordinate(tiff, ifd[tagGPSLongitude], tiff.string(ifd[tagGPSLongitudeRef]), "W", 180)
+	if !ok {
+		return nil, nil
+	}
+	return &latitude, &longitude
+}
+
+// NOTE: 度, 分, 秒の3つの有理数を10進数の度に変換する.
+func readCoordinate(tiff *tiff, entry *ifdEntry, ref, negative string, limit float64) (float64, bool) {
+	values := tiff.rationals(entry)
+	if len(values) != 3 {
+		return 0, false
+	}
+
+	coordinate := values[0] + values[1]/60 + values[2]/3600
+	if strings.EqualFold(ref, negative) {
+		coordinate = -coordinate
+	}
+	if coordinate < -limit || limit < coordinate {
+		return 0, false
+	}
+	return coordinate, true
+}
@@ -0,0 +1,70 @@
+package metadata
+
+import "time"
+
+const (
+	// NOTE: EXIFやID3v2タグは先頭に, PDFのページツリーは末尾に置かれることが多いため両端のみを保持する.
+	headSize = 1 << 20
+	tailSize = 64 << 10
+)
+
+var extractors = map[string]func(*Metadata, []byte, []byte){
+	"image/jpeg":      extractJPEG,
+	"image/png":       extractImage,
+	"image/gif":       extractImage,
+	"image/webp":      extractImage,
+	"application/pdf": extractPDF,
+	"audio/mpeg":      extractID3,
+	"audio/wave":      extractWAV,
+}
+
+type Metadata struct {
+	Width       uint64
+	Height      uint64
+	TakenAt     *time.Time
+	CameraMake  string
+	CameraModel string
+	Latitude    *float64
+	Longitude   *float64
+	PageCount   uint64
+	Duration    uint64
+	Title       string
+	Artist      string
+	Album       string
+}
+
+// NOTE: ボディの書き込みと同時にメタデータの抽出に必要な範囲を記録する.
+type Recorder struct {
+	head []byte
+	tail []byte
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Write(p []byte) (int, error) {
+	if n := min(headSize-len(r.head), len(p)); 0 < n {
+		r.head = append(r.head, p[:n]...)
+	}
+	r.tail = append(r.tail, p...)
+	if tailSize < len(r.tail) {
+		r.tail = r.tail[len(r.tail)-tailSize:]
+	}
+	return len(p), nil
+}
+
+// NOTE: 対応していない形式や抽出できる情報がない場合はnilを返却する.
+func (r *Recorder) Extract(contentType string) *Metadata {
+	extract, ok := extractors[contentType]
+	if !ok {
+		return nil
+	}
+
+	var metadata Metadata
+	extract(&metadata, r.head, r.tail)
+	if metadata == (Metadata{}) {
+		return nil
+	}
+	return &metadata
+}
@@ -0,0 +1,262 @@
+package metadata_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/metadata"
+)
+
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func ascii(value string) tiffEntry {
+	return tiffEntry{typ: 2, count: uint32(len(value) + 1), value: append([]byte(value), 0)}
+}
+
+func short(value uint16) tiffEntry {
+	return tiffEntry{typ: 3, count: 1, value: binary.BigEndian.AppendUint16(nil, value)}
+}
+
+func long(value uint32) tiffEntry {
+	return tiffEntry{typ: 4, count: 1, value: binary.BigEndian.AppendUint32(nil, value)}
+}
+
+func rationals(values ...uint32) tiffEntry {
+	var value []byte
+	for _, v := range values {
+		value = binary.BigEndian.AppendUint32(value, v)
+		value = binary.BigEndian.AppendUint32(value, 1)
+	}
+	return tiffEntry{typ: 5, count: uint32(len(values)), value: value}
+}
+
+func tagged(tag uint16, entry tiffEntry) tiffEntry {
+	entry.tag = tag
+	return entry
+}
+
+func ifdSize(entries []tiffEntry) int {
+	size := 2 + len(entries)*12 + 4
+	for _, entry := range entries {
+		if 4 < len(entry.value) {
+			size += len(entry.value)
+		}
+	}
+	return size
+}
+
+// NOTE: 4バイトを超える値はIFDの直後に配置する.
+func appendIFD(data []byte, entries []tiffEntry) []byte {
+	extra := len(data) + 2 + len(entries)*12 + 4
+	var values []byte
+
+	data = binary.BigEndian.AppendUint16(data, uint16(len(entries)))
+	for _, entry := range entries {
+		data = binary.BigEndian.AppendUint16(data, entry.tag)
+		data = binary.BigEndian.AppendUint16(data, entry.typ)
+		data = binary.BigEndian.AppendUint32(data, entry.count)
+		if len(entry.value) <= 4 {
+			data = append(data, entry.value...)
+			data = append(data, make([]byte, 4-len(entry.value))...)
+			continue
+		}
+		data = binary.BigEndian.AppendUint32(data, uint32(extra+len(values)))
+		values = append(values, entry.value...)
+	}
+	data = binary.BigEndian.AppendUint32(data, 0)
+	return append(data, values...)
+}
+
+// NOTE: IFD0, Exif IFD, GPS IFDの順に配置したEXIFのAPP1セグメントをSOIの直後に挿入する.
+func encodeJPEG(t *testing.T, width, height int, ifd0, exif, gps []tiffEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ifd0 = append(ifd0, tagged(0x8769, long(0)), tagged(0x8825, long(0)))
+	exifOffset := 8 + ifdSize(ifd0)
+	ifd0[len(ifd0)-2] = tagged(0x8769, long(uint32(exifOffset)))
+	ifd0[len(ifd0)-1] = tagged(0x8825, long(uint32(exifOffset+ifdSize(exif))))
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = appendIFD(tiff, ifd0)
+	tiff = appendIFD(tiff, exif)
+	tiff = appendIFD(tiff, gps)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	result := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	result = binary.BigEndian.AppendUint16(result, uint16(len(segment)+2))
+	result = append(result, segment...)
+	return append(result, buf.Bytes()[2:]...)
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func encodeID3(frames map[string]string) []byte {
+	var body []byte
+	for _, id := range []string{"TIT2", "TPE1", "TALB", "TLEN"} {
+		value, ok := frames[id]
+		if !ok {
+			continue
+		}
+		body = append(body, id...)
+		body = binary.BigEndian.AppendUint32(body, uint32(len(value)+1))
+		body = append(body, 0, 0, 3)
+		body = append(body, value...)
+	}
+	body = append(body, make([]byte, 16)...)
+
+	size := len(body)
+	header := []byte{'I', 'D', '3', 3, 0, 0, byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(append(header, body...), 0xFF, 0xFB, 0x90, 0x00)
+}
+
+func encodeWAV(byteRate, dataSize uint32) []byte {
+	data := []byte("RIFF\x00\x00\x00\x00WAVEfmt ")
+	data = binary.LittleEndian.AppendUint32(data, 16)
+	data = binary.LittleEndian.AppendUint16(data, 1)
+	data = binary.LittleEndian.AppendUint16(data, 2)
+	data = binary.LittleEndian.AppendUint32(data, byteRate/4)
+	data = binary.LittleEndian.AppendUint32(data, byteRate)
+	data = binary.LittleEndian.AppendUint16(data, 4)
+	data = binary.LittleEndian.AppendUint16(data, 16)
+	data = append(data, "data"...)
+	data = binary.LittleEndian.AppendUint32(data, dataSize)
+	return append(data, make([]byte, dataSize)...)
+}
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func TestRecorder_Extract(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputData        []byte
+		inputContentType string
+		expectResult     *metadata.Metadata
+	}{
+		{
+			name: "jpeg",
+			inputData: encodeJPEG(t, 40, 20,
+				[]tiffEntry{tagged(0x010F, ascii("Holos")), tagged(0x0110, ascii("Camera 1"))},
+				[]tiffEntry{tagged(0x9003, ascii("2026:10:19 12:34:56")), tagged(0x9011, ascii("+09:00"))},
+				[]tiffEntry{tagged(0x0001, ascii("N")), tagged(0x0002, rationals(35, 30, 36)), tagged(0x0003, ascii("W")), tagged(0x0004, rationals(139, 45, 0))},
+			),
+			inputContentType: "image/jpeg",
+			expectResult: &metadata.Metadata{
+				Width:       40,
+				Height:      20,
+				TakenAt:     pointer(time.Date(2026, 10, 19, 3, 34, 56, 0, time.UTC)),
+				CameraMake:  "Holos",
+				CameraModel: "Camera 1",
+				Latitude:    pointer(35.51),
+				Longitude:   pointer(-139.75),
+			},
+		},
+		{
+			name: "jpeg rotated without offset",
+			inputData: encodeJPEG(t, 40, 20,
+				[]tiffEntry{tagged(0x0112, short(6))},
+				[]tiffEntry{tagged(0x9003, ascii("2026:10:19 12:34:56"))},
+				nil,
+			),
+			inputContentType: "image/jpeg",
+			expectResult: &metadata.Metadata{
+				Width:   20,
+				Height:  40,
+				TakenAt: pointer(time.Date(2026, 10, 19, 12, 34, 56, 0, time.UTC)),
+			},
+		},
+		{
+			name: "jpeg with invalid location",
+			inputData: encodeJPEG(t, 40, 20,
+				nil,
+				nil,
+				[]tiffEntry{tagged(0x0001, ascii("N")), tagged(0x0002, rationals(95, 0, 0)), tagged(0x0003, ascii("E")), tagged(0x0004, rationals(139, 0, 0))},
+			),
+			inputContentType: "image/jpeg",
+			expectResult:     &metadata.Metadata{Width: 40, Height: 20},
+		},
+		{
+			name:             "png",
+			inputData:        encodePNG(t, 30, 10),
+			inputContentType: "image/png",
+			expectResult:     &metadata.Metadata{Width: 30, Height: 10},
+		},
+		{
+			name:             "pdf",
+			inputData:        []byte("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n2 0 obj << /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >> endobj\n3 0 obj << /Type /Page /Parent 2 0 R >> endobj\n4 0 obj << /Type /Page /Parent 2 0 R >> endobj\n%%EOF"),
+			inputContentType: "application/pdf",
+			expectResult:     &metadata.Metadata{PageCount: 2},
+		},
+		{
+			name:             "pdf with page tree at the end",
+			inputData:        []byte("%PDF-1.4\n" + strings.Repeat(" ", 2<<20) + "5 0 obj << /Count 12 /Kids [6 0 R 7 0 R] /Type /Pages >> endobj\n%%EOF"),
+			inputContentType: "application/pdf",
+			expectResult:     &metadata.Metadata{PageCount: 12},
+		},
+		{
+			name:             "mp3",
+			inputData:        encodeID3(map[string]string{"TIT2": "Title", "TPE1": "Artist", "TALB": "Album", "TLEN": "183000"}),
+			inputContentType: "audio/mpeg",
+			expectResult:     &metadata.Metadata{Title: "Title", Artist: "Artist", Album: "Album", Duration: 183000},
+		},
+		{
+			name:             "wav",
+			inputData:        encodeWAV(176400, 264600),
+			inputContentType: "audio/wave",
+			expectResult:     &metadata.Metadata{Duration: 1500},
+		},
+		{
+			name:             "broken image",
+			inputData:        []byte("\xFF\xD8\xFF\xE1broken"),
+			inputContentType: "image/jpeg",
+			expectResult:     nil,
+		},
+		{
+			name:             "unsupported type",
+			inputData:        []byte("text"),
+			inputContentType: "text/plain; charset=utf-8",
+			expectResult:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := metadata.NewRecorder()
+			if _, err := bytes.NewReader(tt.inputData).WriteTo(recorder); err != nil {
+				t.Fatal(err)
+			}
+
+			result := recorder.Extract(tt.inputContentType)
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package metadata
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+const (
+	typeASCII    = 2
+	typeShort    = 3
+	typeLong     = 4
+	typeRational = 5
+)
+
+var typeSizes = map[uint16]int{
+	typeASCII:    1,
+	typeShort:    2,
+	typeLong:     4,
+	typeRational: 8,
+}
+
+type tiff struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+type ifdEntry struct {
+	typ   uint16
+	count int
+	value []byte
+}
+
+func newTIFF(data []byte) *tiff {
+	if len(data) < 8 {
+		return nil
+	}
+
+	switch string(data[:2]) {
+	case "II":
+		return &tiff{data: data, order: binary.LittleEndian}
+	case "MM":
+		return &tiff{data: data, order: binary.BigEndian}
+	default:
+		return nil
+	}
+}
+
+func (t *tiff) firstIFD() int {
+	return int(t.order.Uint32(t.data[4:]))
+}
+
+// NOTE: 4バイト以下の値はエントリーに直接格納され, それ以外はオフセットが格納される.
+func (t *tiff) readIFD(offset int) map[uint16]*ifdEntry {
+	if offset < 8 || len(t.data) < offset+2 {
+		return nil
+	}
+
+	count := int(t.order.Uint16(t.data[offset:]))
+	entries := make(map[uint16]*ifdEntry, count)
+	for i := range count {
+		position := offset + 2 + i*12
+		if len(t.data) < position+12 {
+			break
+		}
+		if entry := t.readEntry(position); entry != nil {
+			entries[t.order.Uint16(t.data[position:])] = entry
+		}
+	}
+	return entries
+}
+
+func (t *tiff) readEntry(position int) *ifdEntry {
+	typ := t.order.Uint16(t.data[position+2:])
+	count := int(t.order.Uint32(t.data[position+4:]))
+	typeSize, ok := typeSizes[typ]
+	if !ok || len(t.data) < count*typeSize {
+		return nil
+	}
+
+	size := count * typeSize
+	if size <= 4 {
+		return &ifdEntry{typ: typ, count: count, value: t.data[position+8 : position+8+size]}
+	}
+
+	offset := int(t.order.Uint32(t.data[position+8:]))
+	if len(t.data) < offset+size {
+		return nil
+	}
+	return &ifdEntry{typ: typ, count: count, value: t.data[offset : offset+size]}
+}
+
+func (t *tiff) string(entry *ifdEntry) string {
+	if entry == nil || entry.typ != typeASCII {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+func (t *tiff) uint(entry *ifdEntry) (uint64, bool) {
+	if entry == nil || entry.count == 0 {
+		return 0, false
+	}
+
+	switch entry.typ {
+	case typeShort:
+		return uint64(t.order.Uint16(entry.value)), true
+	case typeLong:
+		return uint64(t.order.Uint32(entry.value)), true
+	default:
+		return 0, false
+	}
+}
+
+func (t *tiff) rationals(entry *ifdEntry) []float64 {
+	if entry == nil || entry.typ != typeRational {
+		return nil
+	}
+
+	values := make([]float64, entry.count)
+	for i := range values {
+		numerator := t.order.Uint32(entry.value[i*8:])
+		denominator := t.order.Uint32(entry.value[i*8+4:])
+		if denominator == 0 {
+			return nil
+		}
+		values[i] = float64(numerator) / float64(denominator)
+	}
+	return values
+}
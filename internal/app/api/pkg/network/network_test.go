@@ -0,0 +1,40 @@
+package network_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/network"
+)
+
+func TestNetwork_IsPublicAddr(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expect bool
+	}{
+		{name: "public ipv4", input: "93.184.215.14", expect: true},
+		{name: "public ipv6", input: "2606:2800:21f:cb07:6820:80da:af6b:8b2c", expect: true},
+		{name: "loopback", input: "127.0.0.1", expect: false},
+		{name: "ipv6 loopback", input: "::1", expect: false},
+		{name: "private 10", input: "10.0.0.1", expect: false},
+		{name: "private 172", input: "172.16.0.1", expect: false},
+		{name: "private 192", input: "192.168.0.1", expect: false},
+		{name: "link local", input: "169.254.169.254", expect: false},
+		{name: "ipv6 link local", input: "fe80::1", expect: false},
+		{name: "unique local", input: "fd00:ec2::254", expect: false},
+		{name: "unspecified", input: "0.0.0.0", expect: false},
+		{name: "shared address", input: "100.64.0.1", expect: false},
+		{name: "multicast", input: "224.0.0.1", expect: false},
+		{name: "broadcast", input: "255.255.255.255", expect: false},
+		{name: "ipv4 mapped loopback", input: "::ffff:127.0.0.1", expect: false},
+		{name: "nat64 loopback", input: "64:ff9b::7f00:1", expect: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := network.IsPublicAddr(netip.MustParseAddr(tt.input)); result != tt.expect {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expect, result)
+			}
+		})
+	}
+}
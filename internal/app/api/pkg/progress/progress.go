@@ -0,0 +1,55 @@
+package progress
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+)
+
+type counterKey struct{}
+
+type Counter struct {
+	entries atomic.Uint64
+	bytes   atomic.Uint64
+}
+
+func WithCounter(ctx context.Context, counter *Counter) context.Context {
+	return context.WithValue(ctx, counterKey{}, counter)
+}
+
+// NOTE: カウンターが設定されていない場合は何もしない.
+func Add(ctx context.Context, entries, bytes uint64) {
+	if counter, ok := ctx.Value(counterKey{}).(*Counter); ok {
+		counter.Add(entries, bytes)
+	}
+}
+
+func NewReader(ctx context.Context, reader io.Reader) io.Reader {
+	return &countReader{
+		ctx:    ctx,
+		reader: reader,
+	}
+}
+
+func (c *Counter) Add(entries, bytes uint64) {
+	c.entries.Add(entries)
+	c.bytes.Add(bytes)
+}
+
+// NOTE: 前回の取得以降に加算された値を返す.
+func (c *Counter) Swap() (entries, bytes uint64) {
+	return c.entries.Swap(0), c.bytes.Swap(0)
+}
+
+type countReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if 0 < n {
+		Add(r.ctx, 0, uint64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,55 @@
+package progress_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/progress"
+)
+
+func TestProgress_Add(t *testing.T) {
+	tests := []struct {
+		name          string
+		withCounter   bool
+		inputEntries  uint64
+		inputBytes    uint64
+		expectEntries uint64
+		expectBytes   uint64
+	}{
+		{name: "with counter", withCounter: true, inputEntries: 2, inputBytes: 10, expectEntries: 2, expectBytes: 10},
+		{name: "without counter", withCounter: false, inputEntries: 2, inputBytes: 10, expectEntries: 0, expectBytes: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := &progress.Counter{}
+			ctx := t.Context()
+			if tt.withCounter {
+				ctx = progress.WithCounter(ctx, counter)
+			}
+
+			progress.Add(ctx, tt.inputEntries, tt.inputBytes)
+
+			entries, bytes := counter.Swap()
+			if entries != tt.expectEntries || bytes != tt.expectBytes {
+				t.Errorf("\nexpect: %d, %d\ngot: %d, %d", tt.expectEntries, tt.expectBytes, entries, bytes)
+			}
+			if entries, bytes := counter.Swap(); entries != 0 || bytes != 0 {
+				t.Errorf("\nexpect: 0, 0\ngot: %d, %d", entries, bytes)
+			}
+		})
+	}
+}
+
+func TestProgress_NewReader(t *testing.T) {
+	counter := &progress.Counter{}
+	ctx := progress.WithCounter(t.Context(), counter)
+
+	if _, err := io.Copy(io.Discard, progress.NewReader(ctx, bytes.NewBufferString("test"))); err != nil {
+		t.Error(err)
+	}
+
+	if _, bytes := counter.Swap(); bytes != 4 {
+		t.Errorf("\nexpect: 4\ngot: %d", bytes)
+	}
+}
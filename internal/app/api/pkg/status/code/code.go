@@ -8,6 +8,11 @@ var (
 	Forbidden            StatusCode = "FORBIDDEN"
 	NotFound             StatusCode = "NOT_FOUND"
 	Conflict             StatusCode = "CONFLICT"
+	ContentTooLarge      StatusCode = "CONTENT_TOO_LARGE"
+	UnsupportedMediaType StatusCode = "UNSUPPORTED_MEDIA_TYPE"
 	UnprocessableContent StatusCode = "UNPROCESSABLE_CONTENT"
+	FailedDependency     StatusCode = "FAILED_DEPENDENCY"
+	MalwareDetected      StatusCode = "MALWARE_DETECTED"
+	TooManyRequests      StatusCode = "TOO_MANY_REQUESTS"
 	Internal             StatusCode = "INTERNAL"
 )
@@ -0,0 +1,51 @@
+package thumbnail
+
+import (
+	"io"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/imaging"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+)
+
+const (
+	MaxSize = 1024
+
+	jpegQuality = 85
+)
+
+var (
+	ErrInvalidSize      = status.Error(code.BadRequest, "invalid thumbnail size")
+	ErrUnsupportedImage = imaging.ErrUnsupportedImage
+	ErrImageTooLarge    = imaging.ErrImageTooLarge
+)
+
+func Supports(contentType string) bool {
+	return imaging.Supports(contentType)
+}
+
+func OutputType(contentType string) string {
+	return imaging.DefaultFormat(contentType)
+}
+
+func ValidateSize(width, height uint64) error {
+	if width == 0 || MaxSize < width || height == 0 || MaxSize < height {
+		return ErrInvalidSize
+	}
+	return nil
+}
+
+// NOTE: 縦横比を維持して指定された範囲に収まるよう縮小し, 拡大はしない.
+func Generate(reader io.Reader, contentType string, width, height uint64) ([]byte, error) {
+	if err := ValidateSize(width, height); err != nil {
+		return nil, err
+	}
+
+	return imaging.Transform(reader, contentType, &imaging.Options{
+		Width:   int(width),
+		Height:  int(height),
+		Fit:     imaging.FitContain,
+		Quality: jpegQuality,
+		Format:  OutputType(contentType),
+	})
+}
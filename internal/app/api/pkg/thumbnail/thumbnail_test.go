@@ -0,0 +1,206 @@
+package thumbnail_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/thumbnail"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// NOTE: 左半分を赤, 右半分を青とした画像を生成する.
+func newImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			if x < width/2 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func encodeImage(t *testing.T, contentType string, img image.Image) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	case "image/gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// NOTE: SOIの直後にOrientationのみを含むEXIFのAPP1セグメントを挿入する.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0)
+	tiff = binary.BigEndian.AppendUint32(tiff, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	result := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	result = binary.BigEndian.AppendUint16(result, uint16(len(segment)+2))
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return 0xC000 < r && g < 0x4000 && b < 0x4000
+}
+
+func TestThumbnail_Generate(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputData         []byte
+		inputContentType  string
+		inputWidth        uint64
+		inputHeight       uint64
+		expectWidth       int
+		expectHeight      int
+		expectContentType string
+		expectTopRed      bool
+		expectError       error
+	}{
+		{
+			name:              "resize png",
+			inputData:         encodeImage(t, "image/png", newImage(400, 200)),
+			inputContentType:  "image/png",
+			inputWidth:        100,
+			inputHeight:       100,
+			expectWidth:       100,
+			expectHeight:      50,
+			expectContentType: "png",
+			expectError:       nil,
+		},
+		{
+			name:              "resize jpeg",
+			inputData:         encodeImage(t, "image/jpeg", newImage(200, 400)),
+			inputContentType:  "image/jpeg",
+			inputWidth:        100,
+			inputHeight:       100,
+			expectWidth:       50,
+			expectHeight:      100,
+			expectContentType: "jpeg",
+			expectError:       nil,
+		},
+		{
+			name:              "gif is converted to png",
+			inputData:         encodeImage(t, "image/gif", newImage(300, 300)),
+			inputContentType:  "image/gif",
+			inputWidth:        30,
+			inputHeight:       60,
+			expectWidth:       30,
+			expectHeight:      30,
+			expectContentType: "png",
+			expectError:       nil,
+		},
+		{
+			name:              "not upscaled",
+			inputData:         encodeImage(t, "image/png", newImage(40, 20)),
+			inputContentType:  "image/png",
+			inputWidth:        100,
+			inputHeight:       100,
+			expectWidth:       40,
+			expectHeight:      20,
+			expectContentType: "png",
+			expectError:       nil,
+		},
+		{
+			name:              "rotated by exif orientation",
+			inputData:         withOrientation(encodeImage(t, "image/jpeg", newImage(64, 32)), 6),
+			inputContentType:  "image/jpeg",
+			inputWidth:        100,
+			inputHeight:       100,
+			expectWidth:       32,
+			expectHeight:      64,
+			expectContentType: "jpeg",
+			expectTopRed:      true,
+			expectError:       nil,
+		},
+		{
+			name:             "zero size",
+			inputData:        encodeImage(t, "image/png", newImage(40, 20)),
+			inputContentType: "image/png",
+			inputWidth:       0,
+			inputHeight:      100,
+			expectError:      thumbnail.ErrInvalidSize,
+		},
+		{
+			name:             "too large size",
+			inputData:        encodeImage(t, "image/png", newImage(40, 20)),
+			inputContentType: "image/png",
+			inputWidth:       thumbnail.MaxSize + 1,
+			inputHeight:      100,
+			expectError:      thumbnail.ErrInvalidSize,
+		},
+		{
+			name:             "unsupported content type",
+			inputData:        []byte("test"),
+			inputContentType: "text/plain; charset=utf-8",
+			inputWidth:       100,
+			inputHeight:      100,
+			expectError:      thumbnail.ErrUnsupportedImage,
+		},
+		{
+			name:             "broken image",
+			inputData:        []byte("test"),
+			inputContentType: "image/png",
+			inputWidth:       100,
+			inputHeight:      100,
+			expectError:      thumbnail.ErrUnsupportedImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := thumbnail.Generate(bytes.NewReader(tt.inputData), tt.inputContentType, tt.inputWidth, tt.inputHeight)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if tt.expectError != nil {
+				return
+			}
+
+			img, format, err := image.Decode(bytes.NewReader(result))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != tt.expectContentType {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectContentType, format)
+			}
+			if img.Bounds().Dx() != tt.expectWidth || img.Bounds().Dy() != tt.expectHeight {
+				t.Errorf("\nexpect: %dx%d\ngot: %dx%d", tt.expectWidth, tt.expectHeight, img.Bounds().Dx(), img.Bounds().Dy())
+			}
+			if tt.expectTopRed && (!isRed(img.At(tt.expectWidth/2, 0)) || isRed(img.At(tt.expectWidth/2, tt.expectHeight-1))) {
+				t.Error("image is not rotated")
+			}
+		})
+	}
+}
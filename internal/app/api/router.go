@@ -6,6 +6,9 @@ func registerRouter(r *gin.Engine) {
 	health := r.Group("health")
 	health.GET("", healthHdl.Health)
 
+	// NOTE: 認可の失敗も記録するため, 認可より前に監査ログのミドルウェアを登録する.
+	r.Use(auditMW.Record)
+	r.Use(normalizationMW.Normalize)
 	r.Use(authorizationMW.Authorize)
 
 	volumes := r.Group("volumes")
@@ -14,6 +17,20 @@ func registerRouter(r *gin.Engine) {
 	volumes.PUT("/:name", volumeHdl.Update)
 	volumes.DELETE("/:name", volumeHdl.Delete)
 	volumes.GET("/:name", volumeHdl.GetOne)
+	volumes.GET("/:name/fsck", fsckHdl.Check)
+	volumes.POST("/:name/fsck", fsckHdl.Repair)
+	volumes.GET("/:name/content-types", fsckHdl.CheckTypes)
+	volumes.POST("/:name/content-types", fsckHdl.RepairTypes)
+	volumes.POST("/:name/scans", jobHdl.Scan)
+	volumes.POST("/:name/webhooks", webhookHdl.Create)
+	volumes.GET("/:name/webhooks", webhookHdl.GetAll)
+	volumes.DELETE("/:name/webhooks/:id", webhookHdl.Delete)
+	volumes.GET("/:name/webhooks/:id/deliveries", webhookHdl.GetDeliveries)
+	volumes.GET("/:name/events", changeHdl.Stream)
+	volumes.GET("/:name/changes", changeHdl.GetDelta)
+	volumes.POST("/:name/image-presets", imagePresetHdl.Create)
+	volumes.GET("/:name/image-presets", imagePresetHdl.GetAll)
+	volumes.DELETE("/:name/image-presets/:id", imagePresetHdl.Delete)
 
 	entries := r.Group("entries")
 	entries.POST("/:volumeName", entryHdl.Create)
@@ -23,4 +40,25 @@ func registerRouter(r *gin.Engine) {
 	entries.DELETE("/:volumeName/*key", entryHdl.Delete)
 	entries.HEAD("/:volumeName/*key", entryHdl.GetMeta)
 	entries.GET("/:volumeName/*key", entryHdl.GetOne)
+
+	// NOTE: エントリーのルートはキーを末尾まで受け取るため, 一括操作はキーと衝突しないパスとする.
+	batch := r.Group("batch")
+	batch.POST("/entries/:volumeName", entryHdl.Batch)
+
+	// NOTE: ボリューム名はアカウント毎に一意のため, 公開ボリュームやドロップフォルダは所有者を指定して参照する.
+	accounts := r.Group("accounts")
+	accounts.POST("/:ownerID/entries/:volumeName", entryHdl.Create)
+	accounts.HEAD("/:ownerID/entries/:volumeName/*key", entryHdl.GetMeta)
+	accounts.GET("/:ownerID/entries/:volumeName/*key", entryHdl.GetOne)
+
+	jobs := r.Group("jobs")
+	jobs.GET("/:id", jobHdl.GetOne)
+	jobs.DELETE("/:id", jobHdl.Cancel)
+
+	auditLogs := r.Group("audit-logs")
+	auditLogs.GET("", auditLogHdl.Search)
+	auditLogs.GET("/export", auditLogHdl.Export)
+
+	admin := r.Group("admin")
+	admin.GET("/audit-logs", auditLogHdl.SearchAll)
 }
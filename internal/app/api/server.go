@@ -10,20 +10,45 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
 	"github.com/spf13/afero"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/database"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/infrastructure/file"
 )
 
+// NOTE: アップロード中の一時ファイル及び実行中のトランザクションの退避先を削除しないよう猶予を設ける.
+const tempFileExpiration = time.Hour
+
 func Serve() {
-	conf := loadServerConfig()
+	conf, err := loadServerConfig()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
 
 	db, err := NewDatabase(&conf.database)
 	if err != nil {
 		log.Fatalln(err.Error())
 	}
 
-	inject(db, afero.NewOsFs(), conf)
+	fs := afero.NewOsFs()
+	if err := file.RemoveStaleTempFiles(fs, conf.fileSystem.BasePath, tempFileExpiration); err != nil {
+		log.Println(err.Error())
+	}
+	if err := file.RemoveOrphanedTrash(fs, conf.fileSystem.BasePath, tempFileExpiration); err != nil {
+		log.Println(err.Error())
+	}
+	if err := migrateVolumeLayout(db, fs, conf.fileSystem.BasePath); err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	inject(db, fs, conf)
 
 	r := gin.Default()
+	// NOTE: 信頼するプロキシを指定しない場合, 送信元が設定した転送元ヘッダーを接続元のIPアドレスとして扱わないようにする.
+	if err := r.SetTrustedProxies(conf.proxy.TrustedProxies); err != nil {
+		log.Fatalln(err.Error())
+	}
 	registerRouter(r)
 
 	srv := &http.Server{
@@ -35,6 +60,9 @@ func Serve() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt, os.Kill)
 	defer stop()
 
+	startJobWorkers(ctx, jobUC)
+	startWebhookWorkers(ctx, webhookUC)
+
 	go func() {
 		if err := srv.ListenAndServe(); err != nil {
 			log.Println(err.Error())
@@ -50,3 +78,11 @@ func Serve() {
 		log.Println(err.Error())
 	}
 }
+
+func migrateVolumeLayout(db *sqlx.DB, fs afero.Fs, basePath string) error {
+	volumes, err := database.NewVolumeRepository(db).FindAll(context.Background())
+	if err != nil {
+		return err
+	}
+	return file.MigrateVolumeLayout(fs, basePath, volumes)
+}
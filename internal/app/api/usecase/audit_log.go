@@ -0,0 +1,117 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../test/mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
+)
+
+var ErrRequiredAuditLog = status.Error(code.Internal, "audit log is required")
+
+type AuditLogUsecase interface {
+	Record(context.Context, *dto.AuditLogDTO) error
+	Search(context.Context, uuid.UUID, *dto.AuditLogConditionDTO, uint64, uint64) ([]*dto.AuditLogDTO, uint64, error)
+	SearchAll(context.Context, uuid.UUID, *dto.AuditLogConditionDTO, uint64, uint64) ([]*dto.AuditLogDTO, uint64, error)
+}
+
+type auditLogUsecase struct {
+	transactionObj transaction.TransactionObject
+	auditLogRepo   repository.AuditLogRepository
+	adminIDs       []uuid.UUID
+}
+
+func NewAuditLogUsecase(transactionObj transaction.TransactionObject, auditLogRepo repository.AuditLogRepository, adminIDs []uuid.UUID) AuditLogUsecase {
+	return &auditLogUsecase{
+		transactionObj: transactionObj,
+		auditLogRepo:   auditLogRepo,
+		adminIDs:       adminIDs,
+	}
+}
+
+func (u *auditLogUsecase) Record(ctx context.Context, auditLogDTO *dto.AuditLogDTO) error {
+	if auditLogDTO == nil {
+		return ErrRequiredAuditLog
+	}
+
+	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		auditLog := entity.NewAuditLog(
+			auditLogDTO.RequestID,
+			auditLogDTO.OwnerID,
+			auditLogDTO.ActorID,
+			auditLogDTO.CredentialType,
+			auditLogDTO.Principal,
+			auditLogDTO.ClientIP,
+			auditLogDTO.UserAgent,
+			auditLogDTO.Operation,
+			auditLogDTO.VolumeName,
+			auditLogDTO.Key,
+			auditLogDTO.NewVolumeName,
+			auditLogDTO.NewKey,
+			auditLogDTO.Status,
+		)
+		return u.auditLogRepo.Create(ctx, auditLog)
+	})
+}
+
+func (u *auditLogUsecase) Search(ctx context.Context, accountID uuid.UUID, condition *dto.AuditLogConditionDTO, cursor, limit uint64) ([]*dto.AuditLogDTO, uint64, error) {
+	return u.search(ctx, limit, func(ctx context.Context) ([]*entity.AuditLog, error) {
+		return u.auditLogRepo.FindByOwnerID(ctx, accountID, toAuditLogCondition(condition), cursor, limit+1)
+	})
+}
+
+// NOTE: 所有者を特定できない認可の失敗も参照できるよう, 管理者には全ての監査ログを返却する.
+func (u *auditLogUsecase) SearchAll(ctx context.Context, accountID uuid.UUID, condition *dto.AuditLogConditionDTO, cursor, limit uint64) ([]*dto.AuditLogDTO, uint64, error) {
+	if !slices.Contains(u.adminIDs, accountID) {
+		return nil, 0, ErrForbidden
+	}
+
+	return u.search(ctx, limit, func(ctx context.Context) ([]*entity.AuditLog, error) {
+		return u.auditLogRepo.FindAll(ctx, toAuditLogCondition(condition), cursor, limit+1)
+	})
+}
+
+// NOTE: 続きが存在する場合のみ次に指定するカーソルを返却する.
+func (u *auditLogUsecase) search(ctx context.Context, limit uint64, find func(context.Context) ([]*entity.AuditLog, error)) ([]*dto.AuditLogDTO, uint64, error) {
+	var auditLogs []*entity.AuditLog
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		auditLogs, err = find(ctx)
+		return err
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	var next uint64
+	if limit < uint64(len(auditLogs)) {
+		auditLogs = auditLogs[:limit]
+		next = auditLogs[len(auditLogs)-1].ID
+	}
+
+	return mapper.ToAuditLogDTOs(auditLogs), next, nil
+}
+
+func toAuditLogCondition(condition *dto.AuditLogConditionDTO) *repository.AuditLogCondition {
+	if condition == nil {
+		return nil
+	}
+	return &repository.AuditLogCondition{
+		ActorID:    condition.ActorID,
+		VolumeName: condition.VolumeName,
+		Operation:  condition.Operation,
+		ClientIP:   condition.ClientIP,
+		Principal:  condition.Principal,
+		Since:      condition.Since,
+		Until:      condition.Until,
+	}
+}
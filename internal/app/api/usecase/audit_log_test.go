@@ -0,0 +1,365 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+)
+
+func TestAuditLog_Record(t *testing.T) {
+	ownerID := uuid.New()
+	actorID := uuid.New()
+
+	tests := []struct {
+		name                  string
+		inputAuditLog         *dto.AuditLogDTO
+		expectAuditLog        *entity.AuditLog
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockAuditLogRepo   func(*mockRepository.MockAuditLogRepository, **entity.AuditLog)
+	}{
+		{
+			name:           "successfully recorded",
+			inputAuditLog:  &dto.AuditLogDTO{RequestID: "request", OwnerID: ownerID, ActorID: actorID, CredentialType: entity.CredentialTypeSession, Operation: "entry.delete", VolumeName: "volume", Key: "key", Status: 204},
+			expectAuditLog: &entity.AuditLog{RequestID: "request", OwnerID: ownerID, ActorID: actorID, CredentialType: entity.CredentialTypeSession, Operation: "entry.delete", VolumeName: "volume", Key: "key", Status: 204},
+			expectError:    nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository, result **entity.AuditLog) {
+				auditLogRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, auditLog *entity.AuditLog) error {
+						*result = auditLog
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:           "without owner",
+			inputAuditLog:  &dto.AuditLogDTO{RequestID: "request", CredentialType: entity.CredentialTypeAnonymous, Operation: "entry.get", VolumeName: "volume", Key: "key", Status: 404},
+			expectAuditLog: &entity.AuditLog{RequestID: "request", CredentialType: entity.CredentialTypeAnonymous, Operation: "entry.get", VolumeName: "volume", Key: "key", Status: 404},
+			expectError:    nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository, result **entity.AuditLog) {
+				auditLogRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, auditLog *entity.AuditLog) error {
+						*result = auditLog
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:                  "audit log is nil",
+			inputAuditLog:         nil,
+			expectAuditLog:        nil,
+			expectError:           usecase.ErrRequiredAuditLog,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockAuditLogRepo:   func(*mockRepository.MockAuditLogRepository, **entity.AuditLog) {},
+		},
+		{
+			name:           "create error",
+			inputAuditLog:  &dto.AuditLogDTO{RequestID: "request", OwnerID: ownerID, ActorID: actorID, CredentialType: entity.CredentialTypeSession, Operation: "entry.delete", VolumeName: "volume", Key: "key", Status: 204},
+			expectAuditLog: &entity.AuditLog{RequestID: "request", OwnerID: ownerID, ActorID: actorID, CredentialType: entity.CredentialTypeSession, Operation: "entry.delete", VolumeName: "volume", Key: "key", Status: 204},
+			expectError:    sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository, result **entity.AuditLog) {
+				auditLogRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, auditLog *entity.AuditLog) error {
+						*result = auditLog
+						return sql.ErrConnDone
+					}).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			var result *entity.AuditLog
+			auditLogRepo := mockRepository.NewMockAuditLogRepository(ctrl)
+			tt.setMockAuditLogRepo(auditLogRepo, &result)
+
+			uc := usecase.NewAuditLogUsecase(transactionObj, auditLogRepo, nil)
+			if err := uc.Record(t.Context(), tt.inputAuditLog); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(entity.AuditLog{}, "CreatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectAuditLog, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Search(t *testing.T) {
+	accountID := uuid.New()
+	now := time.Now()
+	auditLogs := []*entity.AuditLog{
+		{ID: 3, RequestID: "request3", OwnerID: accountID, ActorID: accountID, Operation: "entry.delete", Status: 204, CreatedAt: now},
+		{ID: 2, RequestID: "request2", OwnerID: accountID, ActorID: accountID, Operation: "entry.create", Status: 201, CreatedAt: now},
+		{ID: 1, RequestID: "request1", OwnerID: accountID, ActorID: accountID, Operation: "volume.create", Status: 201, CreatedAt: now},
+	}
+	condition := &dto.AuditLogConditionDTO{VolumeName: "volume", Since: &now}
+
+	tests := []struct {
+		name                  string
+		expectResult          []*dto.AuditLogDTO
+		expectCursor          uint64
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockAuditLogRepo   func(*mockRepository.MockAuditLogRepository)
+	}{
+		{
+			name: "has more",
+			expectResult: []*dto.AuditLogDTO{
+				{ID: 3, RequestID: "request3", OwnerID: accountID, ActorID: accountID, Operation: "entry.delete", Status: 204, CreatedAt: now},
+				{ID: 2, RequestID: "request2", OwnerID: accountID, ActorID: accountID, Operation: "entry.create", Status: 201, CreatedAt: now},
+			},
+			expectCursor: 2,
+			expectError:  nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository) {
+				auditLogRepo.
+					EXPECT().
+					FindByOwnerID(gomock.Any(), accountID, &repository.AuditLogCondition{VolumeName: "volume", Since: &now}, uint64(4), uint64(3)).
+					Return(auditLogs, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "no more",
+			expectResult: []*dto.AuditLogDTO{
+				{ID: 1, RequestID: "request1", OwnerID: accountID, ActorID: accountID, Operation: "volume.create", Status: 201, CreatedAt: now},
+			},
+			expectCursor: 0,
+			expectError:  nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository) {
+				auditLogRepo.
+					EXPECT().
+					FindByOwnerID(gomock.Any(), accountID, &repository.AuditLogCondition{VolumeName: "volume", Since: &now}, uint64(4), uint64(3)).
+					Return(auditLogs[2:], nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectCursor: 0,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository) {
+				auditLogRepo.
+					EXPECT().
+					FindByOwnerID(gomock.Any(), accountID, gomock.Any(), uint64(4), uint64(3)).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			auditLogRepo := mockRepository.NewMockAuditLogRepository(ctrl)
+			tt.setMockAuditLogRepo(auditLogRepo)
+
+			uc := usecase.NewAuditLogUsecase(transactionObj, auditLogRepo, nil)
+			result, cursor, err := uc.Search(t.Context(), accountID, condition, 4, 2)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+			if cursor != tt.expectCursor {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectCursor, cursor)
+			}
+		})
+	}
+}
+
+func TestAuditLog_SearchAll(t *testing.T) {
+	adminID := uuid.New()
+	now := time.Now()
+	auditLogs := []*entity.AuditLog{
+		{ID: 2, RequestID: "request2", Principal: "0123456789abcdef", ClientIP: "192.0.2.1", Operation: "entry.get", Status: 401, CreatedAt: now},
+		{ID: 1, RequestID: "request1", OwnerID: adminID, ActorID: adminID, Operation: "volume.create", Status: 201, CreatedAt: now},
+	}
+	condition := &dto.AuditLogConditionDTO{ClientIP: "192.0.2.1"}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		expectResult          []*dto.AuditLogDTO
+		expectCursor          uint64
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockAuditLogRepo   func(*mockRepository.MockAuditLogRepository)
+	}{
+		{
+			name:           "successfully found",
+			inputAccountID: adminID,
+			expectResult: []*dto.AuditLogDTO{
+				{ID: 2, RequestID: "request2", Principal: "0123456789abcdef", ClientIP: "192.0.2.1", Operation: "entry.get", Status: 401, CreatedAt: now},
+				{ID: 1, RequestID: "request1", OwnerID: adminID, ActorID: adminID, Operation: "volume.create", Status: 201, CreatedAt: now},
+			},
+			expectCursor: 0,
+			expectError:  nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository) {
+				auditLogRepo.
+					EXPECT().
+					FindAll(gomock.Any(), &repository.AuditLogCondition{ClientIP: "192.0.2.1"}, uint64(0), uint64(3)).
+					Return(auditLogs, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "not admin",
+			inputAccountID:        uuid.New(),
+			expectResult:          nil,
+			expectCursor:          0,
+			expectError:           usecase.ErrForbidden,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockAuditLogRepo:   func(*mockRepository.MockAuditLogRepository) {},
+		},
+		{
+			name:           "find error",
+			inputAccountID: adminID,
+			expectResult:   nil,
+			expectCursor:   0,
+			expectError:    sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockAuditLogRepo: func(auditLogRepo *mockRepository.MockAuditLogRepository) {
+				auditLogRepo.
+					EXPECT().
+					FindAll(gomock.Any(), gomock.Any(), uint64(0), uint64(3)).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			auditLogRepo := mockRepository.NewMockAuditLogRepository(ctrl)
+			tt.setMockAuditLogRepo(auditLogRepo)
+
+			uc := usecase.NewAuditLogUsecase(transactionObj, auditLogRepo, []uuid.UUID{adminID})
+			result, cursor, err := uc.SearchAll(t.Context(), tt.inputAccountID, condition, 0, 2)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+			if cursor != tt.expectCursor {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectCursor, cursor)
+			}
+		})
+	}
+}
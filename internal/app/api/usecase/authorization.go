@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
+
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
@@ -13,41 +15,75 @@ import (
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
 )
 
-var ErrForbidden = status.Error(code.Forbidden, "forbidden")
+const (
+	OperationCreateEntry = "entry.create"
+	OperationHeadEntry   = "entry.head"
+	OperationGetEntry    = "entry.get"
+)
+
+var (
+	ErrForbidden       = status.Error(code.Forbidden, "forbidden")
+	ErrTooManyRequests = status.Error(code.TooManyRequests, "too many requests")
+)
 
 type AuthorizationUsecase interface {
-	Authorize(context.Context, string, string, string, string) (*dto.AccountDTO, error)
+	Authorize(context.Context, string, uuid.UUID, string, string, string, string) (*dto.AccountDTO, error)
 }
 
 type authorizationUsecase struct {
-	accountRepo repository.AccountRepository
-	volumeRepo  repository.VolumeRepository
+	accountRepo   repository.AccountRepository
+	volumeRepo    repository.VolumeRepository
+	rateLimitRepo repository.RateLimitRepository
 }
 
-func NewAuthorizationUsecase(accountRepo repository.AccountRepository, volumeRepo repository.VolumeRepository) AuthorizationUsecase {
+func NewAuthorizationUsecase(accountRepo repository.AccountRepository, volumeRepo repository.VolumeRepository, rateLimitRepo repository.RateLimitRepository) AuthorizationUsecase {
 	return &authorizationUsecase{
-		accountRepo: accountRepo,
-		volumeRepo:  volumeRepo,
+		accountRepo:   accountRepo,
+		volumeRepo:    volumeRepo,
+		rateLimitRepo: rateLimitRepo,
+	}
+}
+
+// NOTE: 操作は監査ログと同じくルートから判定した値を受け取る.
+// NOTE: 所有者を指定しない場合は認証したアカウントのボリュームを対象とする.
+func (u *authorizationUsecase) Authorize(ctx context.Context, credential string, ownerID uuid.UUID, volumeName, key, operation, clientIP string) (*dto.AccountDTO, error) {
+	if ownerID == uuid.Nil {
+		return u.authorizeByCredential(ctx, credential)
 	}
+	return u.authorizeForOwner(ctx, credential, ownerID, volumeName, key, operation, clientIP)
 }
 
-func (u *authorizationUsecase) Authorize(ctx context.Context, credential, volumeName, key, method string) (*dto.AccountDTO, error) {
-	isGetEntry := volumeName != "" && key != "" && (method == "GET" || method == "HEAD")
+// NOTE: 所有者を指定した場合は公開ボリュームの取得, ドロップフォルダへの投稿及び所有者自身の操作のみ許可する.
+func (u *authorizationUsecase) authorizeForOwner(ctx context.Context, credential string, ownerID uuid.UUID, volumeName, key, operation, clientIP string) (*dto.AccountDTO, error) {
+	isGetEntry := key != "" && (operation == OperationGetEntry || operation == OperationHeadEntry)
 	if isGetEntry {
-		return u.authorizeForGetEntry(ctx, credential, volumeName)
+		return u.authorizeForGetEntry(ctx, credential, ownerID, volumeName)
+	}
+	isDrop := credential == "" && operation == OperationCreateEntry
+	if isDrop {
+		return u.authorizeForDrop(ctx, ownerID, volumeName, clientIP)
+	}
+
+	account, err := u.authorizeByCredential(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+	if account.ID != ownerID {
+		return nil, ErrForbidden
 	}
-	return u.authorizeByCredential(ctx, credential)
+	return account, nil
 }
 
-func (u *authorizationUsecase) authorizeForGetEntry(ctx context.Context, credential, volumeName string) (*dto.AccountDTO, error) {
-	volume, err := u.volumeRepo.FindOneByName(ctx, volumeName)
+func (u *authorizationUsecase) authorizeForGetEntry(ctx context.Context, credential string, ownerID uuid.UUID, volumeName string) (*dto.AccountDTO, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, ownerID)
 	if err != nil {
 		return nil, err
 	}
 
 	if volume.IsPublic {
-		account := entity.NewAccount(volume.AccountID)
-		return mapper.ToAccountDTO(account), nil
+		account := mapper.ToAccountDTO(entity.NewAccount(volume.AccountID))
+		account.IsAnonymous = true
+		return account, nil
 	}
 
 	account, err := u.accountRepo.FindOneByCredential(ctx, credential)
@@ -64,6 +100,32 @@ func (u *authorizationUsecase) authorizeForGetEntry(ctx context.Context, credent
 	return mapper.ToAccountDTO(account), nil
 }
 
+// NOTE: 匿名の投稿を許可していないボリュームは認証情報による認可と同じく扱う.
+func (u *authorizationUsecase) authorizeForDrop(ctx context.Context, ownerID uuid.UUID, volumeName, clientIP string) (*dto.AccountDTO, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, ownerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrVolumeNotFound) {
+			return u.authorizeByCredential(ctx, "")
+		}
+		return nil, err
+	}
+	if !volume.IsDroppable() {
+		return u.authorizeByCredential(ctx, "")
+	}
+
+	allowed, err := u.rateLimitRepo.Allow(ctx, clientIP)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrTooManyRequests
+	}
+
+	account := mapper.ToAccountDTO(entity.NewAccount(volume.AccountID))
+	account.IsAnonymous = true
+	return account, nil
+}
+
 func (u *authorizationUsecase) authorizeByCredential(ctx context.Context, credential string) (*dto.AccountDTO, error) {
 	account, err := u.accountRepo.FindOneByCredential(ctx, credential)
 	if err != nil {
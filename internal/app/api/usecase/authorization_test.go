@@ -45,23 +45,36 @@ func TestAuthorization_Authorize(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
+	dropVolume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: ownerAccount.ID,
+		Name:      "name",
+		IsPublic:  false,
+		Drop:      &entity.VolumeDrop{Prefix: "inbox"},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
 	tests := []struct {
-		name               string
-		inputCredential    string
-		inputVolumeName    string
-		inputKey           string
-		inputMethod        string
-		expectResult       *dto.AccountDTO
-		expectError        error
-		setMockAccountRepo func(*mockRepository.MockAccountRepository)
-		setMockVolumeRepo  func(*mockRepository.MockVolumeRepository)
+		name                 string
+		inputCredential      string
+		inputOwnerID         uuid.UUID
+		inputVolumeName      string
+		inputKey             string
+		inputOperation       string
+		expectResult         *dto.AccountDTO
+		expectError          error
+		setMockAccountRepo   func(*mockRepository.MockAccountRepository)
+		setMockVolumeRepo    func(*mockRepository.MockVolumeRepository)
+		setMockRateLimitRepo func(*mockRepository.MockRateLimitRepository)
 	}{
 		{
 			name:            "not get entry",
 			inputCredential: "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:    uuid.Nil,
 			inputVolumeName: "",
 			inputKey:        "",
-			inputMethod:     "",
+			inputOperation:  "",
 			expectResult:    accountDTO,
 			expectError:     nil,
 			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
@@ -70,31 +83,35 @@ func TestAuthorization_Authorize(t *testing.T) {
 					Return(ownerAccount, nil).
 					Times(1)
 			},
-			setMockVolumeRepo: func(*mockRepository.MockVolumeRepository) {},
+			setMockVolumeRepo:    func(*mockRepository.MockVolumeRepository) {},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 		{
 			name:               "get public volume entry",
 			inputCredential:    "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:       ownerAccount.ID,
 			inputVolumeName:    "name",
 			inputKey:           "key/sample.txt",
-			inputMethod:        "GET",
-			expectResult:       accountDTO,
+			inputOperation:     usecase.OperationGetEntry,
+			expectResult:       &dto.AccountDTO{ID: ownerAccount.ID, IsAnonymous: true},
 			expectError:        nil,
 			setMockAccountRepo: func(*mockRepository.MockAccountRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
 					Return(publicVolume, nil).
 					Times(1)
 			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 		{
 			name:            "get private volume entry",
 			inputCredential: "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:    ownerAccount.ID,
 			inputVolumeName: "name",
 			inputKey:        "key/sample.txt",
-			inputMethod:     "GET",
+			inputOperation:  usecase.OperationGetEntry,
 			expectResult:    accountDTO,
 			expectError:     nil,
 			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
@@ -106,17 +123,19 @@ func TestAuthorization_Authorize(t *testing.T) {
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
 					Return(privateVolume, nil).
 					Times(1)
 			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 		{
 			name:            "unauthorized when get entry",
 			inputCredential: "",
+			inputOwnerID:    ownerAccount.ID,
 			inputVolumeName: "name",
 			inputKey:        "key/sample.txt",
-			inputMethod:     "GET",
+			inputOperation:  usecase.OperationGetEntry,
 			expectResult:    nil,
 			expectError:     usecase.ErrForbidden,
 			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
@@ -128,17 +147,19 @@ func TestAuthorization_Authorize(t *testing.T) {
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
 					Return(privateVolume, nil).
 					Times(1)
 			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 		{
 			name:            "authorized account is not owner",
 			inputCredential: "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:    ownerAccount.ID,
 			inputVolumeName: "name",
 			inputKey:        "key/sample.txt",
-			inputMethod:     "GET",
+			inputOperation:  usecase.OperationGetEntry,
 			expectResult:    nil,
 			expectError:     usecase.ErrForbidden,
 			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
@@ -150,17 +171,19 @@ func TestAuthorization_Authorize(t *testing.T) {
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
 					Return(privateVolume, nil).
 					Times(1)
 			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 		{
 			name:            "authorize error",
 			inputCredential: "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:    uuid.Nil,
 			inputVolumeName: "",
 			inputKey:        "",
-			inputMethod:     "",
+			inputOperation:  "",
 			expectResult:    nil,
 			expectError:     http.ErrServerClosed,
 			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
@@ -169,24 +192,223 @@ func TestAuthorization_Authorize(t *testing.T) {
 					Return(nil, http.ErrServerClosed).
 					Times(1)
 			},
-			setMockVolumeRepo: func(*mockRepository.MockVolumeRepository) {},
+			setMockVolumeRepo:    func(*mockRepository.MockVolumeRepository) {},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 		{
 			name:               "find volume error",
 			inputCredential:    "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:       ownerAccount.ID,
 			inputVolumeName:    "name",
 			inputKey:           "key/sample.txt",
-			inputMethod:        "GET",
+			inputOperation:     usecase.OperationGetEntry,
 			expectResult:       nil,
 			expectError:        sql.ErrConnDone,
 			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
-					FindOneByName(gomock.Any(), gomock.Any()).
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
+		},
+		{
+			name:            "volume name without owner",
+			inputCredential: "",
+			inputOwnerID:    uuid.Nil,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			inputOperation:  usecase.OperationGetEntry,
+			expectResult:    nil,
+			expectError:     repository.ErrUnauthorized,
+			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
+				accountRepo.EXPECT().
+					FindOneByCredential(gomock.Any(), "").
+					Return(nil, repository.ErrUnauthorized).
+					Times(1)
+			},
+			setMockVolumeRepo:    func(*mockRepository.MockVolumeRepository) {},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
+		},
+		{
+			name:            "owner operates own volume",
+			inputCredential: "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:    ownerAccount.ID,
+			inputVolumeName: "name",
+			inputKey:        "",
+			inputOperation:  usecase.OperationCreateEntry,
+			expectResult:    accountDTO,
+			expectError:     nil,
+			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
+				accountRepo.EXPECT().
+					FindOneByCredential(gomock.Any(), gomock.Any()).
+					Return(ownerAccount, nil).
+					Times(1)
+			},
+			setMockVolumeRepo:    func(*mockRepository.MockVolumeRepository) {},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
+		},
+		{
+			name:            "other account operates owner volume",
+			inputCredential: "Session: YNDNun_KFu1uFmS691yJ6eqJ9eczRVKn",
+			inputOwnerID:    ownerAccount.ID,
+			inputVolumeName: "name",
+			inputKey:        "",
+			inputOperation:  usecase.OperationCreateEntry,
+			expectResult:    nil,
+			expectError:     usecase.ErrForbidden,
+			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
+				accountRepo.EXPECT().
+					FindOneByCredential(gomock.Any(), gomock.Any()).
+					Return(otherAccount, nil).
+					Times(1)
+			},
+			setMockVolumeRepo:    func(*mockRepository.MockVolumeRepository) {},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
+		},
+		{
+			name:               "drop entry",
+			inputCredential:    "",
+			inputOwnerID:       ownerAccount.ID,
+			inputVolumeName:    "name",
+			inputKey:           "",
+			inputOperation:     usecase.OperationCreateEntry,
+			expectResult:       &dto.AccountDTO{ID: ownerAccount.ID, IsAnonymous: true},
+			expectError:        nil,
+			setMockAccountRepo: func(*mockRepository.MockAccountRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
+					Return(dropVolume, nil).
+					Times(1)
+			},
+			setMockRateLimitRepo: func(rateLimitRepo *mockRepository.MockRateLimitRepository) {
+				rateLimitRepo.
+					EXPECT().
+					Allow(gomock.Any(), "192.0.2.1").
+					Return(true, nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "drop rate limit exceeded",
+			inputCredential:    "",
+			inputOwnerID:       ownerAccount.ID,
+			inputVolumeName:    "name",
+			inputKey:           "",
+			inputOperation:     usecase.OperationCreateEntry,
+			expectResult:       nil,
+			expectError:        usecase.ErrTooManyRequests,
+			setMockAccountRepo: func(*mockRepository.MockAccountRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
+					Return(dropVolume, nil).
+					Times(1)
+			},
+			setMockRateLimitRepo: func(rateLimitRepo *mockRepository.MockRateLimitRepository) {
+				rateLimitRepo.
+					EXPECT().
+					Allow(gomock.Any(), "192.0.2.1").
+					Return(false, nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "drop rate limit error",
+			inputCredential:    "",
+			inputOwnerID:       ownerAccount.ID,
+			inputVolumeName:    "name",
+			inputKey:           "",
+			inputOperation:     usecase.OperationCreateEntry,
+			expectResult:       nil,
+			expectError:        sql.ErrConnDone,
+			setMockAccountRepo: func(*mockRepository.MockAccountRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
+					Return(dropVolume, nil).
+					Times(1)
+			},
+			setMockRateLimitRepo: func(rateLimitRepo *mockRepository.MockRateLimitRepository) {
+				rateLimitRepo.
+					EXPECT().
+					Allow(gomock.Any(), "192.0.2.1").
+					Return(false, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "drop into not droppable volume",
+			inputCredential: "",
+			inputOwnerID:    ownerAccount.ID,
+			inputVolumeName: "name",
+			inputKey:        "",
+			inputOperation:  usecase.OperationCreateEntry,
+			expectResult:    nil,
+			expectError:     repository.ErrUnauthorized,
+			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
+				accountRepo.EXPECT().
+					FindOneByCredential(gomock.Any(), "").
+					Return(nil, repository.ErrUnauthorized).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
+					Return(privateVolume, nil).
+					Times(1)
+			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
+		},
+		{
+			name:            "drop into not found volume",
+			inputCredential: "",
+			inputOwnerID:    ownerAccount.ID,
+			inputVolumeName: "name",
+			inputKey:        "",
+			inputOperation:  usecase.OperationCreateEntry,
+			expectResult:    nil,
+			expectError:     repository.ErrUnauthorized,
+			setMockAccountRepo: func(accountRepo *mockRepository.MockAccountRepository) {
+				accountRepo.EXPECT().
+					FindOneByCredential(gomock.Any(), "").
+					Return(nil, repository.ErrUnauthorized).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
+		},
+		{
+			name:               "find volume error when drop",
+			inputCredential:    "",
+			inputOwnerID:       ownerAccount.ID,
+			inputVolumeName:    "name",
+			inputKey:           "",
+			inputOperation:     usecase.OperationCreateEntry,
+			expectResult:       nil,
+			expectError:        sql.ErrConnDone,
+			setMockAccountRepo: func(*mockRepository.MockAccountRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", ownerAccount.ID).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockRateLimitRepo: func(*mockRepository.MockRateLimitRepository) {},
 		},
 	}
 	for _, tt := range tests {
@@ -202,8 +424,11 @@ func TestAuthorization_Authorize(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			uc := usecase.NewAuthorizationUsecase(accountRepo, volumeRepo)
-			result, err := uc.Authorize(ctx, tt.inputCredential, tt.inputVolumeName, tt.inputKey, tt.inputMethod)
+			rateLimitRepo := mockRepository.NewMockRateLimitRepository(ctrl)
+			tt.setMockRateLimitRepo(rateLimitRepo)
+
+			uc := usecase.NewAuthorizationUsecase(accountRepo, volumeRepo, rateLimitRepo)
+			result, err := uc.Authorize(ctx, tt.inputCredential, tt.inputOwnerID, tt.inputVolumeName, tt.inputKey, tt.inputOperation, "192.0.2.1")
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -0,0 +1,123 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../test/mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
+)
+
+type ChangeUsecase interface {
+	GetLatestSequence(context.Context, uuid.UUID, string) (uint64, error)
+	GetChanges(context.Context, uuid.UUID, string, uint64, string, uint64) ([]*dto.ChangeDTO, uint64, error)
+	GetDelta(context.Context, uuid.UUID, string, string, uint64) (*dto.DeltaDTO, error)
+}
+
+type changeUsecase struct {
+	transactionObj transaction.TransactionObject
+	changeRepo     repository.ChangeRepository
+	volumeRepo     repository.VolumeRepository
+}
+
+func NewChangeUsecase(transactionObj transaction.TransactionObject, changeRepo repository.ChangeRepository, volumeRepo repository.VolumeRepository) ChangeUsecase {
+	return &changeUsecase{
+		transactionObj: transactionObj,
+		changeRepo:     changeRepo,
+		volumeRepo:     volumeRepo,
+	}
+}
+
+func (u *changeUsecase) GetLatestSequence(ctx context.Context, accountID uuid.UUID, volumeName string) (uint64, error) {
+	var sequence uint64
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		sequence, err = u.changeRepo.FindLatestSequenceByVolumeID(ctx, volume.ID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return sequence, nil
+}
+
+// NOTE: 前方一致で除外した変更も読み進めるため, 次に取得を開始する番号を合わせて返却する.
+func (u *changeUsecase) GetChanges(ctx context.Context, accountID uuid.UUID, volumeName string, sequence uint64, prefix string, limit uint64) ([]*dto.ChangeDTO, uint64, error) {
+	var changes []*entity.Change
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		changes, err = u.changeRepo.FindByVolumeIDAndSequenceGreaterThan(ctx, volume.ID, sequence, limit)
+		return err
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	matched := make([]*entity.Change, 0, len(changes))
+	for _, change := range changes {
+		if change.Matches(prefix) {
+			matched = append(matched, change)
+		}
+		sequence = change.Sequence
+	}
+
+	return mapper.ToChangeDTOs(matched), sequence, nil
+}
+
+// NOTE: トークンを省略した場合は変更履歴を返却せず, 現時点のトークンのみを返却する.
+func (u *changeUsecase) GetDelta(ctx context.Context, accountID uuid.UUID, volumeName, token string, limit uint64) (*dto.DeltaDTO, error) {
+	var changeToken *entity.ChangeToken
+	var changes []*entity.Change
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		if token == "" {
+			sequence, err := u.changeRepo.FindLatestSequenceByVolumeID(ctx, volume.ID)
+			changeToken = entity.NewChangeToken(volume.ID, sequence)
+			return err
+		}
+
+		changeToken, err = entity.ParseChangeToken(token)
+		if err != nil {
+			return err
+		}
+		if err := changeToken.Verify(volume.ID); err != nil {
+			return err
+		}
+
+		changes, err = u.changeRepo.FindByVolumeIDAndSequenceGreaterThan(ctx, volume.ID, changeToken.Sequence, limit+1)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	hasMore := limit < uint64(len(changes))
+	if hasMore {
+		changes = changes[:limit]
+	}
+	changeToken.Advance(changes)
+
+	return &dto.DeltaDTO{
+		Changes:   mapper.ToChangeDTOs(changes),
+		NextToken: changeToken.String(),
+		HasMore:   hasMore,
+	}, nil
+}
@@ -0,0 +1,532 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+)
+
+func TestChange_GetLatestSequence(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+
+	tests := []struct {
+		name                  string
+		expectResult          uint64
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockChangeRepo     func(*mockRepository.MockChangeRepository)
+	}{
+		{
+			name:         "successfully got",
+			expectResult: 5,
+			expectError:  nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindLatestSequenceByVolumeID(gomock.Any(), volume.ID).
+					Return(uint64(5), nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "volume not found",
+			expectResult: 0,
+			expectError:  repository.ErrVolumeNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+			setMockChangeRepo: func(*mockRepository.MockChangeRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			changeRepo := mockRepository.NewMockChangeRepository(ctrl)
+			tt.setMockChangeRepo(changeRepo)
+
+			uc := usecase.NewChangeUsecase(transactionObj, changeRepo, volumeRepo)
+			result, err := uc.GetLatestSequence(t.Context(), accountID, "name")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestChange_GetChanges(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	changes := []*entity.Change{
+		{VolumeID: volume.ID, Sequence: 3, EventID: uuid.New(), AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "dir/key"},
+		{VolumeID: volume.ID, Sequence: 4, EventID: uuid.New(), AccountID: accountID, Type: entity.EventTypeEntryDeleted, Key: "key"},
+	}
+
+	tests := []struct {
+		name                  string
+		inputPrefix           string
+		expectResult          []*dto.ChangeDTO
+		expectSequence        uint64
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockChangeRepo     func(*mockRepository.MockChangeRepository)
+	}{
+		{
+			name:        "successfully got",
+			inputPrefix: "",
+			expectResult: []*dto.ChangeDTO{
+				{Sequence: 3, EventID: changes[0].EventID, AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "dir/key"},
+				{Sequence: 4, EventID: changes[1].EventID, AccountID: accountID, Type: entity.EventTypeEntryDeleted, Key: "key"},
+			},
+			expectSequence: 4,
+			expectError:    nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(2), uint64(100)).
+					Return(changes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "filtered by prefix",
+			inputPrefix: "dir",
+			expectResult: []*dto.ChangeDTO{
+				{Sequence: 3, EventID: changes[0].EventID, AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "dir/key"},
+			},
+			expectSequence: 4,
+			expectError:    nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(2), uint64(100)).
+					Return(changes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "no changes",
+			inputPrefix:    "",
+			expectResult:   []*dto.ChangeDTO{},
+			expectSequence: 2,
+			expectError:    nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(2), uint64(100)).
+					Return([]*entity.Change{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "find error",
+			inputPrefix:    "",
+			expectResult:   nil,
+			expectSequence: 0,
+			expectError:    sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(2), uint64(100)).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			changeRepo := mockRepository.NewMockChangeRepository(ctrl)
+			tt.setMockChangeRepo(changeRepo)
+
+			uc := usecase.NewChangeUsecase(transactionObj, changeRepo, volumeRepo)
+			result, sequence, err := uc.GetChanges(t.Context(), accountID, "name", 2, tt.inputPrefix, 100)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+			if sequence != tt.expectSequence {
+				t.Errorf("\nexpect: %d\ngot: %d", tt.expectSequence, sequence)
+			}
+		})
+	}
+}
+
+func TestChange_GetDelta(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	changes := []*entity.Change{
+		{VolumeID: volume.ID, Sequence: 3, EventID: uuid.New(), AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "key"},
+		{VolumeID: volume.ID, Sequence: 4, EventID: uuid.New(), AccountID: accountID, Type: entity.EventTypeEntryDeleted, Key: "key"},
+		{VolumeID: volume.ID, Sequence: 5, EventID: uuid.New(), AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "other"},
+	}
+
+	tests := []struct {
+		name                  string
+		inputToken            string
+		expectResult          *dto.DeltaDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockChangeRepo     func(*mockRepository.MockChangeRepository)
+	}{
+		{
+			name:       "has more",
+			inputToken: entity.NewChangeToken(volume.ID, 2).String(),
+			expectResult: &dto.DeltaDTO{
+				Changes: []*dto.ChangeDTO{
+					{Sequence: 3, EventID: changes[0].EventID, AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "key"},
+					{Sequence: 4, EventID: changes[1].EventID, AccountID: accountID, Type: entity.EventTypeEntryDeleted, Key: "key"},
+				},
+				NextToken: entity.NewChangeToken(volume.ID, 4).String(),
+				HasMore:   true,
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(2), uint64(3)).
+					Return(changes, nil).
+					Times(1)
+			},
+		},
+		{
+			name:       "no more",
+			inputToken: entity.NewChangeToken(volume.ID, 3).String(),
+			expectResult: &dto.DeltaDTO{
+				Changes: []*dto.ChangeDTO{
+					{Sequence: 4, EventID: changes[1].EventID, AccountID: accountID, Type: entity.EventTypeEntryDeleted, Key: "key"},
+					{Sequence: 5, EventID: changes[2].EventID, AccountID: accountID, Type: entity.EventTypeEntryCreated, Key: "other"},
+				},
+				NextToken: entity.NewChangeToken(volume.ID, 5).String(),
+				HasMore:   false,
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(3), uint64(3)).
+					Return(changes[1:], nil).
+					Times(1)
+			},
+		},
+		{
+			name:       "without token",
+			inputToken: "",
+			expectResult: &dto.DeltaDTO{
+				Changes:   []*dto.ChangeDTO{},
+				NextToken: entity.NewChangeToken(volume.ID, 5).String(),
+				HasMore:   false,
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindLatestSequenceByVolumeID(gomock.Any(), volume.ID).
+					Return(uint64(5), nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "token of other volume",
+			inputToken:   entity.NewChangeToken(uuid.New(), 3).String(),
+			expectResult: nil,
+			expectError:  entity.ErrInvalidChangeToken,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(*mockRepository.MockChangeRepository) {},
+		},
+		{
+			name:         "invalid token",
+			inputToken:   "invalid",
+			expectResult: nil,
+			expectError:  entity.ErrInvalidChangeToken,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(*mockRepository.MockChangeRepository) {},
+		},
+		{
+			name:         "volume not found",
+			inputToken:   entity.NewChangeToken(volume.ID, 3).String(),
+			expectResult: nil,
+			expectError:  repository.ErrVolumeNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+			setMockChangeRepo: func(*mockRepository.MockChangeRepository) {},
+		},
+		{
+			name:         "find error",
+			inputToken:   entity.NewChangeToken(volume.ID, 3).String(),
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockChangeRepo: func(changeRepo *mockRepository.MockChangeRepository) {
+				changeRepo.
+					EXPECT().
+					FindByVolumeIDAndSequenceGreaterThan(gomock.Any(), volume.ID, uint64(3), uint64(3)).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			changeRepo := mockRepository.NewMockChangeRepository(ctrl)
+			tt.setMockChangeRepo(changeRepo)
+
+			uc := usecase.NewChangeUsecase(transactionObj, changeRepo, volumeRepo)
+			result, err := uc.GetDelta(t.Context(), accountID, "name", tt.inputToken, 2)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
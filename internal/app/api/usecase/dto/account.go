@@ -2,6 +2,8 @@ package dto
 
 import "github.com/google/uuid"
 
+// NOTE: 公開ボリュームの取得では認証情報を検証しないため, 所有者のアカウントを匿名として返却する.
 type AccountDTO struct {
-	ID uuid.UUID
+	ID          uuid.UUID
+	IsAnonymous bool
 }
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogDTO struct {
+	ID             uint64
+	RequestID      string
+	OwnerID        uuid.UUID
+	ActorID        uuid.UUID
+	CredentialType string
+	Principal      string
+	ClientIP       string
+	UserAgent      string
+	Operation      string
+	VolumeName     string
+	Key            string
+	NewVolumeName  string
+	NewKey         string
+	Status         uint64
+	CreatedAt      time.Time
+}
+
+type AuditLogConditionDTO struct {
+	ActorID    *uuid.UUID
+	VolumeName string
+	Operation  string
+	ClientIP   string
+	Principal  string
+	Since      *time.Time
+	Until      *time.Time
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ChangeDTO struct {
+	Sequence  uint64
+	EventID   uuid.UUID
+	AccountID uuid.UUID
+	ActorID   uuid.UUID
+	Type      string
+	Key       string
+	NewKey    string
+	Size      uint64
+	CreatedAt time.Time
+}
+
+type DeltaDTO struct {
+	Changes   []*ChangeDTO
+	NextToken string
+	HasMore   bool
+}
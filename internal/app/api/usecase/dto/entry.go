@@ -13,6 +13,64 @@ type EntryDTO struct {
 	Key       string
 	Size      uint64
 	Type      string
+	Encoding  string
+	Metadata  *EntryMetadataDTO
+	Scan      *EntryScanDTO
+	Snippet   string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+type EntryScanDTO struct {
+	Status    string
+	Signature string
+	ScannedAt time.Time
+}
+
+type EntryMetadataDTO struct {
+	Width       uint64
+	Height      uint64
+	TakenAt     *time.Time
+	CameraMake  string
+	CameraModel string
+	Latitude    *float64
+	Longitude   *float64
+	PageCount   uint64
+	Duration    uint64
+	Title       string
+	Artist      string
+	Album       string
+}
+
+type EntryConditionDTO struct {
+	TakenFrom *time.Time
+	TakenTo   *time.Time
+	Camera    string
+	Query     string
+}
+
+type ThumbnailDTO struct {
+	Type      string
+	ETag      string
+	UpdatedAt time.Time
+}
+
+type EntryResultDTO struct {
+	Key    string
+	Result string
+}
+
+type EntryOperationDTO struct {
+	Type          string
+	Key           string
+	NewVolumeName string
+	NewKey        string
+	Conflict      string
+}
+
+type EntryOperationResultDTO struct {
+	Type    string
+	Entry   *EntryDTO
+	Results []*EntryResultDTO
+	Error   error
+}
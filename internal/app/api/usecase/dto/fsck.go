@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/google/uuid"
+
+type FsckReportDTO struct {
+	AccountID  uuid.UUID
+	VolumeName string
+	Issues     []*FsckIssueDTO
+}
+
+type FsckIssueDTO struct {
+	Category string
+	Key      string
+	Expected string
+	Actual   string
+	Repaired bool
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImageTransformationDTO struct {
+	Width      uint64
+	Height     uint64
+	Fit        string
+	CropX      uint64
+	CropY      uint64
+	CropWidth  uint64
+	CropHeight uint64
+	Quality    uint64
+	Format     string
+}
+
+type ImagePresetDTO struct {
+	ID             uuid.UUID
+	AccountID      uuid.UUID
+	VolumeID       uuid.UUID
+	Name           string
+	Transformation *ImageTransformationDTO
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type ImageDTO struct {
+	Type      string
+	ETag      string
+	UpdatedAt time.Time
+}
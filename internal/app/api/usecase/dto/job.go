@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobDTO struct {
+	ID               uuid.UUID
+	AccountID        uuid.UUID
+	Type             string
+	Status           string
+	VolumeName       string
+	Key              string
+	NewVolumeName    string
+	NewKey           string
+	Conflict         string
+	ResultKey        string
+	Error            string
+	TotalEntries     uint64
+	ProcessedEntries uint64
+	TotalBytes       uint64
+	ProcessedBytes   uint64
+	Attempts         uint64
+	RunAt            time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
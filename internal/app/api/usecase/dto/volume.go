@@ -7,10 +7,29 @@ import (
 )
 
 type VolumeDTO struct {
-	ID        uuid.UUID
-	AccountID uuid.UUID
-	Name      string
-	IsPublic  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Name        string
+	IsPublic    bool
+	Compression string
+	Policy      *VolumePolicyDTO
+	Drop        *VolumeDropDTO
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type VolumePolicyDTO struct {
+	MaxFileSize         uint64
+	AllowedTypes        []string
+	DeniedTypes         []string
+	AllowedExtensions   []string
+	DeniedExtensions    []string
+	MaxKeyDepth         uint64
+	MaxEntriesPerFolder uint64
+	MaxVolumeSize       uint64
+	MaxVolumeEntries    uint64
+}
+
+type VolumeDropDTO struct {
+	Prefix string
 }
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDTO struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	VolumeID  uuid.UUID
+	URL       string
+	Secret    string
+	Events    []string
+	Prefix    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type WebhookDeliveryDTO struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Status         string
+	ResponseStatus uint64
+	Error          string
+	Attempts       uint64
+	RunAt          time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -4,8 +4,12 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
-	"net/http"
+	"log"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -13,45 +17,196 @@ import (
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/service"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/contenttype"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/fulltext"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/metadata"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/progress"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/thumbnail"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
 )
 
+const folderType = "folder"
+
+const maxEntryContentMatches = 1000
+
+const maxReportedScanFailures = 10
+
+// NOTE: 拒否する場合はアップロードを失敗させ, 隔離する場合は保存したままボディの取得を禁止する.
+const (
+	ScanActionReject     = "reject"
+	ScanActionQuarantine = "quarantine"
+)
+
+const (
+	EntryOperationCreateFolder = "create_folder"
+	EntryOperationDelete       = "delete"
+	EntryOperationMove         = "move"
+	EntryOperationCopy         = "copy"
+)
+
+var (
+	ErrInvalidEntryOperation     = status.Error(code.UnprocessableContent, "entry operation is not supported")
+	ErrEntryOperationRolledBack  = status.Error(code.FailedDependency, "operation was rolled back because another operation failed")
+	ErrEntryOperationNotExecuted = status.Error(code.FailedDependency, "operation was not executed because another operation failed")
+	ErrEntryInfected             = status.Error(code.MalwareDetected, "entry is infected")
+	ErrScannerNotConfigured      = status.Error(code.FailedDependency, "malware scanner is not configured")
+)
+
 type EntryUsecase interface {
-	Create(context.Context, uuid.UUID, string, string, uint64, io.Reader) (*dto.EntryDTO, error)
-	Update(context.Context, uuid.UUID, string, string, string) (*dto.EntryDTO, error)
+	Create(context.Context, uuid.UUID, string, string, uint64, string, io.Reader) (*dto.EntryDTO, error)
+	Drop(context.Context, uuid.UUID, string, string, uint64, string, io.Reader) (*dto.EntryDTO, error)
+	Update(context.Context, uuid.UUID, string, string, string, string, string) (*dto.EntryDTO, []*dto.EntryResultDTO, error)
 	Delete(context.Context, uuid.UUID, string, string) error
-	Copy(context.Context, uuid.UUID, string, string) (*dto.EntryDTO, error)
+	Copy(context.Context, uuid.UUID, string, string, string, string, string) (*dto.EntryDTO, []*dto.EntryResultDTO, error)
+	Batch(context.Context, uuid.UUID, string, bool, []*dto.EntryOperationDTO) ([]*dto.EntryOperationResultDTO, error)
 	GetMeta(context.Context, uuid.UUID, string, string) (*dto.EntryDTO, error)
-	GetOne(context.Context, uuid.UUID, string, string) (*dto.EntryDTO, io.ReadCloser, error)
-	Search(context.Context, uuid.UUID, string, *string, *uint64) ([]*dto.EntryDTO, error)
+	GetOne(context.Context, uuid.UUID, string, string, []string) (*dto.EntryDTO, io.ReadCloser, error)
+	GetThumbnail(context.Context, uuid.UUID, string, string, uint64, uint64) (*dto.ThumbnailDTO, io.ReadCloser, error)
+	Search(context.Context, uuid.UUID, string, *string, *uint64, *dto.EntryConditionDTO) ([]*dto.EntryDTO, error)
+	Scan(context.Context, uuid.UUID, string, string) error
+	ValidateSize(context.Context, uuid.UUID, string, uint64) error
 }
 
 type entryUsecase struct {
-	transactionObj transaction.TransactionObject
-	entryRepo      repository.EntryRepository
-	bodyRepo       repository.BodyRepository
-	volumeRepo     repository.VolumeRepository
-	entryServ      service.EntryService
+	transactionObj    transaction.TransactionObject
+	entryRepo         repository.EntryRepository
+	entryMetadataRepo repository.EntryMetadataRepository
+	entryContentRepo  repository.EntryContentRepository
+	bodyRepo          repository.BodyRepository
+	volumeRepo        repository.VolumeRepository
+	scannerRepo       repository.ScannerRepository
+	entryServ         service.EntryService
+	eventServ         service.EventService
+	scanAction        string
 }
 
 func NewEntryUsecase(
 	transactionObj transaction.TransactionObject,
 	entryRepo repository.EntryRepository,
+	entryMetadataRepo repository.EntryMetadataRepository,
+	entryContentRepo repository.EntryContentRepository,
 	bodyRepo repository.BodyRepository,
 	volumeRepo repository.VolumeRepository,
+	scannerRepo repository.ScannerRepository,
 	entryServ service.EntryService,
+	eventServ service.EventService,
+	scanAction string,
 ) EntryUsecase {
 	return &entryUsecase{
-		transactionObj: transactionObj,
-		entryRepo:      entryRepo,
-		bodyRepo:       bodyRepo,
-		volumeRepo:     volumeRepo,
-		entryServ:      entryServ,
+		transactionObj:    transactionObj,
+		entryRepo:         entryRepo,
+		entryMetadataRepo: entryMetadataRepo,
+		entryContentRepo:  entryContentRepo,
+		bodyRepo:          bodyRepo,
+		volumeRepo:        volumeRepo,
+		scannerRepo:       scannerRepo,
+		entryServ:         entryServ,
+		eventServ:         eventServ,
+		scanAction:        scanAction,
+	}
+}
+
+func (u *entryUsecase) Create(ctx context.Context, accountID uuid.UUID, volumeName, key string, size uint64, declaredType string, body io.Reader) (*dto.EntryDTO, error) {
+	var entry *entity.Entry
+	var entryMetadata *entity.EntryMetadata
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		entry, entryMetadata, err = u.runCreate(ctx, accountID, volumeName, key, size, declaredType, body)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEntryDTOWithMetadata(entry, entryMetadata), nil
+}
+
+func (u *entryUsecase) Drop(ctx context.Context, accountID uuid.UUID, volumeName, key string, size uint64, declaredType string, body io.Reader) (*dto.EntryDTO, error) {
+	var entry *entity.Entry
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		entry, _, err = u.runDrop(ctx, accountID, volumeName, key, size, declaredType, body)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEntryDTO(entry), nil
+}
+
+func (u *entryUsecase) Update(ctx context.Context, accountID uuid.UUID, volumeName, key, newVolumeName, newKey, conflict string) (*dto.EntryDTO, []*dto.EntryResultDTO, error) {
+	var entry *entity.Entry
+	var results []*dto.EntryResultDTO
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		entry, results, err = u.runUpdate(ctx, accountID, volumeName, key, newVolumeName, newKey, conflict)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return mapper.ToEntryDTO(entry), results, nil
+}
+
+func (u *entryUsecase) Delete(ctx context.Context, accountID uuid.UUID, volumeName, key string) error {
+	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		return u.runDelete(ctx, accountID, volumeName, key)
+	})
+}
+
+func (u *entryUsecase) Copy(ctx context.Context, accountID uuid.UUID, volumeName, key, newVolumeName, newKey, conflict string) (*dto.EntryDTO, []*dto.EntryResultDTO, error) {
+	var entry *entity.Entry
+	var results []*dto.EntryResultDTO
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		entry, results, err = u.runCopy(ctx, accountID, volumeName, key, newVolumeName, newKey, conflict)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return mapper.ToEntryDTO(entry), results, nil
+}
+
+// NOTE: 一括で実行する場合は全体を1つのトランザクションとし, 失敗した操作以外には実行されなかった理由を返却する.
+func (u *entryUsecase) Batch(ctx context.Context, accountID uuid.UUID, volumeName string, atomic bool, operations []*dto.EntryOperationDTO) ([]*dto.EntryOperationResultDTO, error) {
+	if !atomic {
+		results := make([]*dto.EntryOperationResultDTO, len(operations))
+		for i, operation := range operations {
+			results[i] = u.runOperationInTransaction(ctx, accountID, volumeName, operation)
+		}
+		return results, nil
+	}
+
+	results := make([]*dto.EntryOperationResultDTO, 0, len(operations))
+	var operationErr error
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		for _, operation := range operations {
+			result := u.runOperation(ctx, accountID, volumeName, operation)
+			results = append(results, result)
+			if result.Error != nil {
+				operationErr = result.Error
+				return operationErr
+			}
+		}
+		return nil
+	}); err != nil {
+		if operationErr == nil {
+			return nil, err
+		}
+		return abortOperations(operations, results), nil
 	}
+
+	return results, nil
 }
 
-func (u *entryUsecase) Create(ctx context.Context, accountID uuid.UUID, volumeName, key string, size uint64, body io.Reader) (*dto.EntryDTO, error) {
+func (u *entryUsecase) GetMeta(ctx context.Context, accountID uuid.UUID, volumeName, key string) (*dto.EntryDTO, error) {
 	var entry *entity.Entry
 
 	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
@@ -60,38 +215,98 @@ func (u *entryUsecase) Create(ctx context.Context, accountID uuid.UUID, volumeNa
 			return err
 		}
 
-		entryType, bodyReader, err := u.getBodyInfo(body)
+		entry, err = u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
 		if err != nil {
 			return err
 		}
 
-		entry, err = entity.NewEntry(accountID, volume.ID, key, size, entryType)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToEntryDTO(entry), nil
+}
+
+// NOTE: 受け入れる圧縮方式に保存時の圧縮方式が含まれる場合は展開せずに返却する.
+func (u *entryUsecase) GetOne(ctx context.Context, accountID uuid.UUID, volumeName, key string, acceptEncodings []string) (*dto.EntryDTO, io.ReadCloser, error) {
+	var entry *entity.Entry
+	var body io.ReadCloser
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) (err error) {
+		var path string
+		entry, path, err = u.findReadableEntry(ctx, accountID, volumeName, key)
 		if err != nil {
 			return err
 		}
 
-		if err := u.entryServ.Exists(ctx, entry); err != nil {
-			return err
-		}
-		if err := u.entryServ.CreateAncestors(ctx, entry); err != nil {
-			return err
+		attributes := entry.BodyAttributes()
+		if entry.Encoding != "" && slices.Contains(acceptEncodings, entry.Encoding) {
+			attributes.ContentEncoding = entry.Encoding
 		}
+		body, err = u.bodyRepo.FindOneByPath(ctx, path, attributes)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
 
-		if err := u.entryRepo.Create(ctx, entry); err != nil {
-			return err
-		}
+	return mapper.ToEntryDTO(entry), body, nil
+}
 
-		path := volume.Name + "/" + entry.Key
-		return u.bodyRepo.Create(path, bodyReader)
+// NOTE: 派生コンテンツの名前に更新日時を含め, 無効化と並行して生成された古いサムネイルを返却しない.
+func (u *entryUsecase) GetThumbnail(ctx context.Context, accountID uuid.UUID, volumeName, key string, width, height uint64) (*dto.ThumbnailDTO, io.ReadCloser, error) {
+	if err := thumbnail.ValidateSize(width, height); err != nil {
+		return nil, nil, err
+	}
+
+	var entry *entity.Entry
+	var path string
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) (err error) {
+		entry, path, err = u.findReadableEntry(ctx, accountID, volumeName, key)
+		return err
 	}); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return mapper.ToEntryDTO(entry), nil
+	if !thumbnail.Supports(entry.Type) {
+		return nil, nil, thumbnail.ErrUnsupportedImage
+	}
+
+	thumbnailDTO := &dto.ThumbnailDTO{Type: thumbnail.OutputType(entry.Type), ETag: entry.ETag(), UpdatedAt: entry.UpdatedAt}
+	name := fmt.Sprintf("thumbnail:%dx%d:%s", width, height, entry.ETag())
+
+	cached, err := u.bodyRepo.FindOneDerived(ctx, path, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cached != nil {
+		return thumbnailDTO, cached, nil
+	}
+
+	data, err := u.generateThumbnail(ctx, entry, path, width, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.bodyRepo.CreateDerived(ctx, path, name, bytes.NewReader(data)); err != nil {
+		return nil, nil, err
+	}
+	return thumbnailDTO, io.NopCloser(bytes.NewReader(data)), nil
 }
 
-func (u *entryUsecase) Update(ctx context.Context, accountID uuid.UUID, volumeName, key, newKey string) (*dto.EntryDTO, error) {
-	var entry *entity.Entry
+func (u *entryUsecase) Search(ctx context.Context, accountID uuid.UUID, volumeName string, prefix *string, depth *uint64, condition *dto.EntryConditionDTO) ([]*dto.EntryDTO, error) {
+	if condition == nil {
+		condition = &dto.EntryConditionDTO{}
+	}
+
+	terms, err := parseTerms(condition.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entity.Entry
+	var entryMetadata []*entity.EntryMetadata
+	var matches []*entity.EntryContentMatch
 
 	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
 		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
@@ -99,178 +314,886 @@ func (u *entryUsecase) Update(ctx context.Context, accountID uuid.UUID, volumeNa
 			return err
 		}
 
-		entry, err = u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+		entries, err = u.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, accountID, prefix, depth, toEntryCondition(condition))
 		if err != nil {
 			return err
 		}
 
-		if err := entry.SetKey(newKey); err != nil {
+		entryMetadata, err = u.entryMetadataRepo.FindByEntryIDs(ctx, fileIDs(entries))
+		if err != nil {
 			return err
 		}
 
-		if err := u.entryServ.Exists(ctx, entry); err != nil {
+		matches, err = u.searchContents(ctx, volume, accountID, terms)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var snippets map[uuid.UUID]string
+	if terms != nil {
+		entries, snippets = rankEntries(entries, matches)
+	}
+	return toEntryDTOs(entries, entryMetadata, snippets), nil
+}
+
+// NOTE: 再スキャンで検出したエントリーは設定に関わらず隔離し, 失敗したエントリーがあっても残りのエントリーのスキャンを続ける.
+func (u *entryUsecase) Scan(ctx context.Context, accountID uuid.UUID, volumeName, key string) error {
+	if u.scannerRepo == nil {
+		return ErrScannerNotConfigured
+	}
+
+	var volume *entity.Volume
+	var entries []*entity.Entry
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		volume, err = u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
 			return err
 		}
-		if err := u.entryServ.CreateAncestors(ctx, entry); err != nil {
-			return err
+
+		entries, err = u.findScanTargets(ctx, volume, accountID, key)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	var failedKeys []string
+	for _, entry := range entries {
+		if err := u.rescan(ctx, volume, entry); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Println(err.Error())
+			failedKeys = append(failedKeys, entry.Key)
 		}
-		if err := u.entryServ.UpdateDescendants(ctx, entry, key); err != nil {
-			return err
+		progress.Add(ctx, 1, 0)
+	}
+	return newScanFailedError(failedKeys)
+}
+
+func (u *entryUsecase) ValidateSize(ctx context.Context, accountID uuid.UUID, volumeName string, size uint64) error {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return err
+	}
+	return volume.ValidateEntrySize(size)
+}
+
+func (u *entryUsecase) runCreate(ctx context.Context, accountID uuid.UUID, volumeName, key string, size uint64, declaredType string, body io.Reader) (*entity.Entry, *entity.EntryMetadata, error) {
+	volume, entry, bodyReader, err := u.buildEntry(ctx, accountID, volumeName, key, size, declaredType, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.entryServ.Exists(ctx, entry); err != nil {
+		return nil, nil, err
+	}
+
+	return u.createEntry(ctx, volume, entry, bodyReader, entity.EventTypeEntryCreated)
+}
+
+// NOTE: 匿名の投稿は既存のエントリーを上書きせず, 競合する場合はキーを変更する.
+func (u *entryUsecase) runDrop(ctx context.Context, accountID uuid.UUID, volumeName, key string, size uint64, declaredType string, body io.Reader) (*entity.Entry, *entity.EntryMetadata, error) {
+	volume, entry, bodyReader, err := u.buildEntry(ctx, accountID, volumeName, key, size, declaredType, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := volume.ValidateDrop(entry); err != nil {
+		return nil, nil, err
+	}
+
+	if _, _, err := u.entryServ.Resolve(ctx, entry, "", uuid.Nil, service.ConflictPolicyRename); err != nil {
+		return nil, nil, err
+	}
+
+	return u.createEntry(ctx, volume, entry, bodyReader, entity.EventTypeEntryDropped)
+}
+
+func (u *entryUsecase) buildEntry(ctx context.Context, accountID uuid.UUID, volumeName, key string, size uint64, declaredType string, body io.Reader) (*entity.Volume, *entity.Entry, io.Reader, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	entryType, bodyReader, err := u.getBodyInfo(key, declaredType, body)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	entry, err := newEntry(accountID, volume, key, size, entryType)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return volume, entry, bodyReader, nil
+}
+
+func (u *entryUsecase) createEntry(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body io.Reader, eventType string) (*entity.Entry, *entity.EntryMetadata, error) {
+	if err := u.entryServ.CreateAncestors(ctx, entry, volume); err != nil {
+		return nil, nil, err
+	}
+	if err := u.validateEntryCount(ctx, volume, entry); err != nil {
+		return nil, nil, err
+	}
+	if err := u.validateUsage(ctx, volume, entry.Size, 1); err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.entryRepo.Create(ctx, entry); err != nil {
+		return nil, nil, err
+	}
+
+	entryMetadata, err := u.storeBody(ctx, volume, entry, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := u.publish(ctx, eventType, volume, entry.Key, entry, nil); err != nil {
+		return nil, nil, err
+	}
+	return entry, entryMetadata, nil
+}
+
+func (u *entryUsecase) runUpdate(ctx context.Context, accountID uuid.UUID, volumeName, key, newVolumeName, newKey, conflict string) (*entity.Entry, []*dto.EntryResultDTO, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	dstVolume, err := u.findDestinationVolume(ctx, accountID, volume, newVolumeName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if conflict == "" {
+		conflict = service.ConflictPolicyFail
+	}
+
+	entry, results, err := u.moveEntry(ctx, entry, volume, dstVolume, newKey, conflict)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if results[0].Result != service.EntryResultSkipped {
+		if err := u.publish(ctx, entity.EventTypeEntryRenamed, volume, key, entry, dstVolume); err != nil {
+			return nil, nil, err
 		}
+	}
+	return entry, results, nil
+}
 
-		if err := u.entryRepo.Update(ctx, entry); err != nil {
-			return err
+func (u *entryUsecase) runDelete(ctx context.Context, accountID uuid.UUID, volumeName, key string) error {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return err
+	}
+
+	entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.remove(ctx, volume, entry); err != nil {
+		return err
+	}
+
+	return u.publish(ctx, entity.EventTypeEntryDeleted, volume, entry.Key, entry, nil)
+}
+
+func (u *entryUsecase) runCopy(ctx context.Context, accountID uuid.UUID, volumeName, key, newVolumeName, newKey, conflict string) (*entity.Entry, []*dto.EntryResultDTO, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	dstVolume, err := u.findDestinationVolume(ctx, accountID, volume, newVolumeName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	srcEntry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// NOTE: 複製先のキーを省略した場合は複製元と同じキーとし, 競合方針を省略した場合は名前を変更する.
+	if newKey == "" {
+		newKey = key
+	}
+	if conflict == "" {
+		conflict = service.ConflictPolicyRename
+	}
+
+	entry, results, err := u.copyEntry(ctx, srcEntry, volume, dstVolume, newKey, conflict)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if results[0].Result != service.EntryResultSkipped {
+		if err := u.publish(ctx, entity.EventTypeEntryCopied, volume, key, entry, dstVolume); err != nil {
+			return nil, nil, err
 		}
+	}
+	return entry, results, nil
+}
 
-		src := volume.Name + "/" + key
-		dst := volume.Name + "/" + entry.Key
-		return u.bodyRepo.Update(src, dst)
+// NOTE: 個別に実行する場合は操作ごとにトランザクションを分け, 失敗した操作のみを取り消す.
+func (u *entryUsecase) runOperationInTransaction(ctx context.Context, accountID uuid.UUID, volumeName string, operation *dto.EntryOperationDTO) *dto.EntryOperationResultDTO {
+	var result *dto.EntryOperationResultDTO
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		result = u.runOperation(ctx, accountID, volumeName, operation)
+		return result.Error
 	}); err != nil {
-		return nil, err
+		return &dto.EntryOperationResultDTO{Type: operation.Type, Error: err}
 	}
+	return result
+}
 
-	return mapper.ToEntryDTO(entry), nil
+func (u *entryUsecase) runOperation(ctx context.Context, accountID uuid.UUID, volumeName string, operation *dto.EntryOperationDTO) *dto.EntryOperationResultDTO {
+	result := &dto.EntryOperationResultDTO{Type: operation.Type}
+
+	var entry *entity.Entry
+	var err error
+	switch operation.Type {
+	case EntryOperationCreateFolder:
+		entry, _, err = u.runCreate(ctx, accountID, volumeName, operation.Key, 0, "", nil)
+	case EntryOperationDelete:
+		err = u.runDelete(ctx, accountID, volumeName, operation.Key)
+	case EntryOperationMove:
+		entry, result.Results, err = u.runUpdate(ctx, accountID, volumeName, operation.Key, operation.NewVolumeName, operation.NewKey, operation.Conflict)
+	case EntryOperationCopy:
+		entry, result.Results, err = u.runCopy(ctx, accountID, volumeName, operation.Key, operation.NewVolumeName, operation.NewKey, operation.Conflict)
+	default:
+		err = ErrInvalidEntryOperation
+	}
+	if err != nil {
+		return &dto.EntryOperationResultDTO{Type: operation.Type, Error: err}
+	}
+
+	if entry != nil {
+		result.Entry = mapper.ToEntryDTO(entry)
+	}
+	return result
 }
 
-func (u *entryUsecase) Delete(ctx context.Context, accountID uuid.UUID, volumeName, key string) error {
-	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
-		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
-		if err != nil {
-			return err
+// NOTE: 失敗した操作より前の操作は取り消され, 後の操作は実行されない.
+func abortOperations(operations []*dto.EntryOperationDTO, results []*dto.EntryOperationResultDTO) []*dto.EntryOperationResultDTO {
+	failed := len(results) - 1
+	aborted := make([]*dto.EntryOperationResultDTO, len(operations))
+	for i, operation := range operations {
+		switch {
+		case i < failed:
+			aborted[i] = &dto.EntryOperationResultDTO{Type: operation.Type, Error: ErrEntryOperationRolledBack}
+		case i == failed:
+			aborted[i] = results[i]
+		default:
+			aborted[i] = &dto.EntryOperationResultDTO{Type: operation.Type, Error: ErrEntryOperationNotExecuted}
 		}
+	}
+	return aborted
+}
 
-		entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+// NOTE: 競合するフォルダを統合する場合は子を1件ずつ移動する.
+func (u *entryUsecase) moveEntry(ctx context.Context, entry *entity.Entry, srcVolume, dstVolume *entity.Volume, key, conflict string) (*entity.Entry, []*dto.EntryResultDTO, error) {
+	src := entry.Key
+	if err := entry.SetKey(key); err != nil {
+		return nil, nil, err
+	}
+	if err := entry.SetVolumeID(dstVolume.ID); err != nil {
+		return nil, nil, err
+	}
+
+	existing, result, err := u.resolve(ctx, entry, src, srcVolume.ID, dstVolume, conflict)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch result {
+	case service.EntryResultSkipped:
+		return existing, []*dto.EntryResultDTO{{Key: existing.Key, Result: result}}, nil
+	case service.EntryResultMerged:
+		results, err := u.moveChildren(ctx, entry, src, srcVolume, existing, dstVolume, conflict)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
+		return existing, append([]*dto.EntryResultDTO{{Key: existing.Key, Result: result}}, results...), nil
+	}
 
-		if err := u.entryServ.DeleteDescendants(ctx, entry); err != nil {
-			return err
-		}
+	if err := u.move(ctx, entry, src, srcVolume, dstVolume); err != nil {
+		return nil, nil, err
+	}
 
-		if err := u.entryRepo.Delete(ctx, entry); err != nil {
-			return err
+	results, err := u.collectResults(ctx, entry, result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry, results, nil
+}
+
+// NOTE: 競合によりスキップした子が残る場合は移動元のフォルダを残す.
+func (u *entryUsecase) moveChildren(ctx context.Context, entry *entity.Entry, src string, srcVolume *entity.Volume, dst *entity.Entry, dstVolume *entity.Volume, conflict string) ([]*dto.EntryResultDTO, error) {
+	depth := uint64(1)
+	children, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, srcVolume.ID, entry.AccountID, &src, &depth, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*dto.EntryResultDTO
+	for _, child := range children {
+		_, childResults, err := u.moveEntry(ctx, child, srcVolume, dstVolume, dst.Key+"/"+child.Name(), conflict)
+		if err != nil {
+			return nil, err
 		}
+		results = append(results, childResults...)
+	}
 
-		path := volume.Name + "/" + entry.Key
-		return u.bodyRepo.Delete(path)
-	})
+	remaining, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, srcVolume.ID, entry.AccountID, &src, &depth, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(remaining) != 0 {
+		return results, nil
+	}
+
+	if err := u.entryRepo.Delete(ctx, entry); err != nil {
+		return nil, err
+	}
+	return results, u.bodyRepo.Delete(ctx, srcVolume.Path()+"/"+src)
 }
 
-func (u *entryUsecase) Copy(ctx context.Context, accountID uuid.UUID, volumeName, key string) (*dto.EntryDTO, error) {
-	var entry *entity.Entry
+func (u *entryUsecase) move(ctx context.Context, entry *entity.Entry, src string, srcVolume, dstVolume *entity.Volume) error {
+	size, count, err := u.validateSubtree(ctx, dstVolume, entry, src, srcVolume.ID)
+	if err != nil {
+		return err
+	}
+	parentID := entry.ParentID
+	if err := u.entryServ.CreateAncestors(ctx, entry, dstVolume); err != nil {
+		return err
+	}
+	if err := u.validateMove(ctx, entry, parentID, srcVolume, dstVolume, size, count); err != nil {
+		return err
+	}
+	if err := u.entryServ.MoveDescendants(ctx, entry, src, srcVolume.ID); err != nil {
+		return err
+	}
+	if err := u.entryRepo.Update(ctx, entry); err != nil {
+		return err
+	}
+	return u.bodyRepo.Update(ctx, srcVolume.Path()+"/"+src, dstVolume.Path()+"/"+entry.Key)
+}
 
-	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
-		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+// NOTE: 競合するフォルダを統合する場合は子を1件ずつ複製する.
+func (u *entryUsecase) copyEntry(ctx context.Context, src *entity.Entry, srcVolume, dstVolume *entity.Volume, key, conflict string) (*entity.Entry, []*dto.EntryResultDTO, error) {
+	entry, err := u.entryServ.Copy(ctx, src, dstVolume.ID, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, result, err := u.resolve(ctx, entry, src.Key, src.VolumeID, dstVolume, conflict)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch result {
+	case service.EntryResultSkipped:
+		return existing, []*dto.EntryResultDTO{{Key: existing.Key, Result: result}}, nil
+	case service.EntryResultMerged:
+		results, err := u.copyChildren(ctx, src, srcVolume, existing, dstVolume, conflict)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
+		return existing, append([]*dto.EntryResultDTO{{Key: existing.Key, Result: result}}, results...), nil
+	}
 
-		srcEntry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err := u.copy(ctx, entry, src, srcVolume, dstVolume); err != nil {
+		return nil, nil, err
+	}
+
+	results, err := u.collectResults(ctx, entry, result)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entry, results, nil
+}
+
+func (u *entryUsecase) copyChildren(ctx context.Context, src *entity.Entry, srcVolume *entity.Volume, dst *entity.Entry, dstVolume *entity.Volume, conflict string) ([]*dto.EntryResultDTO, error) {
+	depth := uint64(1)
+	children, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, srcVolume.ID, src.AccountID, &src.Key, &depth, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*dto.EntryResultDTO
+	for _, child := range children {
+		_, childResults, err := u.copyEntry(ctx, child, srcVolume, dstVolume, dst.Key+"/"+child.Name(), conflict)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		results = append(results, childResults...)
+	}
+	return results, nil
+}
 
-		entry, err = u.entryServ.Copy(ctx, srcEntry)
-		if err != nil {
+func (u *entryUsecase) copy(ctx context.Context, entry, src *entity.Entry, srcVolume, dstVolume *entity.Volume) error {
+	size, count, err := u.validateSubtree(ctx, dstVolume, entry, src.Key, src.VolumeID)
+	if err != nil {
+		return err
+	}
+	if err := u.entryServ.CreateAncestors(ctx, entry, dstVolume); err != nil {
+		return err
+	}
+	if err := u.validateEntryCount(ctx, dstVolume, entry); err != nil {
+		return err
+	}
+	if err := u.validateUsage(ctx, dstVolume, size, count); err != nil {
+		return err
+	}
+	if err := u.entryRepo.Create(ctx, entry); err != nil {
+		return err
+	}
+	if err := u.entryServ.CopyDescendants(ctx, entry, src.Key, src.VolumeID); err != nil {
+		return err
+	}
+	if !entry.IsFolder() {
+		if err := u.entryMetadataRepo.Copy(ctx, src.ID, entry.ID); err != nil {
 			return err
 		}
-		if err := u.entryServ.CopyDescendants(ctx, entry, key); err != nil {
+		if err := u.entryContentRepo.Copy(ctx, src.ID, entry.ID); err != nil {
 			return err
 		}
+	}
+	return u.bodyRepo.Copy(ctx, srcVolume.Path()+"/"+src.Key, dstVolume.Path()+"/"+entry.Key)
+}
+
+// NOTE: 上書きする場合は競合するエントリーを子孫ごと削除し, 上書きされたキーの更新を通知する.
+func (u *entryUsecase) resolve(ctx context.Context, entry *entity.Entry, src string, srcVolumeID uuid.UUID, dstVolume *entity.Volume, conflict string) (*entity.Entry, string, error) {
+	existing, result, err := u.entryServ.Resolve(ctx, entry, src, srcVolumeID, conflict)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if result == service.EntryResultOverwritten {
+		if err := u.remove(ctx, dstVolume, existing); err != nil {
+			return nil, "", err
+		}
+		if err := u.publish(ctx, entity.EventTypeEntryUpdated, dstVolume, existing.Key, entry, nil); err != nil {
+			return nil, "", err
+		}
+	}
+	return existing, result, nil
+}
 
-		if err := u.entryRepo.Create(ctx, entry); err != nil {
+// NOTE: 移動, 複製の場合は dstVolume を指定し, 操作後のエントリーのキーを変更後のキーとする.
+func (u *entryUsecase) publish(ctx context.Context, eventType string, volume *entity.Volume, key string, entry *entity.Entry, dstVolume *entity.Volume) error {
+	event, err := entity.NewEvent(eventType, volume, key)
+	if err != nil {
+		return err
+	}
+	event.SetSize(entry.Size)
+	if dstVolume != nil {
+		if err := event.SetDestination(dstVolume, entry.Key); err != nil {
 			return err
 		}
+	}
+	return u.eventServ.Publish(ctx, event)
+}
 
-		src := volume.Name + "/" + key
-		dst := volume.Name + "/" + entry.Key
-		return u.bodyRepo.Copy(src, dst)
-	}); err != nil {
+func (u *entryUsecase) writeBody(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body io.Reader) error {
+	attributes := entry.BodyAttributes()
+	if err := u.bodyRepo.Create(ctx, volume.Path()+"/"+entry.Key, body, attributes); err != nil {
+		return err
+	}
+	// NOTE: エントリーは作成済みのため, ボディの保存形式が変わった場合のみ更新する.
+	if *attributes == *entry.BodyAttributes() {
+		return nil
+	}
+	entry.SetBodyAttributes(attributes)
+	return u.entryRepo.Update(ctx, entry)
+}
+
+// NOTE: ボディを書き込みながらメタデータと本文の抽出に必要な範囲を記録する.
+func (u *entryUsecase) writeBodyWithMetadata(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body io.Reader) (*entity.EntryMetadata, error) {
+	if body == nil {
+		return nil, u.writeBody(ctx, volume, entry, body)
+	}
+
+	metadataRecorder := metadata.NewRecorder()
+	contentRecorder := fulltext.NewRecorder()
+	if err := u.writeBody(ctx, volume, entry, io.TeeReader(body, io.MultiWriter(metadataRecorder, contentRecorder))); err != nil {
 		return nil, err
 	}
 
-	return mapper.ToEntryDTO(entry), nil
+	if err := u.createEntryContent(ctx, entry, contentRecorder); err != nil {
+		return nil, err
+	}
+	return u.createEntryMetadata(ctx, entry, metadataRecorder)
 }
 
-func (u *entryUsecase) GetMeta(ctx context.Context, accountID uuid.UUID, volumeName, key string) (*dto.EntryDTO, error) {
-	var entry *entity.Entry
+// NOTE: 抽出できない場合は保存しない.
+func (u *entryUsecase) createEntryMetadata(ctx context.Context, entry *entity.Entry, recorder *metadata.Recorder) (*entity.EntryMetadata, error) {
+	extracted := recorder.Extract(entry.Type)
+	if extracted == nil {
+		return nil, nil
+	}
 
-	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
-		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	entryMetadata, err := entity.NewEntryMetadata(entry.ID, extracted.Width, extracted.Height, extracted.TakenAt, extracted.CameraMake, extracted.CameraModel, extracted.Latitude, extracted.Longitude, extracted.PageCount, extracted.Duration, extracted.Title, extracted.Artist, extracted.Album)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.entryMetadataRepo.Create(ctx, entryMetadata); err != nil {
+		return nil, err
+	}
+	return entryMetadata, nil
+}
+
+// NOTE: 抽出できない場合は保存しない.
+func (u *entryUsecase) createEntryContent(ctx context.Context, entry *entity.Entry, recorder *fulltext.Recorder) error {
+	extracted := recorder.Extract(entry.Type)
+	if extracted == "" {
+		return nil
+	}
+
+	entryContent, err := entity.NewEntryContent(entry.ID, extracted)
+	if err != nil {
+		return err
+	}
+	return u.entryContentRepo.Create(ctx, entryContent)
+}
+
+func (u *entryUsecase) searchContents(ctx context.Context, volume *entity.Volume, accountID uuid.UUID, terms []string) ([]*entity.EntryContentMatch, error) {
+	if terms == nil {
+		return nil, nil
+	}
+	return u.entryContentRepo.Search(ctx, volume.ID, accountID, terms, maxEntryContentMatches)
+}
+
+// NOTE: 書き込んだボディを読み直して検査する. 拒否する場合はトランザクションのロールバックでボディも削除される.
+func (u *entryUsecase) storeBody(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body io.Reader) (*entity.EntryMetadata, error) {
+	entryMetadata, err := u.writeBodyWithMetadata(ctx, volume, entry, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.scan(ctx, volume, entry); err != nil {
+		return nil, err
+	}
+	return entryMetadata, nil
+}
+
+func (u *entryUsecase) scan(ctx context.Context, volume *entity.Volume, entry *entity.Entry) error {
+	if u.scannerRepo == nil || entry.IsFolder() {
+		return nil
+	}
+
+	signature, err := u.scanBody(ctx, volume, entry)
+	if err != nil {
+		return err
+	}
+
+	entry.SetScanResult(signature)
+	if entry.IsQuarantined() && u.scanAction != ScanActionQuarantine {
+		return ErrEntryInfected
+	}
+	return u.entryRepo.Update(ctx, entry)
+}
+
+func (u *entryUsecase) scanBody(ctx context.Context, volume *entity.Volume, entry *entity.Entry) (_ string, err error) {
+	body, err := u.bodyRepo.FindOneByPath(ctx, volume.Path()+"/"+entry.Key, entry.BodyAttributes())
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// NOTE: errに直接詰めると関数内のエラーがnilで上書きされるためエラー発生時のみ上書きする.
+		if e := body.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	return u.scannerRepo.Scan(ctx, progress.NewReader(ctx, body))
+}
+
+// NOTE: 対象の取得後の変更を上書きしないよう取得し直し, 移動, 削除されたエントリーはスキップする.
+func (u *entryUsecase) rescan(ctx context.Context, volume *entity.Volume, target *entity.Entry) error {
+	if target.IsFolder() {
+		return nil
+	}
+
+	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, target.Key, volume.ID, target.AccountID)
 		if err != nil {
+			if errors.Is(err, repository.ErrEntryNotFound) {
+				return nil
+			}
 			return err
 		}
+		if entry.ID != target.ID {
+			return nil
+		}
 
-		entry, err = u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+		signature, err := u.scanBody(ctx, volume, entry)
 		if err != nil {
 			return err
 		}
+		entry.SetScanResult(signature)
+		return u.entryRepo.Update(ctx, entry)
+	})
+}
 
+// NOTE: 失敗したエントリーが多い場合は先頭のキーのみを含める.
+func newScanFailedError(keys []string) error {
+	if len(keys) == 0 {
 		return nil
-	}); err != nil {
+	}
+
+	reported := strings.Join(keys[:min(len(keys), maxReportedScanFailures)], ", ")
+	if maxReportedScanFailures < len(keys) {
+		reported += fmt.Sprintf(" and %d more", len(keys)-maxReportedScanFailures)
+	}
+	return status.Error(code.FailedDependency, "failed to scan entries: "+reported)
+}
+
+// NOTE: キーを指定しない場合はボリューム全体を対象とする.
+func (u *entryUsecase) findScanTargets(ctx context.Context, volume *entity.Volume, accountID uuid.UUID, key string) ([]*entity.Entry, error) {
+	if key == "" {
+		return u.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, accountID, nil, nil, nil)
+	}
+
+	entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err != nil {
 		return nil, err
 	}
+	if !entry.IsFolder() {
+		return []*entity.Entry{entry}, nil
+	}
 
-	return mapper.ToEntryDTO(entry), nil
+	descendants, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, accountID, &entry.Key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*entity.Entry{entry}, descendants...), nil
 }
 
-func (u *entryUsecase) GetOne(ctx context.Context, accountID uuid.UUID, volumeName, key string) (*dto.EntryDTO, io.ReadCloser, error) {
-	var entry *entity.Entry
-	var body io.ReadCloser
+// NOTE: 隔離されたエントリーはボディ及び派生コンテンツを返却しない.
+func (u *entryUsecase) findReadableEntry(ctx context.Context, accountID uuid.UUID, volumeName, key string) (*entity.Entry, string, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return nil, "", err
+	}
 
-	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
-		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
-		if err != nil {
-			return err
-		}
+	entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err != nil {
+		return nil, "", err
+	}
+	if entry.IsQuarantined() {
+		return nil, "", entity.ErrEntryQuarantined
+	}
+	return entry, volume.Path() + "/" + entry.Key, nil
+}
 
-		entry, err = u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
-		if err != nil {
-			return err
+func (u *entryUsecase) generateThumbnail(ctx context.Context, entry *entity.Entry, path string, width, height uint64) (_ []byte, err error) {
+	body, err := u.bodyRepo.FindOneByPath(ctx, path, entry.BodyAttributes())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := body.Close(); closeErr != nil && err == nil {
+			err = closeErr
 		}
+	}()
 
-		path := volume.Name + "/" + entry.Key
-		body, err = u.bodyRepo.FindOneByPath(path)
+	return thumbnail.Generate(body, entry.Type, width, height)
+}
+
+func (u *entryUsecase) remove(ctx context.Context, volume *entity.Volume, entry *entity.Entry) error {
+	if err := u.entryServ.DeleteDescendants(ctx, entry); err != nil {
 		return err
-	}); err != nil {
-		return nil, nil, err
 	}
+	if err := u.entryRepo.Delete(ctx, entry); err != nil {
+		return err
+	}
+	return u.bodyRepo.Delete(ctx, volume.Path()+"/"+entry.Key)
+}
 
-	return mapper.ToEntryDTO(entry), body, nil
+// NOTE: フォルダの子孫はまとめて移動または複製されるため作成されたものとして報告する.
+func (u *entryUsecase) collectResults(ctx context.Context, entry *entity.Entry, result string) ([]*dto.EntryResultDTO, error) {
+	results := []*dto.EntryResultDTO{{Key: entry.Key, Result: result}}
+	if !entry.IsFolder() {
+		return results, nil
+	}
+
+	descendants, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, entry.VolumeID, entry.AccountID, &entry.Key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, descendant := range descendants {
+		results = append(results, &dto.EntryResultDTO{Key: descendant.Key, Result: service.EntryResultCreated})
+	}
+	return results, nil
 }
 
-func (u *entryUsecase) Search(ctx context.Context, accountID uuid.UUID, volumeName string, prefix *string, depth *uint64) ([]*dto.EntryDTO, error) {
-	var entries []*entity.Entry
+// NOTE: 移動先のボリュームが指定されていない場合は移動元のボリュームとする.
+func (u *entryUsecase) findDestinationVolume(ctx context.Context, accountID uuid.UUID, volume *entity.Volume, volumeName string) (*entity.Volume, error) {
+	if volumeName == "" || volumeName == volume.Name {
+		return volume, nil
+	}
+	return u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+}
 
-	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
-		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
-		if err != nil {
-			return err
+// NOTE: 上限がない場合は件数を取得しない.
+func (u *entryUsecase) validateEntryCount(ctx context.Context, volume *entity.Volume, entry *entity.Entry) error {
+	if !volume.HasEntryCountLimit() {
+		return nil
+	}
+	count, err := u.entryRepo.CountByParentIDAndVolumeID(ctx, entry.ParentID, volume.ID)
+	if err != nil {
+		return err
+	}
+	return volume.ValidateEntryCount(count)
+}
+
+// NOTE: フォルダの子孫は移動, 複製後のキーで検証し, 子孫を含めたサイズの合計と件数を返却する.
+// 子孫のフォルダ直下のエントリー数は移動, 複製の前後で変わらないため検証しない.
+// 制限がない場合は子孫を取得しない.
+func (u *entryUsecase) validateSubtree(ctx context.Context, volume *entity.Volume, entry *entity.Entry, src string, srcVolumeID uuid.UUID) (uint64, uint64, error) {
+	if err := volume.ValidateEntry(entry); err != nil {
+		return 0, 0, err
+	}
+	size, count := entry.Size, uint64(1)
+	if volume.Policy == nil || !entry.IsFolder() {
+		return size, count, nil
+	}
+
+	descendants, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, srcVolumeID, entry.AccountID, &src, nil, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, descendant := range descendants {
+		target := *descendant
+		target.Key = entry.Key + strings.TrimPrefix(descendant.Key, src)
+		if err := volume.ValidateEntry(&target); err != nil {
+			return 0, 0, err
 		}
+		size += descendant.Size
+		count++
+	}
+	return size, count, nil
+}
 
-		entries, err = u.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, accountID, prefix, depth)
+// NOTE: 同じフォルダ内での名前の変更はフォルダ直下のエントリー数が, 同じボリューム内の移動は使用量が変わらないため検証しない.
+func (u *entryUsecase) validateMove(ctx context.Context, entry *entity.Entry, parentID uuid.UUID, srcVolume, dstVolume *entity.Volume, size, count uint64) error {
+	if srcVolume.ID == dstVolume.ID {
+		if entry.ParentID == parentID {
+			return nil
+		}
+		return u.validateEntryCount(ctx, dstVolume, entry)
+	}
+	if err := u.validateEntryCount(ctx, dstVolume, entry); err != nil {
 		return err
-	}); err != nil {
-		return nil, err
 	}
+	return u.validateUsage(ctx, dstVolume, size, count)
+}
 
-	return mapper.ToEntryDTOs(entries), nil
+// NOTE: 上限がない場合は使用量を取得しない.
+func (u *entryUsecase) validateUsage(ctx context.Context, volume *entity.Volume, size, count uint64) error {
+	if !volume.HasUsageLimit() {
+		return nil
+	}
+	usage, err := u.entryRepo.SumByVolumeID(ctx, volume.ID)
+	if err != nil {
+		return err
+	}
+	return volume.ValidateUsage(usage, size, count)
 }
 
-func (u *entryUsecase) getBodyInfo(body io.Reader) (string, io.Reader, error) {
+func (u *entryUsecase) getBodyInfo(key, declaredType string, body io.Reader) (string, io.Reader, error) {
 	if body == nil {
-		return "folder", nil, nil
+		return folderType, nil, nil
 	}
 
-	buf := make([]byte, 512)
-	n, err := body.Read(buf)
-	if err != nil && err != io.EOF {
+	buf := make([]byte, contenttype.ReadLimit)
+	n, err := io.ReadFull(body, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
 		return "", nil, err
 	}
 
-	entryType := http.DetectContentType(buf[:n])
+	entryType := contenttype.Detect(buf[:n], key, declaredType)
 	bodyReader := io.MultiReader(bytes.NewReader(buf[:n]), body)
 
 	return entryType, bodyReader, nil
 }
+
+func newEntry(accountID uuid.UUID, volume *entity.Volume, key string, size uint64, entryType string) (*entity.Entry, error) {
+	entry, err := entity.NewEntry(accountID, volume.ID, key, size, entryType)
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsCompressible() {
+		entry.SetEncoding(volume.Compression)
+	}
+	if err := volume.ValidateEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func fileIDs(entries []*entity.Entry) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsFolder() {
+			ids = append(ids, entry.ID)
+		}
+	}
+	return ids
+}
+
+func parseTerms(query string) ([]string, error) {
+	if query == "" {
+		return nil, nil
+	}
+	return entity.ParseEntryContentQuery(query)
+}
+
+// NOTE: 本文の検索結果の関連度順に並べ, 検索範囲外のエントリーは除く.
+func rankEntries(entries []*entity.Entry, matches []*entity.EntryContentMatch) ([]*entity.Entry, map[uuid.UUID]string) {
+	entryByID := make(map[uuid.UUID]*entity.Entry, len(entries))
+	for _, entry := range entries {
+		entryByID[entry.ID] = entry
+	}
+
+	ranked := make([]*entity.Entry, 0, len(matches))
+	snippets := make(map[uuid.UUID]string, len(matches))
+	for _, match := range matches {
+		entry, ok := entryByID[match.EntryID]
+		if !ok {
+			continue
+		}
+		ranked = append(ranked, entry)
+		snippets[entry.ID] = match.Snippet
+	}
+	return ranked, snippets
+}
+
+func toEntryCondition(condition *dto.EntryConditionDTO) *repository.EntryCondition {
+	return &repository.EntryCondition{
+		TakenFrom: condition.TakenFrom,
+		TakenTo:   condition.TakenTo,
+		Camera:    condition.Camera,
+	}
+}
+
+func toEntryDTOs(entries []*entity.Entry, entryMetadata []*entity.EntryMetadata, snippets map[uuid.UUID]string) []*dto.EntryDTO {
+	metadataByEntryID := make(map[uuid.UUID]*entity.EntryMetadata, len(entryMetadata))
+	for _, m := range entryMetadata {
+		metadataByEntryID[m.EntryID] = m
+	}
+
+	dtos := make([]*dto.EntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		result := mapper.ToEntryDTOWithMetadata(entry, metadataByEntryID[entry.ID])
+		result.Snippet = snippets[entry.ID]
+		dtos = append(dtos, result)
+	}
+	return dtos
+}
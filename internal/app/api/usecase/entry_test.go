@@ -5,6 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"image"
+	"image/png"
 	"io"
 	"testing"
 	"time"
@@ -16,7 +18,11 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/service"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/thumbnail"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
 	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
 	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
@@ -44,6 +50,26 @@ func TestEntry_Create(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	compressedVolume := &entity.Volume{
+		ID:          uuid.New(),
+		AccountID:   accountID,
+		Name:        "compressed",
+		IsPublic:    false,
+		Compression: "gzip",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	compressedEntryDTO := &dto.EntryDTO{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  compressedVolume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		Encoding:  "gzip",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
 	folderEntryDTO := &dto.EntryDTO{
 		ID:        uuid.New(),
 		AccountID: accountID,
@@ -55,30 +81,66 @@ func TestEntry_Create(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
+	policyVolume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "policy",
+		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{MaxFileSize: 4, DeniedTypes: []string{"image/*"}, MaxEntriesPerFolder: 2},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	yamlEntryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample",
+		Size:      11,
+		Type:      "application/yaml",
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 30, 10))); err != nil {
+		t.Fatal(err)
+	}
+	imageBody := buf.Bytes()
+	imageEntryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.png",
+		Size:      uint64(len(imageBody)),
+		Type:      "image/png",
+		Metadata:  &dto.EntryMetadataDTO{Width: 30, Height: 10},
+	}
+
 	tests := []struct {
-		name                  string
-		inputAccountID        uuid.UUID
-		inputVolumeName       string
-		inputKey              string
-		inputSize             uint64
-		inputBody             io.Reader
-		expectResult          *dto.EntryDTO
-		expectError           error
-		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
-		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
-		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
-		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
-		setMockEntryServ      func(*mockService.MockEntryService)
+		name                     string
+		inputAccountID           uuid.UUID
+		inputVolumeName          string
+		inputKey                 string
+		inputSize                uint64
+		inputDeclaredType        string
+		inputBody                io.Reader
+		expectResult             *dto.EntryDTO
+		expectError              error
+		setMockTransactionObj    func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo         func(*mockRepository.MockEntryRepository)
+		setMockEntryMetadataRepo func(*mockRepository.MockEntryMetadataRepository)
+		setMockEntryContentRepo  func(*mockRepository.MockEntryContentRepository)
+		setMockBodyRepo          func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo        func(*mockRepository.MockVolumeRepository)
+		setMockEntryServ         func(*mockService.MockEntryService)
 	}{
 		{
-			name:            "create file entry",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "key/sample.txt",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    entryDTO,
-			expectError:     nil,
+			name:              "create file entry",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      entryDTO,
+			expectError:       nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -95,10 +157,12 @@ func TestEntry_Create(t *testing.T) {
 					Return(nil).
 					Times(1)
 			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
@@ -117,20 +181,21 @@ func TestEntry_Create(t *testing.T) {
 					Times(1)
 				entryServ.
 					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "create folder entry",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "key",
-			inputSize:       0,
-			inputBody:       nil,
-			expectResult:    folderEntryDTO,
-			expectError:     nil,
+			name:              "create encrypted file entry",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      entryDTO,
+			expectError:       nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -146,12 +211,22 @@ func TestEntry_Create(t *testing.T) {
 					Create(gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Cond(func(entry *entity.Entry) bool { return entry.EncryptionKeyID == "new" })).
+					Return(nil).
+					Times(1)
 			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
-					Return(nil).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ io.Reader, attributes *entity.BodyAttributes) error {
+						attributes.EncryptionKeyID = "new"
+						return nil
+					}).
 					Times(1)
 			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
@@ -169,20 +244,21 @@ func TestEntry_Create(t *testing.T) {
 					Times(1)
 				entryServ.
 					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "find volume error",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:              "create file entry with declared type",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample",
+			inputSize:         11,
+			inputDeclaredType: "application/yaml",
+			inputBody:         bytes.NewBufferString("key: value\n"),
+			expectResult:      yamlEntryDTO,
+			expectError:       nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -192,37 +268,22 @@ func TestEntry_Create(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
-			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
-				volumeRepo.
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
 					EXPECT().
-					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
-		},
-		{
-			name:            "invalid key",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    nil,
-			expectError:     entity.ErrShortEntryKey,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
 					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -230,17 +291,29 @@ func TestEntry_Create(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
 		},
 		{
-			name:            "entry already exists",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "key/sample.txt",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    nil,
-			expectError:     service.ErrEntryAlreadyExists,
+			name:              "create image entry with metadata",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.png",
+			inputSize:         uint64(len(imageBody)),
+			inputDeclaredType: "",
+			inputBody:         bytes.NewReader(imageBody),
+			expectResult:      imageEntryDTO,
+			expectError:       nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -250,8 +323,31 @@ func TestEntry_Create(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ *entity.BodyAttributes) error {
+						_, err := io.Copy(io.Discard, reader)
+						return err
+					}).
+					Times(1)
+			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -263,19 +359,25 @@ func TestEntry_Create(t *testing.T) {
 				entryServ.
 					EXPECT().
 					Exists(gomock.Any(), gomock.Any()).
-					Return(service.ErrEntryAlreadyExists).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "create ancestors error",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "key/sample.txt",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:              "create compressed file entry",
+			inputAccountID:    accountID,
+			inputVolumeName:   compressedVolume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      compressedEntryDTO,
+			expectError:       nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -285,13 +387,42 @@ func TestEntry_Create(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, attributes *entity.BodyAttributes) error {
+						body, err := io.ReadAll(reader)
+						if err != nil {
+							return err
+						}
+						if string(body) != "test" || attributes.Encoding != "gzip" {
+							return io.ErrUnexpectedEOF
+						}
+						return nil
+					}).
+					Times(1)
+			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
+					Return(compressedVolume, nil).
 					Times(1)
 			},
 			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
@@ -302,20 +433,21 @@ func TestEntry_Create(t *testing.T) {
 					Times(1)
 				entryServ.
 					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "create entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "key/sample.txt",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:              "create folder entry",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key",
+			inputSize:         0,
+			inputDeclaredType: "",
+			inputBody:         nil,
+			expectResult:      folderEntryDTO,
+			expectError:       nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -329,10 +461,18 @@ func TestEntry_Create(t *testing.T) {
 				entryRepo.
 					EXPECT().
 					Create(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -348,20 +488,21 @@ func TestEntry_Create(t *testing.T) {
 					Times(1)
 				entryServ.
 					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "create body error",
-			inputAccountID:  accountID,
-			inputVolumeName: volume.Name,
-			inputKey:        "key/sample.txt",
-			inputSize:       4,
-			inputBody:       bytes.NewBufferString("test"),
-			expectResult:    nil,
-			expectError:     io.ErrNoProgress,
+			name:              "find volume error",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -371,20 +512,74 @@ func TestEntry_Create(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
-					Return(nil).
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
-			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
-				bodyRepo.
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:              "invalid key",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       entity.ErrShortEntryKey,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
-					Return(io.ErrNoProgress).
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:              "entry already exists",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       service.ErrEntryAlreadyExists,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
 					Times(1)
 			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -396,19 +591,4303 @@ func TestEntry_Create(t *testing.T) {
 				entryServ.
 					EXPECT().
 					Exists(gomock.Any(), gomock.Any()).
-					Return(nil).
+					Return(service.ErrEntryAlreadyExists).
+					Times(1)
+			},
+		},
+		{
+			name:              "create ancestors error",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
 					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
 				entryServ.
 					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
+					Exists(gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
+		{
+			name:              "entry too large",
+			inputAccountID:    accountID,
+			inputVolumeName:   policyVolume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         5,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("tests"),
+			expectResult:      nil,
+			expectError:       entity.ErrEntryTooLarge,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(policyVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:              "entry type not allowed",
+			inputAccountID:    accountID,
+			inputVolumeName:   policyVolume.Name,
+			inputKey:          "key/sample.png",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewReader(imageBody),
+			expectResult:      nil,
+			expectError:       entity.ErrEntryTypeNotAllowed,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(policyVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:              "too many entries",
+			inputAccountID:    accountID,
+			inputVolumeName:   policyVolume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       entity.ErrTooManyEntries,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					CountByParentIDAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(uint64(2), nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(policyVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "count entries error",
+			inputAccountID:    accountID,
+			inputVolumeName:   policyVolume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					CountByParentIDAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(uint64(0), sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(policyVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "create entry error",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "create body error",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       io.ErrNoProgress,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(io.ErrNoProgress).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "create metadata error",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.png",
+			inputSize:         uint64(len(imageBody)),
+			inputDeclaredType: "",
+			inputBody:         bytes.NewReader(imageBody),
+			expectResult:      nil,
+			expectError:       sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ *entity.BodyAttributes) error {
+						_, err := io.Copy(io.Discard, reader)
+						return err
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:              "create content error",
+			inputAccountID:    accountID,
+			inputVolumeName:   volume.Name,
+			inputKey:          "key/sample.txt",
+			inputSize:         4,
+			inputDeclaredType: "",
+			inputBody:         bytes.NewBufferString("test"),
+			expectResult:      nil,
+			expectError:       sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, reader io.Reader, _ *entity.BodyAttributes) error {
+						_, err := io.Copy(io.Discard, reader)
+						return err
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			tt.setMockEntryMetadataRepo(entryMetadataRepo)
+
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+			tt.setMockEntryContentRepo(entryContentRepo)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			result, err := uc.Create(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, tt.inputSize, tt.inputDeclaredType, tt.inputBody)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.EntryDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_Create_Scan(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	cleanEntryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		Scan:      &dto.EntryScanDTO{Status: entity.EntryScanStatusClean},
+	}
+	infectedEntryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		Scan:      &dto.EntryScanDTO{Status: entity.EntryScanStatusInfected, Signature: "Eicar-Test-Signature"},
+	}
+
+	tests := []struct {
+		name                  string
+		inputScanAction       string
+		expectResult          *dto.EntryDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+		setMockScannerRepo    func(*mockRepository.MockScannerRepository)
+	}{
+		{
+			name:            "clean",
+			inputScanAction: usecase.ScanActionReject,
+			expectResult:    cleanEntryDTO,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "infected and rejected",
+			inputScanAction: usecase.ScanActionReject,
+			expectResult:    nil,
+			expectError:     usecase.ErrEntryInfected,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("Eicar-Test-Signature", nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "infected and quarantined",
+			inputScanAction: usecase.ScanActionQuarantine,
+			expectResult:    infectedEntryDTO,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("Eicar-Test-Signature", nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "find body error",
+			inputScanAction: usecase.ScanActionReject,
+			expectResult:    nil,
+			expectError:     afero.ErrFileNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(nil, afero.ErrFileNotFound).
+					Times(1)
+			},
+			setMockScannerRepo: func(*mockRepository.MockScannerRepository) {},
+		},
+		{
+			name:            "scan error",
+			inputScanAction: usecase.ScanActionReject,
+			expectResult:    nil,
+			expectError:     repository.ErrScanFailed,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", repository.ErrScanFailed).
+					Times(1)
+			},
+		},
+		{
+			name:            "update error",
+			inputScanAction: usecase.ScanActionReject,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			volumeRepo.EXPECT().FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).Return(volume, nil).Times(1)
+
+			scannerRepo := mockRepository.NewMockScannerRepository(ctrl)
+			tt.setMockScannerRepo(scannerRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			entryServ.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			entryServ.EXPECT().CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, bodyRepo, volumeRepo, scannerRepo, entryServ, eventServ, tt.inputScanAction)
+			result, err := uc.Create(ctx, accountID, volume.Name, "key/sample.txt", 4, "", bytes.NewBufferString("test"))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.EntryDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+				cmpopts.IgnoreFields(dto.EntryScanDTO{}, "ScannedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_Drop(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		Drop:      &entity.VolumeDrop{Prefix: "inbox"},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	privateVolume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "inbox/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+	}
+	renamedEntryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "inbox/sample copy.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+	}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputKey              string
+		expectResult          *dto.EntryDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryServ      func(*mockService.MockEntryService)
+	}{
+		{
+			name:            "successfully dropped",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputKey:        "inbox/sample.txt",
+			expectResult:    entryDTO,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "", uuid.Nil, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "renamed on conflict",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputKey:        "inbox/sample.txt",
+			expectResult:    renamedEntryDTO,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "", uuid.Nil, service.ConflictPolicyRename).
+					DoAndReturn(func(_ context.Context, entry *entity.Entry, _ string, _ uuid.UUID, _ string) (*entity.Entry, string, error) {
+						return nil, service.EntryResultRenamed, entry.SetKey("inbox/sample copy.txt")
+					}).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "outside drop folder",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputKey:        "other/sample.txt",
+			expectResult:    nil,
+			expectError:     entity.ErrEntryNotDroppable,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "volume is not droppable",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputKey:        "inbox/sample.txt",
+			expectResult:    nil,
+			expectError:     entity.ErrEntryNotDroppable,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(privateVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputKey:        "inbox/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "resolve error",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputKey:        "inbox/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "", uuid.Nil, service.ConflictPolicyRename).
+					Return(nil, "", sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			result, err := uc.Drop(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, 4, "", bytes.NewBufferString("test"))
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.EntryDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_Update(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entryDTO := &dto.EntryDTO{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		VolumeID:  entry.VolumeID,
+		Key:       "update/sample.txt",
+		Size:      entry.Size,
+		Type:      entry.Type,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+
+	otherVolume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "other",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	movedEntryDTO := &dto.EntryDTO{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		VolumeID:  otherVolume.ID,
+		Key:       "update/sample.txt",
+		Size:      entry.Size,
+		Type:      entry.Type,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+
+	conflictEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "update/sample.txt",
+		Size:      8,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	folderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	dstFolderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "update",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	initEntry := func() error {
+		if err := folderEntry.SetKey("key"); err != nil {
+			return err
+		}
+		return entry.SetKey("key/sample.txt")
+	}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputKey              string
+		inputNewVolumeName    string
+		inputNewKey           string
+		inputConflict         string
+		expectResult          *dto.EntryDTO
+		expectResults         []*dto.EntryResultDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryServ      func(*mockService.MockEntryService)
+	}{
+		{
+			name:            "successfully updated",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    entryDTO,
+			expectResults:   []*dto.EntryResultDTO{{Key: "update/sample.txt", Result: service.EntryResultCreated}},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "successfully moved to other volume",
+			inputAccountID:     accountID,
+			inputVolumeName:    "volume",
+			inputKey:           "key/sample.txt",
+			inputNewVolumeName: "other",
+			inputNewKey:        "update/sample.txt",
+			expectResult:       movedEntryDTO,
+			expectResults:      []*dto.EntryResultDTO{{Key: "update/sample.txt", Result: service.EntryResultCreated}},
+			expectError:        nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "volume", accountID).
+					Return(volume, nil).
+					Times(1)
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "other", accountID).
+					Return(otherVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "invalid update key",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "",
+			expectResult:    nil,
+			expectError:     entity.ErrShortEntryKey,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "entry already exists",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    nil,
+			expectError:     service.ErrEntryAlreadyExists,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, "", service.ErrEntryAlreadyExists).
+					Times(1)
+			},
+		},
+		{
+			name:            "create ancestors error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "update entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "update body error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			expectResult:    nil,
+			expectError:     afero.ErrFileClosed,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(afero.ErrFileClosed).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully moved folder",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key",
+			inputNewKey:     "update",
+			expectResult:    &dto.EntryDTO{ID: folderEntry.ID, AccountID: accountID, VolumeID: volume.ID, Key: "update", Size: 0, Type: "folder", CreatedAt: folderEntry.CreatedAt},
+			expectResults: []*dto.EntryResultDTO{
+				{Key: "update", Result: service.EntryResultCreated},
+				{Key: "update/sample.txt", Result: service.EntryResultCreated},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(folderEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), folderEntry).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{{Key: "update/sample.txt"}}, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), volume.Path()+"/key", volume.Path()+"/update").
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), folderEntry, "key", volume.ID, service.ConflictPolicyFail).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), "key", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully overwritten",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			inputConflict:   service.ConflictPolicyOverwrite,
+			expectResult:    entryDTO,
+			expectResults:   []*dto.EntryResultDTO{{Key: "update/sample.txt", Result: service.EntryResultOverwritten}},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), conflictEntry).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), entry).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), volume.Path()+"/update/sample.txt").
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), volume.Path()+"/key/sample.txt", volume.Path()+"/update/sample.txt").
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicyOverwrite).
+					Return(conflictEntry, service.EntryResultOverwritten, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), conflictEntry).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully skipped",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			inputConflict:   service.ConflictPolicySkip,
+			expectResult:    &dto.EntryDTO{ID: conflictEntry.ID, AccountID: accountID, VolumeID: volume.ID, Key: "update/sample.txt", Size: 8, Type: conflictEntry.Type, CreatedAt: conflictEntry.CreatedAt},
+			expectResults:   []*dto.EntryResultDTO{{Key: "update/sample.txt", Result: service.EntryResultSkipped}},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, service.ConflictPolicySkip).
+					Return(conflictEntry, service.EntryResultSkipped, nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully merged",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key",
+			inputNewKey:     "update",
+			inputConflict:   service.ConflictPolicyMerge,
+			expectResult:    &dto.EntryDTO{ID: dstFolderEntry.ID, AccountID: accountID, VolumeID: volume.ID, Key: "update", Size: 0, Type: "folder", CreatedAt: dstFolderEntry.CreatedAt},
+			expectResults: []*dto.EntryResultDTO{
+				{Key: "update", Result: service.EntryResultMerged},
+				{Key: "update/sample.txt", Result: service.EntryResultCreated},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(folderEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{entry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), entry).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), folderEntry).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), volume.Path()+"/key/sample.txt", volume.Path()+"/update/sample.txt").
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), volume.Path()+"/key").
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), folderEntry, "key", volume.ID, service.ConflictPolicyMerge).
+					Return(dstFolderEntry, service.EntryResultMerged, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), entry, "key/sample.txt", volume.ID, service.ConflictPolicyMerge).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), entry, gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), entry, "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "invalid conflict policy",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "update/sample.txt",
+			inputConflict:   "replace",
+			expectResult:    nil,
+			expectResults:   nil,
+			expectError:     service.ErrInvalidConflictPolicy,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID, "replace").
+					Return(nil, "", service.ErrInvalidConflictPolicy).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := initEntry(); err != nil {
+				t.Error(err)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			result, results, err := uc.Update(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, tt.inputNewVolumeName, tt.inputNewKey, tt.inputConflict)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.EntryDTO{}, "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.expectResults, results); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_Update_Policy(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	deepVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "deep", Policy: &entity.VolumePolicy{MaxKeyDepth: 2}}
+	textDeniedVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "text", Policy: &entity.VolumePolicy{DeniedExtensions: []string{"txt"}}}
+	limitedVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "limited", Policy: &entity.VolumePolicy{MaxEntriesPerFolder: 1}}
+	quotaVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "quota", Policy: &entity.VolumePolicy{MaxVolumeSize: 10, MaxVolumeEntries: 3}}
+	volumes := []*entity.Volume{volume, deepVolume, textDeniedVolume, limitedVolume, quotaVolume}
+
+	newFileEntry := func() *entity.Entry {
+		return &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Key: "sample.txt", Size: 4, Type: "text/plain; charset=utf-8"}
+	}
+	newFolderEntry := func() *entity.Entry {
+		return &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Key: "key", Type: "folder"}
+	}
+	descendantEntry := &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Key: "key/sample.txt", Size: 4, Type: "text/plain; charset=utf-8"}
+
+	tests := []struct {
+		name               string
+		inputEntry         *entity.Entry
+		inputNewVolumeName string
+		inputNewKey        string
+		expectError        error
+		setMockEntryRepo   func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo    func(*mockRepository.MockBodyRepository)
+		setMockEntryServ   func(*mockService.MockEntryService)
+	}{
+		{
+			name:               "exceeds depth limit",
+			inputEntry:         newFileEntry(),
+			inputNewVolumeName: "deep",
+			inputNewKey:        "a/b/sample.txt",
+			expectError:        entity.ErrEntryTooDeep,
+			setMockEntryRepo:   func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:    func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ:   func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "type not allowed",
+			inputEntry:         newFileEntry(),
+			inputNewVolumeName: "text",
+			inputNewKey:        "sample.txt",
+			expectError:        entity.ErrEntryTypeNotAllowed,
+			setMockEntryRepo:   func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:    func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ:   func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "descendant exceeds depth limit",
+			inputEntry:         newFolderEntry(),
+			inputNewVolumeName: "deep",
+			inputNewKey:        "a/key",
+			expectError:        entity.ErrEntryTooDeep,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+			},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "descendant type not allowed",
+			inputEntry:         newFolderEntry(),
+			inputNewVolumeName: "text",
+			inputNewKey:        "key",
+			expectError:        entity.ErrEntryTypeNotAllowed,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+			},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "ancestor exceeds entry count limit",
+			inputEntry:         newFileEntry(),
+			inputNewVolumeName: "limited",
+			inputNewKey:        "dir/sample.txt",
+			expectError:        entity.ErrTooManyEntries,
+			setMockEntryRepo:   func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:    func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), limitedVolume).
+					Return(entity.ErrTooManyEntries).
+					Times(1)
+			},
+		},
+		{
+			name:               "exceeds entry count limit",
+			inputEntry:         newFileEntry(),
+			inputNewVolumeName: "limited",
+			inputNewKey:        "sample.txt",
+			expectError:        entity.ErrTooManyEntries,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					CountByParentIDAndVolumeID(gomock.Any(), uuid.Nil, limitedVolume.ID).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), limitedVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "rename in same folder at entry count limit",
+			inputEntry:         &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: limitedVolume.ID, Key: "sample.txt", Size: 4, Type: "text/plain; charset=utf-8"},
+			inputNewVolumeName: "limited",
+			inputNewKey:        "renamed.txt",
+			expectError:        nil,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), limitedVolume).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "subtree exceeds volume capacity",
+			inputEntry:         newFolderEntry(),
+			inputNewVolumeName: "quota",
+			inputNewKey:        "key",
+			expectError:        entity.ErrVolumeCapacityExceeded,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					SumByVolumeID(gomock.Any(), quotaVolume.ID).
+					Return(&entity.VolumeUsage{Size: 7, EntryCount: 0}, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "subtree exceeds volume entry count",
+			inputEntry:         newFolderEntry(),
+			inputNewVolumeName: "quota",
+			inputNewKey:        "key",
+			expectError:        entity.ErrVolumeEntryCountExceeded,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					SumByVolumeID(gomock.Any(), quotaVolume.ID).
+					Return(&entity.VolumeUsage{Size: 0, EntryCount: 2}, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "rename in same volume at volume quota",
+			inputEntry:         &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: quotaVolume.ID, Key: "sample.txt", Size: 4, Type: "text/plain; charset=utf-8"},
+			inputNewVolumeName: "quota",
+			inputNewKey:        "renamed.txt",
+			expectError:        nil,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					MoveDescendants(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			transactionObj.
+				EXPECT().
+				Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1)
+
+			srcVolume := volume
+			for _, v := range volumes {
+				if v.ID == tt.inputEntry.VolumeID {
+					srcVolume = v
+				}
+			}
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			volumeRepo.
+				EXPECT().
+				FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), accountID).
+				DoAndReturn(func(_ context.Context, name string, _ uuid.UUID) (*entity.Volume, error) {
+					for _, v := range volumes {
+						if v.Name == name {
+							return v, nil
+						}
+					}
+					return nil, repository.ErrVolumeNotFound
+				}).
+				AnyTimes()
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			entryRepo.
+				EXPECT().
+				FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), tt.inputEntry.Key, srcVolume.ID, accountID).
+				Return(tt.inputEntry, nil).
+				Times(1)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			entryServ.
+				EXPECT().
+				Resolve(gomock.Any(), gomock.Any(), gomock.Any(), srcVolume.ID, service.ConflictPolicyFail).
+				Return(nil, service.EntryResultCreated, nil).
+				Times(1)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			if _, _, err := uc.Update(ctx, accountID, srcVolume.Name, tt.inputEntry.Key, tt.inputNewVolumeName, tt.inputNewKey, ""); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestEntry_Delete(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputKey              string
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryServ      func(*mockService.MockEntryService)
+	}{
+		{
+			name:            "successfully deleted",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "delete descendants error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "delete entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "delete body error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectError:     afero.ErrFileClosed,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(afero.ErrFileClosed).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			if err := uc.Delete(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestEntry_Copy(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	copiedEntry := &entity.Entry{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		VolumeID:  entry.VolumeID,
+		Key:       "key/sample copy.txt",
+		Size:      entry.Size,
+		Type:      entry.Type,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	otherVolume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "other",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	otherEntry := &entity.Entry{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		VolumeID:  otherVolume.ID,
+		Key:       "key/sample.txt",
+		Size:      entry.Size,
+		Type:      entry.Type,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	otherEntryDTO := &dto.EntryDTO{
+		ID:        otherEntry.ID,
+		AccountID: otherEntry.AccountID,
+		VolumeID:  otherEntry.VolumeID,
+		Key:       otherEntry.Key,
+		Size:      otherEntry.Size,
+		Type:      otherEntry.Type,
+		CreatedAt: otherEntry.CreatedAt,
+		UpdatedAt: otherEntry.UpdatedAt,
+	}
+	entryDTO := &dto.EntryDTO{
+		ID:        copiedEntry.ID,
+		AccountID: copiedEntry.AccountID,
+		VolumeID:  copiedEntry.VolumeID,
+		Key:       copiedEntry.Key,
+		Size:      copiedEntry.Size,
+		Type:      copiedEntry.Type,
+		CreatedAt: copiedEntry.CreatedAt,
+		UpdatedAt: copiedEntry.UpdatedAt,
+	}
+	folderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	copiedFolderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "dst",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	dstFolderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "dst",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	copiedDstEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "dst/sample.txt",
+		Size:      entry.Size,
+		Type:      entry.Type,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	conflictEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "dst/sample.txt",
+		Size:      8,
+		Type:      entry.Type,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name                     string
+		inputAccountID           uuid.UUID
+		inputVolumeName          string
+		inputKey                 string
+		inputNewVolumeName       string
+		inputNewKey              string
+		inputConflict            string
+		expectResult             *dto.EntryDTO
+		expectResults            []*dto.EntryResultDTO
+		expectError              error
+		setMockTransactionObj    func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo         func(*mockRepository.MockEntryRepository)
+		setMockEntryMetadataRepo func(*mockRepository.MockEntryMetadataRepository)
+		setMockEntryContentRepo  func(*mockRepository.MockEntryContentRepository)
+		setMockBodyRepo          func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo        func(*mockRepository.MockVolumeRepository)
+		setMockEntryServ         func(*mockService.MockEntryService)
+	}{
+		{
+			name:            "successfully copied",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    entryDTO,
+			expectResults:   []*dto.EntryResultDTO{{Key: "key/sample copy.txt", Result: service.EntryResultRenamed}},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(copiedEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultRenamed, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "successfully copied to other volume",
+			inputAccountID:     accountID,
+			inputVolumeName:    "name",
+			inputKey:           "key/sample.txt",
+			inputNewVolumeName: "other",
+			expectResult:       otherEntryDTO,
+			expectResults:      []*dto.EntryResultDTO{{Key: "key/sample.txt", Result: service.EntryResultCreated}},
+			expectError:        nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "name", accountID).
+					Return(volume, nil).
+					Times(1)
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "other", accountID).
+					Return(otherVolume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), otherVolume.ID, "key/sample.txt").
+					Return(otherEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), otherEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "copy entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "copy descendants error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(copiedEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultRenamed, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "create entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(copiedEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultRenamed, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "copy body error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     afero.ErrFileClosed,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(afero.ErrFileClosed).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(copiedEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultRenamed, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "copy metadata error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:         func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(copiedEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultRenamed, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "copy content error",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), volume.ID, "key/sample.txt").
+					Return(copiedEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedEntry, "key/sample.txt", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultRenamed, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), gomock.Any(), "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully copied folder",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key",
+			inputNewKey:     "dst",
+			expectResult:    &dto.EntryDTO{AccountID: accountID, VolumeID: volume.ID, Key: "dst", Size: 0, Type: "folder"},
+			expectResults: []*dto.EntryResultDTO{
+				{Key: "dst", Result: service.EntryResultCreated},
+				{Key: "dst/sample.txt", Result: service.EntryResultCreated},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(folderEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), copiedFolderEntry).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{{Key: "dst/sample.txt"}}, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Copy(gomock.Any(), volume.Path()+"/key", volume.Path()+"/dst").
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), folderEntry, volume.ID, "dst").
+					Return(copiedFolderEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedFolderEntry, "key", volume.ID, service.ConflictPolicyRename).
+					Return(nil, service.EntryResultCreated, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), copiedFolderEntry, gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), copiedFolderEntry, "key", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully overwritten",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "dst/sample.txt",
+			inputConflict:   service.ConflictPolicyOverwrite,
+			expectResult:    &dto.EntryDTO{AccountID: accountID, VolumeID: volume.ID, Key: "dst/sample.txt", Size: entry.Size, Type: entry.Type},
+			expectResults:   []*dto.EntryResultDTO{{Key: "dst/sample.txt", Result: service.EntryResultOverwritten}},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), conflictEntry).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), copiedDstEntry).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Copy(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), volume.Path()+"/dst/sample.txt").
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					Copy(gomock.Any(), volume.Path()+"/key/sample.txt", volume.Path()+"/dst/sample.txt").
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), entry, volume.ID, "dst/sample.txt").
+					Return(copiedDstEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedDstEntry, "key/sample.txt", volume.ID, service.ConflictPolicyOverwrite).
+					Return(conflictEntry, service.EntryResultOverwritten, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), conflictEntry).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), copiedDstEntry, gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CopyDescendants(gomock.Any(), copiedDstEntry, "key/sample.txt", volume.ID).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "successfully merged with skip",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key",
+			inputNewKey:     "dst",
+			inputConflict:   service.ConflictPolicySkip,
+			expectResult:    &dto.EntryDTO{AccountID: accountID, VolumeID: volume.ID, Key: "dst", Size: 0, Type: "folder"},
+			expectResults: []*dto.EntryResultDTO{
+				{Key: "dst", Result: service.EntryResultMerged},
+				{Key: "dst/sample.txt", Result: service.EntryResultSkipped},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(folderEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{entry}, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), folderEntry, volume.ID, "dst").
+					Return(copiedFolderEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedFolderEntry, "key", volume.ID, service.ConflictPolicySkip).
+					Return(dstFolderEntry, service.EntryResultMerged, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), entry, volume.ID, "dst/sample.txt").
+					Return(copiedDstEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedDstEntry, "key/sample.txt", volume.ID, service.ConflictPolicySkip).
+					Return(conflictEntry, service.EntryResultSkipped, nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "entry already exists",
+			inputAccountID:  accountID,
+			inputVolumeName: "name",
+			inputKey:        "key/sample.txt",
+			inputNewKey:     "dst/sample.txt",
+			inputConflict:   service.ConflictPolicyFail,
+			expectResult:    nil,
+			expectResults:   nil,
+			expectError:     service.ErrEntryAlreadyExists,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockBodyRepo:          func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Copy(gomock.Any(), entry, volume.ID, "dst/sample.txt").
+					Return(copiedDstEntry, nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					Resolve(gomock.Any(), copiedDstEntry, "key/sample.txt", volume.ID, service.ConflictPolicyFail).
+					Return(nil, "", service.ErrEntryAlreadyExists).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			tt.setMockEntryMetadataRepo(entryMetadataRepo)
+
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+			tt.setMockEntryContentRepo(entryContentRepo)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			result, results, err := uc.Copy(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, tt.inputNewVolumeName, tt.inputNewKey, tt.inputConflict)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := cmp.Options{
+				cmpopts.IgnoreFields(dto.EntryDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.expectResults, results); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_Copy_Policy(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	deepVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "deep", Policy: &entity.VolumePolicy{MaxKeyDepth: 2}}
+	textDeniedVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "text", Policy: &entity.VolumePolicy{DeniedExtensions: []string{"txt"}}}
+	limitedVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "limited", Policy: &entity.VolumePolicy{MaxEntriesPerFolder: 1}}
+	quotaVolume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "quota", Policy: &entity.VolumePolicy{MaxVolumeSize: 10, MaxVolumeEntries: 3}}
+	volumes := []*entity.Volume{volume, deepVolume, textDeniedVolume, limitedVolume, quotaVolume}
+
+	fileEntry := &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Key: "sample.txt", Size: 4, Type: "text/plain; charset=utf-8"}
+	folderEntry := &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Key: "key", Type: "folder"}
+	descendantEntry := &entity.Entry{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Key: "key/sample.txt", Size: 4, Type: "text/plain; charset=utf-8"}
+
+	tests := []struct {
+		name               string
+		inputEntry         *entity.Entry
+		inputNewVolumeName string
+		inputNewKey        string
+		expectError        error
+		setMockEntryRepo   func(*mockRepository.MockEntryRepository)
+		setMockEntryServ   func(*mockService.MockEntryService)
+	}{
+		{
+			name:               "exceeds depth limit",
+			inputEntry:         fileEntry,
+			inputNewVolumeName: "deep",
+			inputNewKey:        "a/b/sample.txt",
+			expectError:        entity.ErrEntryTooDeep,
+			setMockEntryRepo:   func(*mockRepository.MockEntryRepository) {},
+			setMockEntryServ:   func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "type not allowed",
+			inputEntry:         fileEntry,
+			inputNewVolumeName: "text",
+			inputNewKey:        "sample.txt",
+			expectError:        entity.ErrEntryTypeNotAllowed,
+			setMockEntryRepo:   func(*mockRepository.MockEntryRepository) {},
+			setMockEntryServ:   func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "descendant exceeds depth limit",
+			inputEntry:         folderEntry,
+			inputNewVolumeName: "deep",
+			inputNewKey:        "a/key",
+			expectError:        entity.ErrEntryTooDeep,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "descendant type not allowed",
+			inputEntry:         folderEntry,
+			inputNewVolumeName: "text",
+			inputNewKey:        "key",
+			expectError:        entity.ErrEntryTypeNotAllowed,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:               "ancestor exceeds entry count limit",
+			inputEntry:         fileEntry,
+			inputNewVolumeName: "limited",
+			inputNewKey:        "dir/sample.txt",
+			expectError:        entity.ErrTooManyEntries,
+			setMockEntryRepo:   func(*mockRepository.MockEntryRepository) {},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), limitedVolume).
+					Return(entity.ErrTooManyEntries).
+					Times(1)
+			},
+		},
+		{
+			name:               "exceeds entry count limit",
+			inputEntry:         fileEntry,
+			inputNewVolumeName: "limited",
+			inputNewKey:        "sample.txt",
+			expectError:        entity.ErrTooManyEntries,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					CountByParentIDAndVolumeID(gomock.Any(), uuid.Nil, limitedVolume.ID).
+					Return(uint64(1), nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), limitedVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "exceeds volume capacity",
+			inputEntry:         fileEntry,
+			inputNewVolumeName: "quota",
+			inputNewKey:        "sample.txt",
+			expectError:        entity.ErrVolumeCapacityExceeded,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					SumByVolumeID(gomock.Any(), quotaVolume.ID).
+					Return(&entity.VolumeUsage{Size: 8, EntryCount: 1}, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "exceeds volume entry count",
+			inputEntry:         fileEntry,
+			inputNewVolumeName: "quota",
+			inputNewKey:        "sample.txt",
+			expectError:        entity.ErrVolumeEntryCountExceeded,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					SumByVolumeID(gomock.Any(), quotaVolume.ID).
+					Return(&entity.VolumeUsage{Size: 0, EntryCount: 3}, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "subtree exceeds volume capacity",
+			inputEntry:         folderEntry,
+			inputNewVolumeName: "quota",
+			inputNewKey:        "key",
+			expectError:        entity.ErrVolumeCapacityExceeded,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					SumByVolumeID(gomock.Any(), quotaVolume.ID).
+					Return(&entity.VolumeUsage{Size: 7, EntryCount: 0}, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:               "subtree exceeds volume entry count",
+			inputEntry:         folderEntry,
+			inputNewVolumeName: "quota",
+			inputNewKey:        "key",
+			expectError:        entity.ErrVolumeEntryCountExceeded,
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, gomock.Any(), nil, gomock.Any()).
+					Return([]*entity.Entry{descendantEntry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					SumByVolumeID(gomock.Any(), quotaVolume.ID).
+					Return(&entity.VolumeUsage{Size: 0, EntryCount: 2}, nil).
+					Times(1)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), quotaVolume).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			transactionObj.
+				EXPECT().
+				Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			volumeRepo.
+				EXPECT().
+				FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), accountID).
+				DoAndReturn(func(_ context.Context, name string, _ uuid.UUID) (*entity.Volume, error) {
+					for _, v := range volumes {
+						if v.Name == name {
+							return v, nil
+						}
+					}
+					return nil, repository.ErrVolumeNotFound
+				}).
+				AnyTimes()
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			entryRepo.
+				EXPECT().
+				FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), tt.inputEntry.Key, volume.ID, accountID).
+				Return(tt.inputEntry, nil).
+				Times(1)
+			tt.setMockEntryRepo(entryRepo)
+
+			entryServ := mockService.NewMockEntryService(ctrl)
+			entryServ.
+				EXPECT().
+				Copy(gomock.Any(), tt.inputEntry, gomock.Any(), tt.inputNewKey).
+				DoAndReturn(func(_ context.Context, src *entity.Entry, volumeID uuid.UUID, key string) (*entity.Entry, error) {
+					return &entity.Entry{ID: uuid.New(), AccountID: src.AccountID, VolumeID: volumeID, Key: key, Size: src.Size, Type: src.Type}, nil
+				}).
+				Times(1)
+			entryServ.
+				EXPECT().
+				Resolve(gomock.Any(), gomock.Any(), tt.inputEntry.Key, volume.ID, service.ConflictPolicyRename).
+				Return(nil, service.EntryResultCreated, nil).
+				Times(1)
+			tt.setMockEntryServ(entryServ)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, nil, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			if _, _, err := uc.Copy(ctx, accountID, volume.Name, tt.inputEntry.Key, tt.inputNewVolumeName, tt.inputNewKey, ""); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestEntry_Batch(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	folderEntryDTO := &dto.EntryDTO{
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "folder",
+		Size:      0,
+		Type:      "folder",
+	}
+	deleteOperation := &dto.EntryOperationDTO{Type: usecase.EntryOperationDelete, Key: "key/sample.txt"}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputAtomic           bool
+		inputOperations       []*dto.EntryOperationDTO
+		expectResult          []*dto.EntryOperationResultDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryServ      func(*mockService.MockEntryService)
+	}{
+		{
+			name:            "successfully executed atomically",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputAtomic:     true,
+			inputOperations: []*dto.EntryOperationDTO{
+				{Type: usecase.EntryOperationCreateFolder, Key: "folder"},
+				deleteOperation,
+			},
+			expectResult: []*dto.EntryOperationResultDTO{
+				{Type: usecase.EntryOperationCreateFolder, Entry: folderEntryDTO},
+				{Type: usecase.EntryOperationDelete},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(2)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					Exists(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "rolled back atomically",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputAtomic:     true,
+			inputOperations: []*dto.EntryOperationDTO{deleteOperation, deleteOperation, deleteOperation},
+			expectResult: []*dto.EntryOperationResultDTO{
+				{Type: usecase.EntryOperationDelete, Error: usecase.ErrEntryOperationRolledBack},
+				{Type: usecase.EntryOperationDelete, Error: sql.ErrConnDone},
+				{Type: usecase.EntryOperationDelete, Error: usecase.ErrEntryOperationNotExecuted},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				gomock.InOrder(
+					entryRepo.
+						EXPECT().
+						FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(entry, nil).
+						Times(1),
+					entryRepo.
+						EXPECT().
+						FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, sql.ErrConnDone).
+						Times(1),
+				)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(2)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "executed with best effort",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputAtomic:     false,
+			inputOperations: []*dto.EntryOperationDTO{deleteOperation, deleteOperation},
+			expectResult: []*dto.EntryOperationResultDTO{
+				{Type: usecase.EntryOperationDelete, Error: sql.ErrConnDone},
+				{Type: usecase.EntryOperationDelete},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				gomock.InOrder(
+					entryRepo.
+						EXPECT().
+						FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil, sql.ErrConnDone).
+						Times(1),
+					entryRepo.
+						EXPECT().
+						FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(entry, nil).
+						Times(1),
+				)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(2)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "invalid operation",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputAtomic:     true,
+			inputOperations: []*dto.EntryOperationDTO{{Type: "invalid", Key: "key/sample.txt"}},
+			expectResult: []*dto.EntryOperationResultDTO{
+				{Type: "invalid", Error: usecase.ErrInvalidEntryOperation},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo:  func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:   func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(*mockRepository.MockVolumeRepository) {},
+			setMockEntryServ:  func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "transaction error",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputAtomic:     true,
+			inputOperations: []*dto.EntryOperationDTO{deleteOperation},
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryRepo:  func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:   func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(*mockRepository.MockVolumeRepository) {},
+			setMockEntryServ:  func(*mockService.MockEntryService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
 			ctx := t.Context()
@@ -428,14 +4907,21 @@ func TestEntry_Create(t *testing.T) {
 			entryServ := mockService.NewMockEntryService(ctrl)
 			tt.setMockEntryServ(entryServ)
 
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, entryServ)
-			result, err := uc.Create(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, tt.inputSize, tt.inputBody)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, bodyRepo, volumeRepo, nil, entryServ, eventServ, usecase.ScanActionReject)
+			result, err := uc.Batch(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputAtomic, tt.inputOperations)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 
 			opts := cmp.Options{
 				cmpopts.IgnoreFields(dto.EntryDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+				cmpopts.EquateErrors(),
 			}
 			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
 				t.Error(diff)
@@ -444,7 +4930,7 @@ func TestEntry_Create(t *testing.T) {
 	}
 }
 
-func TestEntry_Update(t *testing.T) {
+func TestEntry_GetMeta(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
@@ -468,15 +4954,195 @@ func TestEntry_Update(t *testing.T) {
 		ID:        entry.ID,
 		AccountID: entry.AccountID,
 		VolumeID:  entry.VolumeID,
-		Key:       "update/sample.txt",
+		Key:       entry.Key,
 		Size:      entry.Size,
 		Type:      entry.Type,
 		CreatedAt: entry.CreatedAt,
 		UpdatedAt: entry.UpdatedAt,
 	}
 
-	initEntry := func() error {
-		return entry.SetKey("key/sample.txt")
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputKey              string
+		expectResult          *dto.EntryDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+	}{
+		{
+			name:            "successfully got meta",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectResult:    entryDTO,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "find entry error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputKey:        "key/sample.txt",
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := t.Context()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, nil, volumeRepo, nil, nil, eventServ, usecase.ScanActionReject)
+			result, err := uc.GetMeta(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEntry_GetOne(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "name",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	entryDTO := &dto.EntryDTO{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		VolumeID:  entry.VolumeID,
+		Key:       entry.Key,
+		Size:      entry.Size,
+		Type:      entry.Type,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+	compressedEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		Encoding:  "gzip",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	compressedEntryDTO := &dto.EntryDTO{
+		ID:        compressedEntry.ID,
+		AccountID: compressedEntry.AccountID,
+		VolumeID:  compressedEntry.VolumeID,
+		Key:       compressedEntry.Key,
+		Size:      compressedEntry.Size,
+		Type:      compressedEntry.Type,
+		Encoding:  compressedEntry.Encoding,
+		CreatedAt: compressedEntry.CreatedAt,
+		UpdatedAt: compressedEntry.UpdatedAt,
 	}
 
 	tests := []struct {
@@ -484,23 +5150,24 @@ func TestEntry_Update(t *testing.T) {
 		inputAccountID        uuid.UUID
 		inputVolumeName       string
 		inputKey              string
-		inputNewKey           string
-		expectResult          *dto.EntryDTO
+		inputAcceptEncodings  []string
+		expectEntry           *dto.EntryDTO
+		expectBody            io.ReadCloser
 		expectError           error
 		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
 		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
 		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
 		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
-		setMockEntryServ      func(*mockService.MockEntryService)
 	}{
 		{
-			name:            "successfully updated",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    entryDTO,
-			expectError:     nil,
+			name:                 "successfully got one",
+			inputAccountID:       accountID,
+			inputVolumeName:      "volume",
+			inputKey:             "key/sample.txt",
+			inputAcceptEncodings: []string{"gzip"},
+			expectEntry:          entryDTO,
+			expectBody:           nil,
+			expectError:          nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -516,17 +5183,12 @@ func TestEntry_Update(t *testing.T) {
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(entry, nil).
 					Times(1)
-				entryRepo.
-					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
 			},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
-					Return(nil).
+					FindOneByPath(gomock.Any(), gomock.Any(), &entity.BodyAttributes{}).
+					Return(nil, nil).
 					Times(1)
 			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
@@ -536,60 +5198,16 @@ func TestEntry_Update(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Exists(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					UpdateDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
-		},
-		{
-			name:            "find volume error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
-					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
-					Times(1)
-			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
-			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
-				volumeRepo.
-					EXPECT().
-					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
-					Times(1)
-			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
 		},
 		{
-			name:            "find entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:                 "successfully got compressed one",
+			inputAccountID:       accountID,
+			inputVolumeName:      "volume",
+			inputKey:             "key/sample.txt",
+			inputAcceptEncodings: []string{"zstd", "gzip"},
+			expectEntry:          compressedEntryDTO,
+			expectBody:           nil,
+			expectError:          nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -603,44 +5221,16 @@ func TestEntry_Update(t *testing.T) {
 				entryRepo.
 					EXPECT().
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
-					Times(1)
-			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
-			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
-				volumeRepo.
-					EXPECT().
-					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
-					Times(1)
-			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
-		},
-		{
-			name:            "invalid update key",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			inputNewKey:     "",
-			expectResult:    nil,
-			expectError:     entity.ErrShortEntryKey,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
-					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
+					Return(compressedEntry, nil).
 					Times(1)
 			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					FindOneByPath(gomock.Any(), gomock.Any(), &entity.BodyAttributes{Encoding: "gzip", ContentEncoding: "gzip"}).
+					Return(nil, nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -648,16 +5238,16 @@ func TestEntry_Update(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
 		},
 		{
-			name:            "entry already exists",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
-			expectError:     service.ErrEntryAlreadyExists,
+			name:                 "successfully got decompressed one",
+			inputAccountID:       accountID,
+			inputVolumeName:      "volume",
+			inputKey:             "key/sample.txt",
+			inputAcceptEncodings: []string{"zstd"},
+			expectEntry:          compressedEntryDTO,
+			expectBody:           nil,
+			expectError:          nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -671,10 +5261,16 @@ func TestEntry_Update(t *testing.T) {
 				entryRepo.
 					EXPECT().
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					Return(compressedEntry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), &entity.BodyAttributes{Encoding: "gzip"}).
+					Return(nil, nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -682,21 +5278,14 @@ func TestEntry_Update(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Exists(gomock.Any(), gomock.Any()).
-					Return(service.ErrEntryAlreadyExists).
-					Times(1)
-			},
 		},
 		{
-			name:            "create ancestors error",
+			name:            "find volume error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
+			expectEntry:     nil,
+			expectBody:      nil,
 			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
@@ -707,41 +5296,23 @@ func TestEntry_Update(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
-					Times(1)
-			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
-					Times(1)
-			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Exists(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
 		{
-			name:            "update descendants error",
+			name:            "find entry error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
+			expectEntry:     nil,
+			expectBody:      nil,
 			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
@@ -756,7 +5327,7 @@ func TestEntry_Update(t *testing.T) {
 				entryRepo.
 					EXPECT().
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
@@ -767,32 +5338,15 @@ func TestEntry_Update(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Exists(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					UpdateDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
-					Times(1)
-			},
 		},
 		{
-			name:            "update entry error",
+			name:            "quarantined",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			expectEntry:     nil,
+			expectBody:      nil,
+			expectError:     entity.ErrEntryQuarantined,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -806,12 +5360,18 @@ func TestEntry_Update(t *testing.T) {
 				entryRepo.
 					EXPECT().
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
-					Times(1)
-				entryRepo.
-					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
+					Return(&entity.Entry{
+						ID:            entry.ID,
+						AccountID:     entry.AccountID,
+						VolumeID:      entry.VolumeID,
+						Key:           entry.Key,
+						Size:          entry.Size,
+						Type:          entry.Type,
+						ScanStatus:    entity.EntryScanStatusInfected,
+						ScanSignature: "Eicar-Test-Signature",
+						CreatedAt:     entry.CreatedAt,
+						UpdatedAt:     entry.UpdatedAt,
+					}, nil).
 					Times(1)
 			},
 			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
@@ -822,32 +5382,15 @@ func TestEntry_Update(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Exists(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					UpdateDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
 		},
 		{
-			name:            "update body error",
+			name:            "find body error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputKey:        "key/sample.txt",
-			inputNewKey:     "update/sample.txt",
-			expectResult:    nil,
-			expectError:     afero.ErrFileClosed,
+			expectEntry:     nil,
+			expectBody:      nil,
+			expectError:     afero.ErrFileNotFound,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -863,17 +5406,12 @@ func TestEntry_Update(t *testing.T) {
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(entry, nil).
 					Times(1)
-				entryRepo.
-					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
 			},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
-					Return(afero.ErrFileClosed).
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, afero.ErrFileNotFound).
 					Times(1)
 			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
@@ -883,31 +5421,10 @@ func TestEntry_Update(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Exists(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					CreateAncestors(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					UpdateDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := initEntry(); err != nil {
-				t.Error(err)
-			}
-
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
@@ -925,26 +5442,27 @@ func TestEntry_Update(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			entryServ := mockService.NewMockEntryService(ctrl)
-			tt.setMockEntryServ(entryServ)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, entryServ)
-			result, err := uc.Update(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, tt.inputNewKey)
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, bodyRepo, volumeRepo, nil, nil, eventServ, usecase.ScanActionReject)
+			entry, body, err := uc.GetOne(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey, tt.inputAcceptEncodings)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 
-			opts := cmp.Options{
-				cmpopts.IgnoreFields(dto.EntryDTO{}, "UpdatedAt"),
+			if diff := cmp.Diff(tt.expectEntry, entry); diff != "" {
+				t.Error(diff)
 			}
-			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+
+			if diff := cmp.Diff(tt.expectBody, body); diff != "" {
 				t.Error(diff)
 			}
 		})
 	}
 }
 
-func TestEntry_Delete(t *testing.T) {
+func TestEntry_GetThumbnail(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
@@ -955,6 +5473,16 @@ func TestEntry_Delete(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.png",
+		Size:      4,
+		Type:      "image/png",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	textEntry := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		VolumeID:  volume.ID,
@@ -964,24 +5492,37 @@ func TestEntry_Delete(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	thumbnailDTO := &dto.ThumbnailDTO{Type: "image/png", ETag: entry.ETag(), UpdatedAt: entry.UpdatedAt}
+	path := volume.Path() + "/key/sample.png"
+	name := "thumbnail:100x100:" + entry.ETag()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 400, 200))); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := thumbnail.Generate(bytes.NewReader(buf.Bytes()), "image/png", 100, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		name                  string
-		inputAccountID        uuid.UUID
-		inputVolumeName       string
-		inputKey              string
+		inputWidth            uint64
+		inputHeight           uint64
+		expectThumbnail       *dto.ThumbnailDTO
+		expectBody            []byte
 		expectError           error
 		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
 		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
 		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
 		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
-		setMockEntryServ      func(*mockService.MockEntryService)
 	}{
 		{
-			name:            "successfully deleted",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
+			name:            "successfully got cached thumbnail",
+			inputWidth:      100,
+			inputHeight:     100,
+			expectThumbnail: thumbnailDTO,
+			expectBody:      []byte("cached"),
 			expectError:     nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
@@ -998,17 +5539,12 @@ func TestEntry_Delete(t *testing.T) {
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(entry, nil).
 					Times(1)
-				entryRepo.
-					EXPECT().
-					Delete(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
 			},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Delete(gomock.Any()).
-					Return(nil).
+					FindOneDerived(gomock.Any(), path, name).
+					Return(io.NopCloser(bytes.NewBufferString("cached")), nil).
 					Times(1)
 			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
@@ -1018,20 +5554,14 @@ func TestEntry_Delete(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					DeleteDescendants(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
 		},
 		{
-			name:            "find volume error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectError:     sql.ErrConnDone,
+			name:            "successfully generated thumbnail",
+			inputWidth:      100,
+			inputHeight:     100,
+			expectThumbnail: thumbnailDTO,
+			expectBody:      generated,
+			expectError:     nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1041,40 +5571,30 @@ func TestEntry_Delete(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
-			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
-				volumeRepo.
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
 					EXPECT().
-					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
-		},
-		{
-			name:            "find entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectError:     sql.ErrConnDone,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
 					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
+					FindOneDerived(gomock.Any(), path, name).
+					Return(nil, nil).
 					Times(1)
-			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
+				bodyRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					FindOneByPath(gomock.Any(), path, gomock.Any()).
+					Return(io.NopCloser(bytes.NewReader(buf.Bytes())), nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					CreateDerived(gomock.Any(), path, name, gomock.Any()).
+					Return(nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1082,14 +5602,26 @@ func TestEntry_Delete(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
 		},
 		{
-			name:            "delete descendants error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectError:     sql.ErrConnDone,
+			name:                  "invalid size",
+			inputWidth:            0,
+			inputHeight:           100,
+			expectThumbnail:       nil,
+			expectBody:            nil,
+			expectError:           thumbnail.ErrInvalidSize,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockEntryRepo:      func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo:     func(*mockRepository.MockVolumeRepository) {},
+		},
+		{
+			name:            "unsupported entry",
+			inputWidth:      100,
+			inputHeight:     100,
+			expectThumbnail: nil,
+			expectBody:      nil,
+			expectError:     thumbnail.ErrUnsupportedImage,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1103,7 +5635,7 @@ func TestEntry_Delete(t *testing.T) {
 				entryRepo.
 					EXPECT().
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					Return(textEntry, nil).
 					Times(1)
 			},
 			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
@@ -1114,19 +5646,13 @@ func TestEntry_Delete(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					DeleteDescendants(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
-					Times(1)
-			},
 		},
 		{
-			name:            "delete entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
+			name:            "find volume error",
+			inputWidth:      100,
+			inputHeight:     100,
+			expectThumbnail: nil,
+			expectBody:      nil,
 			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
@@ -1137,40 +5663,23 @@ func TestEntry_Delete(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
-					Times(1)
-				entryRepo.
-					EXPECT().
-					Delete(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
-					Times(1)
-			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
-					Times(1)
-			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					DeleteDescendants(gomock.Any(), gomock.Any()).
-					Return(nil).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
 		{
-			name:            "delete body error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectError:     afero.ErrFileClosed,
+			name:            "create derived error",
+			inputWidth:      100,
+			inputHeight:     100,
+			expectThumbnail: nil,
+			expectBody:      nil,
+			expectError:     io.ErrShortWrite,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1186,17 +5695,22 @@ func TestEntry_Delete(t *testing.T) {
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(entry, nil).
 					Times(1)
-				entryRepo.
-					EXPECT().
-					Delete(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
 			},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Delete(gomock.Any()).
-					Return(afero.ErrFileClosed).
+					FindOneDerived(gomock.Any(), path, name).
+					Return(nil, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), path, gomock.Any()).
+					Return(io.NopCloser(bytes.NewReader(buf.Bytes())), nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					CreateDerived(gomock.Any(), path, name, gomock.Any()).
+					Return(io.ErrShortWrite).
 					Times(1)
 			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
@@ -1206,13 +5720,6 @@ func TestEntry_Delete(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					DeleteDescendants(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
 		},
 	}
 	for _, tt := range tests {
@@ -1234,18 +5741,34 @@ func TestEntry_Delete(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			entryServ := mockService.NewMockEntryService(ctrl)
-			tt.setMockEntryServ(entryServ)
-
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, entryServ)
-			if err := uc.Delete(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey); !errors.Is(err, tt.expectError) {
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, bodyRepo, volumeRepo, nil, nil, nil, usecase.ScanActionReject)
+			result, body, err := uc.GetThumbnail(ctx, accountID, "name", "key", tt.inputWidth, tt.inputHeight)
+			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
+
+			if diff := cmp.Diff(tt.expectThumbnail, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if tt.expectBody == nil {
+				if body != nil {
+					t.Error("body is returned")
+				}
+				return
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(tt.expectBody, data); diff != "" {
+				t.Error(diff)
+			}
 		})
 	}
 }
 
-func TestEntry_Copy(t *testing.T) {
+func TestEntry_Scan(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
@@ -1255,7 +5778,17 @@ func TestEntry_Copy(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	entry := &entity.Entry{
+	folderEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key",
+		Size:      0,
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	fileEntry := &entity.Entry{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		VolumeID:  volume.ID,
@@ -1265,47 +5798,144 @@ func TestEntry_Copy(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	copiedEntry := &entity.Entry{
-		ID:        entry.ID,
-		AccountID: entry.AccountID,
-		VolumeID:  entry.VolumeID,
-		Key:       "key/sample copy.txt",
-		Size:      entry.Size,
-		Type:      entry.Type,
+	otherEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/other.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	entryDTO := &dto.EntryDTO{
-		ID:        copiedEntry.ID,
-		AccountID: copiedEntry.AccountID,
-		VolumeID:  copiedEntry.VolumeID,
-		Key:       copiedEntry.Key,
-		Size:      copiedEntry.Size,
-		Type:      copiedEntry.Type,
-		CreatedAt: copiedEntry.CreatedAt,
-		UpdatedAt: copiedEntry.UpdatedAt,
-	}
 
 	tests := []struct {
 		name                  string
-		inputAccountID        uuid.UUID
-		inputVolumeName       string
 		inputKey              string
-		expectResult          *dto.EntryDTO
+		hasScanner            bool
 		expectError           error
 		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
 		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
 		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
 		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
-		setMockEntryServ      func(*mockService.MockEntryService)
+		setMockScannerRepo    func(*mockRepository.MockScannerRepository)
 	}{
 		{
-			name:            "successfully copied",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    entryDTO,
-			expectError:     nil,
+			name:        "scan volume",
+			inputKey:    "",
+			hasScanner:  true,
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, nil, nil, gomock.Any()).
+					Return([]*entity.Entry{folderEntry, fileEntry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key/sample.txt", volume.ID, accountID).
+					Return(fileEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "scan folder",
+			inputKey:    "key",
+			hasScanner:  true,
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key", volume.ID, accountID).
+					Return(folderEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, &folderEntry.Key, nil, gomock.Any()).
+					Return([]*entity.Entry{fileEntry}, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key/sample.txt", volume.ID, accountID).
+					Return(fileEntry, nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
+					EXPECT().
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "scan file",
+			inputKey:    "key/sample.txt",
+			hasScanner:  true,
+			expectError: nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1313,25 +5943,25 @@ func TestEntry_Copy(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					}).
-					Times(1)
+					Times(2)
 			},
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
-					Times(1)
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key/sample.txt", volume.ID, accountID).
+					Return(fileEntry, nil).
+					Times(2)
 				entryRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(nil).
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
 					Times(1)
 			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
@@ -1341,26 +5971,30 @@ func TestEntry_Copy(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(copiedEntry, nil).
-					Times(1)
-				entryServ.
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
 					EXPECT().
-					CopyDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
+					Scan(gomock.Any(), gomock.Any()).
+					Return("Eicar-Test-Signature", nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "find volume error",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:                  "scanner not configured",
+			inputKey:              "",
+			hasScanner:            false,
+			expectError:           usecase.ErrScannerNotConfigured,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockEntryRepo:      func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo:     func(*mockRepository.MockVolumeRepository) {},
+			setMockScannerRepo:    func(*mockRepository.MockScannerRepository) {},
+		},
+		{
+			name:        "find volume error",
+			inputKey:    "",
+			hasScanner:  true,
+			expectError: sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1379,15 +6013,13 @@ func TestEntry_Copy(t *testing.T) {
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
+			setMockScannerRepo: func(*mockRepository.MockScannerRepository) {},
 		},
 		{
-			name:            "find entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:        "find entry error",
+			inputKey:    "key/sample.txt",
+			hasScanner:  true,
+			expectError: sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1412,15 +6044,13 @@ func TestEntry_Copy(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(*mockService.MockEntryService) {},
+			setMockScannerRepo: func(*mockRepository.MockScannerRepository) {},
 		},
 		{
-			name:            "copy entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:        "scan error",
+			inputKey:    "key/sample.txt",
+			hasScanner:  true,
+			expectError: status.Error(code.FailedDependency, "failed to scan entries: key/sample.txt"),
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1428,16 +6058,22 @@ func TestEntry_Copy(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					}).
-					Times(1)
+					Times(2)
 			},
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
 					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					Return(fileEntry, nil).
+					Times(2)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(io.NopCloser(bytes.NewBufferString("test")), nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1445,21 +6081,19 @@ func TestEntry_Copy(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
 					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", repository.ErrScanFailed).
 					Times(1)
 			},
 		},
 		{
-			name:            "copy descendants error",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
+			name:        "continue after scan error",
+			inputKey:    "",
+			hasScanner:  true,
+			expectError: status.Error(code.FailedDependency, "failed to scan entries: key/sample.txt"),
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1467,65 +6101,39 @@ func TestEntry_Copy(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					}).
-					Times(1)
+					Times(3)
 			},
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, nil, nil, gomock.Any()).
+					Return([]*entity.Entry{fileEntry, otherEntry}, nil).
 					Times(1)
-			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
-			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
-				volumeRepo.
+				entryRepo.
 					EXPECT().
-					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key/sample.txt", volume.ID, accountID).
+					Return(fileEntry, nil).
 					Times(1)
-			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
+				entryRepo.
 					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(copiedEntry, nil).
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key/other.txt", volume.ID, accountID).
+					Return(otherEntry, nil).
 					Times(1)
-				entryServ.
+				entryRepo.
 					EXPECT().
-					CopyDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
+					Update(gomock.Any(), otherEntry).
+					Return(nil).
 					Times(1)
 			},
-		},
-		{
-			name:            "create entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
 					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(context.Context, string, *entity.BodyAttributes) (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewBufferString("test")), nil
 					}).
-					Times(1)
-			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
-					Times(1)
-				entryRepo.
-					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
-					Return(sql.ErrConnDone).
-					Times(1)
+					Times(2)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1533,26 +6141,24 @@ func TestEntry_Copy(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
+			setMockScannerRepo: func(scannerRepo *mockRepository.MockScannerRepository) {
+				scannerRepo.
 					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(copiedEntry, nil).
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", repository.ErrScanFailed).
 					Times(1)
-				entryServ.
+				scannerRepo.
 					EXPECT().
-					CopyDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
+					Scan(gomock.Any(), gomock.Any()).
+					Return("", nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "copy body error",
-			inputAccountID:  accountID,
-			inputVolumeName: "name",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     afero.ErrFileClosed,
+			name:        "skip moved entry",
+			inputKey:    "",
+			hasScanner:  true,
+			expectError: nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1560,27 +6166,21 @@ func TestEntry_Copy(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 						return fn(ctx)
 					}).
-					Times(1)
+					Times(2)
 			},
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, nil, nil, gomock.Any()).
+					Return([]*entity.Entry{fileEntry}, nil).
 					Times(1)
 				entryRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
-			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
-				bodyRepo.
-					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(afero.ErrFileClosed).
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), "key/sample.txt", volume.ID, accountID).
+					Return(nil, repository.ErrEntryNotFound).
 					Times(1)
 			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1588,18 +6188,7 @@ func TestEntry_Copy(t *testing.T) {
 					Return(volume, nil).
 					Times(1)
 			},
-			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
-				entryServ.
-					EXPECT().
-					Copy(gomock.Any(), gomock.Any()).
-					Return(copiedEntry, nil).
-					Times(1)
-				entryServ.
-					EXPECT().
-					CopyDescendants(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil).
-					Times(1)
-			},
+			setMockScannerRepo: func(*mockRepository.MockScannerRepository) {},
 		},
 	}
 	for _, tt := range tests {
@@ -1621,90 +6210,47 @@ func TestEntry_Copy(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			entryServ := mockService.NewMockEntryService(ctrl)
-			tt.setMockEntryServ(entryServ)
-
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, entryServ)
-			result, err := uc.Copy(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey)
-			if !errors.Is(err, tt.expectError) {
-				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			var scannerRepo repository.ScannerRepository
+			if tt.hasScanner {
+				mockScannerRepo := mockRepository.NewMockScannerRepository(ctrl)
+				tt.setMockScannerRepo(mockScannerRepo)
+				scannerRepo = mockScannerRepo
 			}
 
-			opts := cmp.Options{
-				cmpopts.IgnoreFields(dto.EntryDTO{}, "ID", "CreatedAt", "UpdatedAt"),
-			}
-			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
-				t.Error(diff)
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, nil, bodyRepo, volumeRepo, scannerRepo, nil, nil, usecase.ScanActionReject)
+			if err := uc.Scan(ctx, accountID, volume.Name, tt.inputKey); !status.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
 		})
 	}
 }
 
-func TestEntry_GetMeta(t *testing.T) {
+func TestEntry_ValidateSize(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
 		AccountID: accountID,
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &entity.VolumePolicy{MaxFileSize: 4},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	entry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volume.ID,
-		Key:       "key/sample.txt",
-		Size:      4,
-		Type:      "text/plain; charset=utf-8",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	entryDTO := &dto.EntryDTO{
-		ID:        entry.ID,
-		AccountID: entry.AccountID,
-		VolumeID:  entry.VolumeID,
-		Key:       entry.Key,
-		Size:      entry.Size,
-		Type:      entry.Type,
-		CreatedAt: entry.CreatedAt,
-		UpdatedAt: entry.UpdatedAt,
-	}
 
 	tests := []struct {
-		name                  string
-		inputAccountID        uuid.UUID
-		inputVolumeName       string
-		inputKey              string
-		expectResult          *dto.EntryDTO
-		expectError           error
-		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
-		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
-		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		name              string
+		inputAccountID    uuid.UUID
+		inputVolumeName   string
+		inputSize         uint64
+		expectError       error
+		setMockVolumeRepo func(*mockRepository.MockVolumeRepository)
 	}{
 		{
-			name:            "successfully got meta",
+			name:            "valid size",
 			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectResult:    entryDTO,
+			inputVolumeName: volume.Name,
+			inputSize:       4,
 			expectError:     nil,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
-					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
-					Times(1)
-			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
-					Times(1)
-			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1714,58 +6260,30 @@ func TestEntry_GetMeta(t *testing.T) {
 			},
 		},
 		{
-			name:            "find volume error",
+			name:            "too large",
 			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
-					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
-					Times(1)
-			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			inputVolumeName: volume.Name,
+			inputSize:       5,
+			expectError:     entity.ErrEntryTooLarge,
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
-					Times(1)
-			},
-		},
-		{
-			name:            "find entry error",
-			inputAccountID:  accountID,
-			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectResult:    nil,
-			expectError:     sql.ErrConnDone,
-			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
-				transactionObj.
-					EXPECT().
-					Transaction(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
-						return fn(ctx)
-					}).
-					Times(1)
-			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Return(volume, nil).
 					Times(1)
 			},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: volume.Name,
+			inputSize:       4,
+			expectError:     sql.ErrConnDone,
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
@@ -1777,29 +6295,18 @@ func TestEntry_GetMeta(t *testing.T) {
 
 			ctx := t.Context()
 
-			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
-			tt.setMockTransactionObj(transactionObj)
-
-			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
-			tt.setMockEntryRepo(entryRepo)
-
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, volumeRepo, nil)
-			result, err := uc.GetMeta(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey)
-			if !errors.Is(err, tt.expectError) {
+			uc := usecase.NewEntryUsecase(nil, nil, nil, nil, nil, volumeRepo, nil, nil, nil, usecase.ScanActionReject)
+			if err := uc.ValidateSize(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputSize); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
-
-			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
-				t.Error(diff)
-			}
 		})
 	}
 }
 
-func TestEntry_GetOne(t *testing.T) {
+func TestEntry_Search(t *testing.T) {
 	accountID := uuid.New()
 	volume := &entity.Volume{
 		ID:        uuid.New(),
@@ -1829,27 +6336,84 @@ func TestEntry_GetOne(t *testing.T) {
 		CreatedAt: entry.CreatedAt,
 		UpdatedAt: entry.UpdatedAt,
 	}
+	takenAt := time.Date(2026, 10, 19, 3, 34, 56, 0, time.UTC)
+	photo := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.jpg",
+		Size:      4,
+		Type:      "image/jpeg",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	photoMetadata := &entity.EntryMetadata{EntryID: photo.ID, Width: 40, Height: 20, TakenAt: &takenAt, CameraMake: "Holos"}
+	oldPhoto := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/old.jpg",
+		Size:      4,
+		Type:      "image/jpeg",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	folder := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key",
+		Type:      "folder",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	photoDTO := &dto.EntryDTO{
+		ID:        photo.ID,
+		AccountID: photo.AccountID,
+		VolumeID:  photo.VolumeID,
+		Key:       photo.Key,
+		Size:      photo.Size,
+		Type:      photo.Type,
+		Metadata:  &dto.EntryMetadataDTO{Width: 40, Height: 20, TakenAt: &takenAt, CameraMake: "Holos"},
+		CreatedAt: photo.CreatedAt,
+		UpdatedAt: photo.UpdatedAt,
+	}
+	takenFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	snippetEntryDTO := &dto.EntryDTO{
+		ID:        entry.ID,
+		AccountID: entry.AccountID,
+		VolumeID:  entry.VolumeID,
+		Key:       entry.Key,
+		Size:      entry.Size,
+		Type:      entry.Type,
+		Snippet:   "test content",
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
 
 	tests := []struct {
-		name                  string
-		inputAccountID        uuid.UUID
-		inputVolumeName       string
-		inputKey              string
-		expectEntry           *dto.EntryDTO
-		expectBody            io.ReadCloser
-		expectError           error
-		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
-		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
-		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
-		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		name                     string
+		inputAccountID           uuid.UUID
+		inputVolumeName          string
+		inputPrefix              *string
+		inputDepth               *uint64
+		inputCondition           *dto.EntryConditionDTO
+		expectResult             []*dto.EntryDTO
+		expectError              error
+		setMockTransactionObj    func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo         func(*mockRepository.MockEntryRepository)
+		setMockEntryMetadataRepo func(*mockRepository.MockEntryMetadataRepository)
+		setMockEntryContentRepo  func(*mockRepository.MockEntryContentRepository)
+		setMockVolumeRepo        func(*mockRepository.MockVolumeRepository)
 	}{
 		{
-			name:            "successfully got one",
+			name:            "successfully searched by metadata",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectEntry:     entryDTO,
-			expectBody:      nil,
+			inputPrefix:     nil,
+			inputDepth:      nil,
+			inputCondition:  &dto.EntryConditionDTO{TakenFrom: &takenFrom, Camera: "holos"},
+			expectResult:    []*dto.EntryDTO{photoDTO},
 			expectError:     nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
@@ -1863,17 +6427,18 @@ func TestEntry_GetOne(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, accountID, nil, nil, &repository.EntryCondition{TakenFrom: &takenFrom, Camera: "holos"}).
+					Return([]*entity.Entry{photo}, nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
-				bodyRepo.
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
 					EXPECT().
-					FindOneByPath(gomock.Any()).
-					Return(nil, nil).
+					FindByEntryIDs(gomock.Any(), []uuid.UUID{photo.ID}).
+					Return([]*entity.EntryMetadata{photoMetadata}, nil).
 					Times(1)
 			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1883,13 +6448,14 @@ func TestEntry_GetOne(t *testing.T) {
 			},
 		},
 		{
-			name:            "find volume error",
+			name:            "successfully searched by content",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectEntry:     nil,
-			expectBody:      nil,
-			expectError:     sql.ErrConnDone,
+			inputPrefix:     nil,
+			inputDepth:      nil,
+			inputCondition:  &dto.EntryConditionDTO{Query: "test content"},
+			expectResult:    []*dto.EntryDTO{snippetEntryDTO},
+			expectError:     nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1899,24 +6465,46 @@ func TestEntry_GetOne(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
-			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{folder, entry, photo}, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					FindByEntryIDs(gomock.Any(), []uuid.UUID{entry.ID, photo.ID}).
+					Return([]*entity.EntryMetadata{photoMetadata}, nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Search(gomock.Any(), volume.ID, accountID, []string{"test", "content"}, gomock.Any()).
+					Return([]*entity.EntryContentMatch{
+						{EntryID: oldPhoto.ID, Snippet: "out of range"},
+						{EntryID: entry.ID, Snippet: "test content"},
+					}, nil).
+					Times(1)
+			},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Return(volume, nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "find entry error",
+			name:            "successfully searched",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectEntry:     nil,
-			expectBody:      nil,
-			expectError:     sql.ErrConnDone,
+			inputPrefix:     nil,
+			inputDepth:      nil,
+			expectResult:    []*dto.EntryDTO{entryDTO},
+			expectError:     nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1929,11 +6517,18 @@ func TestEntry_GetOne(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{entry}, nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					FindByEntryIDs(gomock.Any(), []uuid.UUID{entry.ID}).
+					Return([]*entity.EntryMetadata{}, nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1943,13 +6538,13 @@ func TestEntry_GetOne(t *testing.T) {
 			},
 		},
 		{
-			name:            "find body error",
+			name:            "entry not found",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
-			inputKey:        "key/sample.txt",
-			expectEntry:     nil,
-			expectBody:      nil,
-			expectError:     afero.ErrFileNotFound,
+			inputPrefix:     nil,
+			inputDepth:      nil,
+			expectResult:    []*dto.EntryDTO{},
+			expectError:     nil,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -1962,17 +6557,18 @@ func TestEntry_GetOne(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(entry, nil).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil).
 					Times(1)
 			},
-			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
-				bodyRepo.
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
 					EXPECT().
-					FindOneByPath(gomock.Any()).
-					Return(nil, afero.ErrFileNotFound).
+					FindByEntryIDs(gomock.Any(), []uuid.UUID{}).
+					Return([]*entity.EntryMetadata{}, nil).
 					Times(1)
 			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -1981,94 +6577,14 @@ func TestEntry_GetOne(t *testing.T) {
 					Times(1)
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			ctx := t.Context()
-
-			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
-			tt.setMockTransactionObj(transactionObj)
-
-			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
-			tt.setMockEntryRepo(entryRepo)
-
-			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
-			tt.setMockBodyRepo(bodyRepo)
-
-			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
-			tt.setMockVolumeRepo(volumeRepo)
-
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, nil)
-			entry, body, err := uc.GetOne(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputKey)
-			if !errors.Is(err, tt.expectError) {
-				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
-			}
-
-			if diff := cmp.Diff(tt.expectEntry, entry); diff != "" {
-				t.Error(diff)
-			}
-
-			if diff := cmp.Diff(tt.expectBody, body); diff != "" {
-				t.Error(diff)
-			}
-		})
-	}
-}
-
-func TestEntry_Search(t *testing.T) {
-	accountID := uuid.New()
-	volume := &entity.Volume{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		Name:      "name",
-		IsPublic:  false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	entry := &entity.Entry{
-		ID:        uuid.New(),
-		AccountID: accountID,
-		VolumeID:  volume.ID,
-		Key:       "key/sample.txt",
-		Size:      4,
-		Type:      "text/plain; charset=utf-8",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	entryDTO := &dto.EntryDTO{
-		ID:        entry.ID,
-		AccountID: entry.AccountID,
-		VolumeID:  entry.VolumeID,
-		Key:       entry.Key,
-		Size:      entry.Size,
-		Type:      entry.Type,
-		CreatedAt: entry.CreatedAt,
-		UpdatedAt: entry.UpdatedAt,
-	}
-
-	tests := []struct {
-		name                  string
-		inputAccountID        uuid.UUID
-		inputVolumeName       string
-		inputPrefix           *string
-		inputDepth            *uint64
-		expectResult          []*dto.EntryDTO
-		expectError           error
-		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
-		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
-		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
-	}{
 		{
-			name:            "successfully searched",
+			name:            "find volume error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputPrefix:     nil,
 			inputDepth:      nil,
-			expectResult:    []*dto.EntryDTO{entryDTO},
-			expectError:     nil,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -2078,29 +6594,25 @@ func TestEntry_Search(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
-				entryRepo.
-					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return([]*entity.Entry{entry}, nil).
-					Times(1)
-			},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(volume, nil).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
 		},
 		{
-			name:            "entry not found",
+			name:            "find entry error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputPrefix:     nil,
 			inputDepth:      nil,
-			expectResult:    []*dto.EntryDTO{},
-			expectError:     nil,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
 				transactionObj.
 					EXPECT().
@@ -2113,10 +6625,12 @@ func TestEntry_Search(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, nil).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
@@ -2126,11 +6640,12 @@ func TestEntry_Search(t *testing.T) {
 			},
 		},
 		{
-			name:            "find volume error",
+			name:            "find metadata error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputPrefix:     nil,
 			inputDepth:      nil,
+			inputCondition:  nil,
 			expectResult:    nil,
 			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
@@ -2142,21 +6657,51 @@ func TestEntry_Search(t *testing.T) {
 					}).
 					Times(1)
 			},
-			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{entry}, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					FindByEntryIDs(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(*mockRepository.MockEntryContentRepository) {},
 			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
 				volumeRepo.
 					EXPECT().
 					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil, sql.ErrConnDone).
+					Return(volume, nil).
 					Times(1)
 			},
 		},
 		{
-			name:            "find entry error",
+			name:                     "invalid query",
+			inputAccountID:           accountID,
+			inputVolumeName:          "volume",
+			inputPrefix:              nil,
+			inputDepth:               nil,
+			inputCondition:           &dto.EntryConditionDTO{Query: "+-"},
+			expectResult:             nil,
+			expectError:              entity.ErrInvalidEntryContentQuery,
+			setMockTransactionObj:    func(*mockTransaction.MockTransactionObject) {},
+			setMockEntryRepo:         func(*mockRepository.MockEntryRepository) {},
+			setMockEntryMetadataRepo: func(*mockRepository.MockEntryMetadataRepository) {},
+			setMockEntryContentRepo:  func(*mockRepository.MockEntryContentRepository) {},
+			setMockVolumeRepo:        func(*mockRepository.MockVolumeRepository) {},
+		},
+		{
+			name:            "search content error",
 			inputAccountID:  accountID,
 			inputVolumeName: "volume",
 			inputPrefix:     nil,
 			inputDepth:      nil,
+			inputCondition:  &dto.EntryConditionDTO{Query: "test"},
 			expectResult:    nil,
 			expectError:     sql.ErrConnDone,
 			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
@@ -2171,7 +6716,21 @@ func TestEntry_Search(t *testing.T) {
 			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
 				entryRepo.
 					EXPECT().
-					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{entry}, nil).
+					Times(1)
+			},
+			setMockEntryMetadataRepo: func(entryMetadataRepo *mockRepository.MockEntryMetadataRepository) {
+				entryMetadataRepo.
+					EXPECT().
+					FindByEntryIDs(gomock.Any(), []uuid.UUID{entry.ID}).
+					Return([]*entity.EntryMetadata{}, nil).
+					Times(1)
+			},
+			setMockEntryContentRepo: func(entryContentRepo *mockRepository.MockEntryContentRepository) {
+				entryContentRepo.
+					EXPECT().
+					Search(gomock.Any(), volume.ID, accountID, []string{"test"}, gomock.Any()).
 					Return(nil, sql.ErrConnDone).
 					Times(1)
 			},
@@ -2200,8 +6759,17 @@ func TestEntry_Search(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, nil, volumeRepo, nil)
-			result, err := uc.Search(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputPrefix, tt.inputDepth)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			entryMetadataRepo := mockRepository.NewMockEntryMetadataRepository(ctrl)
+			tt.setMockEntryMetadataRepo(entryMetadataRepo)
+
+			entryContentRepo := mockRepository.NewMockEntryContentRepository(ctrl)
+			tt.setMockEntryContentRepo(entryContentRepo)
+
+			uc := usecase.NewEntryUsecase(transactionObj, entryRepo, entryMetadataRepo, entryContentRepo, nil, volumeRepo, nil, nil, eventServ, usecase.ScanActionReject)
+			result, err := uc.Search(ctx, tt.inputAccountID, tt.inputVolumeName, tt.inputPrefix, tt.inputDepth, tt.inputCondition)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
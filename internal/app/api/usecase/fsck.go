@@ -0,0 +1,429 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../test/mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/service"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/contenttype"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+const (
+	FsckCategoryOrphanedBody       = "orphaned_body"
+	FsckCategoryMissingBody        = "missing_body"
+	FsckCategoryKindMismatch       = "kind_mismatch"
+	FsckCategorySizeMismatch       = "size_mismatch"
+	FsckCategoryTypeMismatch       = "type_mismatch"
+	FsckCategoryEncryptionMismatch = "encryption_mismatch"
+)
+
+type FsckUsecase interface {
+	Check(context.Context, uuid.UUID, string, bool) (*dto.FsckReportDTO, error)
+	CheckAll(context.Context, []string, bool) ([]*dto.FsckReportDTO, error)
+	DetectTypes(context.Context, uuid.UUID, string, bool) (*dto.FsckReportDTO, error)
+}
+
+type fsckUsecase struct {
+	transactionObj transaction.TransactionObject
+	volumeRepo     repository.VolumeRepository
+	entryRepo      repository.EntryRepository
+	bodyRepo       repository.BodyRepository
+	entryServ      service.EntryService
+}
+
+func NewFsckUsecase(
+	transactionObj transaction.TransactionObject,
+	volumeRepo repository.VolumeRepository,
+	entryRepo repository.EntryRepository,
+	bodyRepo repository.BodyRepository,
+	entryServ service.EntryService,
+) FsckUsecase {
+	return &fsckUsecase{
+		transactionObj: transactionObj,
+		volumeRepo:     volumeRepo,
+		entryRepo:      entryRepo,
+		bodyRepo:       bodyRepo,
+		entryServ:      entryServ,
+	}
+}
+
+func (u *fsckUsecase) Check(ctx context.Context, accountID uuid.UUID, volumeName string, repair bool) (*dto.FsckReportDTO, error) {
+	var report *dto.FsckReportDTO
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		report, err = u.check(ctx, volume, repair)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (u *fsckUsecase) CheckAll(ctx context.Context, volumeNames []string, repair bool) ([]*dto.FsckReportDTO, error) {
+	var volumes []*entity.Volume
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		if len(volumeNames) == 0 {
+			var err error
+			volumes, err = u.volumeRepo.FindAll(ctx)
+			return err
+		}
+
+		// NOTE: ボリューム名はアカウント毎に一意のため, 同名のボリュームはすべて対象とする.
+		for _, volumeName := range volumeNames {
+			found, err := u.volumeRepo.FindByName(ctx, volumeName)
+			if err != nil {
+				return err
+			}
+			if len(found) == 0 {
+				return repository.ErrVolumeNotFound
+			}
+			volumes = append(volumes, found...)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// NOTE: 修復範囲を限定するためボリューム毎にトランザクションを分ける.
+	reports := make([]*dto.FsckReportDTO, len(volumes))
+	for i, volume := range volumes {
+		if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+			var err error
+			reports[i], err = u.check(ctx, volume, repair)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return reports, nil
+}
+
+func (u *fsckUsecase) DetectTypes(ctx context.Context, accountID uuid.UUID, volumeName string, repair bool) (*dto.FsckReportDTO, error) {
+	var report *dto.FsckReportDTO
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		report, err = u.detectTypes(ctx, volume, repair)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (u *fsckUsecase) check(ctx context.Context, volume *entity.Volume, repair bool) (*dto.FsckReportDTO, error) {
+	entries, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, volume.AccountID, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	bodies, err := u.bodyRepo.FindByPath(ctx, volume.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	bodyMap := make(map[string]*entity.Body, len(bodies))
+	for _, body := range bodies {
+		bodyMap[body.Path] = body
+	}
+
+	report := &dto.FsckReportDTO{
+		AccountID:  volume.AccountID,
+		VolumeName: volume.Name,
+		Issues:     []*dto.FsckIssueDTO{},
+	}
+
+	slices.SortFunc(entries, func(a, b *entity.Entry) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	for _, entry := range entries {
+		body, ok := bodyMap[entry.Key]
+		delete(bodyMap, entry.Key)
+
+		issues, err := u.checkEntry(ctx, volume, entry, body, ok, repair)
+		if err != nil {
+			return nil, err
+		}
+		report.Issues = append(report.Issues, issues...)
+	}
+
+	// NOTE: 上位のフォルダから取り込むためパス順に処理する.
+	for _, body := range bodies {
+		if _, ok := bodyMap[body.Path]; !ok {
+			continue
+		}
+
+		issue, err := u.checkOrphan(ctx, volume, body, repair)
+		if err != nil {
+			return nil, err
+		}
+		report.Issues = append(report.Issues, issue)
+	}
+
+	return report, nil
+}
+
+// NOTE: 保存済みの種別を考慮せずに判定し直すため, ボディが存在するファイルのみを対象とする.
+func (u *fsckUsecase) detectTypes(ctx context.Context, volume *entity.Volume, repair bool) (*dto.FsckReportDTO, error) {
+	entries, err := u.entryRepo.FindByVolumeIDAndAccountID(ctx, volume.ID, volume.AccountID, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	bodies, err := u.bodyRepo.FindByPath(ctx, volume.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool, len(bodies))
+	for _, body := range bodies {
+		files[body.Path] = !body.IsFolder
+	}
+
+	report := &dto.FsckReportDTO{
+		AccountID:  volume.AccountID,
+		VolumeName: volume.Name,
+		Issues:     []*dto.FsckIssueDTO{},
+	}
+
+	slices.SortFunc(entries, func(a, b *entity.Entry) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	for _, entry := range entries {
+		if entry.IsFolder() || !files[entry.Key] {
+			continue
+		}
+
+		issue, err := u.redetectType(ctx, volume, entry, repair)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	return report, nil
+}
+
+func (u *fsckUsecase) redetectType(ctx context.Context, volume *entity.Volume, entry *entity.Entry, repair bool) (*dto.FsckIssueDTO, error) {
+	entryType, err := u.detectType(ctx, volume.Path()+"/"+entry.Key, entry.BodyAttributes(), "")
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type == entryType {
+		return nil, nil
+	}
+
+	issue := &dto.FsckIssueDTO{Category: FsckCategoryTypeMismatch, Key: entry.Key, Expected: entry.Type, Actual: entryType}
+	if !repair {
+		return issue, nil
+	}
+
+	entry.SetType(entryType)
+	if err := u.entryRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	issue.Repaired = true
+	return issue, nil
+}
+
+func (u *fsckUsecase) checkEntry(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body *entity.Body, exists, repair bool) ([]*dto.FsckIssueDTO, error) {
+	switch {
+	case !exists:
+		issue, err := u.checkMissing(ctx, entry, repair)
+		if err != nil {
+			return nil, err
+		}
+		return []*dto.FsckIssueDTO{issue}, nil
+	case entry.IsFolder() != body.IsFolder:
+		issue, err := u.checkKind(ctx, volume, entry, body, repair)
+		if err != nil {
+			return nil, err
+		}
+		return []*dto.FsckIssueDTO{issue}, nil
+	case entry.IsFolder():
+		return nil, nil
+	default:
+		return u.checkFile(ctx, volume, entry, body, repair)
+	}
+}
+
+func (u *fsckUsecase) checkMissing(ctx context.Context, entry *entity.Entry, repair bool) (*dto.FsckIssueDTO, error) {
+	issue := &dto.FsckIssueDTO{Category: FsckCategoryMissingBody, Key: entry.Key}
+	if !repair {
+		return issue, nil
+	}
+
+	if err := u.entryServ.DeleteDescendants(ctx, entry); err != nil {
+		return nil, err
+	}
+	if err := u.entryRepo.Delete(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	issue.Repaired = true
+	return issue, nil
+}
+
+func (u *fsckUsecase) checkKind(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body *entity.Body, repair bool) (*dto.FsckIssueDTO, error) {
+	issue := &dto.FsckIssueDTO{Category: FsckCategoryKindMismatch, Key: entry.Key, Expected: kindOf(entry.IsFolder()), Actual: kindOf(body.IsFolder)}
+	if !repair {
+		return issue, nil
+	}
+
+	entryType, err := u.detectBodyType(ctx, volume, body)
+	if err != nil {
+		return nil, err
+	}
+	entry.SetType(entryType)
+	entry.SetSize(body.Size)
+	entry.SetBodyAttributes(&body.Attributes)
+	if err := u.entryRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	issue.Repaired = true
+	return issue, nil
+}
+
+func (u *fsckUsecase) checkFile(ctx context.Context, volume *entity.Volume, entry *entity.Entry, body *entity.Body, repair bool) ([]*dto.FsckIssueDTO, error) {
+	var issues []*dto.FsckIssueDTO
+
+	if issue := checkEncryption(entry, body); issue != nil {
+		issues = append(issues, issue)
+	}
+
+	// NOTE: 圧縮済みのボディは保存サイズが元のサイズと異なるため比較しない.
+	if entry.Encoding == "" && entry.Size != body.Size {
+		issues = append(issues, &dto.FsckIssueDTO{Category: FsckCategorySizeMismatch, Key: entry.Key, Expected: strconv.FormatUint(entry.Size, 10), Actual: strconv.FormatUint(body.Size, 10)})
+		entry.SetSize(body.Size)
+	}
+
+	entryType, err := u.detectType(ctx, volume.Path()+"/"+entry.Key, entry.BodyAttributes(), entry.Type)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Type != entryType {
+		issues = append(issues, &dto.FsckIssueDTO{Category: FsckCategoryTypeMismatch, Key: entry.Key, Expected: entry.Type, Actual: entryType})
+		entry.SetType(entryType)
+	}
+
+	if !repair || len(issues) == 0 {
+		return issues, nil
+	}
+
+	if err := u.entryRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		issue.Repaired = true
+	}
+	return issues, nil
+}
+
+// NOTE: マスターキーIDが記録されていない暗号化済みのボディはボディのヘッダーから補完する.
+// 暗号化が無効な場合はボディから判定できないため, 記録済みのマスターキーIDは削除しない.
+func checkEncryption(entry *entity.Entry, body *entity.Body) *dto.FsckIssueDTO {
+	if body.Attributes.EncryptionKeyID == "" || entry.EncryptionKeyID == body.Attributes.EncryptionKeyID {
+		return nil
+	}
+	issue := &dto.FsckIssueDTO{Category: FsckCategoryEncryptionMismatch, Key: entry.Key, Expected: entry.EncryptionKeyID, Actual: body.Attributes.EncryptionKeyID}
+	// NOTE: 圧縮方式はボディから判定できないため, エントリーに記録された圧縮方式を維持する.
+	attributes := entry.BodyAttributes()
+	attributes.EncryptionKeyID = body.Attributes.EncryptionKeyID
+	entry.SetBodyAttributes(attributes)
+	return issue
+}
+
+func (u *fsckUsecase) checkOrphan(ctx context.Context, volume *entity.Volume, body *entity.Body, repair bool) (*dto.FsckIssueDTO, error) {
+	issue := &dto.FsckIssueDTO{Category: FsckCategoryOrphanedBody, Key: body.Path, Actual: kindOf(body.IsFolder)}
+	if !repair {
+		return issue, nil
+	}
+
+	entryType, err := u.detectBodyType(ctx, volume, body)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := entity.NewEntry(volume.AccountID, volume.ID, body.Path, body.Size, entryType)
+	if err != nil {
+		// NOTE: キーとして利用できないパスは取り込まずに報告のみ行う.
+		if errors.Is(err, entity.ErrShortEntryKey) || errors.Is(err, entity.ErrLongEntryKey) || errors.Is(err, entity.ErrInvalidEntryKey) {
+			return issue, nil
+		}
+		return nil, err
+	}
+
+	entry.SetBodyAttributes(&body.Attributes)
+
+	// NOTE: 既存のボディを取り込むため, ボリュームの制限は適用しない.
+	if err := u.entryServ.CreateAncestors(ctx, entry, nil); err != nil {
+		return nil, err
+	}
+	if err := u.entryRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	issue.Repaired = true
+	return issue, nil
+}
+
+func (u *fsckUsecase) detectBodyType(ctx context.Context, volume *entity.Volume, body *entity.Body) (string, error) {
+	if body.IsFolder {
+		return folderType, nil
+	}
+	return u.detectType(ctx, volume.Path()+"/"+body.Path, &body.Attributes, "")
+}
+
+// NOTE: 保存済みの種別を申告された種別として扱い, 判定結果と矛盾しない場合は維持する.
+func (u *fsckUsecase) detectType(ctx context.Context, path string, attributes *entity.BodyAttributes, declaredType string) (_ string, err error) {
+	body, err := u.bodyRepo.FindOneByPath(ctx, path, attributes)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := body.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	buf := make([]byte, contenttype.ReadLimit)
+	n, err := io.ReadFull(body, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+
+	return contenttype.Detect(buf[:n], path, declaredType), nil
+}
+
+func kindOf(isFolder bool) string {
+	if isFolder {
+		return folderType
+	}
+	return "file"
+}
@@ -0,0 +1,977 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+	mockService "github.com/atsumarukun/holos-storage-api/test/mock/domain/service"
+)
+
+func TestFsck_Check(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "volume",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	newEntry := func(key string, size uint64, entryType string) *entity.Entry {
+		return &entity.Entry{
+			ID:        uuid.New(),
+			AccountID: accountID,
+			VolumeID:  volume.ID,
+			Key:       key,
+			Size:      size,
+			Type:      entryType,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+	}
+	newBody := func() io.ReadCloser {
+		return io.NopCloser(bytes.NewBufferString("test"))
+	}
+
+	consistentEntries := func() []*entity.Entry {
+		return []*entity.Entry{
+			newEntry("key/sample.txt", 4, "text/plain; charset=utf-8"),
+			newEntry("key", 0, "folder"),
+		}
+	}
+	consistentBodies := []*entity.Body{
+		{Path: "key", Size: 0, IsFolder: true},
+		{Path: "key/sample.txt", Size: 4, IsFolder: false},
+	}
+
+	inconsistentEntries := func() []*entity.Entry {
+		return []*entity.Entry{
+			newEntry("missing.txt", 4, "text/plain; charset=utf-8"),
+			newEntry("kind", 0, "folder"),
+			newEntry("size.txt", 10, "image/png"),
+		}
+	}
+	inconsistentBodies := []*entity.Body{
+		{Path: "kind", Size: 4, IsFolder: false},
+		{Path: "orphan", Size: 0, IsFolder: true},
+		{Path: "orphan/sample.txt", Size: 4, IsFolder: false},
+		{Path: "size.txt", Size: 4, IsFolder: false},
+	}
+	inconsistentIssues := func(repaired bool) []*dto.FsckIssueDTO {
+		return []*dto.FsckIssueDTO{
+			{Category: usecase.FsckCategoryKindMismatch, Key: "kind", Expected: "folder", Actual: "file", Repaired: repaired},
+			{Category: usecase.FsckCategoryMissingBody, Key: "missing.txt", Repaired: repaired},
+			{Category: usecase.FsckCategorySizeMismatch, Key: "size.txt", Expected: "10", Actual: "4", Repaired: repaired},
+			{Category: usecase.FsckCategoryTypeMismatch, Key: "size.txt", Expected: "image/png", Actual: "text/plain; charset=utf-8", Repaired: repaired},
+			{Category: usecase.FsckCategoryOrphanedBody, Key: "orphan", Actual: "folder", Repaired: repaired},
+			{Category: usecase.FsckCategoryOrphanedBody, Key: "orphan/sample.txt", Actual: "file", Repaired: repaired},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputRepair           bool
+		expectResult          *dto.FsckReportDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+		setMockEntryServ      func(*mockService.MockEntryService)
+	}{
+		{
+			name:            "consistent",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    &dto.FsckReportDTO{AccountID: volume.AccountID, VolumeName: "volume", Issues: []*dto.FsckIssueDTO{}},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(consistentEntries(), nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(consistentBodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", gomock.Any()).
+					Return(newBody(), nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "detect inconsistencies",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    &dto.FsckReportDTO{AccountID: volume.AccountID, VolumeName: "volume", Issues: inconsistentIssues(false)},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(inconsistentEntries(), nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(inconsistentBodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/size.txt", gomock.Any()).
+					Return(newBody(), nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "repair inconsistencies",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     true,
+			expectResult:    &dto.FsckReportDTO{AccountID: volume.AccountID, VolumeName: "volume", Issues: inconsistentIssues(true)},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(inconsistentEntries(), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				entryRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(inconsistentBodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(context.Context, string, *entity.BodyAttributes) (io.ReadCloser, error) {
+						return newBody(), nil
+					}).
+					Times(3)
+			},
+			setMockEntryServ: func(entryServ *mockService.MockEntryService) {
+				entryServ.
+					EXPECT().
+					DeleteDescendants(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				entryServ.
+					EXPECT().
+					CreateAncestors(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+		},
+		{
+			name:            "repair missing encryption key id",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     true,
+			expectResult: &dto.FsckReportDTO{AccountID: volume.AccountID, VolumeName: "volume", Issues: []*dto.FsckIssueDTO{
+				{Category: usecase.FsckCategoryEncryptionMismatch, Key: "key/sample.txt", Expected: "", Actual: "new", Repaired: true},
+			}},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(consistentEntries(), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Cond(func(entry *entity.Entry) bool { return entry.EncryptionKeyID == "new" })).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return([]*entity.Body{
+						{Path: "key", Size: 0, IsFolder: true},
+						{Path: "key/sample.txt", Size: 4, IsFolder: false, Attributes: entity.BodyAttributes{EncryptionKeyID: "new"}},
+					}, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), volume.Path()+"/key/sample.txt", &entity.BodyAttributes{EncryptionKeyID: "new"}).
+					Return(newBody(), nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find entries error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "find bodies error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    nil,
+			expectError:     io.ErrUnexpectedEOF,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(consistentEntries(), nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(nil, io.ErrUnexpectedEOF).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+		{
+			name:            "repair error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     true,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(inconsistentEntries(), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(inconsistentBodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(newBody(), nil).
+					Times(1)
+			},
+			setMockEntryServ: func(*mockService.MockEntryService) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+			entryServ := mockService.NewMockEntryService(ctrl)
+			tt.setMockEntryServ(entryServ)
+
+			uc := usecase.NewFsckUsecase(transactionObj, volumeRepo, entryRepo, bodyRepo, entryServ)
+			result, err := uc.Check(t.Context(), tt.inputAccountID, tt.inputVolumeName, tt.inputRepair)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFsck_CheckAll(t *testing.T) {
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		Name:      "volume",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name                  string
+		inputVolumeNames      []string
+		expectResult          []*dto.FsckReportDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+	}{
+		{
+			name:             "check all volumes",
+			inputVolumeNames: nil,
+			expectResult:     []*dto.FsckReportDTO{{AccountID: volume.AccountID, VolumeName: "volume", Issues: []*dto.FsckIssueDTO{}}},
+			expectError:      nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindAll(gomock.Any()).
+					Return([]*entity.Volume{volume}, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, volume.AccountID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{}, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), volume.Path()).
+					Return([]*entity.Body{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "check specified volumes",
+			inputVolumeNames: []string{"volume"},
+			expectResult:     []*dto.FsckReportDTO{{AccountID: volume.AccountID, VolumeName: "volume", Issues: []*dto.FsckIssueDTO{}}},
+			expectError:      nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindByName(gomock.Any(), "volume").
+					Return([]*entity.Volume{volume}, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), volume.ID, volume.AccountID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*entity.Entry{}, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), volume.Path()).
+					Return([]*entity.Body{}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:             "specified volume not found",
+			inputVolumeNames: []string{"volume"},
+			expectResult:     nil,
+			expectError:      repository.ErrVolumeNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindByName(gomock.Any(), "volume").
+					Return([]*entity.Volume{}, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+		},
+		{
+			name:             "find volumes error",
+			inputVolumeNames: nil,
+			expectResult:     nil,
+			expectError:      sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindAll(gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+		},
+		{
+			name:             "check error",
+			inputVolumeNames: nil,
+			expectResult:     nil,
+			expectError:      sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindAll(gomock.Any()).
+					Return([]*entity.Volume{volume}, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+			entryServ := mockService.NewMockEntryService(ctrl)
+
+			uc := usecase.NewFsckUsecase(transactionObj, volumeRepo, entryRepo, bodyRepo, entryServ)
+			result, err := uc.CheckAll(t.Context(), tt.inputVolumeNames, false)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestFsck_DetectTypes(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		Name:      "volume",
+		IsPublic:  false,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	newEntry := func(key string, size uint64, entryType string) *entity.Entry {
+		return &entity.Entry{
+			ID:        uuid.New(),
+			AccountID: accountID,
+			VolumeID:  volume.ID,
+			Key:       key,
+			Size:      size,
+			Type:      entryType,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+	}
+	newBody := func(context.Context, string, *entity.BodyAttributes) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewBufferString("test")), nil
+	}
+
+	entries := func() []*entity.Entry {
+		return []*entity.Entry{
+			newEntry("key/sample.txt", 4, "text/plain; charset=utf-8"),
+			newEntry("key/README.md", 4, "text/plain; charset=utf-8"),
+			newEntry("key/missing.md", 4, "text/plain; charset=utf-8"),
+			newEntry("key", 0, "folder"),
+		}
+	}
+	bodies := []*entity.Body{
+		{Path: "key", Size: 0, IsFolder: true},
+		{Path: "key/README.md", Size: 4, IsFolder: false},
+		{Path: "key/sample.txt", Size: 4, IsFolder: false},
+	}
+	issues := func(repaired bool) []*dto.FsckIssueDTO {
+		return []*dto.FsckIssueDTO{
+			{Category: usecase.FsckCategoryTypeMismatch, Key: "key/README.md", Expected: "text/plain; charset=utf-8", Actual: "text/markdown; charset=utf-8", Repaired: repaired},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		inputAccountID        uuid.UUID
+		inputVolumeName       string
+		inputRepair           bool
+		expectResult          *dto.FsckReportDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockEntryRepo      func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
+	}{
+		{
+			name:            "detect types",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    &dto.FsckReportDTO{AccountID: volume.AccountID, VolumeName: "volume", Issues: issues(false)},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), "volume", accountID).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entries(), nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), volume.Path()).
+					Return(bodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(newBody).
+					Times(2)
+			},
+		},
+		{
+			name:            "repair types",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     true,
+			expectResult:    &dto.FsckReportDTO{AccountID: volume.AccountID, VolumeName: "volume", Issues: issues(true)},
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entries(), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(bodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(newBody).
+					Times(2)
+			},
+		},
+		{
+			name:            "find volume error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryRepo: func(*mockRepository.MockEntryRepository) {},
+			setMockBodyRepo:  func(*mockRepository.MockBodyRepository) {},
+		},
+		{
+			name:            "find bodies error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     false,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entries(), nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:            "update error",
+			inputAccountID:  accountID,
+			inputVolumeName: "volume",
+			inputRepair:     true,
+			expectResult:    nil,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindByVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entries(), nil).
+					Times(1)
+				entryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindByPath(gomock.Any(), gomock.Any()).
+					Return(bodies, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(newBody).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+			entryServ := mockService.NewMockEntryService(ctrl)
+
+			uc := usecase.NewFsckUsecase(transactionObj, volumeRepo, entryRepo, bodyRepo, entryServ)
+			result, err := uc.DetectTypes(t.Context(), tt.inputAccountID, tt.inputVolumeName, tt.inputRepair)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../test/mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/imaging"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
+)
+
+var (
+	ErrImagePresetAlreadyExists      = status.Error(code.Conflict, "image preset already exists")
+	ErrImageTransformationNotAllowed = status.Error(code.Forbidden, "image transformation is not allowed")
+)
+
+var imageFormats = map[string]string{
+	entity.ImageFormatJPEG: "image/jpeg",
+	entity.ImageFormatPNG:  "image/png",
+}
+
+type ImageUsecase interface {
+	CreatePreset(context.Context, uuid.UUID, string, string, *dto.ImageTransformationDTO) (*dto.ImagePresetDTO, error)
+	DeletePreset(context.Context, uuid.UUID, string, uuid.UUID) error
+	GetPresets(context.Context, uuid.UUID, string) ([]*dto.ImagePresetDTO, error)
+	Transform(context.Context, uuid.UUID, string, string, string, *dto.ImageTransformationDTO) (*dto.ImageDTO, io.ReadCloser, error)
+}
+
+type imageUsecase struct {
+	transactionObj  transaction.TransactionObject
+	entryRepo       repository.EntryRepository
+	bodyRepo        repository.BodyRepository
+	volumeRepo      repository.VolumeRepository
+	imagePresetRepo repository.ImagePresetRepository
+}
+
+func NewImageUsecase(
+	transactionObj transaction.TransactionObject,
+	entryRepo repository.EntryRepository,
+	bodyRepo repository.BodyRepository,
+	volumeRepo repository.VolumeRepository,
+	imagePresetRepo repository.ImagePresetRepository,
+) ImageUsecase {
+	return &imageUsecase{
+		transactionObj:  transactionObj,
+		entryRepo:       entryRepo,
+		bodyRepo:        bodyRepo,
+		volumeRepo:      volumeRepo,
+		imagePresetRepo: imagePresetRepo,
+	}
+}
+
+func (u *imageUsecase) CreatePreset(ctx context.Context, accountID uuid.UUID, volumeName, name string, transformationDTO *dto.ImageTransformationDTO) (*dto.ImagePresetDTO, error) {
+	transformation, err := newImageTransformation(transformationDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	var preset *entity.ImagePreset
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		preset, err = entity.NewImagePreset(accountID, volume.ID, name, transformation)
+		if err != nil {
+			return err
+		}
+
+		if _, err := u.imagePresetRepo.FindOneByNameAndVolumeID(ctx, preset.Name, volume.ID); err == nil {
+			return ErrImagePresetAlreadyExists
+		} else if !errors.Is(err, repository.ErrImagePresetNotFound) {
+			return err
+		}
+
+		return u.imagePresetRepo.Create(ctx, preset)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToImagePresetDTO(preset), nil
+}
+
+func (u *imageUsecase) DeletePreset(ctx context.Context, accountID uuid.UUID, volumeName string, id uuid.UUID) error {
+	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		preset, err := u.imagePresetRepo.FindOneByIDAndVolumeIDAndAccountID(ctx, id, volume.ID, accountID)
+		if err != nil {
+			return err
+		}
+
+		return u.imagePresetRepo.Delete(ctx, preset)
+	})
+}
+
+func (u *imageUsecase) GetPresets(ctx context.Context, accountID uuid.UUID, volumeName string) ([]*dto.ImagePresetDTO, error) {
+	var presets []*entity.ImagePreset
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		presets, err = u.imagePresetRepo.FindByVolumeID(ctx, volume.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToImagePresetDTOs(presets), nil
+}
+
+// NOTE: 任意の変換による負荷を防ぐため, ボリュームのプリセットと一致する変換のみを許可する.
+func (u *imageUsecase) Transform(ctx context.Context, accountID uuid.UUID, volumeName, key, presetName string, transformationDTO *dto.ImageTransformationDTO) (*dto.ImageDTO, io.ReadCloser, error) {
+	var entry *entity.Entry
+	var transformation *entity.ImageTransformation
+	var path string
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		entry, err = u.findEntry(ctx, volume, accountID, key)
+		if err != nil {
+			return err
+		}
+
+		transformation, err = u.resolveTransformation(ctx, volume, presetName, transformationDTO)
+		if err != nil {
+			return err
+		}
+
+		path = volume.Path() + "/" + entry.Key
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	if !imaging.Supports(entry.Type) {
+		return nil, nil, imaging.ErrUnsupportedImage
+	}
+
+	imageDTO := &dto.ImageDTO{Type: outputImageType(entry, transformation), ETag: entry.ETag() + "-" + transformation.Key(), UpdatedAt: entry.UpdatedAt}
+	name := fmt.Sprintf("image:%s:%s", entry.ETag(), transformation.Key())
+
+	cached, err := u.bodyRepo.FindOneDerived(ctx, path, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cached != nil {
+		return imageDTO, cached, nil
+	}
+
+	data, err := u.generate(ctx, entry, path, transformation, imageDTO.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.bodyRepo.CreateDerived(ctx, path, name, bytes.NewReader(data)); err != nil {
+		return nil, nil, err
+	}
+	return imageDTO, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// NOTE: 隔離されたエントリーは変換しない.
+func (u *imageUsecase) findEntry(ctx context.Context, volume *entity.Volume, accountID uuid.UUID, key string) (*entity.Entry, error) {
+	entry, err := u.entryRepo.FindOneByKeyAndVolumeIDAndAccountID(ctx, key, volume.ID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsQuarantined() {
+		return nil, entity.ErrEntryQuarantined
+	}
+	return entry, nil
+}
+
+func (u *imageUsecase) resolveTransformation(ctx context.Context, volume *entity.Volume, presetName string, transformationDTO *dto.ImageTransformationDTO) (*entity.ImageTransformation, error) {
+	if presetName != "" {
+		preset, err := u.imagePresetRepo.FindOneByNameAndVolumeID(ctx, presetName, volume.ID)
+		if err != nil {
+			return nil, err
+		}
+		return preset.Transformation, nil
+	}
+
+	transformation, err := newImageTransformation(transformationDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	presets, err := u.imagePresetRepo.FindByVolumeID(ctx, volume.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, preset := range presets {
+		if preset.Transformation.Equal(transformation) {
+			return transformation, nil
+		}
+	}
+	return nil, ErrImageTransformationNotAllowed
+}
+
+func (u *imageUsecase) generate(ctx context.Context, entry *entity.Entry, path string, transformation *entity.ImageTransformation, format string) (_ []byte, err error) {
+	body, err := u.bodyRepo.FindOneByPath(ctx, path, entry.BodyAttributes())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := body.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+
+	options := &imaging.Options{
+		Width:   int(transformation.Width),
+		Height:  int(transformation.Height),
+		Fit:     transformation.Fit,
+		Quality: int(transformation.Quality),
+		Format:  format,
+	}
+	if transformation.HasCrop() {
+		x, y := int(transformation.CropX), int(transformation.CropY)
+		options.Crop = image.Rect(x, y, x+int(transformation.CropWidth), y+int(transformation.CropHeight))
+	}
+	return imaging.Transform(body, entry.Type, options)
+}
+
+func newImageTransformation(transformation *dto.ImageTransformationDTO) (*entity.ImageTransformation, error) {
+	if transformation == nil {
+		transformation = &dto.ImageTransformationDTO{}
+	}
+	return entity.NewImageTransformation(
+		transformation.Width,
+		transformation.Height,
+		transformation.Fit,
+		transformation.CropX,
+		transformation.CropY,
+		transformation.CropWidth,
+		transformation.CropHeight,
+		transformation.Quality,
+		transformation.Format,
+	)
+}
+
+// NOTE: 形式を指定していない場合は元の形式に応じた形式で出力する.
+func outputImageType(entry *entity.Entry, transformation *entity.ImageTransformation) string {
+	if format, ok := imageFormats[transformation.Format]; ok {
+		return format
+	}
+	return imaging.DefaultFormat(entry.Type)
+}
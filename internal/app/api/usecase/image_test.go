@@ -0,0 +1,885 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/imaging"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+)
+
+func TestImage_CreatePreset(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	preset := &entity.ImagePreset{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Name: "small"}
+
+	tests := []struct {
+		name                   string
+		inputTransformation    *dto.ImageTransformationDTO
+		expectResult           *dto.ImagePresetDTO
+		expectError            error
+		setMockTransactionObj  func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo      func(*mockRepository.MockVolumeRepository)
+		setMockImagePresetRepo func(*mockRepository.MockImagePresetRepository)
+	}{
+		{
+			name:                "successfully created",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 100},
+			expectResult: &dto.ImagePresetDTO{
+				AccountID:      accountID,
+				VolumeID:       volume.ID,
+				Name:           "small",
+				Transformation: &dto.ImageTransformationDTO{Width: 100, Fit: entity.ImageFitContain, Quality: 85},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), "small", volume.ID).
+					Return(nil, repository.ErrImagePresetNotFound).
+					Times(1)
+				imagePresetRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                   "invalid transformation",
+			inputTransformation:    &dto.ImageTransformationDTO{Width: 100, Fit: entity.ImageFitCover},
+			expectResult:           nil,
+			expectError:            entity.ErrRequiredImageSize,
+			setMockTransactionObj:  func(*mockTransaction.MockTransactionObject) {},
+			setMockVolumeRepo:      func(*mockRepository.MockVolumeRepository) {},
+			setMockImagePresetRepo: func(*mockRepository.MockImagePresetRepository) {},
+		},
+		{
+			name:                "volume not found",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 100},
+			expectResult:        nil,
+			expectError:         repository.ErrVolumeNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(*mockRepository.MockImagePresetRepository) {},
+		},
+		{
+			name:                "already exists",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 100},
+			expectResult:        nil,
+			expectError:         usecase.ErrImagePresetAlreadyExists,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(preset, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "create error",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 100},
+			expectResult:        nil,
+			expectError:         sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrImagePresetNotFound).
+					Times(1)
+				imagePresetRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			imagePresetRepo := mockRepository.NewMockImagePresetRepository(ctrl)
+			tt.setMockImagePresetRepo(imagePresetRepo)
+
+			uc := usecase.NewImageUsecase(transactionObj, nil, nil, volumeRepo, imagePresetRepo)
+			result, err := uc.CreatePreset(t.Context(), accountID, "name", "small", tt.inputTransformation)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(dto.ImagePresetDTO{}, "ID", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestImage_DeletePreset(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	preset := &entity.ImagePreset{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Name: "small"}
+
+	tests := []struct {
+		name                   string
+		expectError            error
+		setMockTransactionObj  func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo      func(*mockRepository.MockVolumeRepository)
+		setMockImagePresetRepo func(*mockRepository.MockImagePresetRepository)
+	}{
+		{
+			name:        "successfully deleted",
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), preset.ID, volume.ID, accountID).
+					Return(preset, nil).
+					Times(1)
+				imagePresetRepo.
+					EXPECT().
+					Delete(gomock.Any(), preset).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "image preset not found",
+			expectError: repository.ErrImagePresetNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrImagePresetNotFound).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete error",
+			expectError: sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(preset, nil).
+					Times(1)
+				imagePresetRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			imagePresetRepo := mockRepository.NewMockImagePresetRepository(ctrl)
+			tt.setMockImagePresetRepo(imagePresetRepo)
+
+			uc := usecase.NewImageUsecase(transactionObj, nil, nil, volumeRepo, imagePresetRepo)
+			if err := uc.DeletePreset(t.Context(), accountID, "name", preset.ID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestImage_GetPresets(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	preset := &entity.ImagePreset{
+		ID:             uuid.New(),
+		AccountID:      accountID,
+		VolumeID:       volume.ID,
+		Name:           "small",
+		Transformation: &entity.ImageTransformation{Width: 100, Fit: entity.ImageFitContain, Quality: 85},
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	tests := []struct {
+		name                   string
+		expectResult           []*dto.ImagePresetDTO
+		expectError            error
+		setMockTransactionObj  func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo      func(*mockRepository.MockVolumeRepository)
+		setMockImagePresetRepo func(*mockRepository.MockImagePresetRepository)
+	}{
+		{
+			name: "successfully got",
+			expectResult: []*dto.ImagePresetDTO{{
+				ID:             preset.ID,
+				AccountID:      accountID,
+				VolumeID:       volume.ID,
+				Name:           "small",
+				Transformation: &dto.ImageTransformationDTO{Width: 100, Fit: entity.ImageFitContain, Quality: 85},
+				CreatedAt:      preset.CreatedAt,
+				UpdatedAt:      preset.UpdatedAt,
+			}},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), volume.ID).
+					Return([]*entity.ImagePreset{preset}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			imagePresetRepo := mockRepository.NewMockImagePresetRepository(ctrl)
+			tt.setMockImagePresetRepo(imagePresetRepo)
+
+			uc := usecase.NewImageUsecase(transactionObj, nil, nil, volumeRepo, imagePresetRepo)
+			result, err := uc.GetPresets(t.Context(), accountID, "name")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestImage_Transform(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	entry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.png",
+		Size:      4,
+		Type:      "image/png",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	textEntry := &entity.Entry{
+		ID:        uuid.New(),
+		AccountID: accountID,
+		VolumeID:  volume.ID,
+		Key:       "key/sample.txt",
+		Size:      4,
+		Type:      "text/plain; charset=utf-8",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	quarantinedEntry := &entity.Entry{
+		ID:            uuid.New(),
+		AccountID:     accountID,
+		VolumeID:      volume.ID,
+		Key:           "key/sample.png",
+		Size:          4,
+		Type:          "image/png",
+		ScanStatus:    entity.EntryScanStatusInfected,
+		ScanSignature: "Eicar-Test-Signature",
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	transformation := &entity.ImageTransformation{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG}
+	preset := &entity.ImagePreset{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, Name: "square", Transformation: transformation}
+	imageDTO := &dto.ImageDTO{Type: "image/jpeg", ETag: entry.ETag() + "-" + transformation.Key(), UpdatedAt: entry.UpdatedAt}
+	path := volume.Path() + "/key/sample.png"
+	name := "image:" + entry.ETag() + ":" + transformation.Key()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 400, 200))); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := imaging.Transform(bytes.NewReader(buf.Bytes()), "image/png", &imaging.Options{Width: 100, Height: 100, Fit: imaging.FitCover, Quality: 80, Format: "image/jpeg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                   string
+		inputPreset            string
+		inputTransformation    *dto.ImageTransformationDTO
+		expectImage            *dto.ImageDTO
+		expectBody             []byte
+		expectError            error
+		setMockTransactionObj  func(*mockTransaction.MockTransactionObject)
+		setMockEntryRepo       func(*mockRepository.MockEntryRepository)
+		setMockBodyRepo        func(*mockRepository.MockBodyRepository)
+		setMockVolumeRepo      func(*mockRepository.MockVolumeRepository)
+		setMockImagePresetRepo func(*mockRepository.MockImagePresetRepository)
+	}{
+		{
+			name:        "successfully got cached image with preset",
+			inputPreset: "square",
+			expectImage: imageDTO,
+			expectBody:  []byte("cached"),
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneDerived(gomock.Any(), path, name).
+					Return(io.NopCloser(bytes.NewBufferString("cached")), nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), "square", volume.ID).
+					Return(preset, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "successfully generated image with parameters",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 100, Height: 100, Fit: entity.ImageFitCover, Quality: 80, Format: entity.ImageFormatJPEG},
+			expectImage:         imageDTO,
+			expectBody:          generated,
+			expectError:         nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneDerived(gomock.Any(), path, name).
+					Return(nil, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), path, gomock.Any()).
+					Return(io.NopCloser(bytes.NewReader(buf.Bytes())), nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					CreateDerived(gomock.Any(), path, name, gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), volume.ID).
+					Return([]*entity.ImagePreset{preset}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "transformation is not allowed",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 200},
+			expectImage:         nil,
+			expectBody:          nil,
+			expectError:         usecase.ErrImageTransformationNotAllowed,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindByVolumeID(gomock.Any(), gomock.Any()).
+					Return([]*entity.ImagePreset{preset}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:                "invalid transformation",
+			inputTransformation: &dto.ImageTransformationDTO{Width: 4096},
+			expectImage:         nil,
+			expectBody:          nil,
+			expectError:         entity.ErrLargeImageSize,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(*mockRepository.MockImagePresetRepository) {},
+		},
+		{
+			name:        "image preset not found",
+			inputPreset: "unknown",
+			expectImage: nil,
+			expectBody:  nil,
+			expectError: repository.ErrImagePresetNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrImagePresetNotFound).
+					Times(1)
+			},
+		},
+		{
+			name:        "quarantined",
+			inputPreset: "square",
+			expectImage: nil,
+			expectBody:  nil,
+			expectError: entity.ErrEntryQuarantined,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(quarantinedEntry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(*mockRepository.MockImagePresetRepository) {},
+		},
+		{
+			name:        "unsupported image",
+			inputPreset: "square",
+			expectImage: nil,
+			expectBody:  nil,
+			expectError: imaging.ErrUnsupportedImage,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(textEntry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(preset, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "create derived error",
+			inputPreset: "square",
+			expectImage: nil,
+			expectBody:  nil,
+			expectError: io.ErrUnexpectedEOF,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockEntryRepo: func(entryRepo *mockRepository.MockEntryRepository) {
+				entryRepo.
+					EXPECT().
+					FindOneByKeyAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entry, nil).
+					Times(1)
+			},
+			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
+				bodyRepo.
+					EXPECT().
+					FindOneDerived(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					FindOneByPath(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(io.NopCloser(bytes.NewReader(buf.Bytes())), nil).
+					Times(1)
+				bodyRepo.
+					EXPECT().
+					CreateDerived(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(io.ErrUnexpectedEOF).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockImagePresetRepo: func(imagePresetRepo *mockRepository.MockImagePresetRepository) {
+				imagePresetRepo.
+					EXPECT().
+					FindOneByNameAndVolumeID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(preset, nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			entryRepo := mockRepository.NewMockEntryRepository(ctrl)
+			tt.setMockEntryRepo(entryRepo)
+
+			bodyRepo := mockRepository.NewMockBodyRepository(ctrl)
+			tt.setMockBodyRepo(bodyRepo)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			imagePresetRepo := mockRepository.NewMockImagePresetRepository(ctrl)
+			tt.setMockImagePresetRepo(imagePresetRepo)
+
+			uc := usecase.NewImageUsecase(transactionObj, entryRepo, bodyRepo, volumeRepo, imagePresetRepo)
+			result, body, err := uc.Transform(t.Context(), accountID, "name", "key", tt.inputPreset, tt.inputTransformation)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectImage, result); diff != "" {
+				t.Error(diff)
+			}
+
+			if tt.expectBody == nil {
+				if body != nil {
+					t.Error("body is returned")
+				}
+				return
+			}
+			data, err := io.ReadAll(body)
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(tt.expectBody, data); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
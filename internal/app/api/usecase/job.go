@@ -0,0 +1,277 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../test/mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/actor"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/progress"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/pkg/status/code"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
+)
+
+const (
+	JobTypeCopy   = entity.JobTypeCopy
+	JobTypeUpdate = entity.JobTypeUpdate
+	JobTypeDelete = entity.JobTypeDelete
+	JobTypeScan   = entity.JobTypeScan
+)
+
+// NOTE: 実行中のジョブは一定間隔で更新日時を更新し, 更新が途絶えたジョブは他のワーカーが再実行する.
+const (
+	jobHeartbeatInterval = 5 * time.Second
+	jobLeaseDuration     = time.Minute
+)
+
+type JobUsecase interface {
+	Create(context.Context, uuid.UUID, string, string, string, string, string, string) (*dto.JobDTO, error)
+	GetOne(context.Context, uuid.UUID, uuid.UUID) (*dto.JobDTO, error)
+	Cancel(context.Context, uuid.UUID, uuid.UUID) (*dto.JobDTO, error)
+	RunNext(context.Context) (bool, error)
+}
+
+type jobUsecase struct {
+	transactionObj transaction.TransactionObject
+	jobRepo        repository.JobRepository
+	entryUC        EntryUsecase
+}
+
+func NewJobUsecase(
+	transactionObj transaction.TransactionObject,
+	jobRepo repository.JobRepository,
+	entryUC EntryUsecase,
+) JobUsecase {
+	return &jobUsecase{
+		transactionObj: transactionObj,
+		jobRepo:        jobRepo,
+		entryUC:        entryUC,
+	}
+}
+
+func (u *jobUsecase) Create(ctx context.Context, accountID uuid.UUID, jobType, volumeName, key, newVolumeName, newKey, conflict string) (*dto.JobDTO, error) {
+	job, err := entity.NewJob(accountID, jobType, volumeName, key, newVolumeName, newKey, conflict)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		return u.jobRepo.Create(ctx, job)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToJobDTO(job), nil
+}
+
+func (u *jobUsecase) GetOne(ctx context.Context, accountID, id uuid.UUID) (*dto.JobDTO, error) {
+	var job *entity.Job
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		job, err = u.jobRepo.FindOneByIDAndAccountID(ctx, id, accountID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToJobDTO(job), nil
+}
+
+func (u *jobUsecase) Cancel(ctx context.Context, accountID, id uuid.UUID) (*dto.JobDTO, error) {
+	var job *entity.Job
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		job, err = u.jobRepo.FindOneByIDAndAccountID(ctx, id, accountID)
+		if err != nil {
+			return err
+		}
+
+		if err := job.Cancel(); err != nil {
+			return err
+		}
+
+		return u.jobRepo.Update(ctx, job)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToJobDTO(job), nil
+}
+
+// NOTE: 実行可能なジョブが存在しない場合は false を返す.
+func (u *jobUsecase) RunNext(ctx context.Context) (bool, error) {
+	job, err := u.claim(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrJobNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	counter := &progress.Counter{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		u.heartbeat(runCtx, job.ID, counter, cancel)
+	}()
+
+	// NOTE: 集計と実行はジョブの取得と別のトランザクションで行い, その間も生存確認を続ける.
+	u.total(runCtx, job)
+
+	// NOTE: ジョブは所有者のみが作成できるため, 所有者を操作者とする.
+	resultKey, runErr := u.run(actor.WithID(progress.WithCounter(runCtx, counter), job.AccountID), job)
+	cancel()
+	<-done
+
+	return true, u.finish(context.WithoutCancel(ctx), job.ID, resultKey, runErr)
+}
+
+func (u *jobUsecase) claim(ctx context.Context) (*entity.Job, error) {
+	var job *entity.Job
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		job, err = u.jobRepo.FindOneRunnable(ctx, time.Now().Add(-jobLeaseDuration))
+		if err != nil {
+			return err
+		}
+
+		job.Start()
+		return u.jobRepo.Update(ctx, job)
+	}); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// NOTE: 集計に失敗した場合は実行時に同じエラーとなるため, 総量を未設定のまま実行する.
+func (u *jobUsecase) total(ctx context.Context, job *entity.Job) {
+	entries, bytes, err := u.measure(ctx, job)
+	if err != nil {
+		return
+	}
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		return u.jobRepo.SetTotal(ctx, job.ID, entries, bytes)
+	}); err != nil {
+		log.Println(err.Error())
+	}
+}
+
+func (u *jobUsecase) measure(ctx context.Context, job *entity.Job) (uint64, uint64, error) {
+	// NOTE: キーを指定しない再スキャンはボリューム全体を対象とする.
+	if job.Key == "" {
+		return u.measureDescendants(ctx, job, nil, 0, 0)
+	}
+
+	entry, err := u.entryUC.GetMeta(ctx, job.AccountID, job.VolumeName, job.Key)
+	if err != nil {
+		return 0, 0, err
+	}
+	if job.Type == entity.JobTypeUpdate || entry.Type != folderType {
+		return 1, entry.Size, nil
+	}
+
+	return u.measureDescendants(ctx, job, &job.Key, 1, entry.Size)
+}
+
+func (u *jobUsecase) measureDescendants(ctx context.Context, job *entity.Job, prefix *string, entries, bytes uint64) (uint64, uint64, error) {
+	descendants, err := u.entryUC.Search(ctx, job.AccountID, job.VolumeName, prefix, nil, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, descendant := range descendants {
+		entries++
+		bytes += descendant.Size
+	}
+	return entries, bytes, nil
+}
+
+func (u *jobUsecase) run(ctx context.Context, job *entity.Job) (string, error) {
+	switch job.Type {
+	case entity.JobTypeCopy:
+		entry, _, err := u.entryUC.Copy(ctx, job.AccountID, job.VolumeName, job.Key, job.NewVolumeName, job.NewKey, job.Conflict)
+		if err != nil {
+			return "", err
+		}
+		return entry.Key, nil
+	case entity.JobTypeUpdate:
+		entry, _, err := u.entryUC.Update(ctx, job.AccountID, job.VolumeName, job.Key, job.NewVolumeName, job.NewKey, job.Conflict)
+		if err != nil {
+			return "", err
+		}
+		return entry.Key, nil
+	case entity.JobTypeDelete:
+		return "", u.entryUC.Delete(ctx, job.AccountID, job.VolumeName, job.Key)
+	case entity.JobTypeScan:
+		return job.Key, u.entryUC.Scan(ctx, job.AccountID, job.VolumeName, job.Key)
+	default:
+		return "", entity.ErrInvalidJobType
+	}
+}
+
+// NOTE: 進捗を書き込み, キャンセルされたジョブの実行を中断する.
+func (u *jobUsecase) heartbeat(ctx context.Context, id uuid.UUID, counter *progress.Counter, cancel context.CancelFunc) {
+	ticker := time.NewTicker(jobHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			entries, bytes := counter.Swap()
+			if err := u.jobRepo.AddProgress(ctx, id, entries, bytes); err != nil {
+				log.Println(err.Error())
+			}
+
+			job, err := u.jobRepo.FindOneByID(ctx, id)
+			if err != nil {
+				log.Println(err.Error())
+				continue
+			}
+			if job.Status == entity.JobStatusCancelled {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// NOTE: 中断する前に操作が完了した場合はキャンセルより実行結果を優先し, キャンセルされたジョブは再試行しない.
+func (u *jobUsecase) finish(ctx context.Context, id uuid.UUID, resultKey string, runErr error) error {
+	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		job, err := u.jobRepo.FindOneByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		isCancelled := job.Status == entity.JobStatusCancelled
+		if isCancelled && errors.Is(runErr, context.Canceled) {
+			return nil
+		}
+
+		if runErr == nil {
+			job.Succeed(resultKey)
+		} else {
+			job.Fail(runErr, !isCancelled && status.FromError(runErr).Code() == code.Internal)
+		}
+
+		return u.jobRepo.Update(ctx, job)
+	})
+}
@@ -0,0 +1,637 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+	mockUsecase "github.com/atsumarukun/holos-storage-api/test/mock/usecase"
+)
+
+func TestJob_Create(t *testing.T) {
+	accountID := uuid.New()
+
+	tests := []struct {
+		name                  string
+		inputType             string
+		expectResult          *dto.JobDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockJobRepo        func(*mockRepository.MockJobRepository)
+	}{
+		{
+			name:      "successfully created",
+			inputType: entity.JobTypeDelete,
+			expectResult: &dto.JobDTO{
+				AccountID:  accountID,
+				Type:       entity.JobTypeDelete,
+				Status:     entity.JobStatusPending,
+				VolumeName: "volume",
+				Key:        "key",
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository) {
+				jobRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:                  "invalid type",
+			inputType:             "archive",
+			expectResult:          nil,
+			expectError:           entity.ErrInvalidJobType,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockJobRepo:        func(*mockRepository.MockJobRepository) {},
+		},
+		{
+			name:         "create error",
+			inputType:    entity.JobTypeDelete,
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository) {
+				jobRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			jobRepo := mockRepository.NewMockJobRepository(ctrl)
+			tt.setMockJobRepo(jobRepo)
+
+			uc := usecase.NewJobUsecase(transactionObj, jobRepo, nil)
+			result, err := uc.Create(t.Context(), accountID, tt.inputType, "volume", "key", "", "", "")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(dto.JobDTO{}, "ID", "RunAt", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestJob_Cancel(t *testing.T) {
+	accountID := uuid.New()
+	id := uuid.New()
+
+	tests := []struct {
+		name           string
+		inputJobStatus string
+		expectStatus   string
+		expectError    error
+		setMockJobRepo func(*mockRepository.MockJobRepository, *entity.Job)
+	}{
+		{
+			name:           "successfully cancelled",
+			inputJobStatus: entity.JobStatusRunning,
+			expectStatus:   entity.JobStatusCancelled,
+			expectError:    nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneByIDAndAccountID(gomock.Any(), id, accountID).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "already finished",
+			inputJobStatus: entity.JobStatusSucceeded,
+			expectError:    entity.ErrJobAlreadyFinished,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneByIDAndAccountID(gomock.Any(), id, accountID).
+					Return(job, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "not found",
+			expectError: repository.ErrJobNotFound,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, _ *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneByIDAndAccountID(gomock.Any(), id, accountID).
+					Return(nil, repository.ErrJobNotFound).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			transactionObj.
+				EXPECT().
+				Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1)
+
+			job := &entity.Job{ID: id, AccountID: accountID, Type: entity.JobTypeDelete, Status: tt.inputJobStatus}
+			jobRepo := mockRepository.NewMockJobRepository(ctrl)
+			tt.setMockJobRepo(jobRepo, job)
+
+			uc := usecase.NewJobUsecase(transactionObj, jobRepo, nil)
+			result, err := uc.Cancel(t.Context(), accountID, id)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if result != nil && result.Status != tt.expectStatus {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectStatus, result.Status)
+			}
+		})
+	}
+}
+
+func TestJob_RunNext(t *testing.T) {
+	accountID := uuid.New()
+	id := uuid.New()
+
+	tests := []struct {
+		name           string
+		inputType      string
+		expectResult   bool
+		expectStatus   string
+		expectError    error
+		setMockJobRepo func(*mockRepository.MockJobRepository, *entity.Job)
+		setMockEntryUC func(*mockUsecase.MockEntryUsecase)
+	}{
+		{
+			name:         "no runnable job",
+			inputType:    entity.JobTypeDelete,
+			expectResult: false,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, _ *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrJobNotFound).
+					Times(1)
+			},
+			setMockEntryUC: func(*mockUsecase.MockEntryUsecase) {},
+		},
+		{
+			name:         "successfully run",
+			inputType:    entity.JobTypeDelete,
+			expectResult: true,
+			expectStatus: entity.JobStatusSucceeded,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(2), uint64(15)).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					DoAndReturn(func(ctx context.Context, _ uuid.UUID, _, _ string) (*dto.EntryDTO, error) {
+						// NOTE: 集計がジョブの取得と同じトランザクションで行われた場合は総量を更新させない.
+						if ctx.Value(jobTransactionKey{}) != nil {
+							return nil, sql.ErrTxDone
+						}
+						return &dto.EntryDTO{Key: "key", Size: 10, Type: "folder"}, nil
+					}).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Search(gomock.Any(), accountID, "volume", gomock.Any(), nil, nil).
+					Return([]*dto.EntryDTO{{Key: "key/file", Size: 5}}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", "key").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "successfully run copy",
+			inputType:    entity.JobTypeCopy,
+			expectResult: true,
+			expectStatus: entity.JobStatusSucceeded,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(1), uint64(10)).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					Return(&dto.EntryDTO{Key: "key", Size: 10, Type: "text/plain"}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Copy(gomock.Any(), accountID, "volume", "key", "", "new_key", "merge").
+					Return(&dto.EntryDTO{Key: "new_key"}, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "successfully run volume scan",
+			inputType:    entity.JobTypeScan,
+			expectResult: true,
+			expectStatus: entity.JobStatusSucceeded,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				job.Key = ""
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(2), uint64(5)).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					Search(gomock.Any(), accountID, "volume", nil, nil, nil).
+					Return([]*dto.EntryDTO{{Key: "key", Type: "folder"}, {Key: "key/file", Size: 5}}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Scan(gomock.Any(), accountID, "volume", "").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "retry on internal error",
+			inputType:    entity.JobTypeDelete,
+			expectResult: true,
+			expectStatus: entity.JobStatusPending,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(1), uint64(10)).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					Return(&dto.EntryDTO{Key: "key", Size: 10, Type: "text/plain"}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", "key").
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+		{
+			name:         "fail on client error",
+			inputType:    entity.JobTypeDelete,
+			expectResult: true,
+			expectStatus: entity.JobStatusFailed,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					Return(nil, repository.ErrEntryNotFound).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", "key").
+					Return(repository.ErrEntryNotFound).
+					Times(1)
+			},
+		},
+		{
+			name:         "ignore set total error",
+			inputType:    entity.JobTypeDelete,
+			expectResult: true,
+			expectStatus: entity.JobStatusSucceeded,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(1), uint64(10)).
+					Return(sql.ErrConnDone).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					Return(&dto.EntryDTO{Key: "key", Size: 10, Type: "text/plain"}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", "key").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "succeed when cancelled after commit",
+			inputType:    entity.JobTypeDelete,
+			expectResult: true,
+			expectStatus: entity.JobStatusSucceeded,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(1), uint64(10)).
+					Return(nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					DoAndReturn(func(context.Context, uuid.UUID) (*entity.Job, error) {
+						// NOTE: 操作の完了後, 完了の記録より前にキャンセルされた状態とする.
+						job.Status = entity.JobStatusCancelled
+						return job, nil
+					}).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					Return(&dto.EntryDTO{Key: "key", Size: 10, Type: "text/plain"}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", "key").
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "keep cancelled when interrupted",
+			inputType:    entity.JobTypeDelete,
+			expectResult: true,
+			expectStatus: entity.JobStatusCancelled,
+			expectError:  nil,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, job *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(job, nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					SetTotal(gomock.Any(), id, uint64(1), uint64(10)).
+					Return(nil).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					FindOneByID(gomock.Any(), id).
+					DoAndReturn(func(context.Context, uuid.UUID) (*entity.Job, error) {
+						job.Status = entity.JobStatusCancelled
+						return job, nil
+					}).
+					Times(1)
+				jobRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEntryUC: func(entryUC *mockUsecase.MockEntryUsecase) {
+				entryUC.
+					EXPECT().
+					GetMeta(gomock.Any(), accountID, "volume", "key").
+					Return(&dto.EntryDTO{Key: "key", Size: 10, Type: "text/plain"}, nil).
+					Times(1)
+				entryUC.
+					EXPECT().
+					Delete(gomock.Any(), accountID, "volume", "key").
+					Return(context.Canceled).
+					Times(1)
+			},
+		},
+		{
+			name:         "claim error",
+			inputType:    entity.JobTypeDelete,
+			expectResult: false,
+			expectError:  sql.ErrConnDone,
+			setMockJobRepo: func(jobRepo *mockRepository.MockJobRepository, _ *entity.Job) {
+				jobRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEntryUC: func(*mockUsecase.MockEntryUsecase) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			transactionObj.
+				EXPECT().
+				Transaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(context.WithValue(ctx, jobTransactionKey{}, struct{}{}))
+				}).
+				AnyTimes()
+
+			job := &entity.Job{
+				ID:         id,
+				AccountID:  accountID,
+				Type:       tt.inputType,
+				Status:     entity.JobStatusPending,
+				VolumeName: "volume",
+				Key:        "key",
+				NewKey:     "new_key",
+				Conflict:   "merge",
+				RunAt:      time.Now(),
+			}
+			jobRepo := mockRepository.NewMockJobRepository(ctrl)
+			tt.setMockJobRepo(jobRepo, job)
+
+			entryUC := mockUsecase.NewMockEntryUsecase(ctrl)
+			tt.setMockEntryUC(entryUC)
+
+			uc := usecase.NewJobUsecase(transactionObj, jobRepo, entryUC)
+			result, err := uc.RunNext(t.Context())
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if result != tt.expectResult {
+				t.Errorf("\nexpect: %t\ngot: %t", tt.expectResult, result)
+			}
+
+			if tt.expectResult && job.Status != tt.expectStatus {
+				t.Errorf("\nexpect: %s\ngot: %s", tt.expectStatus, job.Status)
+			}
+		})
+	}
+}
+
+type jobTransactionKey struct{}
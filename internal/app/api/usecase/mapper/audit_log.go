@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToAuditLogDTO(auditLog *entity.AuditLog) *dto.AuditLogDTO {
+	return &dto.AuditLogDTO{
+		ID:             auditLog.ID,
+		RequestID:      auditLog.RequestID,
+		OwnerID:        auditLog.OwnerID,
+		ActorID:        auditLog.ActorID,
+		CredentialType: auditLog.CredentialType,
+		Principal:      auditLog.Principal,
+		ClientIP:       auditLog.ClientIP,
+		UserAgent:      auditLog.UserAgent,
+		Operation:      auditLog.Operation,
+		VolumeName:     auditLog.VolumeName,
+		Key:            auditLog.Key,
+		NewVolumeName:  auditLog.NewVolumeName,
+		NewKey:         auditLog.NewKey,
+		Status:         auditLog.Status,
+		CreatedAt:      auditLog.CreatedAt,
+	}
+}
+
+func ToAuditLogDTOs(auditLogs []*entity.AuditLog) []*dto.AuditLogDTO {
+	dtos := make([]*dto.AuditLogDTO, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		dtos[i] = ToAuditLogDTO(auditLog)
+	}
+	return dtos
+}
@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToChangeDTO(change *entity.Change) *dto.ChangeDTO {
+	return &dto.ChangeDTO{
+		Sequence:  change.Sequence,
+		EventID:   change.EventID,
+		AccountID: change.AccountID,
+		ActorID:   change.ActorID,
+		Type:      change.Type,
+		Key:       change.Key,
+		NewKey:    change.NewKey,
+		Size:      change.Size,
+		CreatedAt: change.CreatedAt,
+	}
+}
+
+func ToChangeDTOs(changes []*entity.Change) []*dto.ChangeDTO {
+	dtos := make([]*dto.ChangeDTO, len(changes))
+	for i, change := range changes {
+		dtos[i] = ToChangeDTO(change)
+	}
+	return dtos
+}
@@ -13,11 +13,50 @@ func ToEntryDTO(entry *entity.Entry) *dto.EntryDTO {
 		Key:       entry.Key,
 		Size:      entry.Size,
 		Type:      entry.Type,
+		Encoding:  entry.Encoding,
+		Scan:      ToEntryScanDTO(entry),
 		CreatedAt: entry.CreatedAt,
 		UpdatedAt: entry.UpdatedAt,
 	}
 }
 
+func ToEntryScanDTO(entry *entity.Entry) *dto.EntryScanDTO {
+	if entry.ScannedAt == nil {
+		return nil
+	}
+	return &dto.EntryScanDTO{
+		Status:    entry.ScanStatus,
+		Signature: entry.ScanSignature,
+		ScannedAt: *entry.ScannedAt,
+	}
+}
+
+func ToEntryDTOWithMetadata(entry *entity.Entry, metadata *entity.EntryMetadata) *dto.EntryDTO {
+	result := ToEntryDTO(entry)
+	result.Metadata = ToEntryMetadataDTO(metadata)
+	return result
+}
+
+func ToEntryMetadataDTO(metadata *entity.EntryMetadata) *dto.EntryMetadataDTO {
+	if metadata == nil {
+		return nil
+	}
+	return &dto.EntryMetadataDTO{
+		Width:       metadata.Width,
+		Height:      metadata.Height,
+		TakenAt:     metadata.TakenAt,
+		CameraMake:  metadata.CameraMake,
+		CameraModel: metadata.CameraModel,
+		Latitude:    metadata.Latitude,
+		Longitude:   metadata.Longitude,
+		PageCount:   metadata.PageCount,
+		Duration:    metadata.Duration,
+		Title:       metadata.Title,
+		Artist:      metadata.Artist,
+		Album:       metadata.Album,
+	}
+}
+
 func ToEntryDTOs(entries []*entity.Entry) []*dto.EntryDTO {
 	dtos := make([]*dto.EntryDTO, len(entries))
 	for i, entry := range entries {
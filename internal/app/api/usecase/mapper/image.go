@@ -0,0 +1,40 @@
+package mapper
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToImageTransformationDTO(transformation *entity.ImageTransformation) *dto.ImageTransformationDTO {
+	return &dto.ImageTransformationDTO{
+		Width:      transformation.Width,
+		Height:     transformation.Height,
+		Fit:        transformation.Fit,
+		CropX:      transformation.CropX,
+		CropY:      transformation.CropY,
+		CropWidth:  transformation.CropWidth,
+		CropHeight: transformation.CropHeight,
+		Quality:    transformation.Quality,
+		Format:     transformation.Format,
+	}
+}
+
+func ToImagePresetDTO(preset *entity.ImagePreset) *dto.ImagePresetDTO {
+	return &dto.ImagePresetDTO{
+		ID:             preset.ID,
+		AccountID:      preset.AccountID,
+		VolumeID:       preset.VolumeID,
+		Name:           preset.Name,
+		Transformation: ToImageTransformationDTO(preset.Transformation),
+		CreatedAt:      preset.CreatedAt,
+		UpdatedAt:      preset.UpdatedAt,
+	}
+}
+
+func ToImagePresetDTOs(presets []*entity.ImagePreset) []*dto.ImagePresetDTO {
+	dtos := make([]*dto.ImagePresetDTO, len(presets))
+	for i, preset := range presets {
+		dtos[i] = ToImagePresetDTO(preset)
+	}
+	return dtos
+}
@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToJobDTO(job *entity.Job) *dto.JobDTO {
+	return &dto.JobDTO{
+		ID:               job.ID,
+		AccountID:        job.AccountID,
+		Type:             job.Type,
+		Status:           job.Status,
+		VolumeName:       job.VolumeName,
+		Key:              job.Key,
+		NewVolumeName:    job.NewVolumeName,
+		NewKey:           job.NewKey,
+		Conflict:         job.Conflict,
+		ResultKey:        job.ResultKey,
+		Error:            job.Error,
+		TotalEntries:     job.TotalEntries,
+		ProcessedEntries: job.ProcessedEntries,
+		TotalBytes:       job.TotalBytes,
+		ProcessedBytes:   job.ProcessedBytes,
+		Attempts:         job.Attempts,
+		RunAt:            job.RunAt,
+		CreatedAt:        job.CreatedAt,
+		UpdatedAt:        job.UpdatedAt,
+	}
+}
@@ -7,12 +7,32 @@ import (
 
 func ToVolumeDTO(volume *entity.Volume) *dto.VolumeDTO {
 	return &dto.VolumeDTO{
-		ID:        volume.ID,
-		AccountID: volume.AccountID,
-		Name:      volume.Name,
-		IsPublic:  volume.IsPublic,
-		CreatedAt: volume.CreatedAt,
-		UpdatedAt: volume.UpdatedAt,
+		ID:          volume.ID,
+		AccountID:   volume.AccountID,
+		Name:        volume.Name,
+		IsPublic:    volume.IsPublic,
+		Compression: volume.Compression,
+		Policy:      ToVolumePolicyDTO(volume.Policy),
+		Drop:        ToVolumeDropDTO(volume.Drop),
+		CreatedAt:   volume.CreatedAt,
+		UpdatedAt:   volume.UpdatedAt,
+	}
+}
+
+func ToVolumePolicyDTO(policy *entity.VolumePolicy) *dto.VolumePolicyDTO {
+	if policy == nil {
+		return nil
+	}
+	return &dto.VolumePolicyDTO{
+		MaxFileSize:         policy.MaxFileSize,
+		AllowedTypes:        policy.AllowedTypes,
+		DeniedTypes:         policy.DeniedTypes,
+		AllowedExtensions:   policy.AllowedExtensions,
+		DeniedExtensions:    policy.DeniedExtensions,
+		MaxKeyDepth:         policy.MaxKeyDepth,
+		MaxEntriesPerFolder: policy.MaxEntriesPerFolder,
+		MaxVolumeSize:       policy.MaxVolumeSize,
+		MaxVolumeEntries:    policy.MaxVolumeEntries,
 	}
 }
 
@@ -23,3 +43,12 @@ func ToVolumeDTOs(volumes []*entity.Volume) []*dto.VolumeDTO {
 	}
 	return dtos
 }
+
+func ToVolumeDropDTO(drop *entity.VolumeDrop) *dto.VolumeDropDTO {
+	if drop == nil {
+		return nil
+	}
+	return &dto.VolumeDropDTO{
+		Prefix: drop.Prefix,
+	}
+}
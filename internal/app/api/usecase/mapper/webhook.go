@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+)
+
+func ToWebhookDTO(webhook *entity.Webhook) *dto.WebhookDTO {
+	return &dto.WebhookDTO{
+		ID:        webhook.ID,
+		AccountID: webhook.AccountID,
+		VolumeID:  webhook.VolumeID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    webhook.Events,
+		Prefix:    webhook.Prefix,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func ToWebhookDTOs(webhooks []*entity.Webhook) []*dto.WebhookDTO {
+	dtos := make([]*dto.WebhookDTO, len(webhooks))
+	for i, webhook := range webhooks {
+		dtos[i] = ToWebhookDTO(webhook)
+	}
+	return dtos
+}
+
+func ToWebhookDeliveryDTO(delivery *entity.WebhookDelivery) *dto.WebhookDeliveryDTO {
+	return &dto.WebhookDeliveryDTO{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		Attempts:       delivery.Attempts,
+		RunAt:          delivery.RunAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func ToWebhookDeliveryDTOs(deliveries []*entity.WebhookDelivery) []*dto.WebhookDeliveryDTO {
+	dtos := make([]*dto.WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = ToWebhookDeliveryDTO(delivery)
+	}
+	return dtos
+}
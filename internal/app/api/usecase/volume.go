@@ -15,8 +15,8 @@ import (
 )
 
 type VolumeUsecase interface {
-	Create(context.Context, uuid.UUID, string, bool) (*dto.VolumeDTO, error)
-	Update(context.Context, uuid.UUID, string, string, bool) (*dto.VolumeDTO, error)
+	Create(context.Context, uuid.UUID, string, bool, string, *dto.VolumePolicyDTO, *dto.VolumeDropDTO) (*dto.VolumeDTO, error)
+	Update(context.Context, uuid.UUID, string, string, bool, string, *dto.VolumePolicyDTO, *dto.VolumeDropDTO) (*dto.VolumeDTO, error)
 	Delete(context.Context, uuid.UUID, string) error
 	GetOne(context.Context, uuid.UUID, string) (*dto.VolumeDTO, error)
 	GetAll(context.Context, uuid.UUID) ([]*dto.VolumeDTO, error)
@@ -27,6 +27,7 @@ type volumeUsecase struct {
 	volumeRepo     repository.VolumeRepository
 	bodyRepo       repository.BodyRepository
 	volumeServ     service.VolumeService
+	eventServ      service.EventService
 }
 
 func NewVolumeUsecase(
@@ -34,17 +35,28 @@ func NewVolumeUsecase(
 	volumeRepo repository.VolumeRepository,
 	bodyRepo repository.BodyRepository,
 	volumeServ service.VolumeService,
+	eventServ service.EventService,
 ) VolumeUsecase {
 	return &volumeUsecase{
 		transactionObj: transactionObj,
 		volumeRepo:     volumeRepo,
 		bodyRepo:       bodyRepo,
 		volumeServ:     volumeServ,
+		eventServ:      eventServ,
 	}
 }
 
-func (u *volumeUsecase) Create(ctx context.Context, accountID uuid.UUID, name string, isPublic bool) (*dto.VolumeDTO, error) {
-	volume, err := entity.NewVolume(accountID, name, isPublic)
+func (u *volumeUsecase) Create(ctx context.Context, accountID uuid.UUID, name string, isPublic bool, compression string, policyDTO *dto.VolumePolicyDTO, dropDTO *dto.VolumeDropDTO) (*dto.VolumeDTO, error) {
+	policy, err := newVolumePolicy(policyDTO)
+	if err != nil {
+		return nil, err
+	}
+	drop, err := newVolumeDrop(dropDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := entity.NewVolume(accountID, name, isPublic, compression, policy, drop)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +70,7 @@ func (u *volumeUsecase) Create(ctx context.Context, accountID uuid.UUID, name st
 			return err
 		}
 
-		return u.bodyRepo.Create(volume.Name, nil)
+		return u.bodyRepo.Create(ctx, volume.Path(), nil, nil)
 	}); err != nil {
 		return nil, err
 	}
@@ -66,7 +78,16 @@ func (u *volumeUsecase) Create(ctx context.Context, accountID uuid.UUID, name st
 	return mapper.ToVolumeDTO(volume), nil
 }
 
-func (u *volumeUsecase) Update(ctx context.Context, accountID uuid.UUID, name, newName string, isPublic bool) (*dto.VolumeDTO, error) {
+func (u *volumeUsecase) Update(ctx context.Context, accountID uuid.UUID, name, newName string, isPublic bool, compression string, policyDTO *dto.VolumePolicyDTO, dropDTO *dto.VolumeDropDTO) (*dto.VolumeDTO, error) {
+	policy, err := newVolumePolicy(policyDTO)
+	if err != nil {
+		return nil, err
+	}
+	drop, err := newVolumeDrop(dropDTO)
+	if err != nil {
+		return nil, err
+	}
+
 	var volume *entity.Volume
 
 	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
@@ -76,22 +97,22 @@ func (u *volumeUsecase) Update(ctx context.Context, accountID uuid.UUID, name, n
 			return err
 		}
 
-		volume.SetIsPublic(isPublic)
-		if volume.Name == newName {
-			return u.volumeRepo.Update(ctx, volume)
-		}
-
-		if err := volume.SetName(newName); err != nil {
-			return err
-		}
-		if err := u.volumeServ.Exists(ctx, volume); err != nil {
+		// NOTE: 名前を変更する場合は変更前の名前を通知できるように更新前にイベントを生成する.
+		event, err := entity.NewEvent(entity.EventTypeVolumeUpdated, volume, "")
+		if err != nil {
 			return err
 		}
-		if err := u.volumeRepo.Update(ctx, volume); err != nil {
+
+		if err := u.update(ctx, volume, newName, isPublic, compression, policy, drop); err != nil {
 			return err
 		}
 
-		return u.bodyRepo.Update(name, volume.Name)
+		if volume.Name != name {
+			if err := event.SetDestination(volume, ""); err != nil {
+				return err
+			}
+		}
+		return u.eventServ.Publish(ctx, event)
 	}); err != nil {
 		return nil, err
 	}
@@ -110,11 +131,20 @@ func (u *volumeUsecase) Delete(ctx context.Context, accountID uuid.UUID, name st
 			return err
 		}
 
+		// NOTE: ボリュームの削除で Webhook も削除されるため, 削除前に配信を作成する.
+		event, err := entity.NewEvent(entity.EventTypeVolumeDeleted, volume, "")
+		if err != nil {
+			return err
+		}
+		if err := u.eventServ.Publish(ctx, event); err != nil {
+			return err
+		}
+
 		if err := u.volumeRepo.Delete(ctx, volume); err != nil {
 			return err
 		}
 
-		return u.bodyRepo.Delete(volume.Name)
+		return u.bodyRepo.Delete(ctx, volume.Path())
 	})
 }
 
@@ -133,3 +163,56 @@ func (u *volumeUsecase) GetAll(ctx context.Context, accountID uuid.UUID) ([]*dto
 	}
 	return mapper.ToVolumeDTOs(volumes), nil
 }
+
+func (u *volumeUsecase) update(ctx context.Context, volume *entity.Volume, newName string, isPublic bool, compression string, policy *entity.VolumePolicy, drop *entity.VolumeDrop) error {
+	path := volume.Path()
+
+	volume.SetIsPublic(isPublic)
+	if err := volume.SetCompression(compression); err != nil {
+		return err
+	}
+	if err := volume.SetPolicy(policy); err != nil {
+		return err
+	}
+	volume.SetDrop(drop)
+	if volume.Name == newName {
+		return u.volumeRepo.Update(ctx, volume)
+	}
+
+	if err := volume.SetName(newName); err != nil {
+		return err
+	}
+	if err := u.volumeServ.Exists(ctx, volume); err != nil {
+		return err
+	}
+	if err := u.volumeRepo.Update(ctx, volume); err != nil {
+		return err
+	}
+
+	return u.bodyRepo.Update(ctx, path, volume.Path())
+}
+
+func newVolumePolicy(policy *dto.VolumePolicyDTO) (*entity.VolumePolicy, error) {
+	if policy == nil {
+		policy = &dto.VolumePolicyDTO{}
+	}
+	return entity.NewVolumePolicy(
+		policy.MaxFileSize,
+		policy.AllowedTypes,
+		policy.DeniedTypes,
+		policy.AllowedExtensions,
+		policy.DeniedExtensions,
+		policy.MaxKeyDepth,
+		policy.MaxEntriesPerFolder,
+		policy.MaxVolumeSize,
+		policy.MaxVolumeEntries,
+	)
+}
+
+// NOTE: nilの場合は匿名の投稿を無効とする.
+func newVolumeDrop(drop *dto.VolumeDropDTO) (*entity.VolumeDrop, error) {
+	if drop == nil {
+		return nil, nil
+	}
+	return entity.NewVolumeDrop(drop.Prefix)
+}
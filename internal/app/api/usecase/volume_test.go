@@ -30,6 +30,7 @@ func TestVolume_Create(t *testing.T) {
 		AccountID: accountID,
 		Name:      "name",
 		IsPublic:  false,
+		Policy:    &dto.VolumePolicyDTO{},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -39,6 +40,9 @@ func TestVolume_Create(t *testing.T) {
 		inputAccountID        uuid.UUID
 		inputName             string
 		inputIsPublic         bool
+		inputCompression      string
+		inputPolicy           *dto.VolumePolicyDTO
+		inputDrop             *dto.VolumeDropDTO
 		expectResult          *dto.VolumeDTO
 		expectError           error
 		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
@@ -72,7 +76,7 @@ func TestVolume_Create(t *testing.T) {
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
@@ -96,6 +100,45 @@ func TestVolume_Create(t *testing.T) {
 			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeServ:     func(*mockService.MockVolumeService) {},
 		},
+		{
+			name:                  "invalid compression",
+			inputAccountID:        accountID,
+			inputName:             "name",
+			inputIsPublic:         false,
+			inputCompression:      "br",
+			expectResult:          nil,
+			expectError:           entity.ErrInvalidVolumeCompression,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockVolumeRepo:     func(*mockRepository.MockVolumeRepository) {},
+			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeServ:     func(*mockService.MockVolumeService) {},
+		},
+		{
+			name:                  "invalid drop prefix",
+			inputAccountID:        accountID,
+			inputName:             "name",
+			inputIsPublic:         false,
+			inputDrop:             &dto.VolumeDropDTO{Prefix: "inbox?"},
+			expectResult:          nil,
+			expectError:           entity.ErrInvalidVolumeDropPrefix,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockVolumeRepo:     func(*mockRepository.MockVolumeRepository) {},
+			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeServ:     func(*mockService.MockVolumeService) {},
+		},
+		{
+			name:                  "invalid policy",
+			inputAccountID:        accountID,
+			inputName:             "name",
+			inputIsPublic:         false,
+			inputPolicy:           &dto.VolumePolicyDTO{AllowedTypes: []string{"image"}},
+			expectResult:          nil,
+			expectError:           entity.ErrInvalidVolumePolicyType,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockVolumeRepo:     func(*mockRepository.MockVolumeRepository) {},
+			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeServ:     func(*mockService.MockVolumeService) {},
+		},
 		{
 			name:           "volume already exists",
 			inputAccountID: accountID,
@@ -180,7 +223,7 @@ func TestVolume_Create(t *testing.T) {
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Create(gomock.Any(), gomock.Any()).
+					Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(io.ErrNoProgress).
 					Times(1)
 			},
@@ -212,8 +255,11 @@ func TestVolume_Create(t *testing.T) {
 			volumeServ := mockService.NewMockVolumeService(ctrl)
 			tt.setMockVolumeServ(volumeServ)
 
-			uc := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ)
-			result, err := uc.Create(ctx, tt.inputAccountID, tt.inputName, tt.inputIsPublic)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ, eventServ)
+			result, err := uc.Create(ctx, tt.inputAccountID, tt.inputName, tt.inputIsPublic, tt.inputCompression, tt.inputPolicy, tt.inputDrop)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -243,6 +289,7 @@ func TestVolume_Update(t *testing.T) {
 		AccountID: volume.AccountID,
 		Name:      "update",
 		IsPublic:  volume.IsPublic,
+		Policy:    &dto.VolumePolicyDTO{},
 		CreatedAt: volume.CreatedAt,
 		UpdatedAt: volume.UpdatedAt,
 	}
@@ -251,6 +298,7 @@ func TestVolume_Update(t *testing.T) {
 		AccountID: volume.AccountID,
 		Name:      volume.Name,
 		IsPublic:  volume.IsPublic,
+		Policy:    &dto.VolumePolicyDTO{},
 		CreatedAt: volume.CreatedAt,
 		UpdatedAt: volume.UpdatedAt,
 	}
@@ -266,6 +314,9 @@ func TestVolume_Update(t *testing.T) {
 		inputName             string
 		inputNewName          string
 		inputIsPublic         bool
+		inputCompression      string
+		inputPolicy           *dto.VolumePolicyDTO
+		inputDrop             *dto.VolumeDropDTO
 		expectResult          *dto.VolumeDTO
 		expectError           error
 		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
@@ -273,6 +324,20 @@ func TestVolume_Update(t *testing.T) {
 		setMockBodyRepo       func(*mockRepository.MockBodyRepository)
 		setMockVolumeServ     func(*mockService.MockVolumeService)
 	}{
+		{
+			name:                  "invalid policy",
+			inputAccountID:        accountID,
+			inputName:             "name",
+			inputNewName:          "update",
+			inputIsPublic:         false,
+			inputPolicy:           &dto.VolumePolicyDTO{DeniedExtensions: []string{"t x t"}},
+			expectResult:          nil,
+			expectError:           entity.ErrInvalidVolumePolicyExtension,
+			setMockTransactionObj: func(*mockTransaction.MockTransactionObject) {},
+			setMockVolumeRepo:     func(*mockRepository.MockVolumeRepository) {},
+			setMockBodyRepo:       func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeServ:     func(*mockService.MockVolumeService) {},
+		},
 		{
 			name:           "successfully updated",
 			inputAccountID: accountID,
@@ -305,7 +370,7 @@ func TestVolume_Update(t *testing.T) {
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
@@ -376,6 +441,34 @@ func TestVolume_Update(t *testing.T) {
 			setMockBodyRepo:   func(*mockRepository.MockBodyRepository) {},
 			setMockVolumeServ: func(*mockService.MockVolumeService) {},
 		},
+		{
+			name:             "invalid compression",
+			inputAccountID:   accountID,
+			inputName:        "name",
+			inputNewName:     "name",
+			inputIsPublic:    false,
+			inputCompression: "br",
+			expectResult:     nil,
+			expectError:      entity.ErrInvalidVolumeCompression,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockBodyRepo:   func(*mockRepository.MockBodyRepository) {},
+			setMockVolumeServ: func(*mockService.MockVolumeService) {},
+		},
 		{
 			name:           "volume already exists",
 			inputAccountID: accountID,
@@ -506,7 +599,7 @@ func TestVolume_Update(t *testing.T) {
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Update(gomock.Any(), gomock.Any()).
+					Update(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(afero.ErrFileClosed).
 					Times(1)
 			},
@@ -542,8 +635,11 @@ func TestVolume_Update(t *testing.T) {
 			volumeServ := mockService.NewMockVolumeService(ctrl)
 			tt.setMockVolumeServ(volumeServ)
 
-			uc := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ)
-			result, err := uc.Update(ctx, tt.inputAccountID, tt.inputName, tt.inputNewName, tt.inputIsPublic)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ, eventServ)
+			result, err := uc.Update(ctx, tt.inputAccountID, tt.inputName, tt.inputNewName, tt.inputIsPublic, tt.inputCompression, tt.inputPolicy, tt.inputDrop)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -608,7 +704,7 @@ func TestVolume_Delete(t *testing.T) {
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Delete(gomock.Any()).
+					Delete(gomock.Any(), gomock.Any()).
 					Return(nil).
 					Times(1)
 			},
@@ -738,7 +834,7 @@ func TestVolume_Delete(t *testing.T) {
 			setMockBodyRepo: func(bodyRepo *mockRepository.MockBodyRepository) {
 				bodyRepo.
 					EXPECT().
-					Delete(gomock.Any()).
+					Delete(gomock.Any(), gomock.Any()).
 					Return(afero.ErrFileClosed).
 					Times(1)
 			},
@@ -770,7 +866,10 @@ func TestVolume_Delete(t *testing.T) {
 			volumeServ := mockService.NewMockVolumeService(ctrl)
 			tt.setMockVolumeServ(volumeServ)
 
-			uc := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewVolumeUsecase(transactionObj, volumeRepo, bodyRepo, volumeServ, eventServ)
 			if err := uc.Delete(ctx, tt.inputAccountID, tt.inputName); !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
 			}
@@ -844,7 +943,10 @@ func TestVolume_GetOne(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			uc := usecase.NewVolumeUsecase(nil, volumeRepo, nil, nil)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewVolumeUsecase(nil, volumeRepo, nil, nil, eventServ)
 			result, err := uc.GetOne(ctx, tt.inputAccountID, tt.inputName)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -933,7 +1035,10 @@ func TestVolume_GetAll(t *testing.T) {
 			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
 			tt.setMockVolumeRepo(volumeRepo)
 
-			uc := usecase.NewVolumeUsecase(nil, volumeRepo, nil, nil)
+			eventServ := mockService.NewMockEventService(ctrl)
+			eventServ.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			uc := usecase.NewVolumeUsecase(nil, volumeRepo, nil, nil, eventServ)
 			result, err := uc.GetAll(ctx, tt.inputAccountID)
 			if !errors.Is(err, tt.expectError) {
 				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
@@ -0,0 +1,177 @@
+//go:generate mockgen -source=$GOFILE -package=$GOPACKAGE -destination=../../../../test/mock/$GOPACKAGE/$GOFILE
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository/pkg/transaction"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/mapper"
+)
+
+// NOTE: 送信中のまま更新が途絶えた配信は送信のタイムアウトより長い期間の経過後に再送する.
+const (
+	webhookDeliveryLeaseDuration = time.Minute
+	webhookDeliveryLogLimit      = 100
+)
+
+type WebhookUsecase interface {
+	Create(context.Context, uuid.UUID, string, string, []string, string) (*dto.WebhookDTO, error)
+	Delete(context.Context, uuid.UUID, string, uuid.UUID) error
+	GetAll(context.Context, uuid.UUID, string) ([]*dto.WebhookDTO, error)
+	GetDeliveries(context.Context, uuid.UUID, string, uuid.UUID) ([]*dto.WebhookDeliveryDTO, error)
+	DeliverNext(context.Context) (bool, error)
+}
+
+type webhookUsecase struct {
+	transactionObj      transaction.TransactionObject
+	webhookRepo         repository.WebhookRepository
+	webhookDeliveryRepo repository.WebhookDeliveryRepository
+	webhookEndpointRepo repository.WebhookEndpointRepository
+	volumeRepo          repository.VolumeRepository
+}
+
+func NewWebhookUsecase(
+	transactionObj transaction.TransactionObject,
+	webhookRepo repository.WebhookRepository,
+	webhookDeliveryRepo repository.WebhookDeliveryRepository,
+	webhookEndpointRepo repository.WebhookEndpointRepository,
+	volumeRepo repository.VolumeRepository,
+) WebhookUsecase {
+	return &webhookUsecase{
+		transactionObj:      transactionObj,
+		webhookRepo:         webhookRepo,
+		webhookDeliveryRepo: webhookDeliveryRepo,
+		webhookEndpointRepo: webhookEndpointRepo,
+		volumeRepo:          volumeRepo,
+	}
+}
+
+func (u *webhookUsecase) Create(ctx context.Context, accountID uuid.UUID, volumeName, url string, events []string, prefix string) (*dto.WebhookDTO, error) {
+	var webhook *entity.Webhook
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		webhook, err = entity.NewWebhook(accountID, volume.ID, url, events, prefix)
+		if err != nil {
+			return err
+		}
+
+		if err := u.webhookEndpointRepo.Verify(ctx, webhook.URL); err != nil {
+			return err
+		}
+
+		return u.webhookRepo.Create(ctx, webhook)
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToWebhookDTO(webhook), nil
+}
+
+func (u *webhookUsecase) Delete(ctx context.Context, accountID uuid.UUID, volumeName string, id uuid.UUID) error {
+	return u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		webhook, err := u.findWebhook(ctx, accountID, volumeName, id)
+		if err != nil {
+			return err
+		}
+
+		return u.webhookRepo.Delete(ctx, webhook)
+	})
+}
+
+func (u *webhookUsecase) GetAll(ctx context.Context, accountID uuid.UUID, volumeName string) ([]*dto.WebhookDTO, error) {
+	var webhooks []*entity.Webhook
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+		if err != nil {
+			return err
+		}
+
+		webhooks, err = u.webhookRepo.FindByVolumeID(ctx, volume.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToWebhookDTOs(webhooks), nil
+}
+
+// NOTE: 直近の配信から一定件数を新しい順に返却する.
+func (u *webhookUsecase) GetDeliveries(ctx context.Context, accountID uuid.UUID, volumeName string, id uuid.UUID) ([]*dto.WebhookDeliveryDTO, error) {
+	var deliveries []*entity.WebhookDelivery
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		webhook, err := u.findWebhook(ctx, accountID, volumeName, id)
+		if err != nil {
+			return err
+		}
+
+		deliveries, err = u.webhookDeliveryRepo.FindByWebhookID(ctx, webhook.ID, webhookDeliveryLogLimit)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return mapper.ToWebhookDeliveryDTOs(deliveries), nil
+}
+
+// NOTE: 送信可能な配信が存在しない場合は false を返す.
+func (u *webhookUsecase) DeliverNext(ctx context.Context) (bool, error) {
+	delivery, err := u.claim(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrWebhookDeliveryNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	responseStatus, sendErr := u.webhookEndpointRepo.Send(ctx, delivery)
+	if sendErr == nil {
+		delivery.Succeed(responseStatus)
+	} else {
+		delivery.Fail(responseStatus, sendErr)
+	}
+
+	return true, u.transactionObj.Transaction(context.WithoutCancel(ctx), func(ctx context.Context) error {
+		return u.webhookDeliveryRepo.Update(ctx, delivery)
+	})
+}
+
+func (u *webhookUsecase) claim(ctx context.Context) (*entity.WebhookDelivery, error) {
+	var delivery *entity.WebhookDelivery
+
+	if err := u.transactionObj.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		delivery, err = u.webhookDeliveryRepo.FindOneRunnable(ctx, time.Now().Add(-webhookDeliveryLeaseDuration))
+		if err != nil {
+			return err
+		}
+
+		delivery.Start()
+		return u.webhookDeliveryRepo.Update(ctx, delivery)
+	}); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (u *webhookUsecase) findWebhook(ctx context.Context, accountID uuid.UUID, volumeName string, id uuid.UUID) (*entity.Webhook, error) {
+	volume, err := u.volumeRepo.FindOneByNameAndAccountID(ctx, volumeName, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return u.webhookRepo.FindOneByIDAndVolumeIDAndAccountID(ctx, id, volume.ID, accountID)
+}
@@ -0,0 +1,694 @@
+package usecase_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	mockRepository "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository"
+	mockTransaction "github.com/atsumarukun/holos-storage-api/test/mock/domain/repository/pkg/transaction"
+)
+
+func TestWebhook_Create(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+
+	tests := []struct {
+		name                  string
+		inputURL              string
+		expectResult          *dto.WebhookDTO
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockWebhookRepo    func(*mockRepository.MockWebhookRepository)
+		setMockEndpointRepo   func(*mockRepository.MockWebhookEndpointRepository)
+	}{
+		{
+			name:     "successfully created",
+			inputURL: "https://example.com",
+			expectResult: &dto.WebhookDTO{
+				AccountID: accountID,
+				VolumeID:  volume.ID,
+				URL:       "https://example.com",
+				Events:    []string{entity.EventTypeEntryCreated},
+				Prefix:    "dir",
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+			setMockEndpointRepo: func(webhookEndpointRepo *mockRepository.MockWebhookEndpointRepository) {
+				webhookEndpointRepo.
+					EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "invalid url",
+			inputURL:     "example.com",
+			expectResult: nil,
+			expectError:  entity.ErrInvalidWebhookURL,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo:  func(*mockRepository.MockWebhookRepository) {},
+			setMockEndpointRepo: func(*mockRepository.MockWebhookEndpointRepository) {},
+		},
+		{
+			name:         "private address",
+			inputURL:     "https://internal.example.com",
+			expectResult: nil,
+			expectError:  repository.ErrWebhookPrivateAddress,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(*mockRepository.MockWebhookRepository) {},
+			setMockEndpointRepo: func(webhookEndpointRepo *mockRepository.MockWebhookEndpointRepository) {
+				webhookEndpointRepo.
+					EXPECT().
+					Verify(gomock.Any(), "https://internal.example.com").
+					Return(repository.ErrWebhookPrivateAddress).
+					Times(1)
+			},
+		},
+		{
+			name:         "volume not found",
+			inputURL:     "https://example.com",
+			expectResult: nil,
+			expectError:  repository.ErrVolumeNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+			setMockWebhookRepo:  func(*mockRepository.MockWebhookRepository) {},
+			setMockEndpointRepo: func(*mockRepository.MockWebhookEndpointRepository) {},
+		},
+		{
+			name:         "create error",
+			inputURL:     "https://example.com",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+			setMockEndpointRepo: func(webhookEndpointRepo *mockRepository.MockWebhookEndpointRepository) {
+				webhookEndpointRepo.
+					EXPECT().
+					Verify(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			webhookRepo := mockRepository.NewMockWebhookRepository(ctrl)
+			tt.setMockWebhookRepo(webhookRepo)
+
+			webhookEndpointRepo := mockRepository.NewMockWebhookEndpointRepository(ctrl)
+			tt.setMockEndpointRepo(webhookEndpointRepo)
+
+			uc := usecase.NewWebhookUsecase(transactionObj, webhookRepo, nil, webhookEndpointRepo, volumeRepo)
+			result, err := uc.Create(t.Context(), accountID, "name", tt.inputURL, []string{entity.EventTypeEntryCreated}, "dir")
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(dto.WebhookDTO{}, "ID", "Secret", "CreatedAt", "UpdatedAt"),
+			}
+			if diff := cmp.Diff(tt.expectResult, result, opts...); diff != "" {
+				t.Error(diff)
+			}
+			if result != nil && result.Secret == "" {
+				t.Error("secret is not set")
+			}
+		})
+	}
+}
+
+func TestWebhook_Delete(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	webhook := &entity.Webhook{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, URL: "https://example.com"}
+
+	tests := []struct {
+		name                  string
+		expectError           error
+		setMockTransactionObj func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo     func(*mockRepository.MockVolumeRepository)
+		setMockWebhookRepo    func(*mockRepository.MockWebhookRepository)
+	}{
+		{
+			name:        "successfully deleted",
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), webhook.ID, volume.ID, accountID).
+					Return(webhook, nil).
+					Times(1)
+				webhookRepo.
+					EXPECT().
+					Delete(gomock.Any(), webhook).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "volume not found",
+			expectError: repository.ErrVolumeNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrVolumeNotFound).
+					Times(1)
+			},
+			setMockWebhookRepo: func(*mockRepository.MockWebhookRepository) {},
+		},
+		{
+			name:        "webhook not found",
+			expectError: repository.ErrWebhookNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrWebhookNotFound).
+					Times(1)
+			},
+		},
+		{
+			name:        "delete error",
+			expectError: sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(webhook, nil).
+					Times(1)
+				webhookRepo.
+					EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			webhookRepo := mockRepository.NewMockWebhookRepository(ctrl)
+			tt.setMockWebhookRepo(webhookRepo)
+
+			uc := usecase.NewWebhookUsecase(transactionObj, webhookRepo, nil, nil, volumeRepo)
+			if err := uc.Delete(t.Context(), accountID, "name", webhook.ID); !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetDeliveries(t *testing.T) {
+	accountID := uuid.New()
+	volume := &entity.Volume{ID: uuid.New(), AccountID: accountID, Name: "name"}
+	webhook := &entity.Webhook{ID: uuid.New(), AccountID: accountID, VolumeID: volume.ID, URL: "https://example.com"}
+	delivery := &entity.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, EventID: uuid.New(), EventType: entity.EventTypeEntryCreated, Status: entity.WebhookDeliveryStatusSucceeded, ResponseStatus: 200, Attempts: 1}
+
+	tests := []struct {
+		name                       string
+		expectResult               []*dto.WebhookDeliveryDTO
+		expectError                error
+		setMockTransactionObj      func(*mockTransaction.MockTransactionObject)
+		setMockVolumeRepo          func(*mockRepository.MockVolumeRepository)
+		setMockWebhookRepo         func(*mockRepository.MockWebhookRepository)
+		setMockWebhookDeliveryRepo func(*mockRepository.MockWebhookDeliveryRepository)
+	}{
+		{
+			name: "successfully got",
+			expectResult: []*dto.WebhookDeliveryDTO{
+				{
+					ID:             delivery.ID,
+					WebhookID:      webhook.ID,
+					EventID:        delivery.EventID,
+					EventType:      entity.EventTypeEntryCreated,
+					Status:         entity.WebhookDeliveryStatusSucceeded,
+					ResponseStatus: 200,
+					Attempts:       1,
+				},
+			},
+			expectError: nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(webhook, nil).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					FindByWebhookID(gomock.Any(), webhook.ID, uint64(100)).
+					Return([]*entity.WebhookDelivery{delivery}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:         "webhook not found",
+			expectResult: nil,
+			expectError:  repository.ErrWebhookNotFound,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrWebhookNotFound).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(*mockRepository.MockWebhookDeliveryRepository) {},
+		},
+		{
+			name:         "find error",
+			expectResult: nil,
+			expectError:  sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockVolumeRepo: func(volumeRepo *mockRepository.MockVolumeRepository) {
+				volumeRepo.
+					EXPECT().
+					FindOneByNameAndAccountID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(volume, nil).
+					Times(1)
+			},
+			setMockWebhookRepo: func(webhookRepo *mockRepository.MockWebhookRepository) {
+				webhookRepo.
+					EXPECT().
+					FindOneByIDAndVolumeIDAndAccountID(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(webhook, nil).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					FindByWebhookID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			volumeRepo := mockRepository.NewMockVolumeRepository(ctrl)
+			tt.setMockVolumeRepo(volumeRepo)
+
+			webhookRepo := mockRepository.NewMockWebhookRepository(ctrl)
+			tt.setMockWebhookRepo(webhookRepo)
+
+			webhookDeliveryRepo := mockRepository.NewMockWebhookDeliveryRepository(ctrl)
+			tt.setMockWebhookDeliveryRepo(webhookDeliveryRepo)
+
+			uc := usecase.NewWebhookUsecase(transactionObj, webhookRepo, webhookDeliveryRepo, nil, volumeRepo)
+			result, err := uc.GetDeliveries(t.Context(), accountID, "name", webhook.ID)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+
+			if diff := cmp.Diff(tt.expectResult, result); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWebhook_DeliverNext(t *testing.T) {
+	tests := []struct {
+		name                       string
+		expectDelivered            bool
+		expectError                error
+		setMockTransactionObj      func(*mockTransaction.MockTransactionObject)
+		setMockWebhookDeliveryRepo func(*mockRepository.MockWebhookDeliveryRepository)
+		setMockWebhookEndpointRepo func(*mockRepository.MockWebhookEndpointRepository)
+	}{
+		{
+			name:            "successfully delivered",
+			expectDelivered: true,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(context.Context, time.Time) (*entity.WebhookDelivery, error) {
+						return &entity.WebhookDelivery{ID: uuid.New(), Status: entity.WebhookDeliveryStatusPending}, nil
+					}).
+					Times(1)
+				webhookDeliveryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				webhookDeliveryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Cond(func(delivery *entity.WebhookDelivery) bool {
+						return delivery.Status == entity.WebhookDeliveryStatusSucceeded
+					})).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookEndpointRepo: func(webhookEndpointRepo *mockRepository.MockWebhookEndpointRepository) {
+				webhookEndpointRepo.
+					EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Return(uint64(200), nil).
+					Times(1)
+			},
+		},
+		{
+			name:            "rejected",
+			expectDelivered: true,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(2)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(context.Context, time.Time) (*entity.WebhookDelivery, error) {
+						return &entity.WebhookDelivery{ID: uuid.New(), Status: entity.WebhookDeliveryStatusPending}, nil
+					}).
+					Times(1)
+				webhookDeliveryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+				webhookDeliveryRepo.
+					EXPECT().
+					Update(gomock.Any(), gomock.Cond(func(delivery *entity.WebhookDelivery) bool {
+						return delivery.Status == entity.WebhookDeliveryStatusPending
+					})).
+					Return(nil).
+					Times(1)
+			},
+			setMockWebhookEndpointRepo: func(webhookEndpointRepo *mockRepository.MockWebhookEndpointRepository) {
+				webhookEndpointRepo.
+					EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Return(uint64(500), repository.ErrWebhookRejected).
+					Times(1)
+			},
+		},
+		{
+			name:            "no delivery",
+			expectDelivered: false,
+			expectError:     nil,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(nil, repository.ErrWebhookDeliveryNotFound).
+					Times(1)
+			},
+			setMockWebhookEndpointRepo: func(*mockRepository.MockWebhookEndpointRepository) {},
+		},
+		{
+			name:            "find error",
+			expectDelivered: false,
+			expectError:     sql.ErrConnDone,
+			setMockTransactionObj: func(transactionObj *mockTransaction.MockTransactionObject) {
+				transactionObj.
+					EXPECT().
+					Transaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					}).
+					Times(1)
+			},
+			setMockWebhookDeliveryRepo: func(webhookDeliveryRepo *mockRepository.MockWebhookDeliveryRepository) {
+				webhookDeliveryRepo.
+					EXPECT().
+					FindOneRunnable(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone).
+					Times(1)
+			},
+			setMockWebhookEndpointRepo: func(*mockRepository.MockWebhookEndpointRepository) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transactionObj := mockTransaction.NewMockTransactionObject(ctrl)
+			tt.setMockTransactionObj(transactionObj)
+
+			webhookDeliveryRepo := mockRepository.NewMockWebhookDeliveryRepository(ctrl)
+			tt.setMockWebhookDeliveryRepo(webhookDeliveryRepo)
+
+			webhookEndpointRepo := mockRepository.NewMockWebhookEndpointRepository(ctrl)
+			tt.setMockWebhookEndpointRepo(webhookEndpointRepo)
+
+			uc := usecase.NewWebhookUsecase(transactionObj, nil, webhookDeliveryRepo, webhookEndpointRepo, nil)
+			delivered, err := uc.DeliverNext(t.Context())
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("\nexpect: %v\ngot: %v", tt.expectError, err)
+			}
+			if delivered != tt.expectDelivered {
+				t.Errorf("\nexpect: %t\ngot: %t", tt.expectDelivered, delivered)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/atsumarukun/holos-storage-api/internal/app/api/usecase"
+)
+
+const (
+	jobWorkerCount  = 4
+	jobPollInterval = time.Second
+
+	webhookWorkerCount  = 4
+	webhookPollInterval = time.Second
+)
+
+func startJobWorkers(ctx context.Context, jobUC usecase.JobUsecase) {
+	for range jobWorkerCount {
+		go runJobWorker(ctx, jobUC)
+	}
+}
+
+// NOTE: 実行可能なジョブが存在しない間は一定間隔で確認する.
+func runJobWorker(ctx context.Context, jobUC usecase.JobUsecase) {
+	for ctx.Err() == nil {
+		ran, err := jobUC.RunNext(ctx)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		if ran {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+func startWebhookWorkers(ctx context.Context, webhookUC usecase.WebhookUsecase) {
+	for range webhookWorkerCount {
+		go runWebhookWorker(ctx, webhookUC)
+	}
+}
+
+// NOTE: 送信可能な配信が存在しない間は一定間隔で確認する.
+func runWebhookWorker(ctx context.Context, webhookUC usecase.WebhookUsecase) {
+	for ctx.Err() == nil {
+		delivered, err := webhookUC.DeliverNext(ctx)
+		if err != nil {
+			log.Println(err.Error())
+		}
+		if delivered {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(webhookPollInterval):
+		}
+	}
+}
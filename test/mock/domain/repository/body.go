@@ -10,9 +10,11 @@
 package repository
 
 import (
+	context "context"
 	io "io"
 	reflect "reflect"
 
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,72 +43,116 @@ func (m *MockBodyRepository) EXPECT() *MockBodyRepositoryMockRecorder {
 }
 
 // Copy mocks base method.
-func (m *MockBodyRepository) Copy(arg0, arg1 string) error {
+func (m *MockBodyRepository) Copy(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Copy", arg0, arg1)
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Copy indicates an expected call of Copy.
-func (mr *MockBodyRepositoryMockRecorder) Copy(arg0, arg1 any) *gomock.Call {
+func (mr *MockBodyRepositoryMockRecorder) Copy(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockBodyRepository)(nil).Copy), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockBodyRepository)(nil).Copy), arg0, arg1, arg2)
 }
 
 // Create mocks base method.
-func (m *MockBodyRepository) Create(arg0 string, arg1 io.Reader) error {
+func (m *MockBodyRepository) Create(arg0 context.Context, arg1 string, arg2 io.Reader, arg3 *entity.BodyAttributes) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockBodyRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+func (mr *MockBodyRepositoryMockRecorder) Create(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBodyRepository)(nil).Create), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBodyRepository)(nil).Create), arg0, arg1, arg2, arg3)
+}
+
+// CreateDerived mocks base method.
+func (m *MockBodyRepository) CreateDerived(arg0 context.Context, arg1, arg2 string, arg3 io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDerived", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDerived indicates an expected call of CreateDerived.
+func (mr *MockBodyRepositoryMockRecorder) CreateDerived(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDerived", reflect.TypeOf((*MockBodyRepository)(nil).CreateDerived), arg0, arg1, arg2, arg3)
 }
 
 // Delete mocks base method.
-func (m *MockBodyRepository) Delete(arg0 string) error {
+func (m *MockBodyRepository) Delete(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockBodyRepositoryMockRecorder) Delete(arg0 any) *gomock.Call {
+func (mr *MockBodyRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBodyRepository)(nil).Delete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBodyRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByPath mocks base method.
+func (m *MockBodyRepository) FindByPath(arg0 context.Context, arg1 string) ([]*entity.Body, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPath", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Body)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPath indicates an expected call of FindByPath.
+func (mr *MockBodyRepositoryMockRecorder) FindByPath(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPath", reflect.TypeOf((*MockBodyRepository)(nil).FindByPath), arg0, arg1)
 }
 
 // FindOneByPath mocks base method.
-func (m *MockBodyRepository) FindOneByPath(arg0 string) (io.ReadCloser, error) {
+func (m *MockBodyRepository) FindOneByPath(arg0 context.Context, arg1 string, arg2 *entity.BodyAttributes) (io.ReadCloser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOneByPath", arg0)
+	ret := m.ctrl.Call(m, "FindOneByPath", arg0, arg1, arg2)
 	ret0, _ := ret[0].(io.ReadCloser)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindOneByPath indicates an expected call of FindOneByPath.
-func (mr *MockBodyRepositoryMockRecorder) FindOneByPath(arg0 any) *gomock.Call {
+func (mr *MockBodyRepositoryMockRecorder) FindOneByPath(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByPath", reflect.TypeOf((*MockBodyRepository)(nil).FindOneByPath), arg0, arg1, arg2)
+}
+
+// FindOneDerived mocks base method.
+func (m *MockBodyRepository) FindOneDerived(arg0 context.Context, arg1, arg2 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneDerived", arg0, arg1, arg2)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneDerived indicates an expected call of FindOneDerived.
+func (mr *MockBodyRepositoryMockRecorder) FindOneDerived(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByPath", reflect.TypeOf((*MockBodyRepository)(nil).FindOneByPath), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneDerived", reflect.TypeOf((*MockBodyRepository)(nil).FindOneDerived), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
-func (m *MockBodyRepository) Update(arg0, arg1 string) error {
+func (m *MockBodyRepository) Update(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockBodyRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+func (mr *MockBodyRepositoryMockRecorder) Update(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBodyRepository)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBodyRepository)(nil).Update), arg0, arg1, arg2)
 }
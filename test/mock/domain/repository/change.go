@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change.go
+//
+// Generated by this command:
+//
+//	mockgen -source=change.go -package=repository -destination=../../../../../test/mock/domain/repository/change.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangeRepository is a mock of ChangeRepository interface.
+type MockChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeRepositoryMockRecorder is the mock recorder for MockChangeRepository.
+type MockChangeRepositoryMockRecorder struct {
+	mock *MockChangeRepository
+}
+
+// NewMockChangeRepository creates a new mock instance.
+func NewMockChangeRepository(ctrl *gomock.Controller) *MockChangeRepository {
+	mock := &MockChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeRepository) EXPECT() *MockChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockChangeRepository) Create(arg0 context.Context, arg1 *entity.Change) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChangeRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChangeRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByVolumeIDAndSequenceGreaterThan mocks base method.
+func (m *MockChangeRepository) FindByVolumeIDAndSequenceGreaterThan(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 uint64) ([]*entity.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByVolumeIDAndSequenceGreaterThan", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByVolumeIDAndSequenceGreaterThan indicates an expected call of FindByVolumeIDAndSequenceGreaterThan.
+func (mr *MockChangeRepositoryMockRecorder) FindByVolumeIDAndSequenceGreaterThan(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVolumeIDAndSequenceGreaterThan", reflect.TypeOf((*MockChangeRepository)(nil).FindByVolumeIDAndSequenceGreaterThan), arg0, arg1, arg2, arg3)
+}
+
+// FindLatestSequenceByVolumeID mocks base method.
+func (m *MockChangeRepository) FindLatestSequenceByVolumeID(arg0 context.Context, arg1 uuid.UUID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestSequenceByVolumeID", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestSequenceByVolumeID indicates an expected call of FindLatestSequenceByVolumeID.
+func (mr *MockChangeRepositoryMockRecorder) FindLatestSequenceByVolumeID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestSequenceByVolumeID", reflect.TypeOf((*MockChangeRepository)(nil).FindLatestSequenceByVolumeID), arg0, arg1)
+}
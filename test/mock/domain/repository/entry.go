@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	repository "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/repository"
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,35 @@ func (m *MockEntryRepository) EXPECT() *MockEntryRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CopyByPrefix mocks base method.
+func (m *MockEntryRepository) CopyByPrefix(arg0 context.Context, arg1 string, arg2 uuid.UUID, arg3 string, arg4 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyByPrefix", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyByPrefix indicates an expected call of CopyByPrefix.
+func (mr *MockEntryRepositoryMockRecorder) CopyByPrefix(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyByPrefix", reflect.TypeOf((*MockEntryRepository)(nil).CopyByPrefix), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CountByParentIDAndVolumeID mocks base method.
+func (m *MockEntryRepository) CountByParentIDAndVolumeID(arg0 context.Context, arg1, arg2 uuid.UUID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByParentIDAndVolumeID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByParentIDAndVolumeID indicates an expected call of CountByParentIDAndVolumeID.
+func (mr *MockEntryRepositoryMockRecorder) CountByParentIDAndVolumeID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByParentIDAndVolumeID", reflect.TypeOf((*MockEntryRepository)(nil).CountByParentIDAndVolumeID), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockEntryRepository) Create(arg0 context.Context, arg1 *entity.Entry) error {
 	m.ctrl.T.Helper()
@@ -70,19 +100,33 @@ func (mr *MockEntryRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEntryRepository)(nil).Delete), arg0, arg1)
 }
 
+// DeleteByPrefix mocks base method.
+func (m *MockEntryRepository) DeleteByPrefix(arg0 context.Context, arg1 string, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByPrefix", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByPrefix indicates an expected call of DeleteByPrefix.
+func (mr *MockEntryRepositoryMockRecorder) DeleteByPrefix(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByPrefix", reflect.TypeOf((*MockEntryRepository)(nil).DeleteByPrefix), arg0, arg1, arg2)
+}
+
 // FindByVolumeIDAndAccountID mocks base method.
-func (m *MockEntryRepository) FindByVolumeIDAndAccountID(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 *string, arg4 *uint64) ([]*entity.Entry, error) {
+func (m *MockEntryRepository) FindByVolumeIDAndAccountID(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 *string, arg4 *uint64, arg5 *repository.EntryCondition) ([]*entity.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByVolumeIDAndAccountID", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "FindByVolumeIDAndAccountID", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]*entity.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByVolumeIDAndAccountID indicates an expected call of FindByVolumeIDAndAccountID.
-func (mr *MockEntryRepositoryMockRecorder) FindByVolumeIDAndAccountID(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockEntryRepositoryMockRecorder) FindByVolumeIDAndAccountID(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVolumeIDAndAccountID", reflect.TypeOf((*MockEntryRepository)(nil).FindByVolumeIDAndAccountID), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVolumeIDAndAccountID", reflect.TypeOf((*MockEntryRepository)(nil).FindByVolumeIDAndAccountID), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // FindOneByKeyAndVolumeID mocks base method.
@@ -115,6 +159,35 @@ func (mr *MockEntryRepositoryMockRecorder) FindOneByKeyAndVolumeIDAndAccountID(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByKeyAndVolumeIDAndAccountID", reflect.TypeOf((*MockEntryRepository)(nil).FindOneByKeyAndVolumeIDAndAccountID), arg0, arg1, arg2, arg3)
 }
 
+// MoveByPrefix mocks base method.
+func (m *MockEntryRepository) MoveByPrefix(arg0 context.Context, arg1 string, arg2, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveByPrefix", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveByPrefix indicates an expected call of MoveByPrefix.
+func (mr *MockEntryRepositoryMockRecorder) MoveByPrefix(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveByPrefix", reflect.TypeOf((*MockEntryRepository)(nil).MoveByPrefix), arg0, arg1, arg2, arg3)
+}
+
+// SumByVolumeID mocks base method.
+func (m *MockEntryRepository) SumByVolumeID(arg0 context.Context, arg1 uuid.UUID) (*entity.VolumeUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByVolumeID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.VolumeUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByVolumeID indicates an expected call of SumByVolumeID.
+func (mr *MockEntryRepositoryMockRecorder) SumByVolumeID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByVolumeID", reflect.TypeOf((*MockEntryRepository)(nil).SumByVolumeID), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockEntryRepository) Update(arg0 context.Context, arg1 *entity.Entry) error {
 	m.ctrl.T.Helper()
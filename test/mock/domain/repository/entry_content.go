@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: entry_content.go
+//
+// Generated by this command:
+//
+//	mockgen -source=entry_content.go -package=repository -destination=../../../../../test/mock/domain/repository/entry_content.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEntryContentRepository is a mock of EntryContentRepository interface.
+type MockEntryContentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEntryContentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEntryContentRepositoryMockRecorder is the mock recorder for MockEntryContentRepository.
+type MockEntryContentRepositoryMockRecorder struct {
+	mock *MockEntryContentRepository
+}
+
+// NewMockEntryContentRepository creates a new mock instance.
+func NewMockEntryContentRepository(ctrl *gomock.Controller) *MockEntryContentRepository {
+	mock := &MockEntryContentRepository{ctrl: ctrl}
+	mock.recorder = &MockEntryContentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEntryContentRepository) EXPECT() *MockEntryContentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Copy mocks base method.
+func (m *MockEntryContentRepository) Copy(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockEntryContentRepositoryMockRecorder) Copy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockEntryContentRepository)(nil).Copy), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockEntryContentRepository) Create(arg0 context.Context, arg1 *entity.EntryContent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEntryContentRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEntryContentRepository)(nil).Create), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockEntryContentRepository) Search(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []string, arg4 uint64) ([]*entity.EntryContentMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*entity.EntryContentMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockEntryContentRepositoryMockRecorder) Search(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockEntryContentRepository)(nil).Search), arg0, arg1, arg2, arg3, arg4)
+}
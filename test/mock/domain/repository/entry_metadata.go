@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: entry_metadata.go
+//
+// Generated by this command:
+//
+//	mockgen -source=entry_metadata.go -package=repository -destination=../../../../../test/mock/domain/repository/entry_metadata.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEntryMetadataRepository is a mock of EntryMetadataRepository interface.
+type MockEntryMetadataRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEntryMetadataRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEntryMetadataRepositoryMockRecorder is the mock recorder for MockEntryMetadataRepository.
+type MockEntryMetadataRepositoryMockRecorder struct {
+	mock *MockEntryMetadataRepository
+}
+
+// NewMockEntryMetadataRepository creates a new mock instance.
+func NewMockEntryMetadataRepository(ctrl *gomock.Controller) *MockEntryMetadataRepository {
+	mock := &MockEntryMetadataRepository{ctrl: ctrl}
+	mock.recorder = &MockEntryMetadataRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEntryMetadataRepository) EXPECT() *MockEntryMetadataRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Copy mocks base method.
+func (m *MockEntryMetadataRepository) Copy(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockEntryMetadataRepositoryMockRecorder) Copy(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockEntryMetadataRepository)(nil).Copy), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockEntryMetadataRepository) Create(arg0 context.Context, arg1 *entity.EntryMetadata) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEntryMetadataRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEntryMetadataRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByEntryIDs mocks base method.
+func (m *MockEntryMetadataRepository) FindByEntryIDs(arg0 context.Context, arg1 []uuid.UUID) ([]*entity.EntryMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEntryIDs", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.EntryMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEntryIDs indicates an expected call of FindByEntryIDs.
+func (mr *MockEntryMetadataRepositoryMockRecorder) FindByEntryIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEntryIDs", reflect.TypeOf((*MockEntryMetadataRepository)(nil).FindByEntryIDs), arg0, arg1)
+}
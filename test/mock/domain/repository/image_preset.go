@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: image_preset.go
+//
+// Generated by this command:
+//
+//	mockgen -source=image_preset.go -package=repository -destination=../../../../../test/mock/domain/repository/image_preset.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImagePresetRepository is a mock of ImagePresetRepository interface.
+type MockImagePresetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImagePresetRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImagePresetRepositoryMockRecorder is the mock recorder for MockImagePresetRepository.
+type MockImagePresetRepositoryMockRecorder struct {
+	mock *MockImagePresetRepository
+}
+
+// NewMockImagePresetRepository creates a new mock instance.
+func NewMockImagePresetRepository(ctrl *gomock.Controller) *MockImagePresetRepository {
+	mock := &MockImagePresetRepository{ctrl: ctrl}
+	mock.recorder = &MockImagePresetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImagePresetRepository) EXPECT() *MockImagePresetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImagePresetRepository) Create(arg0 context.Context, arg1 *entity.ImagePreset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImagePresetRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImagePresetRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockImagePresetRepository) Delete(arg0 context.Context, arg1 *entity.ImagePreset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockImagePresetRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockImagePresetRepository)(nil).Delete), arg0, arg1)
+}
+
+// FindByVolumeID mocks base method.
+func (m *MockImagePresetRepository) FindByVolumeID(arg0 context.Context, arg1 uuid.UUID) ([]*entity.ImagePreset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByVolumeID", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.ImagePreset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByVolumeID indicates an expected call of FindByVolumeID.
+func (mr *MockImagePresetRepositoryMockRecorder) FindByVolumeID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVolumeID", reflect.TypeOf((*MockImagePresetRepository)(nil).FindByVolumeID), arg0, arg1)
+}
+
+// FindOneByIDAndVolumeIDAndAccountID mocks base method.
+func (m *MockImagePresetRepository) FindOneByIDAndVolumeIDAndAccountID(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) (*entity.ImagePreset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByIDAndVolumeIDAndAccountID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.ImagePreset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByIDAndVolumeIDAndAccountID indicates an expected call of FindOneByIDAndVolumeIDAndAccountID.
+func (mr *MockImagePresetRepositoryMockRecorder) FindOneByIDAndVolumeIDAndAccountID(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndVolumeIDAndAccountID", reflect.TypeOf((*MockImagePresetRepository)(nil).FindOneByIDAndVolumeIDAndAccountID), arg0, arg1, arg2, arg3)
+}
+
+// FindOneByNameAndVolumeID mocks base method.
+func (m *MockImagePresetRepository) FindOneByNameAndVolumeID(arg0 context.Context, arg1 string, arg2 uuid.UUID) (*entity.ImagePreset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByNameAndVolumeID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.ImagePreset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByNameAndVolumeID indicates an expected call of FindOneByNameAndVolumeID.
+func (mr *MockImagePresetRepositoryMockRecorder) FindOneByNameAndVolumeID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByNameAndVolumeID", reflect.TypeOf((*MockImagePresetRepository)(nil).FindOneByNameAndVolumeID), arg0, arg1, arg2)
+}
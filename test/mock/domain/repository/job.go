@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=job.go -package=repository -destination=../../../../../test/mock/domain/repository/job.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddProgress mocks base method.
+func (m *MockJobRepository) AddProgress(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProgress", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProgress indicates an expected call of AddProgress.
+func (mr *MockJobRepositoryMockRecorder) AddProgress(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProgress", reflect.TypeOf((*MockJobRepository)(nil).AddProgress), arg0, arg1, arg2, arg3)
+}
+
+// Create mocks base method.
+func (m *MockJobRepository) Create(arg0 context.Context, arg1 *entity.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobRepository)(nil).Create), arg0, arg1)
+}
+
+// FindOneByID mocks base method.
+func (m *MockJobRepository) FindOneByID(arg0 context.Context, arg1 uuid.UUID) (*entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByID indicates an expected call of FindOneByID.
+func (mr *MockJobRepositoryMockRecorder) FindOneByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByID", reflect.TypeOf((*MockJobRepository)(nil).FindOneByID), arg0, arg1)
+}
+
+// FindOneByIDAndAccountID mocks base method.
+func (m *MockJobRepository) FindOneByIDAndAccountID(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneByIDAndAccountID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneByIDAndAccountID indicates an expected call of FindOneByIDAndAccountID.
+func (mr *MockJobRepositoryMockRecorder) FindOneByIDAndAccountID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndAccountID", reflect.TypeOf((*MockJobRepository)(nil).FindOneByIDAndAccountID), arg0, arg1, arg2)
+}
+
+// FindOneRunnable mocks base method.
+func (m *MockJobRepository) FindOneRunnable(arg0 context.Context, arg1 time.Time) (*entity.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneRunnable", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneRunnable indicates an expected call of FindOneRunnable.
+func (mr *MockJobRepositoryMockRecorder) FindOneRunnable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneRunnable", reflect.TypeOf((*MockJobRepository)(nil).FindOneRunnable), arg0, arg1)
+}
+
+// SetTotal mocks base method.
+func (m *MockJobRepository) SetTotal(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTotal", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTotal indicates an expected call of SetTotal.
+func (mr *MockJobRepositoryMockRecorder) SetTotal(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTotal", reflect.TypeOf((*MockJobRepository)(nil).SetTotal), arg0, arg1, arg2, arg3)
+}
+
+// Update mocks base method.
+func (m *MockJobRepository) Update(arg0 context.Context, arg1 *entity.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockJobRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockJobRepository)(nil).Update), arg0, arg1)
+}
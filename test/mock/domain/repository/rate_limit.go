@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rate_limit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rate_limit.go -package=repository -destination=../../../../../test/mock/domain/repository/rate_limit.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRateLimitRepository is a mock of RateLimitRepository interface.
+type MockRateLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitRepositoryMockRecorder is the mock recorder for MockRateLimitRepository.
+type MockRateLimitRepositoryMockRecorder struct {
+	mock *MockRateLimitRepository
+}
+
+// NewMockRateLimitRepository creates a new mock instance.
+func NewMockRateLimitRepository(ctrl *gomock.Controller) *MockRateLimitRepository {
+	mock := &MockRateLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockRateLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitRepository) EXPECT() *MockRateLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockRateLimitRepository) Allow(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockRateLimitRepositoryMockRecorder) Allow(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockRateLimitRepository)(nil).Allow), arg0, arg1)
+}
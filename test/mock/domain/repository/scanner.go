@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scanner.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scanner.go -package=repository -destination=../../../../../test/mock/domain/repository/scanner.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScannerRepository is a mock of ScannerRepository interface.
+type MockScannerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScannerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockScannerRepositoryMockRecorder is the mock recorder for MockScannerRepository.
+type MockScannerRepositoryMockRecorder struct {
+	mock *MockScannerRepository
+}
+
+// NewMockScannerRepository creates a new mock instance.
+func NewMockScannerRepository(ctrl *gomock.Controller) *MockScannerRepository {
+	mock := &MockScannerRepository{ctrl: ctrl}
+	mock.recorder = &MockScannerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScannerRepository) EXPECT() *MockScannerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockScannerRepository) Scan(arg0 context.Context, arg1 io.Reader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockScannerRepositoryMockRecorder) Scan(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockScannerRepository)(nil).Scan), arg0, arg1)
+}
@@ -70,6 +70,21 @@ func (mr *MockVolumeRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVolumeRepository)(nil).Delete), arg0, arg1)
 }
 
+// FindAll mocks base method.
+func (m *MockVolumeRepository) FindAll(arg0 context.Context) ([]*entity.Volume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].([]*entity.Volume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockVolumeRepositoryMockRecorder) FindAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockVolumeRepository)(nil).FindAll), arg0)
+}
+
 // FindByAccountID mocks base method.
 func (m *MockVolumeRepository) FindByAccountID(arg0 context.Context, arg1 uuid.UUID) ([]*entity.Volume, error) {
 	m.ctrl.T.Helper()
@@ -85,34 +100,34 @@ func (mr *MockVolumeRepositoryMockRecorder) FindByAccountID(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccountID", reflect.TypeOf((*MockVolumeRepository)(nil).FindByAccountID), arg0, arg1)
 }
 
-// FindOneByIDAndAccountID mocks base method.
-func (m *MockVolumeRepository) FindOneByIDAndAccountID(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Volume, error) {
+// FindByName mocks base method.
+func (m *MockVolumeRepository) FindByName(arg0 context.Context, arg1 string) ([]*entity.Volume, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOneByIDAndAccountID", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*entity.Volume)
+	ret := m.ctrl.Call(m, "FindByName", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Volume)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindOneByIDAndAccountID indicates an expected call of FindOneByIDAndAccountID.
-func (mr *MockVolumeRepositoryMockRecorder) FindOneByIDAndAccountID(arg0, arg1, arg2 any) *gomock.Call {
+// FindByName indicates an expected call of FindByName.
+func (mr *MockVolumeRepositoryMockRecorder) FindByName(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndAccountID", reflect.TypeOf((*MockVolumeRepository)(nil).FindOneByIDAndAccountID), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockVolumeRepository)(nil).FindByName), arg0, arg1)
 }
 
-// FindOneByName mocks base method.
-func (m *MockVolumeRepository) FindOneByName(arg0 context.Context, arg1 string) (*entity.Volume, error) {
+// FindOneByIDAndAccountID mocks base method.
+func (m *MockVolumeRepository) FindOneByIDAndAccountID(arg0 context.Context, arg1, arg2 uuid.UUID) (*entity.Volume, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindOneByName", arg0, arg1)
+	ret := m.ctrl.Call(m, "FindOneByIDAndAccountID", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*entity.Volume)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindOneByName indicates an expected call of FindOneByName.
-func (mr *MockVolumeRepositoryMockRecorder) FindOneByName(arg0, arg1 any) *gomock.Call {
+// FindOneByIDAndAccountID indicates an expected call of FindOneByIDAndAccountID.
+func (mr *MockVolumeRepositoryMockRecorder) FindOneByIDAndAccountID(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByName", reflect.TypeOf((*MockVolumeRepository)(nil).FindOneByName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneByIDAndAccountID", reflect.TypeOf((*MockVolumeRepository)(nil).FindOneByIDAndAccountID), arg0, arg1, arg2)
 }
 
 // FindOneByNameAndAccountID mocks base method.
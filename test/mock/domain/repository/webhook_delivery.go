@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_delivery.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_delivery.go -package=repository -destination=../../../../../test/mock/domain/repository/webhook_delivery.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookDeliveryRepository is a mock of WebhookDeliveryRepository interface.
+type MockWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockWebhookDeliveryRepository.
+type MockWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockWebhookDeliveryRepository
+}
+
+// NewMockWebhookDeliveryRepository creates a new mock instance.
+func NewMockWebhookDeliveryRepository(ctrl *gomock.Controller) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookDeliveryRepository) Create(arg0 context.Context, arg1 *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Create), arg0, arg1)
+}
+
+// FindByWebhookID mocks base method.
+func (m *MockWebhookDeliveryRepository) FindByWebhookID(arg0 context.Context, arg1 uuid.UUID, arg2 uint64) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWebhookID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWebhookID indicates an expected call of FindByWebhookID.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) FindByWebhookID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWebhookID", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).FindByWebhookID), arg0, arg1, arg2)
+}
+
+// FindOneRunnable mocks base method.
+func (m *MockWebhookDeliveryRepository) FindOneRunnable(arg0 context.Context, arg1 time.Time) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOneRunnable", arg0, arg1)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneRunnable indicates an expected call of FindOneRunnable.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) FindOneRunnable(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneRunnable", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).FindOneRunnable), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockWebhookDeliveryRepository) Update(arg0 context.Context, arg1 *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Update(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook_endpoint.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook_endpoint.go -package=repository -destination=../../../../../test/mock/domain/repository/webhook_endpoint.go
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookEndpointRepository is a mock of WebhookEndpointRepository interface.
+type MockWebhookEndpointRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEndpointRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookEndpointRepositoryMockRecorder is the mock recorder for MockWebhookEndpointRepository.
+type MockWebhookEndpointRepositoryMockRecorder struct {
+	mock *MockWebhookEndpointRepository
+}
+
+// NewMockWebhookEndpointRepository creates a new mock instance.
+func NewMockWebhookEndpointRepository(ctrl *gomock.Controller) *MockWebhookEndpointRepository {
+	mock := &MockWebhookEndpointRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookEndpointRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEndpointRepository) EXPECT() *MockWebhookEndpointRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookEndpointRepository) Send(arg0 context.Context, arg1 *entity.WebhookDelivery) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) Send(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).Send), arg0, arg1)
+}
+
+// Verify mocks base method.
+func (m *MockWebhookEndpointRepository) Verify(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockWebhookEndpointRepositoryMockRecorder) Verify(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockWebhookEndpointRepository)(nil).Verify), arg0, arg1)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	entity "github.com/atsumarukun/holos-storage-api/internal/app/api/domain/entity"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,46 +43,46 @@ func (m *MockEntryService) EXPECT() *MockEntryServiceMockRecorder {
 }
 
 // Copy mocks base method.
-func (m *MockEntryService) Copy(arg0 context.Context, arg1 *entity.Entry) (*entity.Entry, error) {
+func (m *MockEntryService) Copy(arg0 context.Context, arg1 *entity.Entry, arg2 uuid.UUID, arg3 string) (*entity.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Copy", arg0, arg1)
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*entity.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Copy indicates an expected call of Copy.
-func (mr *MockEntryServiceMockRecorder) Copy(arg0, arg1 any) *gomock.Call {
+func (mr *MockEntryServiceMockRecorder) Copy(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockEntryService)(nil).Copy), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockEntryService)(nil).Copy), arg0, arg1, arg2, arg3)
 }
 
 // CopyDescendants mocks base method.
-func (m *MockEntryService) CopyDescendants(arg0 context.Context, arg1 *entity.Entry, arg2 string) error {
+func (m *MockEntryService) CopyDescendants(arg0 context.Context, arg1 *entity.Entry, arg2 string, arg3 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CopyDescendants", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CopyDescendants", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CopyDescendants indicates an expected call of CopyDescendants.
-func (mr *MockEntryServiceMockRecorder) CopyDescendants(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockEntryServiceMockRecorder) CopyDescendants(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyDescendants", reflect.TypeOf((*MockEntryService)(nil).CopyDescendants), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyDescendants", reflect.TypeOf((*MockEntryService)(nil).CopyDescendants), arg0, arg1, arg2, arg3)
 }
 
 // CreateAncestors mocks base method.
-func (m *MockEntryService) CreateAncestors(arg0 context.Context, arg1 *entity.Entry) error {
+func (m *MockEntryService) CreateAncestors(arg0 context.Context, arg1 *entity.Entry, arg2 *entity.Volume) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAncestors", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateAncestors", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateAncestors indicates an expected call of CreateAncestors.
-func (mr *MockEntryServiceMockRecorder) CreateAncestors(arg0, arg1 any) *gomock.Call {
+func (mr *MockEntryServiceMockRecorder) CreateAncestors(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAncestors", reflect.TypeOf((*MockEntryService)(nil).CreateAncestors), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAncestors", reflect.TypeOf((*MockEntryService)(nil).CreateAncestors), arg0, arg1, arg2)
 }
 
 // DeleteDescendants mocks base method.
@@ -112,16 +113,32 @@ func (mr *MockEntryServiceMockRecorder) Exists(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockEntryService)(nil).Exists), arg0, arg1)
 }
 
-// UpdateDescendants mocks base method.
-func (m *MockEntryService) UpdateDescendants(arg0 context.Context, arg1 *entity.Entry, arg2 string) error {
+// MoveDescendants mocks base method.
+func (m *MockEntryService) MoveDescendants(arg0 context.Context, arg1 *entity.Entry, arg2 string, arg3 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDescendants", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "MoveDescendants", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateDescendants indicates an expected call of UpdateDescendants.
-func (mr *MockEntryServiceMockRecorder) UpdateDescendants(arg0, arg1, arg2 any) *gomock.Call {
+// MoveDescendants indicates an expected call of MoveDescendants.
+func (mr *MockEntryServiceMockRecorder) MoveDescendants(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDescendants", reflect.TypeOf((*MockEntryService)(nil).UpdateDescendants), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveDescendants", reflect.TypeOf((*MockEntryService)(nil).MoveDescendants), arg0, arg1, arg2, arg3)
+}
+
+// Resolve mocks base method.
+func (m *MockEntryService) Resolve(arg0 context.Context, arg1 *entity.Entry, arg2 string, arg3 uuid.UUID, arg4 string) (*entity.Entry, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*entity.Entry)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockEntryServiceMockRecorder) Resolve(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockEntryService)(nil).Resolve), arg0, arg1, arg2, arg3, arg4)
 }
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit_log.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audit_log.go -package=usecase -destination=../../../../test/mock/usecase/audit_log.go
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditLogUsecase is a mock of AuditLogUsecase interface.
+type MockAuditLogUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditLogUsecaseMockRecorder is the mock recorder for MockAuditLogUsecase.
+type MockAuditLogUsecaseMockRecorder struct {
+	mock *MockAuditLogUsecase
+}
+
+// NewMockAuditLogUsecase creates a new mock instance.
+func NewMockAuditLogUsecase(ctrl *gomock.Controller) *MockAuditLogUsecase {
+	mock := &MockAuditLogUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuditLogUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogUsecase) EXPECT() *MockAuditLogUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockAuditLogUsecase) Record(arg0 context.Context, arg1 *dto.AuditLogDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockAuditLogUsecaseMockRecorder) Record(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockAuditLogUsecase)(nil).Record), arg0, arg1)
+}
+
+// Search mocks base method.
+func (m *MockAuditLogUsecase) Search(arg0 context.Context, arg1 uuid.UUID, arg2 *dto.AuditLogConditionDTO, arg3, arg4 uint64) ([]*dto.AuditLogDTO, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*dto.AuditLogDTO)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAuditLogUsecaseMockRecorder) Search(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAuditLogUsecase)(nil).Search), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SearchAll mocks base method.
+func (m *MockAuditLogUsecase) SearchAll(arg0 context.Context, arg1 uuid.UUID, arg2 *dto.AuditLogConditionDTO, arg3, arg4 uint64) ([]*dto.AuditLogDTO, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAll", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*dto.AuditLogDTO)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchAll indicates an expected call of SearchAll.
+func (mr *MockAuditLogUsecaseMockRecorder) SearchAll(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAll", reflect.TypeOf((*MockAuditLogUsecase)(nil).SearchAll), arg0, arg1, arg2, arg3, arg4)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,16 +43,16 @@ func (m *MockAuthorizationUsecase) EXPECT() *MockAuthorizationUsecaseMockRecorde
 }
 
 // Authorize mocks base method.
-func (m *MockAuthorizationUsecase) Authorize(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*dto.AccountDTO, error) {
+func (m *MockAuthorizationUsecase) Authorize(arg0 context.Context, arg1 string, arg2 uuid.UUID, arg3, arg4, arg5, arg6 string) (*dto.AccountDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Authorize", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Authorize", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(*dto.AccountDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Authorize indicates an expected call of Authorize.
-func (mr *MockAuthorizationUsecaseMockRecorder) Authorize(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockAuthorizationUsecaseMockRecorder) Authorize(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthorizationUsecase)(nil).Authorize), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuthorizationUsecase)(nil).Authorize), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
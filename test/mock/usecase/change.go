@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change.go
+//
+// Generated by this command:
+//
+//	mockgen -source=change.go -package=usecase -destination=../../../../test/mock/usecase/change.go
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangeUsecase is a mock of ChangeUsecase interface.
+type MockChangeUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeUsecaseMockRecorder is the mock recorder for MockChangeUsecase.
+type MockChangeUsecaseMockRecorder struct {
+	mock *MockChangeUsecase
+}
+
+// NewMockChangeUsecase creates a new mock instance.
+func NewMockChangeUsecase(ctrl *gomock.Controller) *MockChangeUsecase {
+	mock := &MockChangeUsecase{ctrl: ctrl}
+	mock.recorder = &MockChangeUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeUsecase) EXPECT() *MockChangeUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetChanges mocks base method.
+func (m *MockChangeUsecase) GetChanges(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 uint64, arg4 string, arg5 uint64) ([]*dto.ChangeDTO, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*dto.ChangeDTO)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockChangeUsecaseMockRecorder) GetChanges(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockChangeUsecase)(nil).GetChanges), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// GetDelta mocks base method.
+func (m *MockChangeUsecase) GetDelta(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 uint64) (*dto.DeltaDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelta", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto.DeltaDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelta indicates an expected call of GetDelta.
+func (mr *MockChangeUsecaseMockRecorder) GetDelta(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelta", reflect.TypeOf((*MockChangeUsecase)(nil).GetDelta), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetLatestSequence mocks base method.
+func (m *MockChangeUsecase) GetLatestSequence(arg0 context.Context, arg1 uuid.UUID, arg2 string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSequence", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSequence indicates an expected call of GetLatestSequence.
+func (mr *MockChangeUsecaseMockRecorder) GetLatestSequence(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSequence", reflect.TypeOf((*MockChangeUsecase)(nil).GetLatestSequence), arg0, arg1, arg2)
+}
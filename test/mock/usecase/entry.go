@@ -43,34 +43,50 @@ func (m *MockEntryUsecase) EXPECT() *MockEntryUsecaseMockRecorder {
 	return m.recorder
 }
 
-// Copy mocks base method.
-func (m *MockEntryUsecase) Copy(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) (*dto.EntryDTO, error) {
+// Batch mocks base method.
+func (m *MockEntryUsecase) Batch(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 bool, arg4 []*dto.EntryOperationDTO) ([]*dto.EntryOperationResultDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(*dto.EntryDTO)
+	ret := m.ctrl.Call(m, "Batch", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*dto.EntryOperationResultDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Batch indicates an expected call of Batch.
+func (mr *MockEntryUsecaseMockRecorder) Batch(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MockEntryUsecase)(nil).Batch), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Copy mocks base method.
+func (m *MockEntryUsecase) Copy(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4, arg5, arg6 string) (*dto.EntryDTO, []*dto.EntryResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*dto.EntryDTO)
+	ret1, _ := ret[1].([]*dto.EntryResultDTO)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Copy indicates an expected call of Copy.
-func (mr *MockEntryUsecaseMockRecorder) Copy(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockEntryUsecaseMockRecorder) Copy(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockEntryUsecase)(nil).Copy), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockEntryUsecase)(nil).Copy), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // Create mocks base method.
-func (m *MockEntryUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 uint64, arg5 io.Reader) (*dto.EntryDTO, error) {
+func (m *MockEntryUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 uint64, arg5 string, arg6 io.Reader) (*dto.EntryDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(*dto.EntryDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockEntryUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+func (mr *MockEntryUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEntryUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEntryUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // Delete mocks base method.
@@ -87,6 +103,21 @@ func (mr *MockEntryUsecaseMockRecorder) Delete(arg0, arg1, arg2, arg3 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEntryUsecase)(nil).Delete), arg0, arg1, arg2, arg3)
 }
 
+// Drop mocks base method.
+func (m *MockEntryUsecase) Drop(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 uint64, arg5 string, arg6 io.Reader) (*dto.EntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drop", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*dto.EntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Drop indicates an expected call of Drop.
+func (mr *MockEntryUsecaseMockRecorder) Drop(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockEntryUsecase)(nil).Drop), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 // GetMeta mocks base method.
 func (m *MockEntryUsecase) GetMeta(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) (*dto.EntryDTO, error) {
 	m.ctrl.T.Helper()
@@ -103,9 +134,9 @@ func (mr *MockEntryUsecaseMockRecorder) GetMeta(arg0, arg1, arg2, arg3 any) *gom
 }
 
 // GetOne mocks base method.
-func (m *MockEntryUsecase) GetOne(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) (*dto.EntryDTO, io.ReadCloser, error) {
+func (m *MockEntryUsecase) GetOne(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 []string) (*dto.EntryDTO, io.ReadCloser, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOne", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "GetOne", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*dto.EntryDTO)
 	ret1, _ := ret[1].(io.ReadCloser)
 	ret2, _ := ret[2].(error)
@@ -113,37 +144,82 @@ func (m *MockEntryUsecase) GetOne(arg0 context.Context, arg1 uuid.UUID, arg2, ar
 }
 
 // GetOne indicates an expected call of GetOne.
-func (mr *MockEntryUsecaseMockRecorder) GetOne(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockEntryUsecaseMockRecorder) GetOne(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockEntryUsecase)(nil).GetOne), arg0, arg1, arg2, arg3, arg4)
+}
+
+// GetThumbnail mocks base method.
+func (m *MockEntryUsecase) GetThumbnail(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4, arg5 uint64) (*dto.ThumbnailDTO, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThumbnail", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*dto.ThumbnailDTO)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetThumbnail indicates an expected call of GetThumbnail.
+func (mr *MockEntryUsecaseMockRecorder) GetThumbnail(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThumbnail", reflect.TypeOf((*MockEntryUsecase)(nil).GetThumbnail), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// Scan mocks base method.
+func (m *MockEntryUsecase) Scan(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockEntryUsecaseMockRecorder) Scan(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockEntryUsecase)(nil).GetOne), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockEntryUsecase)(nil).Scan), arg0, arg1, arg2, arg3)
 }
 
 // Search mocks base method.
-func (m *MockEntryUsecase) Search(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 *string, arg4 *uint64) ([]*dto.EntryDTO, error) {
+func (m *MockEntryUsecase) Search(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 *string, arg4 *uint64, arg5 *dto.EntryConditionDTO) ([]*dto.EntryDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]*dto.EntryDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockEntryUsecaseMockRecorder) Search(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockEntryUsecaseMockRecorder) Search(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockEntryUsecase)(nil).Search), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockEntryUsecase)(nil).Search), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // Update mocks base method.
-func (m *MockEntryUsecase) Update(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4 string) (*dto.EntryDTO, error) {
+func (m *MockEntryUsecase) Update(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4, arg5, arg6 string) (*dto.EntryDTO, []*dto.EntryResultDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(*dto.EntryDTO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].([]*dto.EntryResultDTO)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockEntryUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockEntryUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEntryUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// ValidateSize mocks base method.
+func (m *MockEntryUsecase) ValidateSize(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSize", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSize indicates an expected call of ValidateSize.
+func (mr *MockEntryUsecaseMockRecorder) ValidateSize(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockEntryUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSize", reflect.TypeOf((*MockEntryUsecase)(nil).ValidateSize), arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fsck.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fsck.go -package=usecase -destination=../../../../test/mock/usecase/fsck.go
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFsckUsecase is a mock of FsckUsecase interface.
+type MockFsckUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFsckUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockFsckUsecaseMockRecorder is the mock recorder for MockFsckUsecase.
+type MockFsckUsecaseMockRecorder struct {
+	mock *MockFsckUsecase
+}
+
+// NewMockFsckUsecase creates a new mock instance.
+func NewMockFsckUsecase(ctrl *gomock.Controller) *MockFsckUsecase {
+	mock := &MockFsckUsecase{ctrl: ctrl}
+	mock.recorder = &MockFsckUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFsckUsecase) EXPECT() *MockFsckUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockFsckUsecase) Check(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 bool) (*dto.FsckReportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.FsckReportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockFsckUsecaseMockRecorder) Check(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockFsckUsecase)(nil).Check), arg0, arg1, arg2, arg3)
+}
+
+// CheckAll mocks base method.
+func (m *MockFsckUsecase) CheckAll(arg0 context.Context, arg1 []string, arg2 bool) ([]*dto.FsckReportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.FsckReportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckAll indicates an expected call of CheckAll.
+func (mr *MockFsckUsecaseMockRecorder) CheckAll(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAll", reflect.TypeOf((*MockFsckUsecase)(nil).CheckAll), arg0, arg1, arg2)
+}
+
+// DetectTypes mocks base method.
+func (m *MockFsckUsecase) DetectTypes(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 bool) (*dto.FsckReportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetectTypes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*dto.FsckReportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetectTypes indicates an expected call of DetectTypes.
+func (mr *MockFsckUsecaseMockRecorder) DetectTypes(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetectTypes", reflect.TypeOf((*MockFsckUsecase)(nil).DetectTypes), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: image.go
+//
+// Generated by this command:
+//
+//	mockgen -source=image.go -package=usecase -destination=../../../../test/mock/usecase/image.go
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImageUsecase is a mock of ImageUsecase interface.
+type MockImageUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockImageUsecaseMockRecorder is the mock recorder for MockImageUsecase.
+type MockImageUsecaseMockRecorder struct {
+	mock *MockImageUsecase
+}
+
+// NewMockImageUsecase creates a new mock instance.
+func NewMockImageUsecase(ctrl *gomock.Controller) *MockImageUsecase {
+	mock := &MockImageUsecase{ctrl: ctrl}
+	mock.recorder = &MockImageUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageUsecase) EXPECT() *MockImageUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePreset mocks base method.
+func (m *MockImageUsecase) CreatePreset(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 *dto.ImageTransformationDTO) (*dto.ImagePresetDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePreset", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*dto.ImagePresetDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePreset indicates an expected call of CreatePreset.
+func (mr *MockImageUsecaseMockRecorder) CreatePreset(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePreset", reflect.TypeOf((*MockImageUsecase)(nil).CreatePreset), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeletePreset mocks base method.
+func (m *MockImageUsecase) DeletePreset(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePreset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePreset indicates an expected call of DeletePreset.
+func (mr *MockImageUsecaseMockRecorder) DeletePreset(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreset", reflect.TypeOf((*MockImageUsecase)(nil).DeletePreset), arg0, arg1, arg2, arg3)
+}
+
+// GetPresets mocks base method.
+func (m *MockImageUsecase) GetPresets(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]*dto.ImagePresetDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresets", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.ImagePresetDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresets indicates an expected call of GetPresets.
+func (mr *MockImageUsecaseMockRecorder) GetPresets(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresets", reflect.TypeOf((*MockImageUsecase)(nil).GetPresets), arg0, arg1, arg2)
+}
+
+// Transform mocks base method.
+func (m *MockImageUsecase) Transform(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4 string, arg5 *dto.ImageTransformationDTO) (*dto.ImageDTO, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transform", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*dto.ImageDTO)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Transform indicates an expected call of Transform.
+func (mr *MockImageUsecaseMockRecorder) Transform(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transform", reflect.TypeOf((*MockImageUsecase)(nil).Transform), arg0, arg1, arg2, arg3, arg4, arg5)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=job.go -package=usecase -destination=../../../../test/mock/usecase/job.go
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobUsecase is a mock of JobUsecase interface.
+type MockJobUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockJobUsecaseMockRecorder is the mock recorder for MockJobUsecase.
+type MockJobUsecaseMockRecorder struct {
+	mock *MockJobUsecase
+}
+
+// NewMockJobUsecase creates a new mock instance.
+func NewMockJobUsecase(ctrl *gomock.Controller) *MockJobUsecase {
+	mock := &MockJobUsecase{ctrl: ctrl}
+	mock.recorder = &MockJobUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobUsecase) EXPECT() *MockJobUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockJobUsecase) Cancel(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.JobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.JobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockJobUsecaseMockRecorder) Cancel(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockJobUsecase)(nil).Cancel), arg0, arg1, arg2)
+}
+
+// Create mocks base method.
+func (m *MockJobUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2, arg3, arg4, arg5, arg6, arg7 string) (*dto.JobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*dto.JobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockJobUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// GetOne mocks base method.
+func (m *MockJobUsecase) GetOne(arg0 context.Context, arg1, arg2 uuid.UUID) (*dto.JobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOne", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*dto.JobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOne indicates an expected call of GetOne.
+func (mr *MockJobUsecaseMockRecorder) GetOne(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOne", reflect.TypeOf((*MockJobUsecase)(nil).GetOne), arg0, arg1, arg2)
+}
+
+// RunNext mocks base method.
+func (m *MockJobUsecase) RunNext(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunNext", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunNext indicates an expected call of RunNext.
+func (mr *MockJobUsecaseMockRecorder) RunNext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunNext", reflect.TypeOf((*MockJobUsecase)(nil).RunNext), arg0)
+}
@@ -43,18 +43,18 @@ func (m *MockVolumeUsecase) EXPECT() *MockVolumeUsecaseMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockVolumeUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 bool) (*dto.VolumeDTO, error) {
+func (m *MockVolumeUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 bool, arg4 string, arg5 *dto.VolumePolicyDTO, arg6 *dto.VolumeDropDTO) (*dto.VolumeDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].(*dto.VolumeDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockVolumeUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3 any) *gomock.Call {
+func (mr *MockVolumeUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5, arg6 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVolumeUsecase)(nil).Create), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVolumeUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // Delete mocks base method.
@@ -102,16 +102,16 @@ func (mr *MockVolumeUsecaseMockRecorder) GetOne(arg0, arg1, arg2 any) *gomock.Ca
 }
 
 // Update mocks base method.
-func (m *MockVolumeUsecase) Update(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 bool) (*dto.VolumeDTO, error) {
+func (m *MockVolumeUsecase) Update(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 bool, arg5 string, arg6 *dto.VolumePolicyDTO, arg7 *dto.VolumeDropDTO) (*dto.VolumeDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(*dto.VolumeDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockVolumeUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+func (mr *MockVolumeUsecaseMockRecorder) Update(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVolumeUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVolumeUsecase)(nil).Update), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 }
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -package=usecase -destination=../../../../test/mock/usecase/webhook.go
+//
+
+// Package usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/atsumarukun/holos-storage-api/internal/app/api/usecase/dto"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookUsecase is a mock of WebhookUsecase interface.
+type MockWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookUsecaseMockRecorder is the mock recorder for MockWebhookUsecase.
+type MockWebhookUsecaseMockRecorder struct {
+	mock *MockWebhookUsecase
+}
+
+// NewMockWebhookUsecase creates a new mock instance.
+func NewMockWebhookUsecase(ctrl *gomock.Controller) *MockWebhookUsecase {
+	mock := &MockWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUsecase) EXPECT() *MockWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookUsecase) Create(arg0 context.Context, arg1 uuid.UUID, arg2, arg3 string, arg4 []string, arg5 string) (*dto.WebhookDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*dto.WebhookDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookUsecaseMockRecorder) Create(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookUsecase)(nil).Create), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookUsecase) Delete(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookUsecaseMockRecorder) Delete(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookUsecase)(nil).Delete), arg0, arg1, arg2, arg3)
+}
+
+// DeliverNext mocks base method.
+func (m *MockWebhookUsecase) DeliverNext(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverNext", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverNext indicates an expected call of DeliverNext.
+func (mr *MockWebhookUsecaseMockRecorder) DeliverNext(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverNext", reflect.TypeOf((*MockWebhookUsecase)(nil).DeliverNext), arg0)
+}
+
+// GetAll mocks base method.
+func (m *MockWebhookUsecase) GetAll(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]*dto.WebhookDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*dto.WebhookDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWebhookUsecaseMockRecorder) GetAll(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhookUsecase)(nil).GetAll), arg0, arg1, arg2)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookUsecase) GetDeliveries(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 uuid.UUID) ([]*dto.WebhookDeliveryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*dto.WebhookDeliveryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookUsecaseMockRecorder) GetDeliveries(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookUsecase)(nil).GetDeliveries), arg0, arg1, arg2, arg3)
+}